@@ -0,0 +1,355 @@
+// ================================================================================
+// backups.go
+// ================================================================================
+// このファイルは git の拡張コマンド backups コマンドを実装しています。
+//
+// 【概要】
+// squash、undo-last-commit、amend、split-commit、rename-branch、reset-tag などの
+// 履歴や参照を書き換えるコマンドは、実行前の値を
+// refs/git-plus/backup/<command>/<timestamp> に保存します。
+// backups コマンドは、これらのバックアップ参照の一覧表示・差分確認・復元・整理を行います。
+//
+// 【主な機能】
+// - バックアップの一覧表示（新しい順、番号付き）
+// - バックアップと現在の参照の差分表示
+// - バックアップ元の参照（ブランチ・タグ・HEAD）への復元
+// - 保持期間を過ぎたバックアップの削除（git config gitplus.backup.retention）
+//
+// 【使用例】
+//   git backups                    # バックアップの一覧を表示
+//   git backups diff 1             # 最新のバックアップと現在の差分を表示
+//   git backups restore 1          # 最新のバックアップを復元
+//   git backups prune --older-than 7d  # 7日より古いバックアップを削除
+// ================================================================================
+
+package backups
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/backup"
+	"github.com/tonbiattack/git-plus/internal/gitcmd"
+	"github.com/tonbiattack/git-plus/internal/ui"
+)
+
+var (
+	backupsTo        string // restore で復元先の参照を明示的に指定
+	backupsYes       bool   // restore の確認を省略
+	backupsStat      bool   // diff で統計のみ表示
+	backupsOlderThan string // prune で削除対象とする経過期間
+	backupsDryRun    bool   // prune で削除せずに対象を表示
+)
+
+// backupsCmd は backups コマンドの定義です。
+var backupsCmd = &cobra.Command{
+	Use:   "backups [list|diff|restore|prune] [番号|参照]",
+	Short: "履歴書き換え前のバックアップを一覧・比較・復元・整理",
+	Long: `squash、undo-last-commit、amend、split-commit、rename-branch、reset-tag は
+実行前の参照の値を refs/git-plus/backup/<command>/<timestamp> に保存します。
+このコマンドでバックアップの一覧表示、差分確認、復元、整理を行います。
+
+操作:
+  list              バックアップの一覧を表示（既定）
+  diff <番号|参照>    バックアップと現在の参照の差分を表示
+  restore <番号|参照> バックアップ元の参照をバックアップの値に戻す
+  prune             保持期間を過ぎたバックアップを削除
+
+保持期間は git config gitplus.backup.retention で設定できます（既定: 30d）。
+"never" を設定すると、--older-than を指定しない prune は何も削除しません。
+バックアップの作成時には削除しないため、古いバックアップは prune で整理してください。`,
+	Example: `  git backups                          # 一覧を表示
+  git backups diff 1                   # 最新のバックアップと現在の差分
+  git backups diff 1 --stat            # 差分の統計のみ表示
+  git backups restore 1                # 最新のバックアップを復元
+  git backups restore 2 --to refs/heads/main  # 復元先を指定
+  git backups prune                    # 保持期間を過ぎたものを削除
+  git backups prune --older-than 7d --dry-run
+  git config gitplus.backup.retention 14d     # 保持期間を14日に設定`,
+	Args: cobra.MaximumNArgs(2),
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		action := "list"
+		if len(args) > 0 {
+			action = args[0]
+		}
+
+		switch action {
+		case "list":
+			return runBackupsList()
+		case "prune":
+			return runBackupsPrune()
+		case "diff", "restore":
+			if len(args) < 2 {
+				return fmt.Errorf("%s にはバックアップの番号または参照を指定してください", action)
+			}
+			entries, err := backup.List()
+			if err != nil {
+				return err
+			}
+			entry, err := findEntry(entries, args[1])
+			if err != nil {
+				return err
+			}
+			if action == "diff" {
+				return runBackupsDiff(entry)
+			}
+			return runBackupsRestore(entry)
+		default:
+			return fmt.Errorf("不明な操作です: %s（list, diff, restore, prune のいずれかを指定してください）", action)
+		}
+	},
+}
+
+// runBackupsList はバックアップの一覧を番号付きで表示します。
+func runBackupsList() error {
+	entries, err := backup.List()
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Println("バックアップはありません。")
+		return nil
+	}
+
+	fmt.Printf("バックアップ一覧（%d件、新しい順）:\n\n", len(entries))
+	for i, e := range entries {
+		fmt.Printf("%3d. %s\n", i+1, formatEntry(e))
+		fmt.Printf("     %s\n", e.Ref)
+	}
+	fmt.Println("\n差分を確認するには: git backups diff <番号>")
+	fmt.Println("復元するには: git backups restore <番号>")
+	return nil
+}
+
+// formatEntry はバックアップ1件を一覧表示用の文字列に整形します。
+func formatEntry(e backup.Entry) string {
+	created := "不明な日時"
+	if !e.Time.IsZero() {
+		created = e.Time.Format("2006-01-02 15:04:05")
+	}
+	source := e.Source
+	if source == "" {
+		source = "(不明)"
+	}
+	object := e.Object
+	if len(object) > 8 {
+		object = object[:8]
+	}
+	return fmt.Sprintf("[%s] %s  %s  %s %s", e.Command, created, source, object, e.Subject)
+}
+
+// findEntry は番号または参照名からバックアップを探します。
+//
+// パラメータ:
+//   - entries: バックアップ一覧（新しい順）
+//   - arg: 一覧の番号（1始まり）、完全な参照名、
+//     または refs/git-plus/backup/ を省略した参照名（例: squash/20260102-030405）
+func findEntry(entries []backup.Entry, arg string) (backup.Entry, error) {
+	if n, err := strconv.Atoi(ui.NormalizeNumberInput(arg)); err == nil {
+		if n < 1 || n > len(entries) {
+			return backup.Entry{}, fmt.Errorf("番号は 1〜%d の範囲で指定してください", len(entries))
+		}
+		return entries[n-1], nil
+	}
+
+	for _, e := range entries {
+		if e.Ref == arg || e.Ref == backup.RefPrefix+arg {
+			return e, nil
+		}
+	}
+	return backup.Entry{}, fmt.Errorf("バックアップが見つかりません: %s", arg)
+}
+
+// runBackupsDiff はバックアップと現在の参照の差分を表示します。
+//
+// 内部処理:
+//
+//	バックアップ元の参照が存在すればその参照と、存在しなければ HEAD と比較します。
+//	git log --left-right でどちらか一方にしかないコミットを表示した後、git diff を実行します。
+func runBackupsDiff(e backup.Entry) error {
+	current := "HEAD"
+	if e.Source != "" && refExists(e.Source) {
+		current = e.Source
+	}
+	saved := e.Object + "^{commit}"
+
+	fmt.Printf("バックアップ: %s\n", formatEntry(e))
+	fmt.Printf("比較対象:     %s\n\n", current)
+
+	output, err := gitcmd.Run("log", "--oneline", "--left-right", saved+"..."+current)
+	if err != nil {
+		return fmt.Errorf("コミットの比較に失敗しました: %w", err)
+	}
+	if log := strings.TrimSpace(string(output)); log != "" {
+		fmt.Println("コミットの差分（< バックアップのみ、> 現在のみ）:")
+		fmt.Println(log)
+		fmt.Println()
+	} else {
+		fmt.Println("コミットの差分はありません。")
+	}
+
+	diffArgs := []string{"diff"}
+	if backupsStat {
+		diffArgs = append(diffArgs, "--stat")
+	}
+	diffArgs = append(diffArgs, saved, current)
+	if err := gitcmd.RunWithIO(diffArgs...); err != nil {
+		return fmt.Errorf("差分の表示に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// runBackupsRestore はバックアップ元の参照をバックアップの値に戻します。
+//
+// 内部処理:
+//  1. 復元先（--to またはバックアップ元）を決定
+//  2. 復元先の現在の値を refs/git-plus/backup/backups-restore/ にバックアップ
+//  3. 復元先が現在のブランチ（または HEAD）の場合は git reset --hard、
+//     それ以外は git update-ref で参照を書き換える
+//
+// 備考:
+//
+//	タグのバックアップはタグオブジェクトのまま復元されるため、
+//	注釈付きタグのメッセージや署名も元に戻ります。
+func runBackupsRestore(e backup.Entry) error {
+	target := backupsTo
+	if target == "" {
+		target = e.Source
+	}
+	if target == "" {
+		return fmt.Errorf("バックアップ元の参照が不明です。--to で復元先を指定してください")
+	}
+
+	checkedOut := target == "HEAD"
+	if !checkedOut {
+		if output, err := gitcmd.Run("symbolic-ref", "-q", "HEAD"); err == nil {
+			checkedOut = strings.TrimSpace(string(output)) == target
+		}
+	}
+
+	if checkedOut {
+		output, err := gitcmd.Run("status", "--porcelain", "--untracked-files=no")
+		if err != nil {
+			return fmt.Errorf("作業ツリーの状態確認に失敗しました: %w", err)
+		}
+		if strings.TrimSpace(string(output)) != "" {
+			return fmt.Errorf("コミットされていない変更があります。コミットまたはスタッシュしてから実行してください")
+		}
+	}
+
+	fmt.Printf("バックアップ: %s\n", formatEntry(e))
+	fmt.Printf("復元先:       %s\n", target)
+	exists := refExists(target)
+	if exists {
+		if output, err := gitcmd.Run("log", "-1", "--format=%h %s", target); err == nil {
+			fmt.Printf("現在の値:     %s\n", strings.TrimSpace(string(output)))
+		}
+	} else {
+		fmt.Println("現在の値:     (存在しません)")
+	}
+	if checkedOut {
+		fmt.Println("\n現在チェックアウト中のため git reset --hard で復元します。")
+	}
+
+	if !backupsYes && !ui.Confirm("復元しますか？", false) {
+		fmt.Println("キャンセルしました。")
+		return nil
+	}
+
+	if exists {
+		saved, err := backup.Create("backups-restore", target)
+		if err != nil {
+			return fmt.Errorf("復元前の値のバックアップに失敗しました: %w", err)
+		}
+		fmt.Printf("復元前の値をバックアップしました: %s\n", saved)
+	}
+
+	if checkedOut {
+		if err := gitcmd.RunQuiet("reset", "-q", "--hard", e.Object+"^{commit}"); err != nil {
+			return fmt.Errorf("復元に失敗しました: %w", err)
+		}
+	} else {
+		if err := gitcmd.RunQuiet("update-ref", "-m", "git-plus backups restore", target, e.Object); err != nil {
+			return fmt.Errorf("復元に失敗しました: %w", err)
+		}
+	}
+
+	fmt.Printf("✓ %s を復元しました。\n", target)
+	if tag, ok := strings.CutPrefix(target, "refs/tags/"); ok {
+		fmt.Printf("リモートのタグも戻すには: git push -f origin %s\n", tag)
+	}
+	return nil
+}
+
+// runBackupsPrune は保持期間を過ぎたバックアップを削除します。
+// --older-than を省略した場合は gitplus.backup.retention の設定を使用します。
+func runBackupsPrune() error {
+	var retention time.Duration
+	if backupsOlderThan != "" {
+		d, err := backup.ParseAge(backupsOlderThan)
+		if err != nil {
+			return err
+		}
+		retention = d
+	} else {
+		d, err := backup.Retention()
+		if err != nil {
+			return err
+		}
+		if d == 0 {
+			fmt.Printf("%s によりバックアップの削除は無効です。--older-than で期間を指定してください。\n", backup.RetentionConfigKey)
+			return nil
+		}
+		retention = d
+	}
+
+	now := time.Now()
+	if backupsDryRun {
+		entries, err := backup.List()
+		if err != nil {
+			return err
+		}
+		expired := backup.Expired(entries, retention, now)
+		if len(expired) == 0 {
+			fmt.Println("削除対象のバックアップはありません。")
+			return nil
+		}
+		fmt.Printf("削除対象のバックアップ（%d件）:\n", len(expired))
+		for _, e := range expired {
+			fmt.Printf("  %s\n", formatEntry(e))
+		}
+		return nil
+	}
+
+	pruned, err := backup.Prune(retention, now)
+	for _, e := range pruned {
+		fmt.Printf("削除: %s\n", formatEntry(e))
+	}
+	if err != nil {
+		return err
+	}
+	if len(pruned) == 0 {
+		fmt.Println("削除対象のバックアップはありません。")
+		return nil
+	}
+	fmt.Printf("✓ %d件のバックアップを削除しました。\n", len(pruned))
+	return nil
+}
+
+// refExists は参照が存在するかどうかを返します。
+func refExists(ref string) bool {
+	return gitcmd.RunQuiet("rev-parse", "--verify", "--quiet", ref) == nil
+}
+
+func init() {
+	backupsCmd.Flags().StringVar(&backupsTo, "to", "", "restore: 復元先の参照（例: refs/heads/main）")
+	backupsCmd.Flags().BoolVarP(&backupsYes, "yes", "y", false, "restore: 確認を省略")
+	backupsCmd.Flags().BoolVar(&backupsStat, "stat", false, "diff: 差分の統計のみ表示")
+	backupsCmd.Flags().StringVar(&backupsOlderThan, "older-than", "", "prune: 削除対象とする経過期間（例: 30d, 2w, 12h）")
+	backupsCmd.Flags().BoolVar(&backupsDryRun, "dry-run", false, "prune: 削除せずに対象を表示")
+	cmd.RootCmd.AddCommand(backupsCmd)
+}
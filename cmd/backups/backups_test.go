@@ -0,0 +1,238 @@
+package backups
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/backup"
+	"github.com/tonbiattack/git-plus/internal/testutil"
+)
+
+// chdirRepo はテスト用リポジトリに移動し、終了時に元のディレクトリへ戻します
+func chdirRepo(t *testing.T, repo *testutil.GitRepo) {
+	t.Helper()
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(oldDir) })
+
+	if err := os.Chdir(repo.Dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+}
+
+// TestBackupsCmd_CommandSetup はbackupsコマンドの設定をテストします
+func TestBackupsCmd_CommandSetup(t *testing.T) {
+	if !strings.HasPrefix(backupsCmd.Use, "backups") {
+		t.Errorf("backupsCmd.Use = %q, should start with 'backups'", backupsCmd.Use)
+	}
+	if backupsCmd.Short == "" {
+		t.Error("backupsCmd.Short should not be empty")
+	}
+	if backupsCmd.Long == "" {
+		t.Error("backupsCmd.Long should not be empty")
+	}
+	if backupsCmd.Example == "" {
+		t.Error("backupsCmd.Example should not be empty")
+	}
+	if backupsCmd.RunE == nil {
+		t.Error("backupsCmd.RunE should not be nil")
+	}
+}
+
+// TestBackupsCmd_Flags はフラグが正しく設定されていることを確認します
+func TestBackupsCmd_Flags(t *testing.T) {
+	for _, name := range []string{"to", "yes", "stat", "older-than", "dry-run"} {
+		if backupsCmd.Flags().Lookup(name) == nil {
+			t.Errorf("Flag %s not found", name)
+		}
+	}
+}
+
+// TestBackupsCmd_InRootCmd はbackupsコマンドがRootCmdに登録されていることを確認します
+func TestBackupsCmd_InRootCmd(t *testing.T) {
+	found := false
+	for _, c := range cmd.RootCmd.Commands() {
+		if c == backupsCmd {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("backupsCmd should be registered in RootCmd")
+	}
+}
+
+// TestFindEntry は番号・参照名によるバックアップの検索をテストします
+func TestFindEntry(t *testing.T) {
+	entries := []backup.Entry{
+		{Ref: "refs/git-plus/backup/squash/20260102-030405"},
+		{Ref: "refs/git-plus/backup/amend/20260101-000000"},
+	}
+
+	tests := []struct {
+		name        string
+		arg         string
+		expected    string
+		expectError bool
+	}{
+		{"番号", "2", "refs/git-plus/backup/amend/20260101-000000", false},
+		{"全角番号", "１", "refs/git-plus/backup/squash/20260102-030405", false},
+		{"完全な参照名", "refs/git-plus/backup/squash/20260102-030405", "refs/git-plus/backup/squash/20260102-030405", false},
+		{"接頭辞を省略", "amend/20260101-000000", "refs/git-plus/backup/amend/20260101-000000", false},
+		{"範囲外の番号", "3", "", true},
+		{"存在しない参照", "squash/20990101-000000", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findEntry(entries, tt.arg)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("findEntry(%q) should return error", tt.arg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("findEntry(%q) returned error: %v", tt.arg, err)
+			}
+			if got.Ref != tt.expected {
+				t.Errorf("findEntry(%q) = %q, want %q", tt.arg, got.Ref, tt.expected)
+			}
+		})
+	}
+}
+
+// TestRunBackupsRestore_CurrentBranch は現在のブランチへの復元をテストします
+func TestRunBackupsRestore_CurrentBranch(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("a.txt", "a")
+	repo.Commit("First commit")
+	repo.CreateFile("b.txt", "b")
+	repo.Commit("Second commit")
+	chdirRepo(t, repo)
+
+	if _, err := backup.CreateForHEAD("undo-last-commit"); err != nil {
+		t.Fatalf("CreateForHEAD returned error: %v", err)
+	}
+	repo.MustGit("reset", "--hard", "HEAD^")
+
+	entries, err := backup.List()
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+
+	backupsYes = true
+	defer func() { backupsYes = false }()
+
+	if err := runBackupsRestore(entries[0]); err != nil {
+		t.Fatalf("runBackupsRestore returned error: %v", err)
+	}
+
+	subject := strings.TrimSpace(repo.MustGit("log", "-1", "--format=%s"))
+	if subject != "Second commit" {
+		t.Errorf("HEAD subject = %q, want %q", subject, "Second commit")
+	}
+	if !repo.FileExists("b.txt") {
+		t.Error("b.txt should be restored")
+	}
+
+	// 復元前の値もバックアップされる
+	entries, err = backup.List()
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	found := false
+	for _, e := range entries {
+		if e.Command == "backups-restore" && e.Subject == "First commit" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Restore should back up the current value first, got %+v", entries)
+	}
+}
+
+// TestRunBackupsRestore_Tag は注釈付きタグの復元をテストします
+func TestRunBackupsRestore_Tag(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("a.txt", "a")
+	repo.Commit("First commit")
+	repo.CreateTag("v1.0.0", "Release v1.0.0")
+	chdirRepo(t, repo)
+
+	original := strings.TrimSpace(repo.MustGit("rev-parse", "refs/tags/v1.0.0"))
+	if _, err := backup.Create("reset-tag", "refs/tags/v1.0.0"); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	repo.CreateFile("b.txt", "b")
+	repo.Commit("Second commit")
+	repo.MustGit("tag", "-f", "v1.0.0")
+
+	entries, err := backup.List()
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+
+	backupsYes = true
+	defer func() { backupsYes = false }()
+
+	if err := runBackupsRestore(entries[0]); err != nil {
+		t.Fatalf("runBackupsRestore returned error: %v", err)
+	}
+
+	restored := strings.TrimSpace(repo.MustGit("rev-parse", "refs/tags/v1.0.0"))
+	if restored != original {
+		t.Errorf("Tag object = %s, want %s", restored, original)
+	}
+	if branch := repo.CurrentBranch(); branch == "" {
+		t.Error("Restoring a tag should not detach HEAD")
+	}
+}
+
+// TestRunBackupsRestore_UnknownSource はバックアップ元が不明な場合をテストします
+func TestRunBackupsRestore_UnknownSource(t *testing.T) {
+	err := runBackupsRestore(backup.Entry{Ref: "refs/git-plus/backup/amend/20260101-000000"})
+	if err == nil || !strings.Contains(err.Error(), "--to") {
+		t.Errorf("runBackupsRestore should ask for --to, got %v", err)
+	}
+}
+
+// TestRunBackupsPrune_DryRun はドライランで削除されないことをテストします
+func TestRunBackupsPrune_DryRun(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("a.txt", "a")
+	repo.Commit("First commit")
+	chdirRepo(t, repo)
+
+	oldRef := backup.BuildRefName("amend", time.Now().Add(-10*24*time.Hour))
+	repo.MustGit("update-ref", oldRef, "HEAD")
+
+	backupsOlderThan = "7d"
+	backupsDryRun = true
+	defer func() {
+		backupsOlderThan = ""
+		backupsDryRun = false
+	}()
+
+	if err := runBackupsPrune(); err != nil {
+		t.Fatalf("runBackupsPrune returned error: %v", err)
+	}
+	if _, err := repo.Git("rev-parse", "--verify", oldRef); err != nil {
+		t.Error("Dry run should not delete backups")
+	}
+
+	backupsDryRun = false
+	if err := runBackupsPrune(); err != nil {
+		t.Fatalf("runBackupsPrune returned error: %v", err)
+	}
+	if _, err := repo.Git("rev-parse", "--verify", oldRef); err == nil {
+		t.Error("Expired backup should be deleted")
+	}
+}
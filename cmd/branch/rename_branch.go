@@ -29,6 +29,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/backup"
 	"github.com/tonbiattack/git-plus/internal/gitcmd"
 	"github.com/tonbiattack/git-plus/internal/ui"
 )
@@ -64,7 +65,8 @@ var renameBranchCmd = &cobra.Command{
 // 主な処理:
 //  1. フラグの整合性チェック (--delete-remote は --push が必須)
 //  2. 現在のブランチ名と新しいブランチ名の取得・検証
-//  3. git branch -m でローカルブランチをリネーム
+//  3. 旧ブランチを refs/git-plus/backup/rename-branch/<timestamp> にバックアップし、
+//     git branch -m でローカルブランチをリネーム
 //  4. --push 指定時は `git push --set-upstream <remote> <new>` を実行
 //  5. --delete-remote 指定時は確認後に `git push <remote> --delete <old>` を実行
 func runRenameBranchCommand(newName string) error {
@@ -96,6 +98,12 @@ func runRenameBranchCommand(newName string) error {
 		return fmt.Errorf("ブランチ %s は既に存在します", targetName)
 	}
 
+	backupRef, err := backup.Create("rename-branch", "refs/heads/"+currentBranch)
+	if err != nil {
+		return fmt.Errorf("バックアップの作成に失敗しました: %w", err)
+	}
+	fmt.Printf("バックアップを作成しました: %s\n", backupRef)
+
 	if err := renameLocalBranch(currentBranch, targetName); err != nil {
 		return fmt.Errorf("ブランチ名の変更に失敗しました: %w", err)
 	}
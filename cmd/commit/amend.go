@@ -22,6 +22,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/backup"
 	"github.com/tonbiattack/git-plus/internal/gitcmd"
 )
 
@@ -32,7 +33,8 @@ var amendCmd = &cobra.Command{
 	Use:   "amend",
 	Short: "直前のコミットを修正",
 	Long: `git commit --amend のショートカットです。
-直前のコミットを修正します。引数はそのまま git commit --amend に渡されます。`,
+直前のコミットを修正します。引数はそのまま git commit --amend に渡されます。
+修正前のコミットは refs/git-plus/backup/amend/ に保存されます。`,
 	Example: `  git amend                # 直前のコミットを修正（エディタを開く）
   git amend --no-edit      # コミットメッセージを変更せずに修正
   git amend --reset-author # 作成者情報をリセット`,
@@ -42,6 +44,11 @@ var amendCmd = &cobra.Command{
 		// 例: ["commit", "--amend", "--no-edit"] のような配列を作成
 		gitArgs := append([]string{"commit", "--amend"}, args...)
 
+		// 修正前の HEAD をバックアップ参照に保存する
+		if _, err := backup.CreateForHEAD("amend"); err != nil {
+			return fmt.Errorf("バックアップの作成に失敗しました: %w", err)
+		}
+
 		// git commit --amend コマンドを実行
 		// 標準入出力を接続することで、エディタの起動やユーザー入力を可能にする
 		if err := gitcmd.RunWithIO(gitArgs...); err != nil {
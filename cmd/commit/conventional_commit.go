@@ -0,0 +1,321 @@
+/*
+Package commit は git の拡張コマンドのうち、コミット関連のコマンドを定義します。
+
+このファイル (conventional_commit.go) は、Conventional Commits 形式の
+コミットメッセージを対話的に組み立ててコミットするコマンドを提供します。
+
+主な機能:
+  - コミットタイプの選択（feat, fix, docs など）
+  - ステージされたファイルのパスからスコープ候補を提示
+  - 破壊的変更（BREAKING CHANGE）の指定
+  - Issue 番号の参照（Refs: #123）
+  - 作成したメッセージのプレビューと確認
+
+使用例:
+  git conventional-commit       # 対話的にメッセージを作成してコミット
+  git conventional-commit -a    # 変更済みの追跡ファイルをすべてステージしてから実行
+
+備考:
+  git の組み込みコマンド commit と名前が衝突するため、
+  コマンド名は conventional-commit としています。
+*/
+package commit
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/conventional"
+	"github.com/tonbiattack/git-plus/internal/gitcmd"
+	"github.com/tonbiattack/git-plus/internal/ui"
+)
+
+var (
+	conventionalCommitAll bool // コミット前に git add -u を実行するフラグ
+)
+
+// conventionalCommitCmd は Conventional Commits 形式でコミットを作成するコマンドです。
+var conventionalCommitCmd = &cobra.Command{
+	Use:   "conventional-commit",
+	Short: "Conventional Commits 形式で対話的にコミット",
+	Long: `Conventional Commits 形式のコミットメッセージを対話的に作成してコミットします。
+
+以下の項目を順に入力します:
+  1. タイプ（feat, fix, docs など）
+  2. スコープ（ステージされたファイルのパスから候補を表示）
+  3. 概要
+  4. 本文（省略可、空行で終了）
+  5. 破壊的変更かどうかとその説明
+  6. 参照する Issue 番号（省略可）
+
+作成されたメッセージは lint-commits と同じパーサーで検証されます。`,
+	Example: `  git conventional-commit       # 対話的にメッセージを作成してコミット
+  git conventional-commit -a    # 追跡ファイルの変更をすべてステージしてから実行`,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		if conventionalCommitAll {
+			if err := gitcmd.RunQuiet("add", "-u"); err != nil {
+				return fmt.Errorf("変更のステージに失敗しました: %w", err)
+			}
+		}
+
+		files, err := getStagedFiles()
+		if err != nil {
+			return fmt.Errorf("ステージされたファイルの取得に失敗しました: %w", err)
+		}
+		if len(files) == 0 {
+			return fmt.Errorf("ステージされた変更がありません。git add でファイルをステージしてください")
+		}
+
+		fmt.Printf("ステージされたファイル (%d 個):\n", len(files))
+		for _, f := range files {
+			fmt.Printf("  - %s\n", f)
+		}
+
+		reader := bufio.NewReader(os.Stdin)
+		c, err := promptConventionalCommit(reader, files)
+		if err != nil {
+			return err
+		}
+		if c == nil {
+			fmt.Println("キャンセルしました。")
+			return nil
+		}
+
+		message := conventional.Format(c)
+		if _, problems := conventional.Validate(message, nil); len(problems) > 0 {
+			return fmt.Errorf("コミットメッセージが規約に沿っていません: %s", strings.Join(problems, "; "))
+		}
+
+		fmt.Println("\nコミットメッセージ:")
+		fmt.Println("----------------------------------------")
+		fmt.Println(message)
+		fmt.Println("----------------------------------------")
+
+		if !ui.Confirm("この内容でコミットしますか？", true) {
+			fmt.Println("キャンセルしました。")
+			return nil
+		}
+
+		if err := gitcmd.RunWithIO("commit", "-m", message); err != nil {
+			return fmt.Errorf("コミットに失敗しました: %w", err)
+		}
+		return nil
+	},
+}
+
+// promptConventionalCommit は対話的にコミットメッセージの各要素を入力させます。
+//
+// パラメータ:
+//   - reader: 入力元
+//   - files: ステージされたファイル一覧（スコープ候補の算出に使用）
+//
+// 戻り値:
+//   - *conventional.Commit: 入力されたコミット情報（キャンセル時は nil）
+//   - error: 入力の読み込みに失敗した場合のエラー
+func promptConventionalCommit(reader *bufio.Reader, files []string) (*conventional.Commit, error) {
+	commitType, err := promptCommitType(reader)
+	if err != nil || commitType == "" {
+		return nil, err
+	}
+
+	scope, err := promptScope(reader, suggestScopes(files))
+	if err != nil {
+		return nil, err
+	}
+
+	description, err := readLine(reader, "概要を入力してください (Enterでキャンセル): ")
+	if err != nil || description == "" {
+		return nil, err
+	}
+
+	fmt.Println("本文を入力してください（省略可、空行で終了）:")
+	var bodyLines []string
+	for {
+		line, err := readLine(reader, "> ")
+		if err != nil {
+			return nil, err
+		}
+		if line == "" {
+			break
+		}
+		bodyLines = append(bodyLines, line)
+	}
+
+	c := &conventional.Commit{
+		Type:        commitType,
+		Scope:       scope,
+		Description: description,
+		Body:        strings.Join(bodyLines, "\n"),
+	}
+
+	if ui.Confirm("破壊的変更（BREAKING CHANGE）を含みますか？", false) {
+		c.Breaking = true
+		breaking, err := readLine(reader, "破壊的変更の内容を入力してください: ")
+		if err != nil {
+			return nil, err
+		}
+		if breaking != "" {
+			c.Footers = append(c.Footers, conventional.Footer{Token: conventional.BreakingChangeToken, Value: breaking})
+		}
+	}
+
+	issue, err := readLine(reader, "参照する Issue 番号（省略可、例: 123）: ")
+	if err != nil {
+		return nil, err
+	}
+	if ref := normalizeIssueRef(issue); ref != "" {
+		c.Footers = append(c.Footers, conventional.Footer{Token: "Refs", Value: ref})
+	}
+
+	return c, nil
+}
+
+// promptCommitType はコミットタイプを番号または名前で選択させます。
+//
+// 戻り値:
+//   - string: 選択されたタイプ名（空文字列の場合はキャンセル）
+//   - error: 入力の読み込みに失敗した場合のエラー
+func promptCommitType(reader *bufio.Reader) (string, error) {
+	fmt.Println("\nコミットタイプを選択してください:")
+	for i, t := range conventional.DefaultTypes {
+		fmt.Printf("  [%2d] %-9s - %s\n", i+1, t.Name, t.Description)
+	}
+
+	for {
+		input, err := readLine(reader, "選択 (番号またはタイプ名、Enterでキャンセル): ")
+		if err != nil {
+			return "", err
+		}
+		if input == "" {
+			return "", nil
+		}
+		if t := resolveCommitType(input); t != "" {
+			return t, nil
+		}
+		fmt.Printf("無効な選択です: %s\n", input)
+	}
+}
+
+// resolveCommitType は番号またはタイプ名の入力をタイプ名に変換します。
+// 該当するタイプがない場合は空文字列を返します。
+func resolveCommitType(input string) string {
+	input = ui.NormalizeNumberInput(input)
+	if n, err := strconv.Atoi(input); err == nil {
+		if n >= 1 && n <= len(conventional.DefaultTypes) {
+			return conventional.DefaultTypes[n-1].Name
+		}
+		return ""
+	}
+	input = strings.ToLower(input)
+	for _, t := range conventional.DefaultTypes {
+		if t.Name == input {
+			return t.Name
+		}
+	}
+	return ""
+}
+
+// promptScope はスコープ候補を表示し、番号または自由入力でスコープを決定させます。
+func promptScope(reader *bufio.Reader, candidates []string) (string, error) {
+	fmt.Println("\nスコープを指定してください（省略可）:")
+	for i, s := range candidates {
+		fmt.Printf("  [%d] %s\n", i+1, s)
+	}
+	input, err := readLine(reader, "選択 (番号または任意のスコープ、Enterで省略): ")
+	if err != nil {
+		return "", err
+	}
+	if n, convErr := strconv.Atoi(ui.NormalizeNumberInput(input)); convErr == nil && n >= 1 && n <= len(candidates) {
+		return candidates[n-1], nil
+	}
+	return input, nil
+}
+
+// suggestScopes は変更されたファイルのパスからスコープ候補を算出します。
+//
+// パラメータ:
+//   - files: リポジトリルートからの相対パス一覧
+//
+// 戻り値:
+//   - []string: スコープ候補（該当ファイル数の多い順、同数の場合は名前順）
+//
+// 内部処理:
+//
+//	各ファイルの直近の親ディレクトリ名を候補とします。
+//	例: cmd/tag/new_tag.go → "tag"、internal/ui/confirm.go → "ui"
+//	ルート直下のファイルは候補に含めません。
+func suggestScopes(files []string) []string {
+	counts := make(map[string]int)
+	for _, f := range files {
+		dir := path.Dir(strings.ReplaceAll(f, "\\", "/"))
+		if dir == "." || dir == "/" {
+			continue
+		}
+		counts[path.Base(dir)]++
+	}
+
+	scopes := make([]string, 0, len(counts))
+	for s := range counts {
+		scopes = append(scopes, s)
+	}
+	sort.Slice(scopes, func(i, j int) bool {
+		if counts[scopes[i]] != counts[scopes[j]] {
+			return counts[scopes[i]] > counts[scopes[j]]
+		}
+		return scopes[i] < scopes[j]
+	})
+	return scopes
+}
+
+// normalizeIssueRef は Issue 番号の入力を "#123" 形式に正規化します。
+// 数字以外を含む入力（例: PROJ-123）はそのまま返します。
+func normalizeIssueRef(input string) string {
+	input = strings.TrimSpace(ui.NormalizeNumberInput(input))
+	if input == "" {
+		return ""
+	}
+	trimmed := strings.TrimPrefix(input, "#")
+	if _, err := strconv.Atoi(trimmed); err == nil {
+		return "#" + trimmed
+	}
+	return input
+}
+
+// getStagedFiles はステージされているファイルの一覧を取得します。
+func getStagedFiles() ([]string, error) {
+	output, err := gitcmd.Run("diff", "--cached", "--name-only")
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, line := range strings.Split(string(output), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+// readLine はプロンプトを表示して1行読み込みます。前後の空白は除去されます。
+func readLine(reader *bufio.Reader, prompt string) (string, error) {
+	fmt.Print(prompt)
+	input, err := reader.ReadString('\n')
+	if err != nil && input == "" {
+		return "", fmt.Errorf("入力の読み込みに失敗しました: %w", err)
+	}
+	return strings.TrimSpace(input), nil
+}
+
+// init は conventional-commit コマンドを RootCmd に登録し、フラグを設定します。
+func init() {
+	conventionalCommitCmd.Flags().BoolVarP(&conventionalCommitAll, "all", "a", false, "変更された追跡ファイルをすべてステージしてからコミット")
+	cmd.RootCmd.AddCommand(conventionalCommitCmd)
+}
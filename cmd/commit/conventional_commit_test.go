@@ -0,0 +1,188 @@
+package commit
+
+import (
+	"bufio"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/testutil"
+)
+
+// TestConventionalCommitCmd_CommandSetup はconventional-commitコマンドの設定をテストします
+func TestConventionalCommitCmd_CommandSetup(t *testing.T) {
+	if conventionalCommitCmd.Use != "conventional-commit" {
+		t.Errorf("conventionalCommitCmd.Use = %q, want %q", conventionalCommitCmd.Use, "conventional-commit")
+	}
+
+	if conventionalCommitCmd.Short == "" {
+		t.Error("conventionalCommitCmd.Short should not be empty")
+	}
+
+	if conventionalCommitCmd.Long == "" {
+		t.Error("conventionalCommitCmd.Long should not be empty")
+	}
+
+	if conventionalCommitCmd.RunE == nil {
+		t.Error("conventionalCommitCmd.RunE should not be nil")
+	}
+
+	flag := conventionalCommitCmd.Flags().Lookup("all")
+	if flag == nil || flag.Shorthand != "a" {
+		t.Error("conventionalCommitCmd should have --all/-a flag")
+	}
+}
+
+// TestConventionalCommitCmd_InRootCmd はconventional-commitコマンドがRootCmdに登録されていることを確認します
+func TestConventionalCommitCmd_InRootCmd(t *testing.T) {
+	found := false
+	for _, c := range cmd.RootCmd.Commands() {
+		if c.Use == "conventional-commit" {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		t.Error("conventionalCommitCmd should be registered in RootCmd")
+	}
+}
+
+// TestSuggestScopes はファイルパスからのスコープ候補算出をテストします
+func TestSuggestScopes(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    []string
+		expected []string
+	}{
+		{
+			name:     "件数の多い順",
+			files:    []string{"cmd/tag/new_tag.go", "cmd/tag/tag_diff.go", "internal/ui/confirm.go"},
+			expected: []string{"tag", "ui"},
+		},
+		{
+			name:     "同数の場合は名前順",
+			files:    []string{"web/app.ts", "api/server.go"},
+			expected: []string{"api", "web"},
+		},
+		{
+			name:     "ルート直下のファイルは除外",
+			files:    []string{"README.md", "go.mod"},
+			expected: []string{},
+		},
+		{
+			name:     "Windows形式のパス",
+			files:    []string{"cmd\\stash\\pause.go"},
+			expected: []string{"stash"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := suggestScopes(tt.files)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("suggestScopes(%v) = %v, want %v", tt.files, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestResolveCommitType は番号・名前からのタイプ解決をテストします
+func TestResolveCommitType(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"1", "feat"},
+		{"２", "fix"},
+		{"docs", "docs"},
+		{"FIX", "fix"},
+		{"0", ""},
+		{"99", ""},
+		{"unknown", ""},
+	}
+
+	for _, tt := range tests {
+		if got := resolveCommitType(tt.input); got != tt.expected {
+			t.Errorf("resolveCommitType(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+// TestNormalizeIssueRef はIssue参照の正規化をテストします
+func TestNormalizeIssueRef(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", ""},
+		{"123", "#123"},
+		{"#45", "#45"},
+		{"１２", "#12"},
+		{"PROJ-7", "PROJ-7"},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeIssueRef(tt.input); got != tt.expected {
+			t.Errorf("normalizeIssueRef(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+// TestPromptScope はスコープ選択の入力処理をテストします
+func TestPromptScope(t *testing.T) {
+	candidates := []string{"tag", "ui"}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"2\n", "ui"},
+		{"custom\n", "custom"},
+		{"\n", ""},
+		{"5\n", "5"},
+	}
+
+	for _, tt := range tests {
+		reader := bufio.NewReader(strings.NewReader(tt.input))
+		got, err := promptScope(reader, candidates)
+		if err != nil {
+			t.Fatalf("promptScope returned error: %v", err)
+		}
+		if got != tt.expected {
+			t.Errorf("promptScope(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+// TestGetStagedFiles はステージされたファイル一覧の取得をテストします
+func TestGetStagedFiles(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("README.md", "# Test")
+	repo.Commit("Initial commit")
+
+	repo.CreateFile("cmd/tag/a.go", "package tag")
+	repo.CreateFile("unstaged.txt", "not staged")
+	repo.MustGit("add", "cmd/tag/a.go")
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer func() { _ = os.Chdir(oldDir) }()
+
+	if err := os.Chdir(repo.Dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	files, err := getStagedFiles()
+	if err != nil {
+		t.Fatalf("getStagedFiles returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(files, []string{"cmd/tag/a.go"}) {
+		t.Errorf("getStagedFiles() = %v, want [cmd/tag/a.go]", files)
+	}
+}
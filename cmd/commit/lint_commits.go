@@ -0,0 +1,239 @@
+/*
+Package commit は git の拡張コマンドのうち、コミット関連のコマンドを定義します。
+
+このファイル (lint_commits.go) は、コミットメッセージが Conventional Commits 形式に
+沿っているかを検証するコマンドを提供します。
+
+主な機能:
+  - 指定範囲のコミットメッセージを一括検証（CI 向けに違反時は終了コード1）
+  - コミットメッセージファイルの検証（commit-msg フックから呼び出される）
+  - commit-msg フックのインストール
+
+使用例:
+  git lint-commits origin/main..HEAD     # 範囲内のコミットを検証
+  git lint-commits --file .git/COMMIT_EDITMSG
+  git lint-commits --install-hook        # commit-msg フックをインストール
+*/
+package commit
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/conventional"
+	"github.com/tonbiattack/git-plus/internal/gitcmd"
+)
+
+var (
+	lintFile         string // 検証するコミットメッセージファイル
+	lintInstallHook  bool   // commit-msg フックをインストールするフラグ
+	lintForceInstall bool   // 既存のフックを上書きするフラグ
+)
+
+// commitMsgHookMarker はこのツールがインストールしたフックを識別するための目印です。
+const commitMsgHookMarker = "# installed by git-plus lint-commits"
+
+// commitMsgHookScript は commit-msg フックとしてインストールされるスクリプトです。
+// lint-commits と同じパーサーでメッセージファイルを検証します。
+const commitMsgHookScript = `#!/bin/sh
+` + commitMsgHookMarker + `
+exec git lint-commits --file "$1"
+`
+
+// lintedCommit は検証対象のコミットを表す構造体です。
+type lintedCommit struct {
+	hash    string // コミットハッシュ
+	message string // コミットメッセージ全体
+}
+
+// lintCommitsCmd はコミットメッセージを検証するコマンドです。
+var lintCommitsCmd = &cobra.Command{
+	Use:   "lint-commits [範囲]",
+	Short: "コミットメッセージが Conventional Commits 形式か検証",
+	Long: `コミットメッセージが Conventional Commits 形式に沿っているかを検証します。
+
+範囲を省略した場合は、上流ブランチ（@{upstream}）から HEAD までを検証します。
+上流ブランチが設定されていない場合は HEAD のみを検証します。
+マージコミット、git revert の自動メッセージ、fixup!/squash! コミットは検証対象外です。
+
+違反が見つかった場合は終了コード1で終了するため、CI でも利用できます。
+--install-hook を指定すると、同じ検証を行う commit-msg フックをインストールします。`,
+	Example: `  git lint-commits                        # 上流ブランチから HEAD までを検証
+  git lint-commits origin/main..HEAD      # 範囲を指定して検証
+  git lint-commits --file .git/COMMIT_EDITMSG
+  git lint-commits --install-hook         # commit-msg フックをインストール`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		if lintInstallHook {
+			return installCommitMsgHook(lintForceInstall)
+		}
+
+		if lintFile != "" {
+			data, err := os.ReadFile(lintFile)
+			if err != nil {
+				return fmt.Errorf("コミットメッセージファイルの読み込みに失敗しました: %w", err)
+			}
+			message := string(data)
+			if conventional.IsExempt(message) {
+				return nil
+			}
+			if _, problems := conventional.Validate(message, nil); len(problems) > 0 {
+				fmt.Fprintln(os.Stderr, "コミットメッセージが Conventional Commits 形式ではありません:")
+				for _, p := range problems {
+					fmt.Fprintf(os.Stderr, "  - %s\n", p)
+				}
+				fmt.Fprintln(os.Stderr, "\n例: feat(api): ユーザー検索エンドポイントを追加")
+				os.Exit(1)
+			}
+			return nil
+		}
+
+		revRange := resolveLintRange(args)
+		commits, err := getCommitsInRange(revRange)
+		if err != nil {
+			return fmt.Errorf("コミットの取得に失敗しました: %w", err)
+		}
+
+		failed := 0
+		checked := 0
+		for _, c := range commits {
+			if conventional.IsExempt(c.message) {
+				continue
+			}
+			checked++
+			if _, problems := conventional.Validate(c.message, nil); len(problems) > 0 {
+				failed++
+				fmt.Printf("✗ %s %s\n", shortHash(c.hash), firstLine(c.message))
+				for _, p := range problems {
+					fmt.Printf("    - %s\n", p)
+				}
+			}
+		}
+
+		if failed > 0 {
+			fmt.Printf("\n%d 件中 %d 件のコミットが規約に沿っていません（範囲: %s）\n", checked, failed, revRange)
+			os.Exit(1)
+		}
+
+		fmt.Printf("✓ %d 件のコミットを検証しました（範囲: %s）\n", checked, revRange)
+		return nil
+	},
+}
+
+// resolveLintRange は検証対象の範囲を決定します。
+//
+// 引数で範囲が指定されていればそれを使用し、なければ上流ブランチから HEAD まで、
+// 上流ブランチもなければ HEAD の1コミットのみを対象とします。
+func resolveLintRange(args []string) string {
+	if len(args) > 0 && strings.TrimSpace(args[0]) != "" {
+		return strings.TrimSpace(args[0])
+	}
+	if err := gitcmd.RunQuiet("rev-parse", "--verify", "--quiet", "@{upstream}"); err == nil {
+		return "@{upstream}..HEAD"
+	}
+	return "HEAD^!"
+}
+
+// getCommitsInRange は範囲内のコミットのハッシュとメッセージを取得します。
+//
+// パラメータ:
+//   - revRange: git log に渡すリビジョン範囲（例: origin/main..HEAD）
+//
+// 戻り値:
+//   - []lintedCommit: コミット一覧（新しい順）
+//   - error: git log の実行に失敗した場合のエラー
+//
+// 内部処理:
+//
+//	レコード区切りに \x1e、フィールド区切りに \x00 を使用して
+//	複数行のメッセージを安全に分割します。
+func getCommitsInRange(revRange string) ([]lintedCommit, error) {
+	output, err := gitcmd.Run("log", "--no-merges", "--format=%H%x00%B%x1e", revRange)
+	if err != nil {
+		return nil, err
+	}
+	return parseCommitRecords(output), nil
+}
+
+// parseCommitRecords は git log --format=%H%x00%B%x1e の出力を解析します。
+func parseCommitRecords(output []byte) []lintedCommit {
+	var commits []lintedCommit
+	for _, record := range bytes.Split(output, []byte{0x1e}) {
+		rec := strings.TrimLeft(string(record), "\n")
+		if strings.TrimSpace(rec) == "" {
+			continue
+		}
+		parts := strings.SplitN(rec, "\x00", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		commits = append(commits, lintedCommit{
+			hash:    strings.TrimSpace(parts[0]),
+			message: strings.TrimSpace(parts[1]),
+		})
+	}
+	return commits
+}
+
+// installCommitMsgHook は commit-msg フックをインストールします。
+//
+// パラメータ:
+//   - force: true の場合、このツール以外が作成した既存フックも上書きする
+//
+// 内部処理:
+//
+//	git rev-parse --git-path hooks/commit-msg でフックのパスを取得し
+//	（core.hooksPath の設定も考慮されます）、実行権限付きでスクリプトを書き込みます。
+func installCommitMsgHook(force bool) error {
+	output, err := gitcmd.Run("rev-parse", "--git-path", "hooks/commit-msg")
+	if err != nil {
+		return fmt.Errorf("フックのパスの取得に失敗しました: %w", err)
+	}
+	hookPath := strings.TrimSpace(string(output))
+
+	if existing, err := os.ReadFile(hookPath); err == nil {
+		if !strings.Contains(string(existing), commitMsgHookMarker) && !force {
+			return fmt.Errorf("既存の commit-msg フックがあります: %s\n上書きする場合は --force を指定してください", hookPath)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(hookPath), 0755); err != nil {
+		return fmt.Errorf("フックディレクトリの作成に失敗しました: %w", err)
+	}
+	// 0755: フックとして実行できるよう実行権限を付与する
+	if err := os.WriteFile(hookPath, []byte(commitMsgHookScript), 0755); err != nil {
+		return fmt.Errorf("フックの書き込みに失敗しました: %w", err)
+	}
+
+	fmt.Printf("✓ commit-msg フックをインストールしました: %s\n", hookPath)
+	return nil
+}
+
+// shortHash はコミットハッシュの先頭8文字を返します。
+func shortHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
+
+// firstLine はメッセージの1行目を返します。
+func firstLine(message string) string {
+	if idx := strings.Index(message, "\n"); idx >= 0 {
+		return message[:idx]
+	}
+	return message
+}
+
+// init は lint-commits コマンドを RootCmd に登録し、フラグを設定します。
+func init() {
+	lintCommitsCmd.Flags().StringVar(&lintFile, "file", "", "コミットメッセージファイルを検証（commit-msg フック用）")
+	lintCommitsCmd.Flags().BoolVar(&lintInstallHook, "install-hook", false, "commit-msg フックをインストール")
+	lintCommitsCmd.Flags().BoolVar(&lintForceInstall, "force", false, "--install-hook で既存のフックを上書き")
+	cmd.RootCmd.AddCommand(lintCommitsCmd)
+}
@@ -0,0 +1,172 @@
+package commit
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/testutil"
+)
+
+// TestLintCommitsCmd_CommandSetup はlint-commitsコマンドの設定をテストします
+func TestLintCommitsCmd_CommandSetup(t *testing.T) {
+	if lintCommitsCmd.Use != "lint-commits [範囲]" {
+		t.Errorf("lintCommitsCmd.Use = %q, want %q", lintCommitsCmd.Use, "lint-commits [範囲]")
+	}
+
+	if lintCommitsCmd.Short == "" {
+		t.Error("lintCommitsCmd.Short should not be empty")
+	}
+
+	if lintCommitsCmd.Long == "" {
+		t.Error("lintCommitsCmd.Long should not be empty")
+	}
+
+	for _, name := range []string{"file", "install-hook", "force"} {
+		if lintCommitsCmd.Flags().Lookup(name) == nil {
+			t.Errorf("Flag %q not found", name)
+		}
+	}
+}
+
+// TestLintCommitsCmd_InRootCmd はlint-commitsコマンドがRootCmdに登録されていることを確認します
+func TestLintCommitsCmd_InRootCmd(t *testing.T) {
+	found := false
+	for _, c := range cmd.RootCmd.Commands() {
+		if c.Use == "lint-commits [範囲]" {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		t.Error("lintCommitsCmd should be registered in RootCmd")
+	}
+}
+
+// TestParseCommitRecords はgit log出力の解析をテストします
+func TestParseCommitRecords(t *testing.T) {
+	output := []byte("aaa\x00feat: one\n\nbody\n\x1e\nbbb\x00fix: two\n\x1e\n")
+
+	commits := parseCommitRecords(output)
+	if len(commits) != 2 {
+		t.Fatalf("Expected 2 commits, got %d", len(commits))
+	}
+
+	if commits[0].hash != "aaa" || commits[0].message != "feat: one\n\nbody" {
+		t.Errorf("commits[0] = %+v", commits[0])
+	}
+
+	if commits[1].hash != "bbb" || commits[1].message != "fix: two" {
+		t.Errorf("commits[1] = %+v", commits[1])
+	}
+}
+
+// TestGetCommitsInRange は範囲内のコミット取得をテストします
+func TestGetCommitsInRange(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("a.txt", "a")
+	repo.Commit("chore: initial")
+	repo.MustGit("tag", "base")
+
+	repo.CreateFile("b.txt", "b")
+	repo.Commit("feat(b): add b\n\nRefs: #1")
+
+	repo.CreateFile("c.txt", "c")
+	repo.Commit("bad message")
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer func() { _ = os.Chdir(oldDir) }()
+
+	if err := os.Chdir(repo.Dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	commits, err := getCommitsInRange("base..HEAD")
+	if err != nil {
+		t.Fatalf("getCommitsInRange returned error: %v", err)
+	}
+
+	if len(commits) != 2 {
+		t.Fatalf("Expected 2 commits, got %d", len(commits))
+	}
+
+	if commits[0].message != "bad message" {
+		t.Errorf("commits[0].message = %q, want %q", commits[0].message, "bad message")
+	}
+
+	if !strings.Contains(commits[1].message, "Refs: #1") {
+		t.Errorf("commits[1].message should contain footer, got %q", commits[1].message)
+	}
+}
+
+// TestResolveLintRange は検証範囲の決定をテストします
+func TestResolveLintRange(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("a.txt", "a")
+	repo.Commit("chore: initial")
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer func() { _ = os.Chdir(oldDir) }()
+
+	if err := os.Chdir(repo.Dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	if got := resolveLintRange([]string{"main~3..main"}); got != "main~3..main" {
+		t.Errorf("resolveLintRange with arg = %q, want %q", got, "main~3..main")
+	}
+
+	// 上流ブランチがない場合は HEAD のみ
+	if got := resolveLintRange(nil); got != "HEAD^!" {
+		t.Errorf("resolveLintRange without upstream = %q, want %q", got, "HEAD^!")
+	}
+}
+
+// TestInstallCommitMsgHook はcommit-msgフックのインストールをテストします
+func TestInstallCommitMsgHook(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer func() { _ = os.Chdir(oldDir) }()
+
+	if err := os.Chdir(repo.Dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	if err := installCommitMsgHook(false); err != nil {
+		t.Fatalf("installCommitMsgHook returned error: %v", err)
+	}
+
+	content := repo.ReadFile(".git/hooks/commit-msg")
+	if !strings.Contains(content, commitMsgHookMarker) {
+		t.Error("Hook should contain marker")
+	}
+	if !strings.Contains(content, "git lint-commits --file") {
+		t.Error("Hook should call git lint-commits --file")
+	}
+
+	// 自分でインストールしたフックは再インストールできる
+	if err := installCommitMsgHook(false); err != nil {
+		t.Errorf("Reinstalling own hook should succeed: %v", err)
+	}
+
+	// 他のフックは --force なしでは上書きしない
+	repo.CreateFile(".git/hooks/commit-msg", "#!/bin/sh\necho other\n")
+	if err := installCommitMsgHook(false); err == nil {
+		t.Error("installCommitMsgHook should fail for foreign hook without force")
+	}
+	if err := installCommitMsgHook(true); err != nil {
+		t.Errorf("installCommitMsgHook with force should succeed: %v", err)
+	}
+}
@@ -0,0 +1,493 @@
+// ================================================================================
+// split_commit.go
+// ================================================================================
+// このファイルは git の拡張コマンド split-commit コマンドを実装しています。
+//
+// 【概要】
+// split-commit コマンドは、大きくなりすぎた1つのコミットを複数のコミットに分割します。
+// 対象コミットの変更ファイルを一覧表示し、ファイル単位（必要に応じてハンク単位）で
+// 新しいコミットに割り当てていきます。
+//
+// 【主な機能】
+// - 直前のコミット、または任意の過去のコミットを分割
+// - ファイル番号の指定（例: 1,3-5）で新しいコミットに含めるファイルを選択
+// - git add -p によるハンク単位の割り当て
+// - 過去のコミットを分割した場合、後続のコミットを自動的に積み直し
+// - 実行前にバックアップ参照（refs/git-plus/backup/split-commit/<timestamp>）を作成
+//
+// 【使用例】
+//   git split-commit           # 直前のコミットを分割
+//   git split-commit HEAD~2    # 2つ前のコミットを分割し、後続のコミットを積み直す
+//
+// 【内部仕様】
+// - 対象コミットを detached HEAD でチェックアウトし、git reset --mixed HEAD^ で取り消す
+// - ユーザーが選択したファイルを git add してコミットを繰り返す
+// - すべての変更がコミットされたら git rebase --onto で元のブランチを付け替える
+// - 途中で中止した場合は元のブランチ（またはコミット）を強制チェックアウトして元に戻す
+// ================================================================================
+
+package commit
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/backup"
+	"github.com/tonbiattack/git-plus/internal/gitcmd"
+	"github.com/tonbiattack/git-plus/internal/ui"
+)
+
+// splitFile は分割対象のコミットで変更されたファイルを表す構造体です。
+type splitFile struct {
+	status  string // 変更種別（A: 追加, M: 変更, D: 削除, R: リネーム など）
+	path    string // 変更後のパス
+	oldPath string // リネーム・コピー元のパス（該当しない場合は空）
+}
+
+// splitOrigin は分割開始前の HEAD の状態を表す構造体です。
+type splitOrigin struct {
+	branch string // チェックアウトしていたブランチ名（detached HEAD の場合は空）
+	head   string // HEAD のコミットハッシュ
+}
+
+// splitCommitCmd は split-commit コマンドの定義です。
+var splitCommitCmd = &cobra.Command{
+	Use:   "split-commit [リビジョン]",
+	Short: "1つのコミットを複数のコミットに分割",
+	Long: `指定したコミット（省略時は HEAD）を取り消し、変更されたファイルを
+複数の新しいコミットに割り当て直します。
+
+ファイル番号（例: 1,3-5）を入力して新しいコミットに含めるファイルを選び、
+コミットメッセージを入力します。"p 番号" と入力すると git add -p で
+ハンク単位に割り当てられます。
+
+過去のコミットを指定した場合は、分割後に後続のコミットを自動的に積み直します。
+実行前に元の HEAD を refs/git-plus/backup/split-commit/<timestamp> に保存するため、
+途中で失敗しても git backups restore <バックアップ参照> で元に戻せます。`,
+	Example: `  git split-commit           # 直前のコミットを分割
+  git split-commit HEAD~2    # 2つ前のコミットを分割して後続を積み直す
+  git split-commit abc1234   # 指定したコミットを分割`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		rev := "HEAD"
+		if len(args) == 1 {
+			rev = args[0]
+		}
+		return runSplitCommit(rev)
+	},
+}
+
+// runSplitCommit は split-commit コマンドのメイン処理です。
+//
+// パラメータ:
+//   - rev: 分割するコミットのリビジョン
+//
+// 処理フロー:
+//  1. 作業ツリーがクリーンであること、対象コミットが HEAD の祖先であることを確認
+//  2. 元の HEAD をバックアップ参照に保存
+//  3. 対象コミットを detached HEAD でチェックアウトし、git reset --mixed HEAD^ で取り消す
+//  4. ファイルを選択してコミットする操作を、すべての変更がコミットされるまで繰り返す
+//  5. git rebase --onto で後続のコミットを積み直し、元のブランチを更新
+func runSplitCommit(rev string) error {
+	clean, err := isWorkingTreeClean()
+	if err != nil {
+		return fmt.Errorf("作業ツリーの状態確認に失敗しました: %w", err)
+	}
+	if !clean {
+		return fmt.Errorf("コミットされていない変更があります。コミットまたはスタッシュしてから実行してください")
+	}
+
+	target, err := resolveCommit(rev)
+	if err != nil {
+		return fmt.Errorf("リビジョン %s を解決できません: %w", rev, err)
+	}
+
+	parents, err := getParentCount(target)
+	if err != nil {
+		return fmt.Errorf("コミット情報の取得に失敗しました: %w", err)
+	}
+	switch {
+	case parents == 0:
+		return fmt.Errorf("ルートコミットは分割できません")
+	case parents > 1:
+		return fmt.Errorf("マージコミットは分割できません")
+	}
+
+	origin, err := getSplitOrigin()
+	if err != nil {
+		return err
+	}
+
+	if err := gitcmd.RunQuiet("merge-base", "--is-ancestor", target, origin.head); err != nil {
+		return fmt.Errorf("%s は現在の HEAD の祖先ではありません", rev)
+	}
+
+	merges, err := gitcmd.Run("rev-list", "--merges", target+".."+origin.head)
+	if err != nil {
+		return fmt.Errorf("後続コミットの確認に失敗しました: %w", err)
+	}
+	if strings.TrimSpace(string(merges)) != "" {
+		return fmt.Errorf("対象コミット以降にマージコミットが含まれているため分割できません")
+	}
+
+	files, err := getCommitFiles(target)
+	if err != nil {
+		return fmt.Errorf("変更ファイルの取得に失敗しました: %w", err)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("対象コミットに変更されたファイルがありません")
+	}
+
+	originalMessage, err := gitcmd.Run("log", "-1", "--format=%B", target)
+	if err != nil {
+		return fmt.Errorf("コミットメッセージの取得に失敗しました: %w", err)
+	}
+	originalSubject := firstLine(strings.TrimSpace(string(originalMessage)))
+
+	fmt.Printf("分割するコミット: %s %s\n", shortHash(target), originalSubject)
+	fmt.Printf("変更ファイル数: %d\n", len(files))
+	if target != origin.head {
+		fmt.Println("分割後、後続のコミットを自動的に積み直します。")
+	}
+	if !ui.Confirm("分割を開始しますか？", true) {
+		fmt.Println("キャンセルしました。")
+		return nil
+	}
+
+	backupRef, err := backup.CreateForHEAD("split-commit")
+	if err != nil {
+		return fmt.Errorf("バックアップ参照の作成に失敗しました: %w", err)
+	}
+	fmt.Printf("バックアップを作成しました: %s\n", backupRef)
+
+	if err := gitcmd.RunQuiet("checkout", "-q", "--detach", target); err != nil {
+		return fmt.Errorf("対象コミットのチェックアウトに失敗しました: %w", err)
+	}
+	if err := gitcmd.RunQuiet("reset", "-q", "--mixed", "HEAD^"); err != nil {
+		restoreSplitOrigin(origin)
+		return fmt.Errorf("コミットの取り消しに失敗しました: %w", err)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	completed, err := assignFilesToCommits(reader, target, files, originalSubject)
+	if err != nil || !completed {
+		restoreSplitOrigin(origin)
+		if err != nil {
+			return err
+		}
+		fmt.Println("分割を中止し、元の状態に戻しました。")
+		return nil
+	}
+
+	newBase, err := resolveCommit("HEAD")
+	if err != nil {
+		return fmt.Errorf("新しいコミットの取得に失敗しました: %w", err)
+	}
+
+	if err := reapplyFollowingCommits(origin, target, newBase); err != nil {
+		fmt.Printf("元に戻すには: git rebase --abort を実行した後、git backups restore %s\n", backupRef)
+		return err
+	}
+
+	fmt.Println("✓ コミットの分割が完了しました。")
+	fmt.Printf("元に戻すには: git backups restore %s\n", backupRef)
+	return nil
+}
+
+// assignFilesToCommits は残りのファイルを新しいコミットに割り当てる対話処理です。
+//
+// パラメータ:
+//   - reader: 入力元
+//   - target: 分割対象のコミットハッシュ（残りの変更の判定に使用）
+//   - files: 対象コミットの変更ファイル一覧
+//   - defaultMessage: メッセージ未入力時に使用するメッセージ
+//
+// 戻り値:
+//   - bool: すべての変更をコミットし終えた場合は true、中止した場合は false
+//   - error: git コマンドや入力の読み込みに失敗した場合のエラー
+func assignFilesToCommits(reader *bufio.Reader, target string, files []splitFile, defaultMessage string) (bool, error) {
+	commitNumber := 1
+	for {
+		remaining, err := getRemainingSplitFiles(target, files)
+		if err != nil {
+			return false, fmt.Errorf("残りの変更の確認に失敗しました: %w", err)
+		}
+		if len(remaining) == 0 {
+			return true, nil
+		}
+
+		fmt.Printf("\n[コミット %d] 残りのファイル:\n", commitNumber)
+		for i, f := range remaining {
+			fmt.Printf("  %d. %s\n", i+1, formatSplitFile(f))
+		}
+		fmt.Println("\nファイル番号（例: 1,3-5）、a（残りすべて）、p 番号（ハンク単位で選択）、q（中止）")
+		input, err := readLine(reader, "選択: ")
+		if err != nil {
+			return false, err
+		}
+
+		switch {
+		case input == "q":
+			return false, nil
+		case input == "":
+			continue
+		case strings.HasPrefix(input, "p "):
+			idx, convErr := strconv.Atoi(ui.NormalizeNumberInput(strings.TrimPrefix(input, "p ")))
+			if convErr != nil || idx < 1 || idx > len(remaining) {
+				fmt.Println("無効な番号です。")
+				continue
+			}
+			if err := stageSplitFileHunks(remaining[idx-1]); err != nil {
+				fmt.Printf("ハンクの選択に失敗しました: %v\n", err)
+				continue
+			}
+		default:
+			indexes, selErr := parseFileSelection(input, len(remaining))
+			if selErr != nil {
+				fmt.Println(selErr)
+				continue
+			}
+			for _, idx := range indexes {
+				if err := gitcmd.RunQuiet(append([]string{"add", "-A", "--"}, splitFilePaths(remaining[idx])...)...); err != nil {
+					return false, fmt.Errorf("%s のステージに失敗しました: %w", remaining[idx].path, err)
+				}
+			}
+		}
+
+		if err := gitcmd.RunQuiet("diff", "--cached", "--quiet"); err == nil {
+			fmt.Println("ステージされた変更がありません。")
+			continue
+		}
+
+		message, err := readLine(reader, fmt.Sprintf("コミットメッセージ (Enterで \"%s\"): ", defaultMessage))
+		if err != nil {
+			return false, err
+		}
+		if message == "" {
+			message = defaultMessage
+		}
+		if err := gitcmd.RunQuiet("commit", "-q", "-m", message); err != nil {
+			return false, fmt.Errorf("コミットの作成に失敗しました: %w", err)
+		}
+		fmt.Printf("✓ コミットを作成しました: %s\n", message)
+		commitNumber++
+	}
+}
+
+// reapplyFollowingCommits は分割後のコミットの上に後続のコミットを積み直します。
+//
+// パラメータ:
+//   - origin: 分割開始前の HEAD の状態
+//   - target: 分割したコミットのハッシュ
+//   - newBase: 分割後の最後のコミットのハッシュ
+//
+// 内部処理:
+//
+//	ブランチ上にいた場合は git rebase --onto <newBase> <target> <branch> を実行し、
+//	ブランチを分割後の履歴に付け替えます。detached HEAD の場合は
+//	元の HEAD を対象に同様の rebase を行い、detached HEAD のまま終了します。
+func reapplyFollowingCommits(origin splitOrigin, target, newBase string) error {
+	upstream := origin.branch
+	if upstream == "" {
+		upstream = origin.head
+	}
+	if err := gitcmd.RunWithIO("rebase", "--onto", newBase, target, upstream); err != nil {
+		return fmt.Errorf("後続コミットの積み直しに失敗しました。コンフリクトを解決して git rebase --continue を実行するか、git rebase --abort で中止してください: %w", err)
+	}
+	return nil
+}
+
+// restoreSplitOrigin は分割を中止した場合に元の HEAD に戻します。
+// 作業ツリーの変更は破棄されます（元のコミットに含まれていた内容なので失われません）。
+func restoreSplitOrigin(origin splitOrigin) {
+	target := origin.branch
+	if target == "" {
+		target = origin.head
+	}
+	if err := gitcmd.RunQuiet("checkout", "-q", "-f", target); err != nil {
+		fmt.Printf("警告: 元の状態への復元に失敗しました。git checkout -f %s を実行してください: %v\n", target, err)
+	}
+}
+
+// getRemainingSplitFiles はまだ新しいコミットに含まれていないファイルを返します。
+//
+// HEAD と分割対象コミットの内容を比較し、差分が残っているファイルを未処理とみなします。
+// ハンク単位で一部だけコミットしたファイルも、差分が残っている限り未処理として扱います。
+func getRemainingSplitFiles(target string, files []splitFile) ([]splitFile, error) {
+	var remaining []splitFile
+	for _, f := range files {
+		args := append([]string{"diff", "--quiet", "HEAD", target, "--"}, splitFilePaths(f)...)
+		err := gitcmd.RunQuiet(args...)
+		if err == nil {
+			continue
+		}
+		if gitcmd.IsExitError(err, 1) {
+			remaining = append(remaining, f)
+			continue
+		}
+		return nil, err
+	}
+	return remaining, nil
+}
+
+// stageSplitFileHunks は git add -p でファイルの一部のハンクをステージします。
+// 新規ファイルは git add -p の対象にならないため、先に git add -N で登録します。
+func stageSplitFileHunks(f splitFile) error {
+	if strings.HasPrefix(f.status, "A") {
+		if err := gitcmd.RunQuiet("add", "-N", "--", f.path); err != nil {
+			return err
+		}
+	}
+	return gitcmd.RunWithIO("add", "-p", "--", f.path)
+}
+
+// getCommitFiles はコミットで変更されたファイルの一覧を取得します。
+//
+// 内部処理:
+//
+//	git diff-tree -r -M --name-status -z で NUL 区切りの一覧を取得します。
+//	リネーム・コピー（R/C）の場合は、変更種別の後に元のパスと新しいパスが続きます。
+func getCommitFiles(commit string) ([]splitFile, error) {
+	output, err := gitcmd.Run("diff-tree", "--no-commit-id", "-r", "-M", "--name-status", "-z", commit)
+	if err != nil {
+		return nil, err
+	}
+	return parseNameStatusZ(string(output)), nil
+}
+
+// parseNameStatusZ は git diff-tree --name-status -z の出力を解析します。
+func parseNameStatusZ(output string) []splitFile {
+	fields := strings.Split(strings.TrimRight(output, "\x00"), "\x00")
+	var files []splitFile
+	for i := 0; i < len(fields); i++ {
+		status := fields[i]
+		if status == "" {
+			continue
+		}
+		if (strings.HasPrefix(status, "R") || strings.HasPrefix(status, "C")) && i+2 < len(fields) {
+			files = append(files, splitFile{status: status, oldPath: fields[i+1], path: fields[i+2]})
+			i += 2
+			continue
+		}
+		if i+1 < len(fields) {
+			files = append(files, splitFile{status: status, path: fields[i+1]})
+			i++
+		}
+	}
+	return files
+}
+
+// parseFileSelection はファイル番号の入力を0始まりのインデックスに変換します。
+//
+// パラメータ:
+//   - input: ユーザー入力（例: "1,3-5"、"a"）
+//   - max: 選択可能な番号の最大値
+//
+// 戻り値:
+//   - []int: 重複を除いた昇順のインデックス
+//   - error: 範囲外の番号や解釈できない入力の場合のエラー
+func parseFileSelection(input string, max int) ([]int, error) {
+	input = strings.TrimSpace(ui.NormalizeNumberInput(input))
+	if input == "a" || input == "all" {
+		all := make([]int, max)
+		for i := range all {
+			all[i] = i
+		}
+		return all, nil
+	}
+
+	seen := make(map[int]bool)
+	for _, part := range strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ' ' || r == '、' }) {
+		start, end := part, part
+		if idx := strings.Index(part, "-"); idx > 0 {
+			start, end = part[:idx], part[idx+1:]
+		}
+		from, err1 := strconv.Atoi(start)
+		to, err2 := strconv.Atoi(end)
+		if err1 != nil || err2 != nil || from < 1 || to > max || from > to {
+			return nil, fmt.Errorf("無効な番号です: %s（1から%dの範囲で指定してください）", part, max)
+		}
+		for n := from; n <= to; n++ {
+			seen[n-1] = true
+		}
+	}
+	if len(seen) == 0 {
+		return nil, fmt.Errorf("ファイル番号を指定してください")
+	}
+
+	indexes := make([]int, 0, len(seen))
+	for idx := range seen {
+		indexes = append(indexes, idx)
+	}
+	sort.Ints(indexes)
+	return indexes, nil
+}
+
+// splitFilePaths はステージや比較に使用するパスの一覧を返します。
+// リネームの場合は元のパスと新しいパスの両方を含めます。
+func splitFilePaths(f splitFile) []string {
+	if f.oldPath != "" {
+		return []string{f.oldPath, f.path}
+	}
+	return []string{f.path}
+}
+
+// formatSplitFile はファイルを一覧表示用の文字列に整形します。
+func formatSplitFile(f splitFile) string {
+	if f.oldPath != "" {
+		return fmt.Sprintf("[%s] %s → %s", f.status[:1], f.oldPath, f.path)
+	}
+	return fmt.Sprintf("[%s] %s", f.status[:1], f.path)
+}
+
+// getSplitOrigin は現在のブランチ名と HEAD のコミットハッシュを取得します。
+func getSplitOrigin() (splitOrigin, error) {
+	head, err := resolveCommit("HEAD")
+	if err != nil {
+		return splitOrigin{}, fmt.Errorf("HEAD の取得に失敗しました: %w", err)
+	}
+	output, err := gitcmd.Run("branch", "--show-current")
+	if err != nil {
+		return splitOrigin{}, fmt.Errorf("現在のブランチの取得に失敗しました: %w", err)
+	}
+	return splitOrigin{branch: strings.TrimSpace(string(output)), head: head}, nil
+}
+
+// resolveCommit はリビジョンをコミットハッシュに解決します。
+func resolveCommit(rev string) (string, error) {
+	output, err := gitcmd.Run("rev-parse", "--verify", "--quiet", rev+"^{commit}")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// getParentCount はコミットの親の数を返します。
+func getParentCount(commit string) (int, error) {
+	output, err := gitcmd.Run("rev-list", "--parents", "-n", "1", commit)
+	if err != nil {
+		return 0, err
+	}
+	return len(strings.Fields(string(output))) - 1, nil
+}
+
+// isWorkingTreeClean は追跡ファイルに未コミットの変更がないかを確認します。
+// 未追跡ファイルは分割処理に影響しないため対象外です。
+func isWorkingTreeClean() (bool, error) {
+	output, err := gitcmd.Run("status", "--porcelain", "--untracked-files=no")
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(string(output)) == "", nil
+}
+
+// init は split-commit コマンドを RootCmd に登録します。
+func init() {
+	cmd.RootCmd.AddCommand(splitCommitCmd)
+}
@@ -0,0 +1,235 @@
+package commit
+
+import (
+	"bufio"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/testutil"
+)
+
+// TestSplitCommitCmd_CommandSetup はsplit-commitコマンドの設定をテストします
+func TestSplitCommitCmd_CommandSetup(t *testing.T) {
+	if splitCommitCmd.Use != "split-commit [リビジョン]" {
+		t.Errorf("splitCommitCmd.Use = %q, want %q", splitCommitCmd.Use, "split-commit [リビジョン]")
+	}
+
+	if splitCommitCmd.Short == "" {
+		t.Error("splitCommitCmd.Short should not be empty")
+	}
+
+	if splitCommitCmd.Long == "" {
+		t.Error("splitCommitCmd.Long should not be empty")
+	}
+
+	if splitCommitCmd.RunE == nil {
+		t.Error("splitCommitCmd.RunE should not be nil")
+	}
+
+	found := false
+	for _, c := range cmd.RootCmd.Commands() {
+		if c == splitCommitCmd {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("splitCommitCmd should be registered in RootCmd")
+	}
+}
+
+// TestParseFileSelection はファイル番号入力の解析をテストします
+func TestParseFileSelection(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		max         int
+		expected    []int
+		expectError bool
+	}{
+		{"単一番号", "2", 3, []int{1}, false},
+		{"カンマ区切り", "1,3", 3, []int{0, 2}, false},
+		{"範囲指定", "2-4", 5, []int{1, 2, 3}, false},
+		{"重複と順不同", "3,1,1-2", 3, []int{0, 1, 2}, false},
+		{"全角数字", "１,２", 2, []int{0, 1}, false},
+		{"すべて", "a", 3, []int{0, 1, 2}, false},
+		{"範囲外", "4", 3, nil, true},
+		{"ゼロ", "0", 3, nil, true},
+		{"逆順の範囲", "3-1", 3, nil, true},
+		{"数値以外", "x", 3, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFileSelection(tt.input, tt.max)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("parseFileSelection(%q) should return error", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFileSelection(%q) returned error: %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("parseFileSelection(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestParseNameStatusZ はdiff-treeのNUL区切り出力の解析をテストします
+func TestParseNameStatusZ(t *testing.T) {
+	output := "M\x00a.txt\x00R100\x00old.txt\x00new.txt\x00A\x00dir/b.txt\x00"
+
+	files := parseNameStatusZ(output)
+	expected := []splitFile{
+		{status: "M", path: "a.txt"},
+		{status: "R100", oldPath: "old.txt", path: "new.txt"},
+		{status: "A", path: "dir/b.txt"},
+	}
+
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("parseNameStatusZ() = %+v, want %+v", files, expected)
+	}
+
+	if got := formatSplitFile(files[1]); got != "[R] old.txt → new.txt" {
+		t.Errorf("formatSplitFile() = %q", got)
+	}
+}
+
+// TestSplitCommit_PastCommit は過去のコミットを分割して後続コミットを積み直す流れをテストします
+func TestSplitCommit_PastCommit(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("README.md", "# Test")
+	repo.Commit("Initial commit")
+
+	repo.CreateFile("a.txt", "a")
+	repo.CreateFile("b.txt", "b")
+	repo.Commit("Add a and b")
+
+	repo.CreateFile("c.txt", "c")
+	repo.Commit("Add c")
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer func() { _ = os.Chdir(oldDir) }()
+
+	if err := os.Chdir(repo.Dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	origin, err := getSplitOrigin()
+	if err != nil {
+		t.Fatalf("getSplitOrigin returned error: %v", err)
+	}
+	if origin.branch != repo.CurrentBranch() {
+		t.Fatalf("origin.branch = %q, want %q", origin.branch, repo.CurrentBranch())
+	}
+
+	target, err := resolveCommit("HEAD~1")
+	if err != nil {
+		t.Fatalf("resolveCommit returned error: %v", err)
+	}
+
+	files, err := getCommitFiles(target)
+	if err != nil {
+		t.Fatalf("getCommitFiles returned error: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("Expected 2 files, got %d", len(files))
+	}
+
+	repo.MustGit("checkout", "-q", "--detach", target)
+	repo.MustGit("reset", "-q", "--mixed", "HEAD^")
+
+	input := "1\nAdd a\na\n\n"
+	completed, err := assignFilesToCommits(bufio.NewReader(strings.NewReader(input)), target, files, "Add b")
+	if err != nil {
+		t.Fatalf("assignFilesToCommits returned error: %v", err)
+	}
+	if !completed {
+		t.Fatal("assignFilesToCommits should complete")
+	}
+
+	newBase, err := resolveCommit("HEAD")
+	if err != nil {
+		t.Fatalf("resolveCommit returned error: %v", err)
+	}
+	if err := reapplyFollowingCommits(origin, target, newBase); err != nil {
+		t.Fatalf("reapplyFollowingCommits returned error: %v", err)
+	}
+
+	if branch := repo.CurrentBranch(); branch != origin.branch {
+		t.Errorf("Current branch = %q, want %q", branch, origin.branch)
+	}
+
+	subjects := strings.Fields(strings.ReplaceAll(repo.MustGit("log", "--format=%s|"), " ", "_"))
+	expected := []string{"Add_c|", "Add_b|", "Add_a|", "Initial_commit|"}
+	if !reflect.DeepEqual(subjects, expected) {
+		t.Errorf("Commit subjects = %v, want %v", subjects, expected)
+	}
+
+	// 分割前後でツリーの内容が変わらないこと
+	if diff := repo.MustGit("diff", origin.head, "HEAD"); diff != "" {
+		t.Errorf("Tree should be unchanged after split, diff:\n%s", diff)
+	}
+}
+
+// TestSplitCommit_Abort は中止時に元の状態へ戻ることをテストします
+func TestSplitCommit_Abort(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("README.md", "# Test")
+	repo.Commit("Initial commit")
+
+	repo.CreateFile("a.txt", "a")
+	repo.CreateFile("b.txt", "b")
+	repo.Commit("Add a and b")
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer func() { _ = os.Chdir(oldDir) }()
+
+	if err := os.Chdir(repo.Dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	origin, err := getSplitOrigin()
+	if err != nil {
+		t.Fatalf("getSplitOrigin returned error: %v", err)
+	}
+	files, err := getCommitFiles(origin.head)
+	if err != nil {
+		t.Fatalf("getCommitFiles returned error: %v", err)
+	}
+
+	repo.MustGit("checkout", "-q", "--detach", origin.head)
+	repo.MustGit("reset", "-q", "--mixed", "HEAD^")
+
+	completed, err := assignFilesToCommits(bufio.NewReader(strings.NewReader("q\n")), origin.head, files, "msg")
+	if err != nil {
+		t.Fatalf("assignFilesToCommits returned error: %v", err)
+	}
+	if completed {
+		t.Fatal("assignFilesToCommits should not complete when aborted")
+	}
+
+	restoreSplitOrigin(origin)
+
+	if branch := repo.CurrentBranch(); branch != origin.branch {
+		t.Errorf("Current branch = %q, want %q", branch, origin.branch)
+	}
+	if head := strings.TrimSpace(repo.MustGit("rev-parse", "HEAD")); head != origin.head {
+		t.Errorf("HEAD = %s, want %s", head, origin.head)
+	}
+	if repo.HasUncommittedChanges() {
+		t.Error("Working tree should be clean after restore")
+	}
+}
@@ -38,6 +38,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/backup"
 	"github.com/tonbiattack/git-plus/internal/gitcmd"
 	"github.com/tonbiattack/git-plus/internal/ui"
 )
@@ -235,14 +236,21 @@ func getRecentCommitsList(count int) ([]commitInfo, error) {
 //   - error: エラーが発生した場合のエラー情報
 //
 // 内部処理:
-//   1. git reset --soft HEAD~<numCommits> でコミットを取り消す
-//   2. 元のコミットメッセージを表示
-//   3. ユーザーに新しいコミットメッセージを入力してもらう
-//   4. 新しいコミットメッセージで git commit を実行
+//   1. 現在のブランチを refs/git-plus/backup/squash/<timestamp> にバックアップ
+//   2. git reset --soft HEAD~<numCommits> でコミットを取り消す
+//   3. 元のコミットメッセージを表示
+//   4. ユーザーに新しいコミットメッセージを入力してもらう
+//   5. 新しいコミットメッセージで git commit を実行
 //
 // 備考:
 //   git reset --soft を使用するため、変更はステージングエリアに保持されます。
 func executeSquash(numCommits int, commits []commitInfo) error {
+	backupRef, err := backup.CreateForHEAD("squash")
+	if err != nil {
+		return fmt.Errorf("バックアップの作成に失敗しました: %w", err)
+	}
+	fmt.Printf("バックアップを作成しました: %s\n", backupRef)
+
 	// git reset --soft を使用してコミットを取り消し
 	resetTarget := fmt.Sprintf("HEAD~%d", numCommits)
 	if err := gitcmd.RunQuiet("reset", "--soft", resetTarget); err != nil {
@@ -275,6 +283,7 @@ func executeSquash(numCommits int, commits []commitInfo) error {
 	}
 
 	fmt.Printf("スカッシュが完了しました。%d個のコミットが1つにまとめられました。\n", numCommits)
+	fmt.Printf("元に戻すには: git backups restore %s\n", backupRef)
 	return nil
 }
 
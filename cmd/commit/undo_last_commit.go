@@ -20,6 +20,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/backup"
 	"github.com/tonbiattack/git-plus/internal/gitcmd"
 )
 
@@ -35,14 +36,22 @@ var undoLastCommitCmd = &cobra.Command{
 注意:
   - コミットは取り消されますが、変更内容は保持されます
   - ステージングエリアに変更が残ります
-  - コミットメッセージを修正したい時などに便利です`,
+  - コミットメッセージを修正したい時などに便利です
+  - 取り消し前のコミットは refs/git-plus/backup/undo-last-commit/ に保存されます`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		// 取り消し前の HEAD をバックアップ参照に保存する
+		backupRef, err := backup.CreateForHEAD("undo-last-commit")
+		if err != nil {
+			return fmt.Errorf("バックアップの作成に失敗しました: %w", err)
+		}
+
 		// git reset --soft HEAD^ を実行して直前のコミットのみを取り消す
 		// --soft オプションにより、変更内容はステージングエリアに保持される
 		if err := gitcmd.RunWithIO("reset", "--soft", "HEAD^"); err != nil {
 			return fmt.Errorf("コミットの取り消しに失敗しました: %w", err)
 		}
 		fmt.Println("最後のコミットを取り消しました（変更は残っています）")
+		fmt.Printf("バックアップ: %s\n", backupRef)
 		return nil
 	},
 }
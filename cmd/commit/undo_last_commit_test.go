@@ -2,6 +2,7 @@ package commit
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/tonbiattack/git-plus/cmd"
@@ -90,6 +91,12 @@ func TestUndoLastCommitCmd_UndoCommit(t *testing.T) {
 	if !repo.HasUncommittedChanges() {
 		t.Error("Should have uncommitted changes after undo")
 	}
+
+	// 取り消し前のコミットがバックアップされているはず
+	backups := repo.MustGit("for-each-ref", "--format=%(subject)", "refs/git-plus/backup/undo-last-commit/")
+	if strings.TrimSpace(backups) != "Add file1" {
+		t.Errorf("Backup ref should point to the undone commit, got %q", backups)
+	}
 }
 
 // TestUndoLastCommitCmd_NoCommitToUndo は取り消すコミットがない場合をテストします
@@ -38,6 +38,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/editor"
 	"github.com/tonbiattack/git-plus/internal/ui"
 )
 
@@ -173,7 +174,7 @@ Issue番号を複数指定して、同一のコメントを全てのissueに追
 // promptForBulkCloseComment はエディタで一括クローズ用のコメントを入力してもらい、その内容を返します。
 func promptForBulkCloseComment(issueNumbers []int) (string, error) {
 	// エディタを取得
-	editor, err := getEditor()
+	editorName, err := editor.Get()
 	if err != nil {
 		return "", fmt.Errorf("エディタの取得に失敗: %w", err)
 	}
@@ -186,8 +187,8 @@ func promptForBulkCloseComment(issueNumbers []int) (string, error) {
 	defer func() { _ = os.Remove(tmpFile) }()
 
 	// エディタで編集
-	fmt.Printf("エディタでコメントを入力中... (%s)\n", editor)
-	if err := openEditor(editor, tmpFile); err != nil {
+	fmt.Printf("エディタでコメントを入力中... (%s)\n", editorName)
+	if err := editor.Open(editorName, tmpFile); err != nil {
 		return "", fmt.Errorf("エディタの起動に失敗: %w", err)
 	}
 
@@ -35,6 +35,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/editor"
 )
 
 // issueCreateCmd は issue-create コマンドの定義です。
@@ -88,7 +89,7 @@ var issueCreateCmd = &cobra.Command{
 // エディタがキャンセルされた場合や、内容が変更されていない場合はエラーを返します。
 func createIssueInEditor() (*IssueContent, error) {
 	// エディタを取得
-	editor, err := getEditor()
+	editorName, err := editor.Get()
 	if err != nil {
 		return nil, fmt.Errorf("エディタの取得に失敗: %w", err)
 	}
@@ -101,9 +102,9 @@ func createIssueInEditor() (*IssueContent, error) {
 	defer func() { _ = os.Remove(tmpFile) }()
 
 	// エディタで編集
-	fmt.Printf("エディタで新しいissueを作成中... (%s)\n", editor)
+	fmt.Printf("エディタで新しいissueを作成中... (%s)\n", editorName)
 	fmt.Println("ヒント: エディタを保存せずに閉じるか、題名を空のままにするとキャンセルされます")
-	if err := openEditor(editor, tmpFile); err != nil {
+	if err := editor.Open(editorName, tmpFile); err != nil {
 		// エディタがキャンセルされた場合
 		if strings.Contains(err.Error(), "キャンセル") {
 			return nil, fmt.Errorf("issueの作成がキャンセルされました")
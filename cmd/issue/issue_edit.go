@@ -48,8 +48,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/tonbiattack/git-plus/cmd"
-	"github.com/tonbiattack/git-plus/internal/gitcmd"
-	"github.com/tonbiattack/git-plus/internal/terminal"
+	"github.com/tonbiattack/git-plus/internal/editor"
 	"github.com/tonbiattack/git-plus/internal/ui"
 )
 
@@ -290,7 +289,7 @@ func getIssueByNumber(issueNumber int) (*IssueEntry, error) {
 // エディタがキャンセルされた場合は操作を中断します。
 func editIssue(issue *IssueEntry) error {
 	// エディタを取得
-	editor, err := getEditor()
+	editorName, err := editor.Get()
 	if err != nil {
 		return fmt.Errorf("エディタの取得に失敗: %w", err)
 	}
@@ -303,9 +302,9 @@ func editIssue(issue *IssueEntry) error {
 	defer func() { _ = os.Remove(tmpFile) }()
 
 	// エディタで編集
-	fmt.Printf("エディタで編集中... (%s)\n", editor)
+	fmt.Printf("エディタで編集中... (%s)\n", editorName)
 	fmt.Println("ヒント: エディタを保存せずに閉じると変更はキャンセルされます")
-	if err := openEditor(editor, tmpFile); err != nil {
+	if err := editor.Open(editorName, tmpFile); err != nil {
 		// エディタがキャンセルされた場合
 		if strings.Contains(err.Error(), "キャンセル") {
 			fmt.Println("編集がキャンセルされました。")
@@ -337,32 +336,6 @@ func editIssue(issue *IssueEntry) error {
 	return nil
 }
 
-// getEditor はユーザーが設定しているエディタを取得します。
-// 優先順位: git config core.editor > VISUAL > EDITOR > デフォルト(vi)
-func getEditor() (string, error) {
-	// git config core.editor を確認
-	output, err := gitcmd.Run("config", "--get", "core.editor")
-	if err == nil && len(output) > 0 {
-		editor := strings.TrimSpace(string(output))
-		if editor != "" {
-			return editor, nil
-		}
-	}
-
-	// 環境変数 VISUAL を確認
-	if visual := os.Getenv("VISUAL"); visual != "" {
-		return visual, nil
-	}
-
-	// 環境変数 EDITOR を確認
-	if editor := os.Getenv("EDITOR"); editor != "" {
-		return editor, nil
-	}
-
-	// デフォルトは vi
-	return "vi", nil
-}
-
 // createTempIssueFile はissueの題名と本文を含む一時ファイルを作成します。
 func createTempIssueFile(issue *IssueEntry) (string, error) {
 	tmpDir := os.TempDir()
@@ -392,96 +365,6 @@ Title: %s
 	return tmpFile, nil
 }
 
-// openEditor は指定されたエディタでファイルを開きます。
-// エディタが中断された場合や、ユーザーがキャンセルした場合はエラーを返します。
-// ターミナルの状態は常に保護され、不正な状態になることはありません。
-func openEditor(editor, filepath string) error {
-	// VSCodeなどのエディタには --wait フラグを追加
-	editor = terminal.AddWaitFlagIfNeeded(editor)
-
-	// エディタコマンドをパースして引数を分割
-	// 引用符を考慮したパースを行う
-	parts, err := parseCommand(editor)
-	if err != nil {
-		return fmt.Errorf("エディタコマンドのパースに失敗: %w", err)
-	}
-	if len(parts) == 0 {
-		return fmt.Errorf("エディタコマンドが空です")
-	}
-
-	args := append(parts[1:], filepath)
-	cmd := exec.Command(parts[0], args...)
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	// ターミナル状態を保護しながらエディタを実行
-	result := terminal.RunEditorWithProtection(cmd)
-
-	if result.Cancelled {
-		return fmt.Errorf("操作がキャンセルされました")
-	}
-	if result.Error != nil {
-		return result.Error
-	}
-
-	return nil
-}
-
-// parseCommand はエディタコマンド文字列をパースして、引用符を考慮した引数リストに分割します。
-func parseCommand(command string) ([]string, error) {
-	var args []string
-	var current strings.Builder
-	inQuote := false
-	quoteChar := rune(0)
-
-	runes := []rune(command)
-	for i := 0; i < len(runes); i++ {
-		r := runes[i]
-
-		switch {
-		case !inQuote && (r == '"' || r == '\''):
-			// 引用符の開始
-			inQuote = true
-			quoteChar = r
-		case inQuote && r == quoteChar:
-			// 引用符の終了
-			inQuote = false
-			quoteChar = 0
-		case !inQuote && r == ' ':
-			// スペース: 引数の区切り
-			if current.Len() > 0 {
-				args = append(args, current.String())
-				current.Reset()
-			}
-		case r == '\\' && i+1 < len(runes):
-			// バックスラッシュエスケープ
-			next := runes[i+1]
-			if inQuote && next == quoteChar {
-				// 引用符内のエスケープされた引用符
-				current.WriteRune(next)
-				i++ // 次の文字をスキップ
-			} else {
-				// 通常のバックスラッシュ（Windowsのパス区切りなど）
-				current.WriteRune(r)
-			}
-		default:
-			current.WriteRune(r)
-		}
-	}
-
-	// 最後の引数を追加
-	if current.Len() > 0 {
-		args = append(args, current.String())
-	}
-
-	if inQuote {
-		return nil, fmt.Errorf("引用符が閉じられていません")
-	}
-
-	return args, nil
-}
-
 // readFileContent はファイルの内容を読み込み、題名と本文を分離して返します。
 func readFileContent(filepath string) (*IssueContent, error) {
 	content, err := os.ReadFile(filepath)
@@ -541,7 +424,7 @@ func updateIssue(issueNumber int, newTitle, newBody string) error {
 // エディタがキャンセルされた場合は空文字列を返します。
 func promptForComment(issue *IssueEntry) (string, error) {
 	// エディタを取得
-	editor, err := getEditor()
+	editorName, err := editor.Get()
 	if err != nil {
 		return "", fmt.Errorf("エディタの取得に失敗: %w", err)
 	}
@@ -554,9 +437,9 @@ func promptForComment(issue *IssueEntry) (string, error) {
 	defer func() { _ = os.Remove(tmpFile) }()
 
 	// エディタで編集
-	fmt.Printf("エディタでコメントを入力中... (%s)\n", editor)
+	fmt.Printf("エディタでコメントを入力中... (%s)\n", editorName)
 	fmt.Println("ヒント: エディタを保存せずに閉じるか、コメントを空のままにするとスキップされます")
-	if err := openEditor(editor, tmpFile); err != nil {
+	if err := editor.Open(editorName, tmpFile); err != nil {
 		// エディタがキャンセルされた場合
 		if strings.Contains(err.Error(), "キャンセル") {
 			fmt.Println("コメント入力がキャンセルされました。")
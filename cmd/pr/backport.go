@@ -0,0 +1,429 @@
+// ================================================================================
+// backport.go
+// ================================================================================
+// このファイルは git の拡張コマンド backport コマンドを実装しています。
+//
+// 【概要】
+// マージ済みの PR またはコミットを、複数のリリースブランチにバックポートします。
+// ターゲットごとに一時的なワークツリーを作成して cherry-pick -x を実行するため、
+// 現在の作業ツリーやブランチには影響しません。
+//
+// 【主な機能】
+// - ターゲットごとに backport/<PR番号>-<ターゲット> ブランチを作成してプッシュ
+// - GitHub CLI (gh) でターゲットへの PR を作成
+// - ターゲットごとに成功・コンフリクト・スキップ・失敗を表示
+// - マージコミット（PR のマージ方法が merge の場合）は -m 1 で cherry-pick
+// - リベースマージされた PR は、リベース後のコミットをすべて cherry-pick
+//
+// 【使用例】
+//   git backport 123 --to release/1.x,release/2.x    # PR #123 をバックポート
+//   git backport abc1234 --to release/1.x            # コミットを指定
+//   git backport 123 --to release/1.x --no-pr        # プッシュのみ（PR は作成しない）
+//
+// 【必要な外部ツール】
+// - GitHub CLI (gh): PR 番号の指定と PR の作成に使用
+// ================================================================================
+
+package pr
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/gitcmd"
+)
+
+var (
+	backportTargets []string // バックポート先のブランチ
+	backportNoPush  bool     // プッシュしないフラグ
+	backportNoPR    bool     // PR を作成しないフラグ
+)
+
+// バックポートの結果
+const (
+	backportSucceeded  = "成功"
+	backportConflicted = "コンフリクト"
+	backportSkipped    = "スキップ"
+	backportFailed     = "失敗"
+)
+
+// prNumberPattern は PR 番号（123 または #123）に一致します
+var prNumberPattern = regexp.MustCompile(`^#?(\d+)$`)
+
+// backportSource はバックポートするコミットの情報です
+type backportSource struct {
+	Label    string   // ブランチ名に使用する識別子（PR 番号またはコミットの短縮ハッシュ）
+	PR       string   // PR 番号（コミットを指定した場合は空）
+	Commits  []string // cherry-pick するコミット（古い順）
+	Title    string   // PR のタイトルまたはコミットの件名
+	Mainline bool     // マージコミットの場合 true（cherry-pick -m 1 を使用）
+}
+
+// backportResult はターゲットごとのバックポートの結果です
+type backportResult struct {
+	Target string // バックポート先のブランチ
+	Branch string // 作成したブランチ
+	Status string // 成功・コンフリクト・スキップ・失敗
+	Detail string // スキップの理由、コンフリクトしたファイル、エラーなど
+	URL    string // 作成した PR の URL
+}
+
+// backportCmd は backport コマンドの定義です。
+var backportCmd = &cobra.Command{
+	Use:   "backport <コミット|PR番号>",
+	Short: "PRまたはコミットを複数のリリースブランチにバックポート",
+	Long: `マージ済みの PR またはコミットを、--to で指定したブランチにバックポートします。
+
+ターゲットごとに以下を実行します:
+  1. 一時的なワークツリーに origin/<ターゲット> から backport/<PR番号>-<ターゲット> を作成
+  2. cherry-pick -x でコミットを適用（マージコミットは -m 1）
+  3. ブランチをプッシュし、gh でターゲットへの PR を作成
+
+ターゲットに既に含まれている場合やブランチがない場合はスキップします。
+コンフリクトしたターゲットは変更を残さず、最後に結果をまとめて表示します。
+コンフリクトまたは失敗したターゲットがある場合は終了コード 1 で終了します。`,
+	Example: `  git backport 123 --to release/1.x,release/2.x   # PR #123 をバックポート
+  git backport abc1234 --to release/1.x           # コミットをバックポート
+  git backport 123 --to release/1.x --no-pr       # PR を作成しない
+  git backport 123 --to release/1.x --no-push     # ローカルにブランチを作成するのみ`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(c *cobra.Command, args []string) error {
+		if len(backportTargets) == 0 {
+			return fmt.Errorf("--to でバックポート先のブランチを指定してください")
+		}
+		if (prNumberPattern.MatchString(args[0]) || (!backportNoPush && !backportNoPR)) && !checkGitHubCLI() {
+			return fmt.Errorf("GitHub CLI (gh) がインストールされていません\nインストール方法: https://cli.github.com/\nPR を作成しない場合は --no-pr を指定してください")
+		}
+
+		if hasOriginRemote() {
+			fmt.Println("リモートの最新の状態を取得しています...")
+			if err := gitcmd.RunQuiet("fetch", "origin"); err != nil {
+				return fmt.Errorf("git fetch origin に失敗しました: %w", err)
+			}
+		}
+
+		source, err := resolveBackportSource(args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("バックポート: %s %s\n", source.Head()[:7], source.Title)
+
+		var results []backportResult
+		for i, target := range backportTargets {
+			fmt.Printf("\n[%d/%d] %s\n", i+1, len(backportTargets), target)
+			result := backportToTarget(source, target)
+			printBackportResult(result)
+			results = append(results, result)
+		}
+
+		fmt.Println("\nバックポートの結果:")
+		failed := 0
+		for _, result := range results {
+			printBackportResult(result)
+			if result.Status == backportConflicted || result.Status == backportFailed {
+				failed++
+			}
+		}
+		if failed > 0 {
+			fmt.Printf("\n%d 個のターゲットでバックポートできませんでした\n", failed)
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+// resolveBackportSource は引数からバックポートするコミットを決定します。
+//
+// パラメータ:
+//   - arg: PR 番号（123 または #123）、またはコミット
+//
+// 戻り値:
+//   - backportSource: バックポートするコミットの情報
+//   - error: PR がマージされていない場合や、コミットが見つからない場合のエラー
+//
+// 内部処理:
+//
+//	PR 番号の場合は gh pr view でマージコミットを取得します。
+//	スカッシュマージの場合はマージコミットがそのまま対象になり、
+//	マージコミットの場合は -m 1 で PR の変更全体を cherry-pick します。
+//	リベースマージの場合はマージコミットが最後のコミットになるため、
+//	PR のコミット数だけさかのぼった件名が PR のコミットと一致すれば、それらをすべて対象にします。
+func resolveBackportSource(arg string) (backportSource, error) {
+	var source backportSource
+
+	if m := prNumberPattern.FindStringSubmatch(arg); m != nil {
+		output, err := exec.Command("gh", "pr", "view", m[1], "--json", "number,title,state,mergeCommit,commits").Output()
+		if err != nil {
+			return source, fmt.Errorf("PR #%s の取得に失敗しました: %w", m[1], err)
+		}
+		var pr struct {
+			Title       string `json:"title"`
+			State       string `json:"state"`
+			MergeCommit *struct {
+				Oid string `json:"oid"`
+			} `json:"mergeCommit"`
+			Commits []struct {
+				MessageHeadline string `json:"messageHeadline"`
+			} `json:"commits"`
+		}
+		if err := json.Unmarshal(output, &pr); err != nil {
+			return source, fmt.Errorf("JSONのパースに失敗: %w", err)
+		}
+		if pr.State != "MERGED" || pr.MergeCommit == nil {
+			return source, fmt.Errorf("PR #%s はマージされていません", m[1])
+		}
+		source = backportSource{Label: m[1], PR: m[1], Commits: []string{pr.MergeCommit.Oid}, Title: pr.Title}
+		if err := gitcmd.RunQuiet("cat-file", "-e", pr.MergeCommit.Oid+"^{commit}"); err != nil {
+			return source, fmt.Errorf("PR #%s のマージコミット %s がローカルにありません（git fetch を実行してください）", m[1], pr.MergeCommit.Oid)
+		}
+		headlines := make([]string, 0, len(pr.Commits))
+		for _, c := range pr.Commits {
+			headlines = append(headlines, c.MessageHeadline)
+		}
+		if commits := rebasedCommits(pr.MergeCommit.Oid, headlines); commits != nil {
+			source.Commits = commits
+		}
+	} else {
+		output, err := gitcmd.Run("rev-parse", "--verify", "--quiet", arg+"^{commit}")
+		if err != nil {
+			return source, fmt.Errorf("コミット %s が見つかりません", arg)
+		}
+		commit := strings.TrimSpace(string(output))
+		subject, err := gitcmd.Run("log", "-1", "--format=%s", commit)
+		if err != nil {
+			return source, fmt.Errorf("コミットの件名の取得に失敗しました: %w", err)
+		}
+		source = backportSource{Label: commit[:7], Commits: []string{commit}, Title: strings.TrimSpace(string(subject))}
+	}
+
+	parents, err := gitcmd.Run("rev-list", "--parents", "-n", "1", source.Head())
+	if err != nil {
+		return source, fmt.Errorf("コミットの親の取得に失敗しました: %w", err)
+	}
+	source.Mainline = len(strings.Fields(string(parents))) > 2
+	return source, nil
+}
+
+// Head はバックポートする最後のコミットを返します
+func (s backportSource) Head() string {
+	return s.Commits[len(s.Commits)-1]
+}
+
+// rebasedCommits はリベースマージされた PR のコミットを返します。
+//
+// パラメータ:
+//   - head: PR のマージコミット（リベースマージの場合は最後のコミット）
+//   - headlines: PR のコミットの件名（古い順）
+//
+// 戻り値:
+//   - []string: head から PR のコミット数だけさかのぼったコミット（古い順）。
+//     PR のコミットが1つの場合や、件名が一致しない場合（スカッシュマージなど）は nil
+func rebasedCommits(head string, headlines []string) []string {
+	if len(headlines) < 2 {
+		return nil
+	}
+	output, err := gitcmd.Run("log", "--reverse", "--first-parent", "--format=%H%x00%s", "-n", fmt.Sprint(len(headlines)), head)
+	if err != nil {
+		return nil
+	}
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	if len(lines) != len(headlines) {
+		return nil
+	}
+	commits := make([]string, 0, len(lines))
+	for i, line := range lines {
+		hash, subject, _ := strings.Cut(line, "\x00")
+		if subject != headlines[i] {
+			return nil
+		}
+		commits = append(commits, hash)
+	}
+	return commits
+}
+
+// backportBranchName はバックポート用のブランチ名を返します（例: backport/123-release-1.x）
+func backportBranchName(label, target string) string {
+	return fmt.Sprintf("backport/%s-%s", label, strings.ReplaceAll(target, "/", "-"))
+}
+
+// backportToTarget は1つのターゲットにバックポートします。
+//
+// パラメータ:
+//   - source: バックポートするコミットの情報
+//   - target: バックポート先のブランチ
+//
+// 戻り値:
+//   - backportResult: バックポートの結果
+//
+// 内部処理:
+//
+//	一時ディレクトリにワークツリーを作成して cherry-pick を実行し、
+//	終了時にワークツリーを削除します。コンフリクトなどで適用できなかった場合は
+//	作成したブランチも削除し、リポジトリに何も残しません。
+func backportToTarget(source backportSource, target string) backportResult {
+	branch := backportBranchName(source.Label, target)
+	result := backportResult{Target: target, Branch: branch}
+
+	base := baseBranchRef(target)
+	if base == "" {
+		result.Status = backportSkipped
+		result.Detail = "ブランチがありません"
+		return result
+	}
+	if gitcmd.RunQuiet("merge-base", "--is-ancestor", source.Head(), base) == nil {
+		result.Status = backportSkipped
+		result.Detail = "既に含まれています"
+		return result
+	}
+	if picked, _ := gitcmd.Run("log", "-1", "--format=%h", "--grep=cherry picked from commit "+source.Head(), base); strings.TrimSpace(string(picked)) != "" {
+		result.Status = backportSkipped
+		result.Detail = fmt.Sprintf("既にバックポート済みです（%s）", strings.TrimSpace(string(picked)))
+		return result
+	}
+	if refExists("refs/heads/"+branch) || refExists("refs/remotes/origin/"+branch) {
+		result.Status = backportSkipped
+		result.Detail = fmt.Sprintf("ブランチ %s は既に存在します", branch)
+		return result
+	}
+
+	tmpDir, err := os.MkdirTemp("", "git-plus-backport-")
+	if err != nil {
+		result.Status = backportFailed
+		result.Detail = fmt.Sprintf("一時ディレクトリの作成に失敗しました: %v", err)
+		return result
+	}
+	worktree := filepath.Join(tmpDir, "worktree")
+	defer func() {
+		_ = gitcmd.RunQuiet("worktree", "remove", "--force", worktree)
+		_ = os.RemoveAll(tmpDir)
+		_ = gitcmd.RunQuiet("worktree", "prune")
+	}()
+
+	if output, err := exec.Command("git", "worktree", "add", "--quiet", "-b", branch, worktree, base).CombinedOutput(); err != nil {
+		result.Status = backportFailed
+		result.Detail = fmt.Sprintf("ワークツリーの作成に失敗しました: %s", strings.TrimSpace(string(output)))
+		return result
+	}
+
+	if err := cherryPickInWorktree(worktree, source, &result); err != nil {
+		_ = gitcmd.RunQuiet("-C", worktree, "cherry-pick", "--abort")
+		_ = gitcmd.RunQuiet("worktree", "remove", "--force", worktree)
+		_ = gitcmd.RunQuiet("branch", "-D", branch)
+		return result
+	}
+
+	if backportNoPush {
+		result.Status = backportSucceeded
+		result.Detail = "プッシュしていません"
+		return result
+	}
+	if output, err := exec.Command("git", "-C", worktree, "push", "--quiet", "-u", "origin", branch).CombinedOutput(); err != nil {
+		result.Status = backportFailed
+		result.Detail = fmt.Sprintf("プッシュに失敗しました: %s", strings.TrimSpace(string(output)))
+		return result
+	}
+
+	result.Status = backportSucceeded
+	if backportNoPR {
+		return result
+	}
+	url, err := createPRWithIssueLink(target, branch, backportPRTitle(source, target), backportPRBody(source, target), prCreateOptions{})
+	if err != nil {
+		result.Status = backportFailed
+		result.Detail = fmt.Sprintf("PRの作成に失敗しました: %v", err)
+		return result
+	}
+	result.URL = url
+	return result
+}
+
+// cherryPickInWorktree はワークツリーでコミットを cherry-pick -x します。
+// 適用できなかった場合は result に状態と詳細を設定してエラーを返します。
+func cherryPickInWorktree(worktree string, source backportSource, result *backportResult) error {
+	args := []string{"-C", worktree, "cherry-pick", "-x"}
+	if source.Mainline {
+		args = append(args, "-m", "1")
+	}
+	args = append(args, source.Commits...)
+	output, err := exec.Command("git", args...).CombinedOutput()
+	if err == nil {
+		return nil
+	}
+
+	unmerged, _ := gitcmd.Run("-C", worktree, "diff", "--name-only", "--diff-filter=U")
+	status, _ := gitcmd.Run("-C", worktree, "status", "--porcelain", "--untracked-files=no")
+	switch {
+	case strings.TrimSpace(string(unmerged)) != "":
+		result.Status = backportConflicted
+		result.Detail = strings.Join(strings.Fields(string(unmerged)), ", ")
+	case strings.TrimSpace(string(status)) == "":
+		// 変更がすべて適用済みの場合、cherry-pick は空のコミットとして停止する
+		result.Status = backportSkipped
+		result.Detail = "変更が既に含まれています"
+	default:
+		result.Status = backportFailed
+		result.Detail = fmt.Sprintf("cherry-pick に失敗しました: %s", strings.TrimSpace(string(output)))
+	}
+	return err
+}
+
+// backportPRTitle はバックポートの PR のタイトルを返します（例: [release/1.x] Fix crash）
+func backportPRTitle(source backportSource, target string) string {
+	return fmt.Sprintf("[%s] %s", target, source.Title)
+}
+
+// backportPRBody はバックポートの PR の本文を返します
+func backportPRBody(source backportSource, target string) string {
+	if source.PR != "" {
+		return fmt.Sprintf("#%s を %s にバックポートします。\n\ncherry-pick: %s", source.PR, target, strings.Join(source.Commits, " "))
+	}
+	return fmt.Sprintf("コミット %s を %s にバックポートします。\n\ncherry-pick: %s", source.Head()[:7], target, source.Head())
+}
+
+// printBackportResult はターゲットごとの結果を1行で表示します
+func printBackportResult(result backportResult) {
+	mark := "✓"
+	switch result.Status {
+	case backportConflicted, backportFailed:
+		mark = "✗"
+	case backportSkipped:
+		mark = "-"
+	}
+
+	line := fmt.Sprintf("  %s %s: %s", mark, result.Target, result.Status)
+	if result.Status == backportSucceeded {
+		line += " " + result.Branch
+	}
+	if result.URL != "" {
+		line += " " + result.URL
+	}
+	if result.Detail != "" {
+		line += "（" + result.Detail + "）"
+	}
+	fmt.Println(line)
+}
+
+// refExists は参照が存在するかどうかを返します
+func refExists(ref string) bool {
+	return gitcmd.RunQuiet("show-ref", "--verify", "--quiet", ref) == nil
+}
+
+// hasOriginRemote は origin リモートが設定されているかどうかを返します
+func hasOriginRemote() bool {
+	return gitcmd.RunQuiet("remote", "get-url", "origin") == nil
+}
+
+// init は backport コマンドを root コマンドに登録します。
+func init() {
+	backportCmd.Flags().StringSliceVar(&backportTargets, "to", nil, "バックポート先のブランチ（カンマ区切りまたは複数指定）")
+	backportCmd.Flags().BoolVar(&backportNoPush, "no-push", false, "ブランチをプッシュしない（PR も作成しない）")
+	backportCmd.Flags().BoolVar(&backportNoPR, "no-pr", false, "PR を作成しない")
+	cmd.RootCmd.AddCommand(backportCmd)
+}
@@ -0,0 +1,227 @@
+package pr
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/testutil"
+)
+
+// setupBackportRepo は release/1.x と release/2.x を持つテスト用リポジトリを作成します。
+// main には release/1.x とだけコンフリクトする修正コミットがあり、そのハッシュを返します。
+func setupBackportRepo(t *testing.T) (*testutil.GitRepo, string) {
+	t.Helper()
+
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("app.txt", "version 1\n")
+	repo.CreateFile("fix.txt", "before\n")
+	repo.Commit("Initial commit")
+
+	repo.CreateBranch("release/2.x")
+	repo.CreateAndCheckoutBranch("release/1.x")
+	repo.CreateFile("fix.txt", "1.x only\n")
+	repo.Commit("Diverge release/1.x")
+	repo.CheckoutBranch("master")
+
+	repo.CreateFile("fix.txt", "fixed\n")
+	repo.Commit("Fix crash")
+	commit := strings.TrimSpace(repo.MustGit("rev-parse", "HEAD"))
+
+	oldDir, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(oldDir) })
+	if err := os.Chdir(repo.Dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	backportNoPR = true
+	t.Cleanup(func() { backportNoPR = false })
+	return repo, commit
+}
+
+// addBackportOrigin は bare リポジトリを origin として追加し、すべてのブランチをプッシュします
+func addBackportOrigin(t *testing.T, repo *testutil.GitRepo) string {
+	t.Helper()
+
+	remote := filepath.Join(t.TempDir(), "origin.git")
+	if output, err := exec.Command("git", "init", "--bare", remote).CombinedOutput(); err != nil {
+		t.Fatalf("git init --bare failed: %v\n%s", err, output)
+	}
+	repo.MustGit("remote", "add", "origin", remote)
+	repo.MustGit("push", "--quiet", "origin", "--all")
+	repo.MustGit("fetch", "--quiet", "origin")
+	return remote
+}
+
+// TestBackportCmd_CommandSetup は backport コマンドの設定をテストします
+func TestBackportCmd_CommandSetup(t *testing.T) {
+	if backportCmd.Use != "backport <コミット|PR番号>" {
+		t.Errorf("backportCmd.Use = %q", backportCmd.Use)
+	}
+	if backportCmd.Short == "" || backportCmd.Long == "" || backportCmd.Example == "" {
+		t.Error("backportCmd should have Short, Long and Example")
+	}
+	for _, name := range []string{"to", "no-push", "no-pr"} {
+		if backportCmd.Flags().Lookup(name) == nil {
+			t.Errorf("backportCmd should have --%s flag", name)
+		}
+	}
+
+	found := false
+	for _, c := range cmd.RootCmd.Commands() {
+		if c == backportCmd {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("backportCmd should be registered in RootCmd")
+	}
+}
+
+// TestBackportBranchName はバックポート用のブランチ名をテストします
+func TestBackportBranchName(t *testing.T) {
+	tests := []struct {
+		label, target, want string
+	}{
+		{"123", "release/1.x", "backport/123-release-1.x"},
+		{"abc1234", "stable", "backport/abc1234-stable"},
+	}
+	for _, tt := range tests {
+		if got := backportBranchName(tt.label, tt.target); got != tt.want {
+			t.Errorf("backportBranchName(%q, %q) = %q, want %q", tt.label, tt.target, got, tt.want)
+		}
+	}
+}
+
+// TestPRNumberPattern は PR 番号の判定をテストします
+func TestPRNumberPattern(t *testing.T) {
+	for _, arg := range []string{"123", "#123"} {
+		if !prNumberPattern.MatchString(arg) {
+			t.Errorf("%q should be a PR number", arg)
+		}
+	}
+	for _, arg := range []string{"abc1234", "HEAD~1", "12a"} {
+		if prNumberPattern.MatchString(arg) {
+			t.Errorf("%q should not be a PR number", arg)
+		}
+	}
+}
+
+// TestResolveBackportSource_Commit はコミット指定のバックポート元の解決をテストします
+func TestResolveBackportSource_Commit(t *testing.T) {
+	repo, commit := setupBackportRepo(t)
+
+	source, err := resolveBackportSource("HEAD")
+	if err != nil {
+		t.Fatalf("resolveBackportSource() error = %v", err)
+	}
+	if source.Head() != commit || source.Label != commit[:7] || source.Title != "Fix crash" || source.Mainline {
+		t.Errorf("resolveBackportSource() = %+v", source)
+	}
+
+	repo.CreateAndCheckoutBranch("feature")
+	repo.CreateFile("feature.txt", "feature\n")
+	repo.Commit("Add feature")
+	repo.CheckoutBranch("master")
+	repo.MustGit("merge", "--no-ff", "-m", "Merge feature", "feature")
+	source, err = resolveBackportSource("HEAD")
+	if err != nil {
+		t.Fatalf("resolveBackportSource() error = %v", err)
+	}
+	if !source.Mainline {
+		t.Error("merge commit should be cherry-picked with -m 1")
+	}
+
+	if _, err := resolveBackportSource("no-such-commit"); err == nil {
+		t.Error("resolveBackportSource() should fail for unknown commit")
+	}
+}
+
+// TestRebasedCommits はリベースマージされた PR のコミットの判定をテストします
+func TestRebasedCommits(t *testing.T) {
+	repo, _ := setupBackportRepo(t)
+	repo.CreateFile("a.txt", "a\n")
+	repo.Commit("Add a")
+	repo.CreateFile("b.txt", "b\n")
+	repo.Commit("Add b")
+	head := strings.TrimSpace(repo.MustGit("rev-parse", "HEAD"))
+
+	commits := rebasedCommits(head, []string{"Add a", "Add b"})
+	if len(commits) != 2 || commits[1] != head {
+		t.Errorf("rebasedCommits() = %v", commits)
+	}
+	if commits := rebasedCommits(head, []string{"Add x", "Add b"}); commits != nil {
+		t.Errorf("rebasedCommits() should be nil for squash merge, got %v", commits)
+	}
+	if commits := rebasedCommits(head, []string{"Add b"}); commits != nil {
+		t.Errorf("rebasedCommits() should be nil for single commit, got %v", commits)
+	}
+}
+
+// TestBackportToTarget はターゲットごとの成功・コンフリクト・スキップをテストします
+func TestBackportToTarget(t *testing.T) {
+	repo, commit := setupBackportRepo(t)
+	remote := addBackportOrigin(t, repo)
+	source := backportSource{Label: "42", PR: "42", Commits: []string{commit}, Title: "Fix crash"}
+
+	result := backportToTarget(source, "release/2.x")
+	if result.Status != backportSucceeded || result.Branch != "backport/42-release-2.x" {
+		t.Fatalf("release/2.x: %+v", result)
+	}
+	message := exec.Command("git", "--git-dir", remote, "log", "-1", "--format=%B", result.Branch)
+	output, err := message.Output()
+	if err != nil {
+		t.Fatalf("backport branch should be pushed: %v", err)
+	}
+	if !strings.Contains(string(output), "(cherry picked from commit "+commit+")") {
+		t.Errorf("commit message should contain cherry-pick -x trailer, got %q", output)
+	}
+
+	result = backportToTarget(source, "release/1.x")
+	if result.Status != backportConflicted || result.Detail != "fix.txt" {
+		t.Errorf("release/1.x: %+v", result)
+	}
+	if refExists("refs/heads/backport/42-release-1.x") {
+		t.Error("branch should be deleted after conflict")
+	}
+
+	result = backportToTarget(source, "release/3.x")
+	if result.Status != backportSkipped {
+		t.Errorf("release/3.x: %+v", result)
+	}
+	result = backportToTarget(source, "master")
+	if result.Status != backportSkipped {
+		t.Errorf("master: %+v", result)
+	}
+	result = backportToTarget(source, "release/2.x")
+	if result.Status != backportSkipped {
+		t.Errorf("release/2.x (existing branch): %+v", result)
+	}
+
+	if worktrees := repo.MustGit("worktree", "list"); strings.Count(worktrees, "\n") != 1 {
+		t.Errorf("temporary worktrees should be removed:\n%s", worktrees)
+	}
+	if status := repo.MustGit("status", "--porcelain"); status != "" {
+		t.Errorf("working tree should not change:\n%s", status)
+	}
+}
+
+// TestBackportToTarget_AlreadyBackported は cherry-pick -x 済みのターゲットをスキップすることをテストします
+func TestBackportToTarget_AlreadyBackported(t *testing.T) {
+	repo, commit := setupBackportRepo(t)
+	repo.CheckoutBranch("release/2.x")
+	repo.MustGit("cherry-pick", "-x", commit)
+	repo.CheckoutBranch("master")
+
+	backportNoPush = true
+	t.Cleanup(func() { backportNoPush = false })
+
+	source := backportSource{Label: "42", Commits: []string{commit}, Title: "Fix crash"}
+	result := backportToTarget(source, "release/2.x")
+	if result.Status != backportSkipped || !strings.Contains(result.Detail, "バックポート済み") {
+		t.Errorf("backportToTarget() = %+v", result)
+	}
+}
@@ -0,0 +1,145 @@
+// ================================================================================
+// codeowners.go
+// ================================================================================
+// このファイルは CODEOWNERS の読み込みと、変更したファイルのオーナーの判定を実装しています。
+//
+// 【CODEOWNERS の場所】
+// GitHub と同じく .github/CODEOWNERS、CODEOWNERS、docs/CODEOWNERS の順に探します。
+//
+// 【パターンの扱い】
+// gitignore と同じ形式のパターンを扱います。
+// - / で始まるパターンや途中に / を含むパターンはリポジトリのルートからのパス
+// - / を含まないパターンは任意の階層のファイル名・ディレクトリ名
+// - * は / 以外の任意の文字列、** は任意の階層、? は / 以外の1文字
+// - / で終わるパターンやディレクトリに一致したパターンは、その配下の全てのファイル
+// 複数のルールに一致した場合は、後に書かれたルールが優先されます。
+// ================================================================================
+
+package pr
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// codeownersRule は CODEOWNERS の1行のルールです
+type codeownersRule struct {
+	Pattern string         // 元のパターン
+	Regexp  *regexp.Regexp // パターンを変換した正規表現
+	Owners  []string       // オーナー（@ を除いたユーザー名または org/team）
+}
+
+// loadCodeowners はリポジトリの CODEOWNERS を読み込みます。
+//
+// パラメータ:
+//   - root: リポジトリのルートディレクトリ
+//
+// 戻り値:
+//   - []codeownersRule: ルール（CODEOWNERS がない場合は nil）
+//   - error: ファイルの読み込みに失敗した場合のエラー
+func loadCodeowners(root string) ([]codeownersRule, error) {
+	for _, name := range []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"} {
+		content, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(name)))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return parseCodeowners(string(content)), nil
+	}
+	return nil, nil
+}
+
+// parseCodeowners は CODEOWNERS の内容を解析します。
+// コメント・空行・解析できないパターンは無視します。
+// メールアドレスで指定されたオーナーは gh pr create --reviewer に渡せないため除外します。
+func parseCodeowners(content string) []codeownersRule {
+	var rules []codeownersRule
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		re, err := codeownersPatternRegexp(fields[0])
+		if err != nil {
+			continue
+		}
+		rule := codeownersRule{Pattern: fields[0], Regexp: re}
+		for _, owner := range fields[1:] {
+			if strings.HasPrefix(owner, "@") {
+				rule.Owners = append(rule.Owners, strings.TrimPrefix(owner, "@"))
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// codeownersPatternRegexp は CODEOWNERS のパターンを正規表現に変換します
+func codeownersPatternRegexp(pattern string) (*regexp.Regexp, error) {
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	pattern = strings.Trim(pattern, "/")
+
+	var sb strings.Builder
+	if anchored {
+		sb.WriteString("^")
+	} else {
+		sb.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '*' && strings.HasPrefix(pattern[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case c == '*' && strings.HasPrefix(pattern[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	// ディレクトリに一致した場合は配下の全てのファイルに一致させる
+	sb.WriteString("(?:/.*)?$")
+	return regexp.Compile(sb.String())
+}
+
+// codeownersFor はファイルのオーナーを返します（後に書かれたルールが優先）
+func codeownersFor(rules []codeownersRule, file string) []string {
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].Regexp.MatchString(file) {
+			return rules[i].Owners
+		}
+	}
+	return nil
+}
+
+// codeownersReviewers は変更したファイルのオーナーを重複なく、出現順に返します
+func codeownersReviewers(rules []codeownersRule, files []string) []string {
+	seen := make(map[string]bool)
+	var owners []string
+	for _, file := range files {
+		for _, owner := range codeownersFor(rules, file) {
+			key := strings.ToLower(owner)
+			if !seen[key] {
+				seen[key] = true
+				owners = append(owners, owner)
+			}
+		}
+	}
+	return owners
+}
@@ -0,0 +1,101 @@
+package pr
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestCodeownersPatternRegexp は CODEOWNERS のパターンの一致をテストします
+func TestCodeownersPatternRegexp(t *testing.T) {
+	tests := []struct {
+		pattern string
+		file    string
+		want    bool
+	}{
+		{"*", "cmd/pr/backport.go", true},
+		{"*.go", "cmd/pr/backport.go", true},
+		{"*.go", "README.md", false},
+		{"/docs/", "docs/commands/tag.md", true},
+		{"/docs/", "internal/docs/a.md", false},
+		{"docs/", "internal/docs/a.md", true},
+		{"docs", "internal/docs/a.md", true},
+		{"cmd/pr/", "cmd/pr/backport.go", true},
+		{"cmd/*.go", "cmd/root.go", true},
+		{"cmd/*.go", "cmd/pr/backport.go", false},
+		{"**/testutil", "internal/testutil/git.go", true},
+		{"internal/**/*.go", "internal/a/b/c.go", true},
+		{"internal/**/*.go", "cmd/a.go", false},
+		{"go.?od", "go.mod", true},
+		{"/go.mod", "sub/go.mod", false},
+	}
+	for _, tt := range tests {
+		re, err := codeownersPatternRegexp(tt.pattern)
+		if err != nil {
+			t.Fatalf("codeownersPatternRegexp(%q) error = %v", tt.pattern, err)
+		}
+		if got := re.MatchString(tt.file); got != tt.want {
+			t.Errorf("pattern %q match %q = %v, want %v (%s)", tt.pattern, tt.file, got, tt.want, re)
+		}
+	}
+}
+
+// TestCodeownersReviewers は後のルールの優先と、オーナーの重複の除去をテストします
+func TestCodeownersReviewers(t *testing.T) {
+	rules := parseCodeowners(`# 全体のオーナー
+*               @alice
+/cmd/pr/        @bob @org/pr-team   # PR コマンド
+*.md            docs@example.com
+/cmd/pr/README.md
+/internal/      @Alice
+`)
+	if len(rules) != 5 {
+		t.Fatalf("parseCodeowners() = %d rules, want 5", len(rules))
+	}
+
+	tests := []struct {
+		file string
+		want []string
+	}{
+		{"main.go", []string{"alice"}},
+		{"cmd/pr/backport.go", []string{"bob", "org/pr-team"}},
+		{"README.md", nil},        // メールアドレスのオーナーは除外
+		{"cmd/pr/README.md", nil}, // オーナーなしのルールが優先
+	}
+	for _, tt := range tests {
+		if got := codeownersFor(rules, tt.file); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("codeownersFor(%q) = %v, want %v", tt.file, got, tt.want)
+		}
+	}
+
+	got := codeownersReviewers(rules, []string{"main.go", "cmd/pr/backport.go", "internal/ui/confirm.go", "cmd/pr/helpers.go"})
+	if want := []string{"alice", "bob", "org/pr-team"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("codeownersReviewers() = %v, want %v", got, want)
+	}
+}
+
+// TestLoadCodeowners は CODEOWNERS の探索順をテストします
+func TestLoadCodeowners(t *testing.T) {
+	root := t.TempDir()
+	if rules, err := loadCodeowners(root); err != nil || rules != nil {
+		t.Errorf("loadCodeowners() without CODEOWNERS = %v, %v", rules, err)
+	}
+
+	for name, owner := range map[string]string{"CODEOWNERS": "@root", ".github/CODEOWNERS": "@github"} {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("* "+owner+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rules, err := loadCodeowners(root)
+	if err != nil {
+		t.Fatalf("loadCodeowners() error = %v", err)
+	}
+	if got := codeownersFor(rules, "a.go"); !reflect.DeepEqual(got, []string{"github"}) {
+		t.Errorf(".github/CODEOWNERS should take precedence, got %v", got)
+	}
+}
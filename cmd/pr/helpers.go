@@ -9,6 +9,8 @@ package pr
 import (
 	"os/exec"
 	"strings"
+
+	"github.com/tonbiattack/git-plus/internal/pausestate"
 )
 
 // getBranchCurrent は現在チェックアウトされているブランチ名を取得します。
@@ -52,23 +54,30 @@ func checkUncommittedChanges() (bool, error) {
 //   - message: stash に付けるメッセージ
 //
 // 戻り値:
-//   - string: 作成された stash の参照（SHA-1 ハッシュ）
-//   - error: stash の作成に失敗した場合のエラー情報
+//   - string: 作成された stash のコミットハッシュ
+//   - error: stash の作成に失敗した場合のエラー情報（保存する変更がない場合は pausestate.ErrNothingToStash）
 //
 // 内部処理:
-//   1. git stash push -m "<メッセージ>" で変更を stash に保存
-//   2. git rev-parse stash@{0} で最新の stash の参照を取得
+//   pausestate.CreateStash で git stash create / git stash store を実行し、
+//   作成したコミットのハッシュをそのまま返します（stash@{0} は読みません）。
 func createStashWithMessage(message string) (string, error) {
-	cmd := exec.Command("git", "stash", "push", "-m", message)
-	if err := cmd.Run(); err != nil {
-		return "", err
-	}
+	return pausestate.CreateStash(message)
+}
 
-	cmd = exec.Command("git", "rev-parse", "stash@{0}")
-	output, err := cmd.Output()
-	if err != nil {
-		return "", err
+// baseBranchRef はベースブランチの参照を返します。
+// origin のリモートブランチを優先し、なければローカルブランチを使用します。
+// どちらもない場合は空文字列を返します。
+//
+// パラメータ:
+//   - branch: ベースブランチ名（例: main, release/1.x）
+//
+// 戻り値:
+//   - string: refs/remotes/origin/<branch> または refs/heads/<branch>
+func baseBranchRef(branch string) string {
+	for _, ref := range []string{"refs/remotes/origin/" + branch, "refs/heads/" + branch} {
+		if refExists(ref) {
+			return ref
+		}
 	}
-
-	return strings.TrimSpace(string(output)), nil
+	return ""
 }
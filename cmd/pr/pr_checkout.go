@@ -13,7 +13,7 @@
 // - PR番号を指定したチェックアウト
 // - 現在の作業内容の自動保存（pause コマンドと同様）
 // - resume コマンドでの復元に対応
-// - 既存の pause 状態の検出と上書き確認
+// - 既存の pause 状態がある場合は重ねて保存（git resume で順に復元）
 //
 // 【使用例】
 //   git pr-checkout          # 最新のPRをチェックアウト
@@ -21,7 +21,7 @@
 //
 // 【内部仕様】
 // - GitHub CLI (gh) の gh pr checkout コマンドを使用
-// - 現在の状態は $HOME/.config/git-plus/pause-state.json に保存
+// - 現在の状態は $HOME/.git-plus/pause-state.json の現在のリポジトリ・ワークツリーのスタックに保存
 // - 未コミットの変更は stash に自動保存
 //
 // 【必要な外部ツール】
@@ -32,6 +32,7 @@ package pr
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -40,7 +41,6 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/tonbiattack/git-plus/cmd"
 	"github.com/tonbiattack/git-plus/internal/pausestate"
-	"github.com/tonbiattack/git-plus/internal/ui"
 )
 
 // prCheckoutCmd は pr-checkout コマンドの定義です。
@@ -94,39 +94,42 @@ PR番号を指定すると、その番号のPRをチェックアウトします
 			return fmt.Errorf("変更の確認に失敗: %w", err)
 		}
 
-		// 既に pause 状態かチェック（変更がある場合のみ確認プロンプトを表示）
-		exists, err := pausestate.Exists()
+		// pause 状態は現在のリポジトリ・ワークツリーのスタックに積む
+		ctx, err := pausestate.CurrentContext()
 		if err != nil {
-			return fmt.Errorf("状態の確認に失敗: %w", err)
+			return err
 		}
 
-		if exists && hasChanges {
-			state, err := pausestate.Load()
-			if err != nil {
-				return fmt.Errorf("既存の状態の読み込みに失敗: %w", err)
-			}
-
-			fmt.Printf("警告: 既に pause 状態です（%s → %s）\n", state.FromBranch, state.ToBranch)
-
-			if !ui.Confirm("上書きしてPRをチェックアウトしますか？", false) {
-				fmt.Println("キャンセルしました")
-				return nil
-			}
+		// 既に pause 状態であれば、その上に重ねて保存する
+		stack, err := pausestate.Stack(ctx)
+		if err != nil {
+			return fmt.Errorf("状態の確認に失敗: %w", err)
+		}
+		if len(stack) > 0 {
+			latest := stack[len(stack)-1]
+			fmt.Printf("既に pause 状態です（%s → %s）。重ねて保存します（%d 件目）\n", latest.FromBranch, latest.ToBranch, len(stack)+1)
 		}
 
-		var stashRef string
+		var stashCommit string
 		stashMessage := fmt.Sprintf("git-pr-checkout: from %s", currentBranch)
 
 		if hasChanges {
 			fmt.Println("変更を保存中...")
-			stashRef, err = createStashWithMessage(stashMessage)
-			if err != nil {
+			stashCommit, err = createStashWithMessage(stashMessage)
+			if errors.Is(err, pausestate.ErrNothingToStash) {
+				// 未追跡のファイルのみの場合は保存せず、作業ツリーに残したまま切り替える
+				fmt.Println("追跡中のファイルに変更がないため、スタッシュはスキップします（未追跡のファイルはそのまま残ります）")
+			} else if err != nil {
 				return fmt.Errorf("スタッシュの作成に失敗: %w", err)
+			} else {
+				// stash 一覧から drop されても復元できるよう、専用の参照でも保持する
+				if _, err := pausestate.KeepStash(stashCommit); err != nil {
+					fmt.Printf("警告: %v\n", err)
+				}
+				fmt.Printf("✓ 変更を保存しました: %s\n", stashCommit)
 			}
-			fmt.Printf("✓ 変更を保存しました: %s\n", stashRef)
 		} else {
 			fmt.Println("変更がないため、スタッシュはスキップします")
-			stashRef = ""
 		}
 
 		// PRをチェックアウト
@@ -134,11 +137,11 @@ PR番号を指定すると、その番号のPRをチェックアウトします
 		targetBranch, err := performPRCheckout(prNumber)
 		if err != nil {
 			// エラー時はスタッシュを戻す
-			if stashRef != "" {
+			if stashCommit != "" {
 				fmt.Println("スタッシュを復元中...")
-				if popErr := popStashNow(); popErr != nil {
+				if popErr := popStashNow(stashCommit); popErr != nil {
 					fmt.Printf("警告: スタッシュの復元に失敗: %v\n", popErr)
-					fmt.Println("手動で復元してください: git stash pop")
+					fmt.Printf("手動で復元してください: git stash apply %s\n", stashCommit)
 				}
 			}
 			return fmt.Errorf("PRのチェックアウトに失敗: %w", err)
@@ -148,12 +151,13 @@ PR番号を指定すると、その番号のPRをチェックアウトします
 		state := &pausestate.PauseState{
 			FromBranch:   currentBranch,
 			ToBranch:     targetBranch,
-			StashRef:     stashRef,
+			StashCommit:  stashCommit,
 			StashMessage: stashMessage,
+			Command:      "pr-checkout",
 			Timestamp:    time.Now(),
 		}
 
-		if err := pausestate.Save(state); err != nil {
+		if err := pausestate.Push(ctx, state); err != nil {
 			return fmt.Errorf("状態の保存に失敗: %w", err)
 		}
 
@@ -235,19 +239,19 @@ func performPRCheckout(prNumber string) (string, error) {
 	return branch, nil
 }
 
-// popStashNow は最新のスタッシュを復元します。
+// popStashNow は pr-checkout で作成したスタッシュを復元します。
+//
+// パラメータ:
+//   - commit: 復元するスタッシュのコミットハッシュ
 //
 // 戻り値:
 //   - error: スタッシュの復元に失敗した場合のエラー情報
 //
 // 内部処理:
-//   git stash pop コマンドで最新のスタッシュを復元します。
+//   stash@{0} ではなくコミットハッシュでスタッシュを探して復元し、保持用の参照を削除します。
 //   エラー発生時（PRチェックアウト失敗など）のロールバックに使用されます。
-func popStashNow() error {
-	cmd := exec.Command("git", "stash", "pop")
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+func popStashNow(commit string) error {
+	return pausestate.RestoreStash(&pausestate.PauseState{StashCommit: commit})
 }
 
 // init は pr-checkout コマンドを root コマンドに登録します。
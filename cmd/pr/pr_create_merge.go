@@ -10,6 +10,8 @@
 //
 // 【主な機能】
 // - タイトル・本文なしでのPR作成（--fill オプションで自動生成）
+// - PR テンプレートがある場合はコミットとテンプレートから本文を作成してエディタで編集
+// - レビュアー・ラベル・担当者・マイルストーンの指定、CODEOWNERS からのレビュアーの提案
 // - PRの即座のマージとブランチ削除
 // - ベースブランチへの自動切り替え
 // - 最新の変更の自動取得（git pull）
@@ -20,6 +22,7 @@
 //   git pr-create-merge              # 対話的にベースブランチを入力
 //   git pr-create-merge main         # main ブランチへマージ
 //   git pr-create-merge develop      # develop ブランチへマージ
+//   git pr-create-merge main -l bug  # ラベルを付けて main ブランチへマージ
 //
 // 【必要な外部ツール】
 // - GitHub CLI (gh): https://cli.github.com/
@@ -39,6 +42,9 @@ import (
 	"github.com/tonbiattack/git-plus/internal/ui"
 )
 
+// prCreateMergeOpts は pr-create-merge の PR 作成オプションです
+var prCreateMergeOpts prCreateOptions
+
 // prCreateMergeCmd は pr-create-merge コマンドの定義です。
 // PRの作成からマージ、ブランチ削除までを一気に実行します。
 var prCreateMergeCmd = &cobra.Command{
@@ -48,10 +54,17 @@ var prCreateMergeCmd = &cobra.Command{
   1. タイトル・本文なしでPRを作成（--fillオプション使用）
   2. PRをマージしてブランチを削除（--merge --delete-branch）
   3. ベースブランチに切り替え（git switch）
-  4. 最新の変更を取得（git pull）`,
+  4. 最新の変更を取得（git pull）
+
+PRテンプレート（.github/pull_request_template.md やディレクトリごとのテンプレート）が
+ある場合は、コミットメッセージとテンプレートから本文を作成してエディタで編集します。
+変更したファイルに CODEOWNERS が設定されている場合は、レビュアーへの追加を提案します。
+すぐにマージするため、--draft は使用できません（git pr-issue-link --draft を使用してください）。`,
 	Example: `  git pr-create-merge              # 対話的にベースブランチを入力
   git pr-create-merge main         # mainブランチへマージ
-  git pr-create-merge develop      # developブランチへマージ`,
+  git pr-create-merge develop      # developブランチへマージ
+  git pr-create-merge main -l bug -a @me       # ラベルと担当者を指定
+  git pr-create-merge main --no-template       # テンプレートを使用しない`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// 現在のブランチを取得
 		currentBranch, err := getCurrentBranchForPR()
@@ -86,6 +99,24 @@ var prCreateMergeCmd = &cobra.Command{
 		fmt.Printf("\nベースブランチ: %s\n", baseBranch)
 		fmt.Printf("ヘッドブランチ: %s\n", currentBranch)
 
+		// PRテンプレートがある場合は本文を作成してエディタで編集
+		draft, err := collectPRDraft(baseBranch, currentBranch, prCreateMergeOpts)
+		if err != nil {
+			return err
+		}
+		var body string
+		if draft.Template != "" {
+			if body, err = preparePRBody(draft, prCreateMergeOpts); err != nil {
+				return fmt.Errorf("PRの本文の作成に失敗しました: %w", err)
+			}
+		}
+
+		opts := prCreateMergeOpts
+		suggestCodeownersReviewers(draft.Files, &opts)
+		for _, line := range opts.describe() {
+			fmt.Println(line)
+		}
+
 		if !ui.Confirm("\nPRを作成してマージしますか？", true) {
 			fmt.Println("キャンセルしました。")
 			return nil
@@ -93,7 +124,7 @@ var prCreateMergeCmd = &cobra.Command{
 
 		// Step 1: PRを作成
 		fmt.Println("\n[1/4] PRを作成しています...")
-		if err := createPRForMerge(baseBranch, currentBranch); err != nil {
+		if err := createPRForMerge(baseBranch, currentBranch, body, opts); err != nil {
 			return fmt.Errorf("PRの作成に失敗しました: %w", err)
 		}
 		fmt.Println("✓ PRを作成しました")
@@ -146,6 +177,8 @@ func getCurrentBranchForPR() (string, error) {
 // パラメータ:
 //   - base: マージ先のベースブランチ名
 //   - head: マージ元のヘッドブランチ名
+//   - body: PRの本文（空の場合はコミット履歴から自動生成）
+//   - opts: レビュアー・ラベル・担当者・マイルストーンの指定
 //
 // 戻り値:
 //   - error: PR作成に失敗した場合のエラー情報
@@ -153,11 +186,15 @@ func getCurrentBranchForPR() (string, error) {
 // 内部処理:
 //   gh pr create --base <base> --head <head> --fill コマンドを実行します。
 //   --fill オプションにより、タイトルと本文はコミット履歴から自動生成されます。
-func createPRForMerge(base, head string) error {
-	cmd := exec.Command("gh", "pr", "create",
-		"--base", base,
-		"--head", head,
-		"--fill")
+//   本文が指定された場合は --body で本文を上書きします。
+func createPRForMerge(base, head, body string, opts prCreateOptions) error {
+	args := []string{"pr", "create", "--base", base, "--head", head, "--fill"}
+	if body != "" {
+		args = append(args, "--body", body)
+	}
+	args = append(args, opts.args()...)
+
+	cmd := exec.Command("gh", args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	cmd.Stdin = os.Stdin
@@ -219,5 +256,6 @@ func pullLatestChanges() error {
 // init は pr-create-merge コマンドを root コマンドに登録します。
 // この関数はパッケージの初期化時に自動的に呼び出されます。
 func init() {
+	addPRCreateFlags(prCreateMergeCmd, &prCreateMergeOpts)
 	cmd.RootCmd.AddCommand(prCreateMergeCmd)
 }
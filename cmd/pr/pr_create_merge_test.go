@@ -46,3 +46,17 @@ func TestPrCreateMergeCmd_HasRunE(t *testing.T) {
 		t.Error("prCreateMergeCmd.RunE should not be nil")
 	}
 }
+
+// TestPrCreateMergeCmd_HasFlags は PR 作成オプションのフラグが設定されていることを確認します
+func TestPrCreateMergeCmd_HasFlags(t *testing.T) {
+	for _, name := range []string{"reviewer", "label", "assignee", "milestone", "template", "no-template", "no-edit", "no-codeowners"} {
+		if prCreateMergeCmd.Flags().Lookup(name) == nil {
+			t.Errorf("prCreateMergeCmd should have '%s' flag", name)
+		}
+	}
+
+	// すぐにマージするためドラフトは作成できない
+	if prCreateMergeCmd.Flags().Lookup("draft") != nil {
+		t.Error("prCreateMergeCmd should not have 'draft' flag")
+	}
+}
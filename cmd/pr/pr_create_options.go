@@ -0,0 +1,441 @@
+// ================================================================================
+// pr_create_options.go
+// ================================================================================
+// このファイルは pr-create-merge と pr-issue-link で共通の PR 作成オプションを実装しています。
+//
+// 【概要】
+// gh pr create に渡すレビュアー・ラベル・担当者・マイルストーン・ドラフトの指定と、
+// PR テンプレートとコミットメッセージからの本文の作成、CODEOWNERS からの
+// レビュアーの提案を提供します。
+//
+// 【PR テンプレートの探索】
+// 変更したファイルに共通するディレクトリから、リポジトリのルートに向かって順に
+// 以下のファイルを探し、最初に見つかったものを使用します（大文字・小文字は区別しません）。
+//   <ディレクトリ>/.github/pull_request_template.md
+//   <ディレクトリ>/pull_request_template.md
+//   <ディレクトリ>/docs/pull_request_template.md
+// 見つからない場合は .github/PULL_REQUEST_TEMPLATE/ 内のテンプレートから選択します。
+//
+// 【本文の作成】
+// コミットが1つの場合はコミットメッセージの本文、複数の場合はコミットの件名の
+// 箇条書きを先頭に置き、その後にテンプレートを続けます。作成した本文は
+// エディタで編集できます（--no-edit で省略）。
+// ================================================================================
+
+package pr
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tonbiattack/git-plus/internal/editor"
+	"github.com/tonbiattack/git-plus/internal/gitcmd"
+	"github.com/tonbiattack/git-plus/internal/ui"
+)
+
+// prTemplateFileName は PR テンプレートのファイル名です
+const prTemplateFileName = "pull_request_template.md"
+
+// prCreateOptions は PR の作成時に指定するオプションです
+type prCreateOptions struct {
+	Reviewers    []string // レビュアー（--reviewer）
+	Labels       []string // ラベル（--label）
+	Assignees    []string // 担当者（--assignee）
+	Milestone    string   // マイルストーン（--milestone）
+	Draft        bool     // ドラフトとして作成（--draft）
+	Template     string   // 使用するテンプレートのパス（--template）
+	NoTemplate   bool     // テンプレートを使用しない（--no-template）
+	NoEdit       bool     // 本文をエディタで編集しない（--no-edit）
+	NoCodeowners bool     // CODEOWNERS のレビュアーを提案しない（--no-codeowners）
+}
+
+// prCommit は PR に含まれるコミットです
+type prCommit struct {
+	Subject string // 件名
+	Body    string // 本文
+}
+
+// prDraft は PR の本文を作成するために集めた情報です
+type prDraft struct {
+	Commits      []prCommit // ベースブランチにないコミット（古い順）
+	Files        []string   // 変更したファイル（リポジトリのルートからの相対パス）
+	Template     string     // 使用するテンプレートのパス（なければ空）
+	TemplateBody string     // テンプレートの内容
+}
+
+// addPRCreateFlags は PR の作成オプションのフラグをコマンドに登録します。
+// --draft はマージまで行う pr-create-merge では使えないため、ここでは登録しません。
+func addPRCreateFlags(c *cobra.Command, opts *prCreateOptions) {
+	c.Flags().StringSliceVarP(&opts.Reviewers, "reviewer", "r", nil, "レビュアー（カンマ区切りまたは複数指定、チームは org/team）")
+	c.Flags().StringSliceVarP(&opts.Labels, "label", "l", nil, "ラベル（カンマ区切りまたは複数指定）")
+	c.Flags().StringSliceVarP(&opts.Assignees, "assignee", "a", nil, "担当者（カンマ区切りまたは複数指定、自分は @me）")
+	c.Flags().StringVarP(&opts.Milestone, "milestone", "m", "", "マイルストーン")
+	c.Flags().StringVar(&opts.Template, "template", "", "使用する PR テンプレートのパス（リポジトリのルートからの相対パス）")
+	c.Flags().BoolVar(&opts.NoTemplate, "no-template", false, "PR テンプレートを使用しない")
+	c.Flags().BoolVar(&opts.NoEdit, "no-edit", false, "PR の本文をエディタで編集しない")
+	c.Flags().BoolVar(&opts.NoCodeowners, "no-codeowners", false, "CODEOWNERS のレビュアーを提案しない")
+}
+
+// args は gh pr create に追加する引数を返します
+func (o prCreateOptions) args() []string {
+	var args []string
+	for _, reviewer := range o.Reviewers {
+		args = append(args, "--reviewer", reviewer)
+	}
+	for _, label := range o.Labels {
+		args = append(args, "--label", label)
+	}
+	for _, assignee := range o.Assignees {
+		args = append(args, "--assignee", assignee)
+	}
+	if o.Milestone != "" {
+		args = append(args, "--milestone", o.Milestone)
+	}
+	if o.Draft {
+		args = append(args, "--draft")
+	}
+	return args
+}
+
+// describe は確認用にオプションを1行ずつ返します（指定がないものは省略）
+func (o prCreateOptions) describe() []string {
+	var lines []string
+	if len(o.Reviewers) > 0 {
+		lines = append(lines, "レビュアー: "+strings.Join(o.Reviewers, ", "))
+	}
+	if len(o.Labels) > 0 {
+		lines = append(lines, "ラベル: "+strings.Join(o.Labels, ", "))
+	}
+	if len(o.Assignees) > 0 {
+		lines = append(lines, "担当者: "+strings.Join(o.Assignees, ", "))
+	}
+	if o.Milestone != "" {
+		lines = append(lines, "マイルストーン: "+o.Milestone)
+	}
+	if o.Draft {
+		lines = append(lines, "ドラフト: はい")
+	}
+	return lines
+}
+
+// collectPRDraft はコミット・変更したファイル・テンプレートを集めます。
+//
+// パラメータ:
+//   - base: マージ先のベースブランチ名
+//   - head: マージ元のヘッドブランチ名
+//   - opts: PR の作成オプション（テンプレートの指定）
+//
+// 戻り値:
+//   - prDraft: 集めた情報（ベースブランチが見つからない場合はコミットと変更したファイルは空）
+//   - error: テンプレートの読み込みに失敗した場合のエラー
+//
+// 内部処理:
+//   ベースブランチは origin のリモートブランチを優先します。
+//   コミットは git log <base>..<head>、変更したファイルは git diff --name-only <base>...<head> で取得します。
+func collectPRDraft(base, head string, opts prCreateOptions) (prDraft, error) {
+	var draft prDraft
+
+	if ref := baseBranchRef(base); ref != "" {
+		draft.Commits = getPRCommits(ref, head)
+		if output, err := gitcmd.Run("diff", "--name-only", ref+"..."+head); err == nil {
+			draft.Files = splitLines(string(output))
+		}
+	}
+
+	if opts.NoTemplate {
+		return draft, nil
+	}
+	root, err := getRepoRootForPR()
+	if err != nil {
+		return draft, fmt.Errorf("リポジトリのルートディレクトリを取得できませんでした: %w", err)
+	}
+
+	template := opts.Template
+	if template == "" {
+		template = findPRTemplate(root, draft.Files)
+	}
+	if template == "" {
+		if template, err = selectPRTemplate(root); err != nil {
+			return draft, err
+		}
+	}
+	if template == "" {
+		return draft, nil
+	}
+
+	content, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(template)))
+	if err != nil {
+		return draft, fmt.Errorf("PR テンプレート %s の読み込みに失敗しました: %w", template, err)
+	}
+	draft.Template = template
+	draft.TemplateBody = strings.TrimSpace(string(content))
+	return draft, nil
+}
+
+// Body はコミットメッセージとテンプレートから PR の本文を作成します。
+// コミットが1つの場合はその本文を、複数の場合は件名の箇条書きを先頭に置きます。
+func (d prDraft) Body() string {
+	var parts []string
+
+	if len(d.Commits) == 1 {
+		if body := strings.TrimSpace(d.Commits[0].Body); body != "" {
+			parts = append(parts, body)
+		}
+	} else if len(d.Commits) > 1 {
+		var lines []string
+		for _, c := range d.Commits {
+			lines = append(lines, "- "+c.Subject)
+		}
+		parts = append(parts, strings.Join(lines, "\n"))
+	}
+
+	if d.TemplateBody != "" {
+		parts = append(parts, d.TemplateBody)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// getPRCommits はベースブランチにないコミットを古い順に返します
+func getPRCommits(baseRef, head string) []prCommit {
+	output, err := gitcmd.Run("log", "--reverse", "--format=%s%x1f%b%x1e", baseRef+".."+head)
+	if err != nil {
+		return nil
+	}
+
+	var commits []prCommit
+	for _, record := range strings.Split(string(output), "\x1e") {
+		record = strings.TrimSpace(record)
+		if record == "" {
+			continue
+		}
+		subject, body, _ := strings.Cut(record, "\x1f")
+		commits = append(commits, prCommit{Subject: strings.TrimSpace(subject), Body: strings.TrimSpace(body)})
+	}
+	return commits
+}
+
+// findPRTemplate は変更したファイルに最も近い PR テンプレートを探します。
+//
+// パラメータ:
+//   - root: リポジトリのルートディレクトリ
+//   - files: 変更したファイル（リポジトリのルートからの / 区切りの相対パス）
+//
+// 戻り値:
+//   - string: テンプレートのパス（リポジトリのルートからの / 区切りの相対パス、なければ空）
+func findPRTemplate(root string, files []string) string {
+	for dir := commonDir(files); ; dir = path.Dir(dir) {
+		for _, sub := range []string{".github", "", "docs"} {
+			candidate := path.Join(dir, sub)
+			if name := findFileFold(filepath.Join(root, filepath.FromSlash(candidate)), prTemplateFileName); name != "" {
+				return path.Join(candidate, name)
+			}
+		}
+		if dir == "." {
+			return ""
+		}
+	}
+}
+
+// selectPRTemplate は .github/PULL_REQUEST_TEMPLATE/ 内のテンプレートを選択させます。
+// テンプレートが1つの場合はそれを使用し、ない場合は空文字列を返します。
+func selectPRTemplate(root string) (string, error) {
+	dirName := findFileFold(filepath.Join(root, ".github"), "PULL_REQUEST_TEMPLATE")
+	if dirName == "" {
+		return "", nil
+	}
+	entries, err := os.ReadDir(filepath.Join(root, ".github", dirName))
+	if err != nil {
+		return "", nil
+	}
+
+	var templates []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.EqualFold(filepath.Ext(entry.Name()), ".md") {
+			templates = append(templates, path.Join(".github", dirName, entry.Name()))
+		}
+	}
+	switch len(templates) {
+	case 0:
+		return "", nil
+	case 1:
+		return templates[0], nil
+	}
+
+	fmt.Println("PR テンプレートを選択してください:")
+	for i, template := range templates {
+		fmt.Printf("  %d. %s\n", i+1, path.Base(template))
+	}
+	fmt.Print("番号を入力してください（空の場合はテンプレートを使用しない）: ")
+
+	reader := bufio.NewReader(os.Stdin)
+	input, err := reader.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("入力の読み込みに失敗しました: %w", err)
+	}
+	input = ui.NormalizeNumberInput(strings.TrimSpace(input))
+	if input == "" {
+		return "", nil
+	}
+	idx, err := strconv.Atoi(input)
+	if err != nil || idx < 1 || idx > len(templates) {
+		return "", fmt.Errorf("無効な番号: %s (1から%dの範囲で入力してください)", input, len(templates))
+	}
+	return templates[idx-1], nil
+}
+
+// commonDir は全てのファイルを含む最も深いディレクトリを返します（ファイルがない場合は "."）
+func commonDir(files []string) string {
+	if len(files) == 0 {
+		return "."
+	}
+	common := path.Dir(files[0])
+	for _, file := range files[1:] {
+		for common != "." && !strings.HasPrefix(file, common+"/") {
+			common = path.Dir(common)
+		}
+	}
+	return common
+}
+
+// findFileFold はディレクトリ内で大文字・小文字を区別せずに名前が一致するエントリを探します
+func findFileFold(dir, name string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	for _, entry := range entries {
+		if strings.EqualFold(entry.Name(), name) {
+			return entry.Name()
+		}
+	}
+	return ""
+}
+
+// editPRBody は PR の本文をエディタで編集します。
+//
+// パラメータ:
+//   - body: 編集前の本文
+//
+// 戻り値:
+//   - string: 編集後の本文（前後の空白は除去されます）
+//   - error: エディタの起動に失敗した場合や、編集がキャンセルされた場合のエラー
+func editPRBody(body string) (string, error) {
+	editorName, err := editor.Get()
+	if err != nil {
+		return "", fmt.Errorf("エディタの取得に失敗: %w", err)
+	}
+
+	tmpFile, err := os.CreateTemp("", "git-plus-pr-*.md")
+	if err != nil {
+		return "", fmt.Errorf("一時ファイルの作成に失敗: %w", err)
+	}
+	defer func() { _ = os.Remove(tmpFile.Name()) }()
+	if _, err := tmpFile.WriteString(body + "\n"); err != nil {
+		_ = tmpFile.Close()
+		return "", fmt.Errorf("一時ファイルの書き込みに失敗: %w", err)
+	}
+	_ = tmpFile.Close()
+
+	fmt.Printf("エディタで PR の本文を編集中... (%s)\n", editorName)
+	if err := editor.Open(editorName, tmpFile.Name()); err != nil {
+		return "", err
+	}
+
+	content, err := os.ReadFile(tmpFile.Name())
+	if err != nil {
+		return "", fmt.Errorf("編集内容の読み込みに失敗: %w", err)
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+// preparePRBody は PR の本文を作成し、--no-edit でなければエディタで編集します
+func preparePRBody(draft prDraft, opts prCreateOptions) (string, error) {
+	body := draft.Body()
+	if draft.Template != "" {
+		fmt.Printf("PR テンプレート: %s\n", draft.Template)
+	}
+	if opts.NoEdit {
+		return body, nil
+	}
+	return editPRBody(body)
+}
+
+// suggestCodeownersReviewers は変更したファイルの CODEOWNERS をレビュアーとして提案します。
+// 承認された場合は opts.Reviewers に追加します。
+//
+// パラメータ:
+//   - files: 変更したファイル
+//   - opts: PR の作成オプション（Reviewers を更新します）
+func suggestCodeownersReviewers(files []string, opts *prCreateOptions) {
+	if opts.NoCodeowners || len(files) == 0 {
+		return
+	}
+	root, err := getRepoRootForPR()
+	if err != nil {
+		return
+	}
+	rules, err := loadCodeowners(root)
+	if err != nil || len(rules) == 0 {
+		return
+	}
+
+	exclude := make(map[string]bool)
+	for _, reviewer := range opts.Reviewers {
+		exclude[strings.ToLower(strings.TrimPrefix(reviewer, "@"))] = true
+	}
+	if login := getGitHubLogin(); login != "" {
+		// PR の作成者自身はレビュアーに指定できない
+		exclude[strings.ToLower(login)] = true
+	}
+
+	var suggested []string
+	for _, owner := range codeownersReviewers(rules, files) {
+		if !exclude[strings.ToLower(owner)] {
+			suggested = append(suggested, owner)
+		}
+	}
+	if len(suggested) == 0 {
+		return
+	}
+
+	fmt.Printf("CODEOWNERS のレビュアー: %s\n", strings.Join(suggested, ", "))
+	if ui.Confirm("レビュアーに追加しますか？", true) {
+		opts.Reviewers = append(opts.Reviewers, suggested...)
+	}
+}
+
+// getRepoRootForPR はリポジトリのルートディレクトリを返します
+func getRepoRootForPR() (string, error) {
+	output, err := gitcmd.Run("rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// getGitHubLogin は gh で認証しているユーザーのログイン名を返します（取得できない場合は空）
+func getGitHubLogin() string {
+	output, err := exec.Command("gh", "api", "user", "--jq", ".login").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// splitLines は空行を除いて行に分割します
+func splitLines(output string) []string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
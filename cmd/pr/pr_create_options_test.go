@@ -0,0 +1,202 @@
+package pr
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tonbiattack/git-plus/internal/testutil"
+)
+
+// writePRFiles はリポジトリのルートからの相対パスでファイルを作成します
+func writePRFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestPrCreateOptions_Args は gh pr create に渡す引数をテストします
+func TestPrCreateOptions_Args(t *testing.T) {
+	opts := prCreateOptions{
+		Reviewers: []string{"alice", "org/team"},
+		Labels:    []string{"bug"},
+		Assignees: []string{"@me"},
+		Milestone: "v1.2",
+		Draft:     true,
+		NoEdit:    true,
+	}
+	want := []string{
+		"--reviewer", "alice", "--reviewer", "org/team",
+		"--label", "bug",
+		"--assignee", "@me",
+		"--milestone", "v1.2",
+		"--draft",
+	}
+	if got := opts.args(); !reflect.DeepEqual(got, want) {
+		t.Errorf("args() = %v, want %v", got, want)
+	}
+	if got := len(opts.describe()); got != 5 {
+		t.Errorf("describe() = %d lines, want 5", got)
+	}
+	if got := (prCreateOptions{}).args(); len(got) != 0 {
+		t.Errorf("args() of empty options = %v", got)
+	}
+}
+
+// TestCommonDir は変更したファイルに共通するディレクトリをテストします
+func TestCommonDir(t *testing.T) {
+	tests := []struct {
+		files []string
+		want  string
+	}{
+		{nil, "."},
+		{[]string{"README.md"}, "."},
+		{[]string{"services/api/main.go"}, "services/api"},
+		{[]string{"services/api/main.go", "services/api/handler/user.go"}, "services/api"},
+		{[]string{"services/api/main.go", "services/web/app.ts"}, "services"},
+		{[]string{"services/api/main.go", "services/apiv2/main.go"}, "services"},
+		{[]string{"services/api/main.go", "README.md"}, "."},
+	}
+	for _, tt := range tests {
+		if got := commonDir(tt.files); got != tt.want {
+			t.Errorf("commonDir(%v) = %q, want %q", tt.files, got, tt.want)
+		}
+	}
+}
+
+// TestFindPRTemplate はディレクトリごとのテンプレートの探索をテストします
+func TestFindPRTemplate(t *testing.T) {
+	root := t.TempDir()
+	writePRFiles(t, root, map[string]string{
+		".github/pull_request_template.md":              "root",
+		"services/api/PULL_REQUEST_TEMPLATE.md":         "api",
+		"services/web/.github/pull_request_template.md": "web",
+	})
+
+	tests := []struct {
+		files []string
+		want  string
+	}{
+		{[]string{"README.md"}, ".github/pull_request_template.md"},
+		{[]string{"services/api/handler/user.go"}, "services/api/PULL_REQUEST_TEMPLATE.md"},
+		{[]string{"services/web/app.ts"}, "services/web/.github/pull_request_template.md"},
+		{[]string{"services/api/main.go", "services/web/app.ts"}, ".github/pull_request_template.md"},
+	}
+	for _, tt := range tests {
+		if got := findPRTemplate(root, tt.files); got != tt.want {
+			t.Errorf("findPRTemplate(%v) = %q, want %q", tt.files, got, tt.want)
+		}
+	}
+
+	if got := findPRTemplate(t.TempDir(), []string{"a.go"}); got != "" {
+		t.Errorf("findPRTemplate() without templates = %q, want empty", got)
+	}
+}
+
+// TestSelectPRTemplate はテンプレートが1つだけのディレクトリから選択せずに使用することをテストします
+func TestSelectPRTemplate(t *testing.T) {
+	root := t.TempDir()
+	if got, err := selectPRTemplate(root); err != nil || got != "" {
+		t.Errorf("selectPRTemplate() without directory = %q, %v", got, err)
+	}
+
+	writePRFiles(t, root, map[string]string{
+		".github/PULL_REQUEST_TEMPLATE/feature.md": "feature",
+		".github/PULL_REQUEST_TEMPLATE/notes.txt":  "ignored",
+	})
+	got, err := selectPRTemplate(root)
+	if err != nil || got != ".github/PULL_REQUEST_TEMPLATE/feature.md" {
+		t.Errorf("selectPRTemplate() = %q, %v", got, err)
+	}
+}
+
+// TestPrDraft_Body はコミットとテンプレートからの本文の作成をテストします
+func TestPrDraft_Body(t *testing.T) {
+	tests := []struct {
+		name  string
+		draft prDraft
+		want  string
+	}{
+		{"なし", prDraft{}, ""},
+		{
+			"1コミットは本文を使用",
+			prDraft{Commits: []prCommit{{Subject: "Fix crash", Body: "nil を確認する"}}, TemplateBody: "## 確認事項"},
+			"nil を確認する\n\n## 確認事項",
+		},
+		{
+			"1コミットで本文なし",
+			prDraft{Commits: []prCommit{{Subject: "Fix crash"}}},
+			"",
+		},
+		{
+			"複数コミットは件名の箇条書き",
+			prDraft{Commits: []prCommit{{Subject: "Add API"}, {Subject: "Add tests"}}},
+			"- Add API\n- Add tests",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.draft.Body(); got != tt.want {
+				t.Errorf("Body() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCollectPRDraft はブランチのコミット・変更したファイル・テンプレートの収集をテストします
+func TestCollectPRDraft(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("README.md", "# Test")
+	repo.CreateFile(".github/pull_request_template.md", "## 概要\n\n## 確認事項\n")
+	repo.Commit("Initial commit")
+	base := repo.CurrentBranch()
+
+	repo.CreateAndCheckoutBranch("feature/api")
+	repo.CreateFile("api/server.go", "package api\n")
+	repo.Commit("Add API server")
+	repo.CreateFile("api/server_test.go", "package api\n")
+	repo.MustGit("add", ".")
+	repo.MustGit("commit", "-m", "Add tests\n\nサーバーのテストを追加")
+
+	oldDir, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(oldDir) })
+	if err := os.Chdir(repo.Dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	draft, err := collectPRDraft(base, "feature/api", prCreateOptions{})
+	if err != nil {
+		t.Fatalf("collectPRDraft() error = %v", err)
+	}
+	if len(draft.Commits) != 2 || draft.Commits[0].Subject != "Add API server" || draft.Commits[1].Body != "サーバーのテストを追加" {
+		t.Errorf("Commits = %+v", draft.Commits)
+	}
+	if want := []string{"api/server.go", "api/server_test.go"}; !reflect.DeepEqual(draft.Files, want) {
+		t.Errorf("Files = %v, want %v", draft.Files, want)
+	}
+	if draft.Template != ".github/pull_request_template.md" {
+		t.Errorf("Template = %q", draft.Template)
+	}
+	if body := draft.Body(); !strings.HasPrefix(body, "- Add API server\n- Add tests\n\n## 概要") {
+		t.Errorf("Body() = %q", body)
+	}
+
+	draft, err = collectPRDraft(base, "feature/api", prCreateOptions{NoTemplate: true})
+	if err != nil || draft.Template != "" || len(draft.Files) != 2 {
+		t.Errorf("collectPRDraft(NoTemplate) = %+v, %v", draft, err)
+	}
+
+	if _, err := collectPRDraft(base, "feature/api", prCreateOptions{Template: "missing.md"}); err == nil {
+		t.Error("collectPRDraft() should fail for a missing template")
+	}
+}
@@ -0,0 +1,466 @@
+// ================================================================================
+// pr_dashboard.go
+// ================================================================================
+// このファイルは pr-list の --dashboard オプション（PR のダッシュボード）を実装しています。
+//
+// 【概要】
+// オープンな PR を「レビュー依頼」「自分の PR」「承認済み・CI 成功」「CI 失敗」
+// 「その他」に分けて、CI の結果・レビューの状態・マージ可否・経過時間・差分の
+// 大きさとともに表示します。一覧から番号を選んでチェックアウト・ブラウザ表示・
+// マージを実行できます。
+//
+// 【GitHub からの取得】
+// gh api graphql の1回のクエリで、ログインユーザー・オープンな PR の詳細・
+// 自分にレビュー依頼されている PR（チーム経由の依頼を含む検索結果）を取得します。
+//
+// 【グループ分け】
+// PR は以下の順で最初に当てはまるグループに表示します。
+//   1. レビュー依頼: 自分（または自分のチーム）にレビューが依頼されている
+//   2. 自分の PR: 自分が作成した
+//   3. 承認済み・CI 成功: 承認済みで、CI が成功している（CI がない場合を含む）
+//   4. CI 失敗: CI が失敗している
+//   5. その他
+// ================================================================================
+
+package pr
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/tonbiattack/git-plus/internal/ui"
+)
+
+// prDashboardDefaultLimit は取得する PR の数のデフォルトです
+const prDashboardDefaultLimit = 50
+
+// prDashboardMaxLimit は GitHub の GraphQL API で1回に取得できる PR の最大数です
+const prDashboardMaxLimit = 100
+
+// prDashboardQuery はダッシュボードの情報を1回で取得する GraphQL クエリです
+const prDashboardQuery = `query($owner: String!, $name: String!, $limit: Int!, $reviewQuery: String!) {
+  viewer { login }
+  repository(owner: $owner, name: $name) {
+    pullRequests(states: OPEN, first: $limit, orderBy: {field: UPDATED_AT, direction: DESC}) {
+      nodes {
+        number title url isDraft createdAt additions deletions
+        author { login }
+        reviewDecision
+        mergeable
+        commits(last: 1) { nodes { commit { statusCheckRollup { state } } } }
+      }
+    }
+  }
+  reviewRequested: search(query: $reviewQuery, type: ISSUE, first: 100) {
+    nodes { ... on PullRequest { number } }
+  }
+}`
+
+// ダッシュボードのグループ
+const (
+	prGroupReviewRequested = "レビュー依頼"
+	prGroupMine            = "自分の PR"
+	prGroupApproved        = "承認済み・CI 成功"
+	prGroupFailing         = "CI 失敗"
+	prGroupOther           = "その他"
+)
+
+// prDashboardGroups はグループの表示順です
+var prDashboardGroups = []string{prGroupReviewRequested, prGroupMine, prGroupApproved, prGroupFailing, prGroupOther}
+
+// dashboardPR はダッシュボードに表示する PR です
+type dashboardPR struct {
+	Number          int
+	Title           string
+	URL             string
+	Author          string
+	IsDraft         bool
+	CreatedAt       time.Time
+	Additions       int
+	Deletions       int
+	ReviewDecision  string // APPROVED / CHANGES_REQUESTED / REVIEW_REQUIRED / 空
+	Mergeable       string // MERGEABLE / CONFLICTING / UNKNOWN
+	CheckState      string // SUCCESS / FAILURE / ERROR / PENDING / EXPECTED / 空（CI なし）
+	ReviewRequested bool   // 自分にレビューが依頼されている場合 true
+}
+
+// prDashboardGroup はグループと、そのグループに表示する PR です
+type prDashboardGroup struct {
+	Name string
+	PRs  []dashboardPR
+}
+
+// prDashboardResponse は prDashboardQuery の応答です
+type prDashboardResponse struct {
+	Data struct {
+		Viewer struct {
+			Login string `json:"login"`
+		} `json:"viewer"`
+		Repository struct {
+			PullRequests struct {
+				Nodes []struct {
+					Number    int       `json:"number"`
+					Title     string    `json:"title"`
+					URL       string    `json:"url"`
+					IsDraft   bool      `json:"isDraft"`
+					CreatedAt time.Time `json:"createdAt"`
+					Additions int       `json:"additions"`
+					Deletions int       `json:"deletions"`
+					Author    *struct {
+						Login string `json:"login"`
+					} `json:"author"`
+					ReviewDecision string `json:"reviewDecision"`
+					Mergeable      string `json:"mergeable"`
+					Commits        struct {
+						Nodes []struct {
+							Commit struct {
+								StatusCheckRollup *struct {
+									State string `json:"state"`
+								} `json:"statusCheckRollup"`
+							} `json:"commit"`
+						} `json:"nodes"`
+					} `json:"commits"`
+				} `json:"nodes"`
+			} `json:"pullRequests"`
+		} `json:"repository"`
+		ReviewRequested struct {
+			Nodes []struct {
+				Number int `json:"number"`
+			} `json:"nodes"`
+		} `json:"reviewRequested"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// runPRDashboard はダッシュボードを表示し、選択された PR に対する操作を実行します。
+//
+// パラメータ:
+//   - args: --dashboard 以外の引数（--limit のみ指定可能）
+//
+// 戻り値:
+//   - error: 引数が不正な場合や、PR の取得に失敗した場合のエラー
+func runPRDashboard(args []string) error {
+	limit, err := parsePRDashboardArgs(args)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("PR を取得中...")
+	login, prs, err := fetchPRDashboard(limit)
+	if err != nil {
+		return fmt.Errorf("PR の取得に失敗しました: %w", err)
+	}
+	if len(prs) == 0 {
+		fmt.Println("オープンな PR はありません")
+		return nil
+	}
+
+	groups := groupDashboardPRs(prs, login)
+	indexed := printPRDashboard(groups, time.Now())
+	return promptPRDashboardAction(indexed)
+}
+
+// parsePRDashboardArgs はダッシュボードの引数を解析します（--limit / -L のみ）
+func parsePRDashboardArgs(args []string) (int, error) {
+	limit := prDashboardDefaultLimit
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		value := ""
+		switch {
+		case arg == "--limit" || arg == "-L":
+			if i+1 >= len(args) {
+				return 0, fmt.Errorf("%s には数値を指定してください", arg)
+			}
+			i++
+			value = args[i]
+		case strings.HasPrefix(arg, "--limit="):
+			value = strings.TrimPrefix(arg, "--limit=")
+		default:
+			return 0, fmt.Errorf("--dashboard と一緒に指定できるのは --limit のみです: %s", arg)
+		}
+
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > prDashboardMaxLimit {
+			return 0, fmt.Errorf("--limit には 1 から %d の数値を指定してください: %s", prDashboardMaxLimit, value)
+		}
+		limit = n
+	}
+	return limit, nil
+}
+
+// fetchPRDashboard は gh api graphql の1回のクエリでダッシュボードの情報を取得します。
+//
+// パラメータ:
+//   - limit: 取得するオープンな PR の数（更新日時の新しい順）
+//
+// 戻り値:
+//   - string: ログインユーザー
+//   - []dashboardPR: オープンな PR
+//   - error: gh の実行や応答の解析に失敗した場合のエラー
+//
+// 内部処理:
+//
+//	{owner} と {repo} は gh が現在のリポジトリの値に置き換えます。
+//	レビュー依頼は review-requested:@me の検索で取得するため、チーム経由の依頼も含まれます。
+func fetchPRDashboard(limit int) (string, []dashboardPR, error) {
+	output, err := exec.Command("gh", "api", "graphql",
+		"-F", "owner={owner}",
+		"-F", "name={repo}",
+		"-F", fmt.Sprintf("limit=%d", limit),
+		"-F", "reviewQuery=repo:{owner}/{repo} is:pr is:open review-requested:@me",
+		"-f", "query="+prDashboardQuery).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return "", nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", nil, err
+	}
+	return parsePRDashboardResponse(output)
+}
+
+// parsePRDashboardResponse は prDashboardQuery の応答を解析します
+func parsePRDashboardResponse(output []byte) (string, []dashboardPR, error) {
+	var response prDashboardResponse
+	if err := json.Unmarshal(output, &response); err != nil {
+		return "", nil, fmt.Errorf("gh api graphql の応答の解析に失敗しました: %w", err)
+	}
+	if len(response.Errors) > 0 {
+		return "", nil, fmt.Errorf("gh api graphql がエラーを返しました: %s", response.Errors[0].Message)
+	}
+
+	requested := make(map[int]bool)
+	for _, node := range response.Data.ReviewRequested.Nodes {
+		requested[node.Number] = true
+	}
+
+	var prs []dashboardPR
+	for _, node := range response.Data.Repository.PullRequests.Nodes {
+		pr := dashboardPR{
+			Number:          node.Number,
+			Title:           node.Title,
+			URL:             node.URL,
+			IsDraft:         node.IsDraft,
+			CreatedAt:       node.CreatedAt,
+			Additions:       node.Additions,
+			Deletions:       node.Deletions,
+			ReviewDecision:  node.ReviewDecision,
+			Mergeable:       node.Mergeable,
+			ReviewRequested: requested[node.Number],
+		}
+		if node.Author != nil {
+			pr.Author = node.Author.Login
+		} else {
+			// 削除されたユーザーは GitHub と同じく ghost と表示する
+			pr.Author = "ghost"
+		}
+		if len(node.Commits.Nodes) > 0 && node.Commits.Nodes[0].Commit.StatusCheckRollup != nil {
+			pr.CheckState = node.Commits.Nodes[0].Commit.StatusCheckRollup.State
+		}
+		prs = append(prs, pr)
+	}
+	return response.Data.Viewer.Login, prs, nil
+}
+
+// dashboardGroupOf は PR を表示するグループを返します
+func dashboardGroupOf(pr dashboardPR, login string) string {
+	switch {
+	case pr.ReviewRequested:
+		return prGroupReviewRequested
+	case login != "" && strings.EqualFold(pr.Author, login):
+		return prGroupMine
+	case pr.ReviewDecision == "APPROVED" && (pr.CheckState == "SUCCESS" || pr.CheckState == ""):
+		return prGroupApproved
+	case pr.CheckState == "FAILURE" || pr.CheckState == "ERROR":
+		return prGroupFailing
+	default:
+		return prGroupOther
+	}
+}
+
+// groupDashboardPRs は PR をグループに分けます（PR がないグループは含めません）
+func groupDashboardPRs(prs []dashboardPR, login string) []prDashboardGroup {
+	byGroup := make(map[string][]dashboardPR)
+	for _, pr := range prs {
+		name := dashboardGroupOf(pr, login)
+		byGroup[name] = append(byGroup[name], pr)
+	}
+
+	var groups []prDashboardGroup
+	for _, name := range prDashboardGroups {
+		if len(byGroup[name]) > 0 {
+			groups = append(groups, prDashboardGroup{Name: name, PRs: byGroup[name]})
+		}
+	}
+	return groups
+}
+
+// printPRDashboard はダッシュボードを表示し、表示した順の PR を返します（選択番号は1から）
+func printPRDashboard(groups []prDashboardGroup, now time.Time) []dashboardPR {
+	var indexed []dashboardPR
+	for _, group := range groups {
+		fmt.Printf("\n■ %s (%d)\n", group.Name, len(group.PRs))
+		for _, pr := range group.PRs {
+			indexed = append(indexed, pr)
+			fmt.Println(formatDashboardRow(len(indexed), pr, now))
+		}
+	}
+	fmt.Println()
+	return indexed
+}
+
+// formatDashboardRow は PR を1行で表示する文字列を返します
+func formatDashboardRow(index int, pr dashboardPR, now time.Time) string {
+	title := pr.Title
+	if pr.IsDraft {
+		title = "[ドラフト] " + title
+	}
+	return fmt.Sprintf("  %3d. #%-5d %s %s %s %s %s %s %s",
+		index,
+		pr.Number,
+		padDisplay(formatCheckState(pr.CheckState), 10),
+		padDisplay(formatReviewDecision(pr.ReviewDecision), 10),
+		padDisplay(formatMergeable(pr.Mergeable), 12),
+		padDisplay(formatPRAge(pr.CreatedAt, now), 7),
+		padDisplay(fmt.Sprintf("+%d/-%d", pr.Additions, pr.Deletions), 12),
+		padDisplay("@"+pr.Author, 16),
+		title)
+}
+
+// formatCheckState は CI の結果を表示用の文字列にします
+func formatCheckState(state string) string {
+	switch state {
+	case "SUCCESS":
+		return "✓ CI成功"
+	case "FAILURE", "ERROR":
+		return "✗ CI失敗"
+	case "PENDING", "EXPECTED":
+		return "… CI実行中"
+	default:
+		return "- CIなし"
+	}
+}
+
+// formatReviewDecision はレビューの状態を表示用の文字列にします
+func formatReviewDecision(decision string) string {
+	switch decision {
+	case "APPROVED":
+		return "承認済み"
+	case "CHANGES_REQUESTED":
+		return "変更要求"
+	case "REVIEW_REQUIRED":
+		return "レビュー待ち"
+	default:
+		return "-"
+	}
+}
+
+// formatMergeable はマージ可否を表示用の文字列にします
+func formatMergeable(mergeable string) string {
+	switch mergeable {
+	case "MERGEABLE":
+		return "マージ可"
+	case "CONFLICTING":
+		return "コンフリクト"
+	default:
+		return "確認中"
+	}
+}
+
+// formatPRAge は PR の作成からの経過時間を表示用の文字列にします（例: 45分, 3時間, 12日）
+func formatPRAge(createdAt, now time.Time) string {
+	age := now.Sub(createdAt)
+	switch {
+	case age < time.Hour:
+		return fmt.Sprintf("%d分", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%d時間", int(age.Hours()))
+	default:
+		return fmt.Sprintf("%d日", int(age.Hours()/24))
+	}
+}
+
+// padDisplay は全角文字を幅2として、表示幅が width になるよう右に空白を追加します
+func padDisplay(s string, width int) string {
+	w := 0
+	for _, r := range s {
+		if utf8.RuneLen(r) > 1 && r != '✓' && r != '✗' && r != '…' {
+			w += 2
+		} else {
+			w++
+		}
+	}
+	if w >= width {
+		return s
+	}
+	return s + strings.Repeat(" ", width-w)
+}
+
+// promptPRDashboardAction は PR の番号と操作を入力させて実行します。
+// ブラウザ表示の後は続けて入力でき、チェックアウトとマージの後は終了します。
+func promptPRDashboardAction(prs []dashboardPR) error {
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Print("操作を入力してください（c 番号: チェックアウト, b 番号: ブラウザ, m 番号: マージ, 空: 終了）: ")
+		input, err := reader.ReadString('\n')
+		if err != nil && input == "" {
+			return nil
+		}
+		input = strings.TrimSpace(input)
+		if input == "" || input == "q" {
+			return nil
+		}
+
+		action, pr, parseErr := parsePRDashboardAction(input, prs)
+		if parseErr != nil {
+			fmt.Printf("警告: %v\n", parseErr)
+			continue
+		}
+		number := strconv.Itoa(pr.Number)
+
+		switch action {
+		case "b":
+			if err := prBrowseCmd.RunE(prBrowseCmd, []string{number}); err != nil {
+				fmt.Printf("警告: %v\n", err)
+			}
+			continue
+		case "c":
+			return prCheckoutCmd.RunE(prCheckoutCmd, []string{number})
+		case "m":
+			if pr.CheckState == "FAILURE" || pr.CheckState == "ERROR" || pr.Mergeable == "CONFLICTING" || pr.IsDraft {
+				fmt.Printf("警告: PR #%d は %s・%s・%s です\n", pr.Number, formatCheckState(pr.CheckState), formatReviewDecision(pr.ReviewDecision), formatMergeable(pr.Mergeable))
+			}
+			if !ui.Confirm(fmt.Sprintf("PR #%d「%s」をマージしますか？", pr.Number, pr.Title), false) {
+				fmt.Println("キャンセルしました。")
+				continue
+			}
+			return prMergeCmd.RunE(prMergeCmd, []string{number})
+		}
+	}
+}
+
+// parsePRDashboardAction は「c 3」のような入力を操作と PR に変換します
+func parsePRDashboardAction(input string, prs []dashboardPR) (string, dashboardPR, error) {
+	fields := strings.Fields(input)
+	if len(fields) != 2 {
+		return "", dashboardPR{}, fmt.Errorf("「操作 番号」の形式で入力してください（例: c 1）: %s", input)
+	}
+
+	action := strings.ToLower(fields[0])
+	if action != "c" && action != "b" && action != "m" {
+		return "", dashboardPR{}, fmt.Errorf("無効な操作: %s（c, b, m のいずれか）", fields[0])
+	}
+	idx, err := strconv.Atoi(ui.NormalizeNumberInput(fields[1]))
+	if err != nil || idx < 1 || idx > len(prs) {
+		return "", dashboardPR{}, fmt.Errorf("無効な番号: %s (1から%dの範囲で入力してください)", fields[1], len(prs))
+	}
+	return action, prs[idx-1], nil
+}
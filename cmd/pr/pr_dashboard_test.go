@@ -0,0 +1,173 @@
+package pr
+
+import (
+	"testing"
+	"time"
+)
+
+// prDashboardFixture は prDashboardQuery の応答の例です
+const prDashboardFixture = `{"data":{"viewer":{"login":"me"},"repository":{"pullRequests":{"nodes":[
+{"number":12,"title":"Fix login","url":"https://github.com/o/r/pull/12","isDraft":false,"createdAt":"2026-10-15T00:00:00Z","additions":120,"deletions":4,"author":{"login":"alice"},"reviewDecision":"REVIEW_REQUIRED","mergeable":"MERGEABLE","commits":{"nodes":[{"commit":{"statusCheckRollup":{"state":"SUCCESS"}}}]}},
+{"number":11,"title":"Add API","url":"u","isDraft":true,"createdAt":"2026-10-18T00:00:00Z","additions":5,"deletions":1,"author":{"login":"Me"},"reviewDecision":null,"mergeable":"UNKNOWN","commits":{"nodes":[{"commit":{"statusCheckRollup":{"state":"FAILURE"}}}]}},
+{"number":10,"title":"Bump deps","url":"u","isDraft":false,"createdAt":"2026-09-01T00:00:00Z","additions":300,"deletions":280,"author":{"login":"bot"},"reviewDecision":"APPROVED","mergeable":"MERGEABLE","commits":{"nodes":[{"commit":{"statusCheckRollup":null}}]}},
+{"number":9,"title":"Broken","url":"u","isDraft":false,"createdAt":"2026-10-10T00:00:00Z","additions":1,"deletions":1,"author":null,"reviewDecision":"APPROVED","mergeable":"CONFLICTING","commits":{"nodes":[{"commit":{"statusCheckRollup":{"state":"ERROR"}}}]}},
+{"number":8,"title":"WIP","url":"u","isDraft":false,"createdAt":"2026-10-17T00:00:00Z","additions":0,"deletions":0,"author":{"login":"bob"},"reviewDecision":"APPROVED","mergeable":"MERGEABLE","commits":{"nodes":[{"commit":{"statusCheckRollup":{"state":"PENDING"}}}]}}
+]}},"reviewRequested":{"nodes":[{"number":12},{}]}}}`
+
+// TestParsePRDashboardResponse は GraphQL の応答の解析をテストします
+func TestParsePRDashboardResponse(t *testing.T) {
+	login, prs, err := parsePRDashboardResponse([]byte(prDashboardFixture))
+	if err != nil {
+		t.Fatalf("parsePRDashboardResponse() error = %v", err)
+	}
+	if login != "me" || len(prs) != 5 {
+		t.Fatalf("parsePRDashboardResponse() = %q, %d PRs", login, len(prs))
+	}
+
+	first := prs[0]
+	if first.Number != 12 || first.Author != "alice" || !first.ReviewRequested || first.CheckState != "SUCCESS" || first.Additions != 120 {
+		t.Errorf("prs[0] = %+v", first)
+	}
+	if prs[2].CheckState != "" {
+		t.Errorf("PR without checks: CheckState = %q, want empty", prs[2].CheckState)
+	}
+	if prs[3].Author != "ghost" {
+		t.Errorf("deleted author = %q, want ghost", prs[3].Author)
+	}
+
+	if _, _, err := parsePRDashboardResponse([]byte(`{"errors":[{"message":"Could not resolve to a Repository"}]}`)); err == nil {
+		t.Error("parsePRDashboardResponse() should fail for GraphQL errors")
+	}
+	if _, _, err := parsePRDashboardResponse([]byte("not json")); err == nil {
+		t.Error("parsePRDashboardResponse() should fail for invalid JSON")
+	}
+}
+
+// TestGroupDashboardPRs はグループ分けと表示順をテストします
+func TestGroupDashboardPRs(t *testing.T) {
+	login, prs, err := parsePRDashboardResponse([]byte(prDashboardFixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	groups := groupDashboardPRs(prs, login)
+	want := []struct {
+		name    string
+		numbers []int
+	}{
+		{prGroupReviewRequested, []int{12}},
+		{prGroupMine, []int{11}}, // 作成者は大文字・小文字を区別しない
+		{prGroupApproved, []int{10}},
+		{prGroupFailing, []int{9}},
+		{prGroupOther, []int{8}}, // 承認済みでも CI 実行中は含めない
+	}
+	if len(groups) != len(want) {
+		t.Fatalf("groupDashboardPRs() = %d groups, want %d", len(groups), len(want))
+	}
+	for i, w := range want {
+		if groups[i].Name != w.name || len(groups[i].PRs) != len(w.numbers) || groups[i].PRs[0].Number != w.numbers[0] {
+			t.Errorf("groups[%d] = %s %+v, want %s %v", i, groups[i].Name, groups[i].PRs, w.name, w.numbers)
+		}
+	}
+
+	// PR がないグループは含めない
+	if got := groupDashboardPRs(prs[2:3], login); len(got) != 1 || got[0].Name != prGroupApproved {
+		t.Errorf("groupDashboardPRs() = %+v", got)
+	}
+}
+
+// TestParsePRDashboardArgs は --limit の解析をテストします
+func TestParsePRDashboardArgs(t *testing.T) {
+	tests := []struct {
+		args    []string
+		want    int
+		wantErr bool
+	}{
+		{nil, prDashboardDefaultLimit, false},
+		{[]string{"--limit", "20"}, 20, false},
+		{[]string{"-L", "100"}, 100, false},
+		{[]string{"--limit=5"}, 5, false},
+		{[]string{"--limit"}, 0, true},
+		{[]string{"--limit", "101"}, 0, true},
+		{[]string{"--limit", "x"}, 0, true},
+		{[]string{"--state", "open"}, 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parsePRDashboardArgs(tt.args)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parsePRDashboardArgs(%v) = %d, %v, want %d (error: %v)", tt.args, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+// TestParsePRDashboardAction は操作の入力の解析をテストします
+func TestParsePRDashboardAction(t *testing.T) {
+	prs := []dashboardPR{{Number: 12}, {Number: 11}}
+
+	action, pr, err := parsePRDashboardAction("C ２", prs)
+	if err != nil || action != "c" || pr.Number != 11 {
+		t.Errorf("parsePRDashboardAction() = %q, %d, %v", action, pr.Number, err)
+	}
+
+	for _, input := range []string{"c", "x 1", "m 3", "b 0", "c 1 2"} {
+		if _, _, err := parsePRDashboardAction(input, prs); err == nil {
+			t.Errorf("parsePRDashboardAction(%q) should fail", input)
+		}
+	}
+}
+
+// TestFormatDashboardRow は PR の1行の表示をテストします
+func TestFormatDashboardRow(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	pr := dashboardPR{
+		Number:         12,
+		Title:          "Fix login",
+		Author:         "alice",
+		IsDraft:        true,
+		CreatedAt:      now.Add(-3 * 24 * time.Hour),
+		Additions:      120,
+		Deletions:      4,
+		ReviewDecision: "APPROVED",
+		Mergeable:      "CONFLICTING",
+		CheckState:     "SUCCESS",
+	}
+	want := "    1. #12    ✓ CI成功   承認済み   コンフリクト 3日     +120/-4      @alice           [ドラフト] Fix login"
+	if got := formatDashboardRow(1, pr, now); got != want {
+		t.Errorf("formatDashboardRow() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+// TestFormatPRAge は経過時間の表示をテストします
+func TestFormatPRAge(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	tests := map[time.Duration]string{
+		45 * time.Minute:    "45分",
+		5 * time.Hour:       "5時間",
+		36 * time.Hour:      "1日",
+		30 * 24 * time.Hour: "30日",
+	}
+	for age, want := range tests {
+		if got := formatPRAge(now.Add(-age), now); got != want {
+			t.Errorf("formatPRAge(%v) = %q, want %q", age, got, want)
+		}
+	}
+}
+
+// TestPadDisplay は全角文字を含む文字列の幅揃えをテストします
+func TestPadDisplay(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{"abc", 5, "abc  "},
+		{"承認済み", 10, "承認済み  "},
+		{"✓ CI成功", 10, "✓ CI成功  "},
+		{"コンフリクト", 10, "コンフリクト"},
+	}
+	for _, tt := range tests {
+		if got := padDisplay(tt.s, tt.width); got != tt.want {
+			t.Errorf("padDisplay(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+		}
+	}
+}
@@ -13,6 +13,9 @@
 // - オープンなIssueの一覧表示と選択
 // - 複数のIssueを選択可能
 // - PRの説明欄に「Closes #番号」を自動追加
+// - PR テンプレートとコミットメッセージから本文を作成してエディタで編集
+// - レビュアー・ラベル・担当者・マイルストーン・ドラフトの指定
+// - CODEOWNERS からのレビュアーの提案
 // - GitHub CLI (gh) を使用したPR作成
 //
 // 【使用例】
@@ -20,6 +23,7 @@
 //   git pr-issue-link --base main        # mainブランチへのPR作成
 //   git pr-issue-link --issue 123        # Issue #123 を指定してPR作成
 //   git pr-issue-link --issue 123,456    # 複数のIssueを指定
+//   git pr-issue-link -i 42 -r alice -d  # レビュアーを指定してドラフトPRを作成
 //
 // 【必要な外部ツール】
 // - GitHub CLI (gh): https://cli.github.com/
@@ -50,6 +54,9 @@ type IssueInfo struct {
 	URL    string `json:"url"`
 }
 
+// prIssueLinkOpts は pr-issue-link の PR 作成オプションです
+var prIssueLinkOpts prCreateOptions
+
 // prIssueLinkCmd は pr-issue-link コマンドの定義です。
 // PRとIssueを紐づけて作成します。
 var prIssueLinkCmd = &cobra.Command{
@@ -60,12 +67,19 @@ PRの説明欄に「Closes #<issue番号>」を自動的に追加することで
 PRがマージされた際に関連するIssueが自動的にクローズされます。
 
 対話的にIssueを選択するか、--issue オプションで直接指定できます。
-複数のIssueを紐づける場合は、カンマ区切りで指定します。`,
+複数のIssueを紐づける場合は、カンマ区切りで指定します。
+
+--body を指定しない場合は、コミットメッセージとPRテンプレート
+（.github/pull_request_template.md やディレクトリごとのテンプレート）から本文を作成し、
+エディタで編集します。変更したファイルに CODEOWNERS が設定されている場合は、
+レビュアーへの追加を提案します。`,
 	Example: `  git pr-issue-link                    # 対話的にIssueを選択してPR作成
   git pr-issue-link --base main        # mainブランチへのPR作成
   git pr-issue-link --issue 123        # Issue #123 を指定してPR作成
   git pr-issue-link --issue 123,456    # 複数のIssueを指定
-  git pr-issue-link -b develop -i 42   # developブランチへ、Issue #42 と紐づけ`,
+  git pr-issue-link -b develop -i 42   # developブランチへ、Issue #42 と紐づけ
+  git pr-issue-link -i 42 -r alice,org/team -l bug  # レビュアーとラベルを指定
+  git pr-issue-link -i 42 --draft --no-edit         # 本文を編集せずにドラフトPRを作成`,
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
 		// GitHub CLI の確認
 		if !checkGitHubCLIForPR() {
@@ -181,6 +195,24 @@ PRがマージされた際に関連するIssueが自動的にクローズされ
 			title = strings.TrimSpace(input)
 		}
 
+		// --body がない場合はコミットとテンプレートから本文を作成
+		draftOpts := prIssueLinkOpts
+		if body != "" {
+			draftOpts.NoTemplate = true
+		}
+		draft, err := collectPRDraft(baseBranch, currentBranch, draftOpts)
+		if err != nil {
+			return err
+		}
+		if body == "" {
+			if body, err = preparePRBody(draft, prIssueLinkOpts); err != nil {
+				return fmt.Errorf("PRの本文の作成に失敗しました: %w", err)
+			}
+		}
+
+		opts := prIssueLinkOpts
+		suggestCodeownersReviewers(draft.Files, &opts)
+
 		// 本文の構築
 		finalBody := buildPRBody(body, selectedIssues)
 
@@ -203,6 +235,9 @@ PRがマージされた際に関連するIssueが自動的にクローズされ
 			}
 			fmt.Println()
 		}
+		for _, line := range opts.describe() {
+			fmt.Println(line)
+		}
 		if finalBody != "" {
 			fmt.Println("\n--- PR本文 ---")
 			fmt.Println(finalBody)
@@ -216,7 +251,7 @@ PRがマージされた際に関連するIssueが自動的にクローズされ
 
 		// PRを作成
 		fmt.Println("\nPRを作成しています...")
-		prURL, err := createPRWithIssueLink(baseBranch, currentBranch, title, finalBody)
+		prURL, err := createPRWithIssueLink(baseBranch, currentBranch, title, finalBody, opts)
 		if err != nil {
 			return fmt.Errorf("PRの作成に失敗しました: %w", err)
 		}
@@ -346,7 +381,8 @@ func buildPRBody(userBody string, issueNumbers []int) string {
 }
 
 // createPRWithIssueLink はPRを作成し、URLを返します。
-func createPRWithIssueLink(baseBranch, headBranch, title, body string) (string, error) {
+// opts のレビュアー・ラベル・担当者・マイルストーン・ドラフトの指定を gh pr create に渡します。
+func createPRWithIssueLink(baseBranch, headBranch, title, body string, opts prCreateOptions) (string, error) {
 	args := []string{"pr", "create", "--base", baseBranch, "--head", headBranch}
 
 	if title != "" {
@@ -359,6 +395,7 @@ func createPRWithIssueLink(baseBranch, headBranch, title, body string) (string,
 	if body != "" {
 		args = append(args, "--body", body)
 	}
+	args = append(args, opts.args()...)
 
 	ghCmd := exec.Command("gh", args...)
 	output, err := ghCmd.Output()
@@ -382,4 +419,6 @@ func init() {
 	prIssueLinkCmd.Flags().StringP("issue", "i", "", "紐づけるIssue番号（カンマ区切りで複数指定可能）")
 	prIssueLinkCmd.Flags().StringP("title", "t", "", "PRのタイトル")
 	prIssueLinkCmd.Flags().String("body", "", "PRの本文（Closes #番号 は自動追加されます）")
+	prIssueLinkCmd.Flags().BoolVarP(&prIssueLinkOpts.Draft, "draft", "d", false, "ドラフトPRとして作成")
+	addPRCreateFlags(prIssueLinkCmd, &prIssueLinkOpts)
 }
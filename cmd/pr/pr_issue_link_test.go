@@ -84,6 +84,27 @@ func TestPrIssueLinkCmd_HasFlags(t *testing.T) {
 	if bodyFlag == nil {
 		t.Error("prIssueLinkCmd should have 'body' flag")
 	}
+
+	// PR 作成オプションのフラグの確認
+	shorthands := map[string]string{
+		"draft":         "d",
+		"reviewer":      "r",
+		"label":         "l",
+		"assignee":      "a",
+		"milestone":     "m",
+		"template":      "",
+		"no-template":   "",
+		"no-edit":       "",
+		"no-codeowners": "",
+	}
+	for name, shorthand := range shorthands {
+		flag := prIssueLinkCmd.Flags().Lookup(name)
+		if flag == nil {
+			t.Errorf("prIssueLinkCmd should have '%s' flag", name)
+		} else if flag.Shorthand != shorthand {
+			t.Errorf("%s flag shorthand = %q, want %q", name, flag.Shorthand, shorthand)
+		}
+	}
 }
 
 // TestBuildPRBody_SingleIssue は単一のIssueで本文が正しく構築されることをテストします
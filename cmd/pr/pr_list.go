@@ -7,21 +7,25 @@
 // 【概要】
 // pr-list コマンドは、GitHub CLI の `gh pr list` をラップして、
 // Git コマンドとして実行できるようにします。
+// --dashboard を指定すると、PR をレビュー依頼・自分の PR などに分けて
+// CI とレビューの状態とともに表示します（pr_dashboard.go）。
 //
 // 【主な機能】
 // - PRの一覧表示
 // - すべての gh pr list のオプションをサポート
 // - シェル補完機能の有効化
+// - ダッシュボード表示とチェックアウト・ブラウザ表示・マージ（--dashboard）
 //
 // 【使用例】
 //   git pr-list                    # PR一覧を表示
 //   git pr-list --state open       # オープンなPRのみ表示
 //   git pr-list --author @me       # 自分が作成したPRを表示
 //   git pr-list --assignee @me     # 自分にアサインされたPRを表示
+//   git pr-list --dashboard        # ダッシュボードを表示
 //
 // 【内部仕様】
 // - GitHub CLI (gh) の gh pr list コマンドをそのままラップ
-// - すべての引数とオプションを gh に転送
+// - すべての引数とオプションを gh に転送（--dashboard と -h/--help を除く）
 //
 // 【必要な外部ツール】
 // - GitHub CLI (gh): https://cli.github.com/
@@ -58,6 +62,16 @@ var prListCmd = &cobra.Command{
   --template <string>    Go template形式で出力
   --web                  ブラウザで開く
 
+--dashboard を指定すると、オープンなPRを以下のグループに分けて表示します：
+  レビュー依頼        自分（または自分のチーム）にレビューが依頼されているPR
+  自分の PR           自分が作成したPR
+  承認済み・CI 成功   承認済みでCIが成功しているPR
+  CI 失敗             CIが失敗しているPR
+  その他
+各PRのCIの結果・レビューの状態・マージ可否・経過時間・差分の大きさを表示し、
+一覧の番号を選んでチェックアウト（c）・ブラウザ表示（b）・マージ（m）を実行できます。
+情報は gh api graphql の1回のクエリで取得します（--limit で取得数を指定、デフォルト: 50）。
+
 内部的に GitHub CLI (gh) を使用してプルリクエストの一覧を取得します。`,
 	Example: `  git pr-list                      # PR一覧を表示
   git pr-list --state open         # オープンなPRのみ表示
@@ -67,13 +81,26 @@ var prListCmd = &cobra.Command{
   git pr-list --assignee @me       # 自分にアサインされたPRを表示
   git pr-list --limit 10           # 最新10件のPRを表示
   git pr-list --label bug          # "bug" ラベルが付いたPRを表示
-  git pr-list --base main          # mainブランチへのPRを表示`,
+  git pr-list --base main          # mainブランチへのPRを表示
+  git pr-list --dashboard          # ダッシュボードを表示
+  git pr-list --dashboard --limit 100  # 最新100件のPRでダッシュボードを表示`,
+	DisableFlagParsing: true, // gh pr list のフラグをそのまま渡すため
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		for _, arg := range args {
+			if arg == "-h" || arg == "--help" {
+				return cobraCmd.Help()
+			}
+		}
+
 		// GitHub CLI の確認
 		if !checkGitHubCLI() {
 			return fmt.Errorf("GitHub CLI (gh) がインストールされていません\nインストール方法: https://cli.github.com/")
 		}
 
+		if dashboard, rest := extractDashboardFlag(args); dashboard {
+			return runPRDashboard(rest)
+		}
+
 		// gh pr list コマンドを構築
 		ghArgs := []string{"pr", "list"}
 		ghArgs = append(ghArgs, args...)
@@ -92,6 +119,24 @@ var prListCmd = &cobra.Command{
 	},
 }
 
+// extractDashboardFlag は引数から --dashboard を取り除きます。
+//
+// 戻り値:
+//   - bool: --dashboard が指定された場合 true
+//   - []string: --dashboard を除いた引数
+func extractDashboardFlag(args []string) (bool, []string) {
+	dashboard := false
+	rest := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg == "--dashboard" {
+			dashboard = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return dashboard, rest
+}
+
 // init は pr-list コマンドを root コマンドに登録します。
 // この関数はパッケージの初期化時に自動的に呼び出されます。
 func init() {
@@ -46,3 +46,23 @@ func TestPrListCmd_HasRunE(t *testing.T) {
 		t.Error("prListCmd.RunE should not be nil")
 	}
 }
+
+// TestPrListCmd_DisableFlagParsing は gh pr list のフラグをそのまま渡せることを確認します
+func TestPrListCmd_DisableFlagParsing(t *testing.T) {
+	if !prListCmd.DisableFlagParsing {
+		t.Error("prListCmd.DisableFlagParsing should be true")
+	}
+}
+
+// TestExtractDashboardFlag は --dashboard の取り出しをテストします
+func TestExtractDashboardFlag(t *testing.T) {
+	dashboard, rest := extractDashboardFlag([]string{"--limit", "10", "--dashboard"})
+	if !dashboard || len(rest) != 2 || rest[0] != "--limit" || rest[1] != "10" {
+		t.Errorf("extractDashboardFlag() = %v, %v", dashboard, rest)
+	}
+
+	dashboard, rest = extractDashboardFlag([]string{"--state", "open"})
+	if dashboard || len(rest) != 2 {
+		t.Errorf("extractDashboardFlag() = %v, %v", dashboard, rest)
+	}
+}
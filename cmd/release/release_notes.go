@@ -13,6 +13,12 @@
 // - GitHub CLIによるリリースノートの自動生成
 // - ドラフトまたはプレリリースとしての作成
 // - 最新タグまたは指定タグからの作成
+// - ローカルでのリリースノート生成（--local オプション、GitHub に依存しない）
+//   - 前のタグとの間のコミットを feat/fix/perf/破壊的変更 に分類し、PR 番号を付ける
+//   - テンプレート（--template オプションまたは git config）から Markdown を生成
+// - CHANGELOG.md の先頭への追記（--changelog オプション）
+// - ビルド成果物と checksums.txt の添付（--asset オプション）
+//   - リリースが既に存在する場合は、アセットのアップロードのみを行う
 //
 // 【使用例】
 //   git release-notes                  # 対話的にタグを選択
@@ -20,9 +26,14 @@
 //   git release-notes --latest         # 最新タグからリリース作成
 //   git release-notes --draft          # ドラフトとして作成
 //   git release-notes --prerelease     # プレリリースとして作成
+//   git release-notes --latest --local                  # ローカルで生成した本文でリリース作成
+//   git release-notes --latest --dry-run                # ローカルで生成して表示するのみ
+//   git release-notes --latest --changelog --no-github  # CHANGELOG.md の更新のみ（オフライン）
+//   git release-notes --latest --asset 'dist/*'         # dist 配下のファイルを添付
 //
 // 【必要な外部ツール】
 // - GitHub CLI (gh): https://cli.github.com/
+//   （--dry-run または --no-github の場合は不要）
 //
 // 【パッケージ】
 // このファイルは release パッケージに属し、リリース関連のコマンドを提供します。
@@ -40,14 +51,25 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/tonbiattack/git-plus/cmd"
 	"github.com/tonbiattack/git-plus/internal/gitcmd"
+	"github.com/tonbiattack/git-plus/internal/releaseassets"
+	"github.com/tonbiattack/git-plus/internal/releasenotes"
 	"github.com/tonbiattack/git-plus/internal/ui"
 )
 
 var (
-	releaseTag        string // リリースを作成するタグ
-	releaseDraft      bool   // ドラフトとして作成
-	releasePrerelease bool   // プレリリースとして作成
-	releaseLatest     bool   // 最新タグを使用
+	releaseTag           string   // リリースを作成するタグ
+	releaseDraft         bool     // ドラフトとして作成
+	releasePrerelease    bool     // プレリリースとして作成
+	releaseLatest        bool     // 最新タグを使用
+	releaseLocal         bool     // リリースノートをローカルで生成
+	releaseFrom          string   // 比較元のタグ（ローカル生成時）
+	releaseTemplate      string   // リリースノートのテンプレートファイル
+	releaseChangelog     bool     // CHANGELOG に追記
+	releaseChangelogFile string   // CHANGELOG ファイルのパス
+	releaseNoGitHub      bool     // GitHub リリースを作成しない
+	releaseDryRun        bool     // 生成したリリースノートを表示するのみ
+	releaseAssets        []string // リリースに添付するファイルの glob パターン
+	releaseReplaceAssets bool     // 既存のアセットを置き換える
 )
 
 // releaseNotesCmd は release-notes コマンドの定義です。
@@ -58,6 +80,15 @@ var releaseNotesCmd = &cobra.Command{
 GitHub CLIのgh release createコマンドを使用して、タグ間の変更内容を
 自動的に解析し、リリースノートを作成します。
 
+--local を指定すると、GitHub に依存せずにローカルでリリースノートを生成し、
+リリースの本文として使用します。前のタグとの間のコミットを Conventional Commits として
+解析し、破壊的変更・新機能・バグ修正・パフォーマンス改善に分類して PR 番号を付けます。
+--changelog を指定すると、同じ内容を CHANGELOG.md の先頭に追記します。
+
+--asset を指定すると、一致するファイルと SHA256 の checksums.txt をリリースに添付します。
+リリースが既に存在する場合はアセットのアップロードのみを行い、同じ名前のアセットは
+スキップします（--replace-assets で置き換え）。
+
 注意: このコマンドは既存のタグに対してリリースを作成します。
       新しいタグを作成する場合は、事前に git new-tag コマンドを使用してください。`,
 	Example: `  git release-notes                  # 対話的にタグを選択
@@ -65,10 +96,22 @@ GitHub CLIのgh release createコマンドを使用して、タグ間の変更
   git release-notes --latest         # 最新タグからリリース作成
   git release-notes --draft          # ドラフトとして作成
   git release-notes --prerelease     # プレリリースとして作成
-  git release-notes --tag v1.2.3 --draft --prerelease`,
+  git release-notes --tag v1.2.3 --draft --prerelease
+  git release-notes --latest --local                  # ローカルで生成した本文でリリース作成
+  git release-notes --tag v1.3.0 --from v1.1.0 --dry-run
+  git release-notes --latest --changelog --no-github  # CHANGELOG.md の更新のみ
+  git release-notes --latest --local --template .github/release-notes.tmpl
+  git release-notes --tag v1.2.3 --asset 'dist/*.tar.gz' --asset 'dist/*.zip'
+  git release-notes --tag v1.2.3 --asset 'dist/*' --replace-assets  # 既存のアセットを置き換え`,
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		// --changelog と --dry-run はローカル生成を前提とする
+		if releaseChangelog || releaseDryRun {
+			releaseLocal = true
+		}
+		publish := !releaseNoGitHub && !releaseDryRun
+
 		// GitHub CLI の確認
-		if !checkGitHubCLIInstalled() {
+		if publish && !checkGitHubCLIInstalled() {
 			return fmt.Errorf("GitHub CLI (gh) がインストールされていません\nインストール方法: https://cli.github.com/")
 		}
 
@@ -107,18 +150,73 @@ GitHub CLIのgh release createコマンドを使用して、タグ間の変更
 			fmt.Println("モード: プレリリース")
 		}
 
+		// ローカルでリリースノートを生成
+		var body string
+		if releaseLocal {
+			notes, generated, err := releasenotes.Generate(releaseFrom, selectedTag, releaseTemplate)
+			if err != nil {
+				return fmt.Errorf("リリースノートの生成に失敗しました: %w", err)
+			}
+			body = generated
+			printGeneratedNotes(notes, body)
+		}
+
+		// リリースに添付するアセットを確認
+		var assets []releaseassets.Asset
+		if len(releaseAssets) > 0 {
+			collected, err := releaseassets.Collect(releaseAssets)
+			if err != nil {
+				return fmt.Errorf("アセットの確認に失敗しました: %w", err)
+			}
+			assets = collected
+			fmt.Print(releaseassets.Describe(assets))
+		}
+
+		// --dry-run の場合はここで終了
+		if releaseDryRun {
+			fmt.Println("(--dry-run のため、リリースノートは作成されません)")
+			return nil
+		}
+
 		// 確認プロンプト
 		if !ui.Confirm("\nリリースノートを作成しますか？", true) {
 			fmt.Println("キャンセルしました")
 			return nil
 		}
 
-		// リリースノートを作成
-		if err := createReleaseNotes(selectedTag, releaseDraft, releasePrerelease); err != nil {
-			return fmt.Errorf("リリースノートの作成に失敗しました: %w", err)
+		// CHANGELOG に追記
+		if releaseChangelog {
+			if err := releasenotes.PrependChangelog(releaseChangelogFile, selectedTag, body); err != nil {
+				return fmt.Errorf("CHANGELOG の更新に失敗しました: %w", err)
+			}
+			fmt.Printf("✓ %s に %s のセクションを追加しました\n", releaseChangelogFile, selectedTag)
 		}
 
-		fmt.Printf("\n✓ リリースノートを作成しました\n")
+		if !publish {
+			return nil
+		}
+
+		// アセットを添付する場合は、既存のリリースにアップロードのみ行う
+		if len(assets) > 0 && releaseassets.ReleaseExists(selectedTag) {
+			fmt.Printf("\nリリース %s は既に存在するため、アセットのアップロードのみを行います\n", selectedTag)
+		} else {
+			// リリースノートを作成
+			if err := createReleaseNotes(selectedTag, releaseDraft, releasePrerelease, body); err != nil {
+				return fmt.Errorf("リリースノートの作成に失敗しました: %w", err)
+			}
+			fmt.Printf("\n✓ リリースノートを作成しました\n")
+		}
+
+		if len(assets) > 0 {
+			results, err := releaseassets.Upload(selectedTag, assets, releaseReplaceAssets)
+			if err != nil {
+				return err
+			}
+			fmt.Println("アセット:")
+			for _, result := range results {
+				fmt.Printf("  %s\n", result)
+			}
+		}
 		fmt.Printf("詳細を確認するには: gh release view %s --web\n", selectedTag)
 
 		return nil
@@ -200,17 +298,35 @@ func getRecentTags(limit int) ([]string, error) {
 	return tags, nil
 }
 
+// printGeneratedNotes はローカルで生成したリリースノートを表示します。
+func printGeneratedNotes(notes *releasenotes.Notes, body string) {
+	from := notes.PreviousTag
+	if from == "" {
+		from = "(最初のコミット)"
+	}
+	fmt.Printf("比較元: %s（%d 個のコミット）\n", from, notes.Commits)
+	fmt.Println("--------------------------------------------------------------------------------")
+	fmt.Print(body)
+	fmt.Println("--------------------------------------------------------------------------------")
+}
+
 // createReleaseNotes はGitHubリリースノートを作成します。
 //
 // パラメータ:
 //   - tag: リリースを作成するタグ
 //   - draft: ドラフトとして作成するかどうか
 //   - prerelease: プレリリースとして作成するかどうか
+//   - notes: リリースの本文（空の場合は GitHub の --generate-notes で自動生成）
 //
 // 戻り値:
 //   - error: エラーが発生した場合のエラー情報
-func createReleaseNotes(tag string, draft, prerelease bool) error {
-	args := []string{"release", "create", tag, "--generate-notes"}
+func createReleaseNotes(tag string, draft, prerelease bool, notes string) error {
+	args := []string{"release", "create", tag}
+	if notes != "" {
+		args = append(args, "--notes-file", "-")
+	} else {
+		args = append(args, "--generate-notes")
+	}
 
 	if draft {
 		args = append(args, "--draft")
@@ -220,6 +336,9 @@ func createReleaseNotes(tag string, draft, prerelease bool) error {
 	}
 
 	ghCmd := exec.Command("gh", args...)
+	if notes != "" {
+		ghCmd.Stdin = strings.NewReader(notes)
+	}
 
 	// 出力をキャプチャして表示
 	var stdout, stderr bytes.Buffer
@@ -248,5 +367,14 @@ func init() {
 	releaseNotesCmd.Flags().BoolVarP(&releaseDraft, "draft", "d", false, "ドラフトとして作成")
 	releaseNotesCmd.Flags().BoolVarP(&releasePrerelease, "prerelease", "p", false, "プレリリースとして作成")
 	releaseNotesCmd.Flags().BoolVarP(&releaseLatest, "latest", "l", false, "最新タグからリリースを作成")
+	releaseNotesCmd.Flags().BoolVar(&releaseLocal, "local", false, "リリースノートをローカルで生成して本文に使用")
+	releaseNotesCmd.Flags().StringVar(&releaseFrom, "from", "", "比較元のタグ（未指定時は1つ前のリリースタグ）")
+	releaseNotesCmd.Flags().StringVar(&releaseTemplate, "template", "", "リリースノートのテンプレートファイル（未指定時は git config "+releasenotes.TemplateConfigKey+"）")
+	releaseNotesCmd.Flags().BoolVar(&releaseChangelog, "changelog", false, "生成したリリースノートを CHANGELOG の先頭に追記")
+	releaseNotesCmd.Flags().StringVar(&releaseChangelogFile, "changelog-file", releasenotes.DefaultChangelogPath, "CHANGELOG ファイルのパス")
+	releaseNotesCmd.Flags().BoolVar(&releaseNoGitHub, "no-github", false, "GitHubリリースを作成しない（CHANGELOG の更新のみ）")
+	releaseNotesCmd.Flags().BoolVar(&releaseDryRun, "dry-run", false, "ローカルで生成したリリースノートを表示するのみ")
+	releaseNotesCmd.Flags().StringArrayVar(&releaseAssets, "asset", nil, "リリースに添付するファイルの glob パターン（例: 'dist/*.tar.gz'。複数指定可、checksums.txt を自動生成）")
+	releaseNotesCmd.Flags().BoolVar(&releaseReplaceAssets, "replace-assets", false, "同じ名前のアセットが既にある場合に置き換える（未指定時はスキップ）")
 	cmd.RootCmd.AddCommand(releaseNotesCmd)
 }
@@ -3,6 +3,8 @@ package release
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/tonbiattack/git-plus/cmd"
@@ -38,6 +40,15 @@ func TestReleaseNotesCmd_Flags(t *testing.T) {
 		{"draft", "d"},
 		{"prerelease", "p"},
 		{"latest", "l"},
+		{"local", ""},
+		{"from", ""},
+		{"template", ""},
+		{"changelog", ""},
+		{"changelog-file", ""},
+		{"no-github", ""},
+		{"dry-run", ""},
+		{"asset", ""},
+		{"replace-assets", ""},
 	}
 
 	for _, tt := range tests {
@@ -171,3 +182,55 @@ func TestGetRecentTags_NoTags(t *testing.T) {
 		t.Errorf("Expected 0 tags, got %d", len(tags))
 	}
 }
+
+// TestReleaseNotesCmd_ChangelogNoGitHub は --changelog --no-github で CHANGELOG だけを更新することを確認します
+func TestReleaseNotesCmd_ChangelogNoGitHub(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+
+	repo.CreateFile("README.md", "# Test")
+	repo.Commit("chore: initial commit")
+	repo.CreateTag("v1.0.0", "Release v1.0.0")
+	repo.CreateFile("a.txt", "a")
+	repo.Commit("feat(api): add endpoint (#12)")
+	repo.CreateFile("b.txt", "b")
+	repo.Commit("fix: handle empty input")
+	repo.CreateTag("v1.1.0", "Release v1.1.0")
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer func() { _ = os.Chdir(oldDir) }()
+
+	if err := os.Chdir(repo.Dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	releaseTag = "v1.1.0"
+	releaseChangelog = true
+	releaseNoGitHub = true
+	releaseChangelogFile = "CHANGELOG.md"
+	defer func() {
+		releaseTag = ""
+		releaseChangelog = false
+		releaseNoGitHub = false
+		releaseLocal = false
+	}()
+
+	if err := releaseNotesCmd.RunE(releaseNotesCmd, nil); err != nil {
+		t.Fatalf("RunE returned error: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(repo.Dir, "CHANGELOG.md"))
+	if err != nil {
+		t.Fatalf("CHANGELOG.md was not created: %v", err)
+	}
+	for _, want := range []string{"## v1.1.0", "**api:** add endpoint (#12)", "handle empty input"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("CHANGELOG.md does not contain %q:\n%s", want, content)
+		}
+	}
+	if strings.Contains(string(content), "initial commit") {
+		t.Errorf("CHANGELOG.md should not contain commits before v1.0.0:\n%s", content)
+	}
+}
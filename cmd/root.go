@@ -29,14 +29,15 @@ import (
 // Cobraのコマンド構造:
 // RootCmd (git plus)
 //   ├── branch/ (newbranch, back, recent, sync, delete-local-branches)
-//   ├── tag/ (reset-tag, tag-diff, new-tag, etc.)
-//   ├── commit/ (amend, squash, undo-last-commit, track)
-//   ├── stash/ (stash-cleanup, stash-select, pause, resume)
-//   ├── pr/ (pr-create-merge, pr-list, pr-merge, pr-checkout)
+//   ├── tag/ (reset-tag, tag-diff, new-tag, verify-tags, release, hotfix, etc.)
+//   ├── commit/ (amend, squash, undo-last-commit, track, conventional-commit, lint-commits, split-commit)
+//   ├── stash/ (stash-cleanup, stash-select, stash-grep, stash-export, stash-import, pause, resume)
+//   ├── pr/ (pr-create-merge, pr-list, pr-merge, pr-checkout, backport)
 //   ├── repo/ (create-repository, clone-org, batch-clone, browse, repo-others)
 //   ├── issue/ (issue-list, issue-create, issue-edit)
 //   ├── release/ (release-notes)
-//   └── stats/ (step)
+//   ├── stats/ (step)
+//   └── backups/ (backups)
 var RootCmd = &cobra.Command{
 	Use:   "plus",
 	Short: "Git の日常操作を少しだけ楽にするための拡張コマンド集",
@@ -13,32 +13,43 @@
 // - 現在の作業内容（未コミット変更）を stash に保存
 // - 現在のブランチ情報と移動先ブランチ情報を状態ファイルに保存
 // - 指定されたブランチへの自動切り替え
-// - 既存の pause 状態の検出と上書き確認
+// - リポジトリ・ワークツリーごとに状態をスタックとして保持（pause を重ねられる）
+// - すべてのリポジトリの pause 状態の一覧表示（--list）
 //
 // 【使用例】
 //   git pause main              # main ブランチに一時切り替え
 //   git pause feature/login     # feature/login ブランチに一時切り替え
+//   git pause --list            # すべての pause 状態を表示
 //
 // 【内部仕様】
-// - 状態は $HOME/.config/git-plus/pause-state.json に保存されます
+// - 状態は $HOME/.git-plus/pause-state.json に、リポジトリ（共通の .git ディレクトリ）と
+//   ワークツリーの組み合わせごとに保存されます
 // - stash メッセージには "git-pause: from <ブランチ名>" の形式が使用されます
+// - stash はコミットハッシュで記録し、refs/git-plus/pause/<hash> でも保持します
+//   （stash@{N} は他のスタッシュ操作で指す先が変わるため）
+// - stash は git stash create で作成し、そのハッシュをそのまま使用します
+//   （未追跡のファイルは保存せず、作業ツリーに残します）
 // - 変更がない場合は stash をスキップします
 // ================================================================================
 
 package stash
 
 import (
+	"errors"
 	"fmt"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/tonbiattack/git-plus/cmd"
 	"github.com/tonbiattack/git-plus/internal/pausestate"
-	"github.com/tonbiattack/git-plus/internal/ui"
 )
 
+// pauseList は --list フラグの値です。すべての pause 状態を一覧表示します。
+var pauseList bool
+
 // pauseCmd は pause コマンドの定義です。
 // 作業中の変更を stash に保存し、別のブランチへ移動します。
 // 後で resume コマンドで元のブランチと変更を復元できます。
@@ -46,31 +57,41 @@ var pauseCmd = &cobra.Command{
 	Use:   "pause <branch>",
 	Short: "作業中の変更を stash して別ブランチへ移動します",
 	Long: `現在のブランチ名とコミットしていない変更を保存し、指定したブランチへ切り替えます。
-作業へ戻る準備ができたら git resume を実行して保存内容を復元してください。`,
+作業へ戻る準備ができたら git resume を実行して保存内容を復元してください。
+
+pause 状態はリポジトリとワークツリーごとに保存されるため、別のリポジトリで
+pause しても状態は上書きされません。同じリポジトリで pause を重ねた場合は、
+git resume で後から保存したものから順に復元します。
+--list を指定すると、すべてのリポジトリの pause 状態を表示します。`,
 	Example: `  git pause main
-  git pause feature/login`,
-	Args: cobra.ExactArgs(1),
+  git pause feature/login
+  git pause --list`,
+	Args: func(c *cobra.Command, args []string) error {
+		if pauseList {
+			return cobra.NoArgs(c, args)
+		}
+		return cobra.ExactArgs(1)(c, args)
+	},
 	RunE: func(c *cobra.Command, args []string) error {
+		if pauseList {
+			return printPauseList()
+		}
+
 		targetBranch := args[0]
 
-		// 既に pause 状態かチェック
-		exists, err := pausestate.Exists()
+		ctx, err := pausestate.CurrentContext()
 		if err != nil {
-			return fmt.Errorf("状態の確認に失敗: %w", err)
+			return err
 		}
 
-		if exists {
-			state, err := pausestate.Load()
-			if err != nil {
-				return fmt.Errorf("既存の状態の読み込みに失敗: %w", err)
-			}
-
-			fmt.Printf("警告: 既に pause 状態です（%s → %s）\n", state.FromBranch, state.ToBranch)
-
-			if !ui.Confirm("上書きしますか？", false) {
-				fmt.Println("キャンセルしました")
-				return nil
-			}
+		// 既に pause 状態であれば、その上に重ねて保存する
+		stack, err := pausestate.Stack(ctx)
+		if err != nil {
+			return fmt.Errorf("状態の確認に失敗: %w", err)
+		}
+		if len(stack) > 0 {
+			latest := stack[len(stack)-1]
+			fmt.Printf("既に pause 状態です（%s → %s）。重ねて保存します（%d 件目）\n", latest.FromBranch, latest.ToBranch, len(stack)+1)
 		}
 
 		// 現在のブランチを取得
@@ -85,38 +106,50 @@ var pauseCmd = &cobra.Command{
 			return fmt.Errorf("変更の確認に失敗: %w", err)
 		}
 
-		var stashRef string
+		var stashCommit string
 		stashMessage := fmt.Sprintf("git-pause: from %s", currentBranch)
 
 		if hasChanges {
 			fmt.Println("変更を保存中...")
-			stashRef, err = createStashWithMessage(stashMessage)
-			if err != nil {
+			stashCommit, err = createStashWithMessage(stashMessage)
+			if errors.Is(err, pausestate.ErrNothingToStash) {
+				// 未追跡のファイルのみの場合は保存せず、作業ツリーに残したまま切り替える
+				fmt.Println("追跡中のファイルに変更がないため、スタッシュはスキップします（未追跡のファイルはそのまま残ります）")
+			} else if err != nil {
 				return fmt.Errorf("スタッシュの作成に失敗: %w", err)
+			} else {
+				// stash 一覧から drop されても復元できるよう、専用の参照でも保持する
+				if _, err := pausestate.KeepStash(stashCommit); err != nil {
+					fmt.Printf("警告: %v\n", err)
+				}
+				fmt.Printf("✓ 変更を保存しました: %s\n", stashCommit)
 			}
-			fmt.Printf("✓ 変更を保存しました: %s\n", stashRef)
 		} else {
 			fmt.Println("変更がないため、スタッシュはスキップします")
-			stashRef = ""
 		}
 
 		// 状態を保存
 		state := &pausestate.PauseState{
 			FromBranch:   currentBranch,
 			ToBranch:     targetBranch,
-			StashRef:     stashRef,
+			StashCommit:  stashCommit,
 			StashMessage: stashMessage,
+			Command:      "pause",
 			Timestamp:    time.Now(),
 		}
 
-		if err := pausestate.Save(state); err != nil {
+		if err := pausestate.Push(ctx, state); err != nil {
 			return fmt.Errorf("状態の保存に失敗: %w", err)
 		}
 
 		// ブランチを切り替え
 		fmt.Printf("ブランチを切り替え中: %s → %s\n", currentBranch, targetBranch)
 		if err := checkoutBranch(targetBranch); err != nil {
-			_ = pausestate.Delete()
+			_, _ = pausestate.Pop(ctx)
+			if stashCommit != "" {
+				_ = pausestate.ReleaseStash(stashCommit)
+				fmt.Printf("変更はスタッシュに残っています: %s\n", stashMessage)
+			}
 			return fmt.Errorf("ブランチの切り替えに失敗: %w", err)
 		}
 
@@ -126,6 +159,66 @@ var pauseCmd = &cobra.Command{
 	},
 }
 
+// printPauseList はすべてのリポジトリ・ワークツリーの pause 状態を表示します。
+//
+// 内部処理:
+//   各ワークツリーのスタックを、次の resume で復元されるもの（最後に保存したもの）から順に表示します。
+//   現在のワークツリーには印を付けます。以前のバージョンで保存された状態がある場合はあわせて表示します。
+func printPauseList() error {
+	contexts, err := pausestate.List()
+	if err != nil {
+		return fmt.Errorf("状態の読み込みに失敗: %w", err)
+	}
+	legacy, err := pausestate.LoadLegacy()
+	if err != nil {
+		return fmt.Errorf("状態の読み込みに失敗: %w", err)
+	}
+
+	if len(contexts) == 0 && legacy == nil {
+		fmt.Println("pause 状態はありません")
+		return nil
+	}
+
+	// リポジトリ外で実行された場合は現在のワークツリーの印を付けない
+	current, _ := pausestate.CurrentContext()
+
+	for _, c := range contexts {
+		marker := ""
+		if c.Context == current {
+			marker = " (現在のワークツリー)"
+		}
+		fmt.Printf("%s%s\n", c.Worktree, marker)
+		if filepath.Dir(c.Repository) != c.Worktree {
+			fmt.Printf("  リポジトリ: %s\n", c.Repository)
+		}
+		for i := len(c.Stack) - 1; i >= 0; i-- {
+			fmt.Printf("  %d. %s\n", len(c.Stack)-i, formatPauseState(c.Stack[i]))
+		}
+		fmt.Println()
+	}
+
+	if legacy != nil {
+		fmt.Println("以前のバージョンで保存された状態（リポジトリ不明）:")
+		fmt.Printf("  %s\n\n", formatPauseState(*legacy))
+	}
+
+	fmt.Println("番号 1 の状態から順に git resume で復元されます")
+	return nil
+}
+
+// formatPauseState は pause 状態を1行で表示するための文字列に整形します。
+func formatPauseState(s pausestate.PauseState) string {
+	command := s.Command
+	if command == "" {
+		command = "pause"
+	}
+	line := fmt.Sprintf("%s → %s  [%s] %s", s.FromBranch, s.ToBranch, command, s.Timestamp.Local().Format("2006-01-02 15:04"))
+	if s.StashCommit != "" || s.StashRef != "" {
+		line += "  stash: " + s.StashMessage
+	}
+	return line
+}
+
 // getBranchCurrent は現在チェックアウトされているブランチ名を取得します。
 //
 // 戻り値:
@@ -167,25 +260,14 @@ func checkUncommittedChanges() (bool, error) {
 //   - message: stash に付けるメッセージ
 //
 // 戻り値:
-//   - string: 作成された stash の参照（SHA-1 ハッシュ）
-//   - error: stash の作成に失敗した場合のエラー情報
+//   - string: 作成された stash のコミットハッシュ
+//   - error: stash の作成に失敗した場合のエラー情報（保存する変更がない場合は pausestate.ErrNothingToStash）
 //
 // 内部処理:
-//   1. git stash push -m "<メッセージ>" で変更を stash に保存
-//   2. git rev-parse stash@{0} で最新の stash の参照を取得
+//   pausestate.CreateStash で git stash create / git stash store を実行し、
+//   作成したコミットのハッシュをそのまま返します（stash@{0} は読みません）。
 func createStashWithMessage(message string) (string, error) {
-	cmd := exec.Command("git", "stash", "push", "-m", message)
-	if err := cmd.Run(); err != nil {
-		return "", err
-	}
-
-	cmd = exec.Command("git", "rev-parse", "stash@{0}")
-	output, err := cmd.Output()
-	if err != nil {
-		return "", err
-	}
-
-	return strings.TrimSpace(string(output)), nil
+	return pausestate.CreateStash(message)
 }
 
 // checkoutBranch は指定されたブランチにチェックアウトします。
@@ -206,5 +288,6 @@ func checkoutBranch(branch string) error {
 // init は pause コマンドを root コマンドに登録します。
 // この関数はパッケージの初期化時に自動的に呼び出されます。
 func init() {
+	pauseCmd.Flags().BoolVarP(&pauseList, "list", "l", false, "すべてのリポジトリの pause 状態を表示")
 	cmd.RootCmd.AddCommand(pauseCmd)
 }
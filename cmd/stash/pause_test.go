@@ -2,6 +2,7 @@ package stash
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/tonbiattack/git-plus/cmd"
@@ -35,6 +36,263 @@ func TestPauseCmd_Args(t *testing.T) {
 	}
 }
 
+// TestPauseCmd_ListArgs は --list 指定時の引数の検証をテストします
+func TestPauseCmd_ListArgs(t *testing.T) {
+	if pauseCmd.Flags().Lookup("list") == nil {
+		t.Fatal("Flag list not found")
+	}
+
+	pauseList = true
+	defer func() { pauseList = false }()
+	if err := pauseCmd.Args(pauseCmd, []string{}); err != nil {
+		t.Errorf("--list should accept no arguments: %v", err)
+	}
+	if err := pauseCmd.Args(pauseCmd, []string{"main"}); err == nil {
+		t.Error("--list should reject branch argument")
+	}
+
+	pauseList = false
+	if err := pauseCmd.Args(pauseCmd, []string{}); err == nil {
+		t.Error("pause without --list should require a branch")
+	}
+}
+
+// TestPauseResume_Stacked は pause を重ねた場合に resume が LIFO で復元することをテストします
+func TestPauseResume_Stacked(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("README.md", "# Test")
+	repo.Commit("Initial commit")
+	base := repo.CurrentBranch()
+	repo.CreateBranch("develop")
+	repo.CreateAndCheckoutBranch("feature")
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer func() { _ = os.Chdir(oldDir) }()
+
+	if err := os.Chdir(repo.Dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	// feature → develop → base の順に pause を重ねる
+	if err := pauseCmd.RunE(pauseCmd, []string{"develop"}); err != nil {
+		t.Fatalf("First pause returned error: %v", err)
+	}
+	if err := pauseCmd.RunE(pauseCmd, []string{base}); err != nil {
+		t.Fatalf("Second pause returned error: %v", err)
+	}
+	if got := repo.CurrentBranch(); got != base {
+		t.Fatalf("Current branch = %q, want %q", got, base)
+	}
+
+	// 後から保存したものから順に戻る
+	if err := resumeCmd.RunE(resumeCmd, []string{}); err != nil {
+		t.Fatalf("First resume returned error: %v", err)
+	}
+	if got := repo.CurrentBranch(); got != "develop" {
+		t.Errorf("After first resume branch = %q, want develop", got)
+	}
+	if err := resumeCmd.RunE(resumeCmd, []string{}); err != nil {
+		t.Fatalf("Second resume returned error: %v", err)
+	}
+	if got := repo.CurrentBranch(); got != "feature" {
+		t.Errorf("After second resume branch = %q, want feature", got)
+	}
+
+	// スタックが空になったら resume はエラー
+	if err := resumeCmd.RunE(resumeCmd, []string{}); err == nil {
+		t.Error("resume should fail when no pause state remains")
+	}
+}
+
+// TestPauseResume_IsolatedByRepository は別リポジトリの pause 状態を使用しないことをテストします
+func TestPauseResume_IsolatedByRepository(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	repoA := testutil.NewGitRepo(t)
+	repoA.CreateFile("README.md", "# A")
+	repoA.Commit("Initial commit")
+	repoA.CreateBranch("other")
+
+	repoB := testutil.NewGitRepo(t)
+	repoB.CreateFile("README.md", "# B")
+	repoB.Commit("Initial commit")
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer func() { _ = os.Chdir(oldDir) }()
+
+	if err := os.Chdir(repoA.Dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+	if err := pauseCmd.RunE(pauseCmd, []string{"other"}); err != nil {
+		t.Fatalf("pause returned error: %v", err)
+	}
+
+	// リポジトリBにはリポジトリAの状態は見えない
+	if err := os.Chdir(repoB.Dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+	if err := resumeCmd.RunE(resumeCmd, []string{}); err == nil {
+		t.Error("resume in another repository should fail")
+	}
+
+	// リポジトリAでは復元できる
+	if err := os.Chdir(repoA.Dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+	if err := resumeCmd.RunE(resumeCmd, []string{}); err != nil {
+		t.Errorf("resume in original repository returned error: %v", err)
+	}
+}
+
+// TestPauseResume_StashIdentity は pause 後に別のスタッシュが作成されても正しい変更を復元することをテストします
+func TestPauseResume_StashIdentity(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("a.txt", "original")
+	repo.Commit("Initial commit")
+	repo.CreateBranch("other")
+	base := repo.CurrentBranch()
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer func() { _ = os.Chdir(oldDir) }()
+
+	if err := os.Chdir(repo.Dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	repo.CreateFile("a.txt", "paused change")
+	if err := pauseCmd.RunE(pauseCmd, []string{"other"}); err != nil {
+		t.Fatalf("pause returned error: %v", err)
+	}
+
+	// pause 先で別のスタッシュを作成する
+	repo.CreateFile("b.txt", "other change")
+	repo.MustGit("stash", "push", "-u", "-m", "other work")
+
+	if err := resumeCmd.RunE(resumeCmd, []string{}); err != nil {
+		t.Fatalf("resume returned error: %v", err)
+	}
+	if got := repo.CurrentBranch(); got != base {
+		t.Errorf("Current branch = %q, want %q", got, base)
+	}
+	if got := repo.ReadFile("a.txt"); got != "paused change" {
+		t.Errorf("a.txt = %q, want paused change", got)
+	}
+	if repo.FileExists("b.txt") {
+		t.Error("Unrelated stash should not be applied")
+	}
+}
+
+// TestPause_UntrackedOnlyWithExistingStash は未追跡のファイルしかない場合に既存のスタッシュを記録しないことをテストします
+func TestPause_UntrackedOnlyWithExistingStash(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("a.txt", "original")
+	repo.Commit("Initial commit")
+	repo.CreateBranch("other")
+	base := repo.CurrentBranch()
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer func() { _ = os.Chdir(oldDir) }()
+
+	if err := os.Chdir(repo.Dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	// pause とは無関係なスタッシュが既にある
+	repo.CreateFile("a.txt", "older work")
+	repo.StashPush("older work")
+	older := strings.TrimSpace(repo.MustGit("rev-parse", "stash@{0}"))
+
+	repo.CreateFile("untracked.txt", "untracked")
+	if err := pauseCmd.RunE(pauseCmd, []string{"other"}); err != nil {
+		t.Fatalf("pause returned error: %v", err)
+	}
+	if refs := strings.TrimSpace(repo.MustGit("for-each-ref", "refs/git-plus/pause/")); refs != "" {
+		t.Errorf("unrelated stash should not be kept: %s", refs)
+	}
+
+	if err := resumeCmd.RunE(resumeCmd, []string{}); err != nil {
+		t.Fatalf("resume returned error: %v", err)
+	}
+	if got := repo.CurrentBranch(); got != base {
+		t.Errorf("Current branch = %q, want %q", got, base)
+	}
+	if got := repo.ReadFile("a.txt"); got != "original" {
+		t.Errorf("a.txt = %q, want original (older stash must not be applied)", got)
+	}
+	if top := strings.TrimSpace(repo.MustGit("rev-parse", "stash@{0}")); top != older {
+		t.Errorf("stash@{0} = %s, want the older stash %s", top, older)
+	}
+}
+
+// TestResume_MissingStash はスタッシュが失われている場合にブランチを切り替えずに中止することをテストします
+func TestResume_MissingStash(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("a.txt", "original")
+	repo.Commit("Initial commit")
+	repo.CreateBranch("other")
+	base := repo.CurrentBranch()
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer func() { _ = os.Chdir(oldDir) }()
+
+	if err := os.Chdir(repo.Dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	repo.CreateFile("a.txt", "paused change")
+	if err := pauseCmd.RunE(pauseCmd, []string{"other"}); err != nil {
+		t.Fatalf("pause returned error: %v", err)
+	}
+
+	// スタッシュと保持用の参照を両方削除し、オブジェクトも消す
+	repo.MustGit("stash", "clear")
+	for _, ref := range strings.Fields(repo.MustGit("for-each-ref", "--format=%(refname)", "refs/git-plus/pause/")) {
+		repo.MustGit("update-ref", "-d", ref)
+	}
+	repo.MustGit("reflog", "expire", "--expire=now", "--all")
+	repo.MustGit("gc", "--prune=now", "--quiet")
+
+	if err := resumeCmd.RunE(resumeCmd, []string{}); err == nil {
+		t.Fatal("resume should fail when the stash is missing")
+	}
+	if got := repo.CurrentBranch(); got != "other" {
+		t.Errorf("Branch should not change when the stash is missing, got %q", got)
+	}
+
+	// --no-stash ならブランチのみ戻る
+	resumeNoStash = true
+	defer func() { resumeNoStash = false }()
+	if err := resumeCmd.RunE(resumeCmd, []string{}); err != nil {
+		t.Fatalf("resume --no-stash returned error: %v", err)
+	}
+	if got := repo.CurrentBranch(); got != base {
+		t.Errorf("Current branch = %q, want %q", got, base)
+	}
+}
+
 // TestPauseCmd_InRootCmd はpauseコマンドがrootCmdに登録されていることを確認します
 func TestPauseCmd_InRootCmd(t *testing.T) {
 	found := false
@@ -9,23 +9,26 @@
 // 保存されていたブランチに戻り、stash に保存されていた変更を復元します。
 //
 // 【主な機能】
-// - pause コマンドで保存された状態ファイルの読み込み
+// - 現在のリポジトリ・ワークツリーの pause 状態の読み込み
 // - 元のブランチへの自動切り替え
 // - stash に保存された変更の自動復元
-// - 復元後の状態ファイルの自動削除
+// - 復元後の状態の削除（pause を重ねていた場合は1つ前の状態が次の復元対象になる）
 //
 // 【使用例】
 //   git resume  # pause で保存した状態を復元
 //
 // 【内部仕様】
-// - 状態ファイルは $HOME/.config/git-plus/pause-state.json から読み込まれます
-// - stash が存在する場合は git stash pop で復元されます
-// - 復元が成功すると状態ファイルは自動的に削除されます
+// - 状態は $HOME/.git-plus/pause-state.json の現在のリポジトリ・ワークツリーのスタックから読み込まれます
+// - stash はコミットハッシュで探し、スタッシュ一覧に残っていれば git stash pop stash@{N}、
+//   一覧から削除されていれば refs/git-plus/pause/<hash> から git stash apply で復元されます
+// - stash が見つからない場合は、ブランチを切り替える前に中止します
+// - 復元が成功するとスタックの先頭の状態が削除されます
 // ================================================================================
 
 package stash
 
 import (
+	"errors"
 	"fmt"
 	"os/exec"
 	"strings"
@@ -33,41 +36,79 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/tonbiattack/git-plus/cmd"
 	"github.com/tonbiattack/git-plus/internal/pausestate"
+	"github.com/tonbiattack/git-plus/internal/ui"
 )
 
+// resumeNoStash は --no-stash フラグの値です。
+// スタッシュを適用せずにブランチのみ戻し、pause 状態を削除します。
+var resumeNoStash bool
+
 // resumeCmd は resume コマンドの定義です。
 // git pause で保存した作業を再開し、元のブランチと変更を復元します。
 var resumeCmd = &cobra.Command{
 	Use:   "resume",
 	Short: "git pause で保存した作業を再開します",
 	Long: `git pause が記録したブランチへ戻り、必要であれば保存された stash を適用して、
-休止状態のメタデータを削除します。`,
-	Example: `  git resume`,
+休止状態のメタデータを削除します。
+
+復元するのは現在のリポジトリ・ワークツリーで保存された状態のみです。
+pause を重ねていた場合は、最後に保存したものから順に復元します。
+
+スタッシュは pause 時に記録したコミットハッシュで探します。スタッシュが
+削除されていて見つからない場合は、ブランチを切り替える前に中止します。
+--no-stash を指定すると、スタッシュを適用せずにブランチのみ戻します。`,
+	Example: `  git resume
+  git resume --no-stash`,
 	RunE: func(c *cobra.Command, args []string) error {
-		// 状態ファイルが存在するかチェック
-		exists, err := pausestate.Exists()
+		ctx, err := pausestate.CurrentContext()
 		if err != nil {
-			return fmt.Errorf("状態の確認に失敗: %w", err)
+			return err
 		}
 
-		if !exists {
-			fmt.Println("エラー: pause 状態がありません")
-			fmt.Println("git pause <branch> で作業を一時保存してください")
-			return fmt.Errorf("pause 状態がありません")
-		}
-
-		// 状態を読み込み
-		state, err := pausestate.Load()
+		// 現在のワークツリーのスタックの先頭を読み込み
+		state, err := pausestate.Peek(ctx)
 		if err != nil {
 			return fmt.Errorf("状態の読み込みに失敗: %w", err)
 		}
 
+		// 以前のバージョンで保存された状態はリポジトリが不明なため、確認してから使用する
+		legacy := false
+		if state == nil {
+			state, err = pausestate.LoadLegacy()
+			if err != nil {
+				return fmt.Errorf("状態の読み込みに失敗: %w", err)
+			}
+			if state != nil {
+				fmt.Println("このワークツリーの pause 状態はありませんが、以前のバージョンで保存された状態があります:")
+				fmt.Printf("  %s\n", formatPauseState(*state))
+				if !ui.Confirm("この状態をこのリポジトリで復元しますか？", false) {
+					fmt.Println("キャンセルしました")
+					return nil
+				}
+				legacy = true
+			}
+		}
+
 		if state == nil {
+			fmt.Println("エラー: このワークツリーに pause 状態がありません")
+			fmt.Println("git pause <branch> で作業を一時保存してください")
+			fmt.Println("他のリポジトリの状態を確認するには: git pause --list")
 			return fmt.Errorf("pause 状態がありません")
 		}
 
 		fmt.Printf("元のブランチに戻ります: %s → %s\n", state.ToBranch, state.FromBranch)
 
+		// ブランチを切り替える前に、スタッシュが残っているか確認する
+		hasStash := (state.StashCommit != "" || state.StashRef != "") && !resumeNoStash
+		if hasStash {
+			if _, _, err := pausestate.FindStash(state); err != nil {
+				if errors.Is(err, pausestate.ErrStashNotFound) {
+					printMissingStash(state)
+				}
+				return err
+			}
+		}
+
 		// 現在のブランチを確認
 		currentBranch, err := getCurrentBranchName()
 		if err != nil {
@@ -86,24 +127,38 @@ var resumeCmd = &cobra.Command{
 		}
 
 		// スタッシュを復元（スタッシュが存在する場合のみ）
-		if state.StashRef != "" {
+		switch {
+		case hasStash:
 			fmt.Println("変更を復元中...")
-			if err := popStashRef(state.StashRef); err != nil {
+			if err := pausestate.RestoreStash(state); err != nil {
 				fmt.Println("警告: スタッシュの復元に失敗しました")
-				fmt.Println("手動で復元してください: git stash list")
+				fmt.Println("競合を解消してから、手動で復元してください: git stash list")
 				return fmt.Errorf("スタッシュの復元に失敗: %w", err)
 			}
 			fmt.Println("✓ 変更を復元しました")
-		} else {
+		case resumeNoStash && state.StashCommit != "":
+			fmt.Printf("スタッシュは適用しません（git stash list に残っています: %s）\n", state.StashMessage)
+			_ = pausestate.ReleaseStash(state.StashCommit)
+		default:
 			fmt.Println("復元するスタッシュがありません")
 		}
 
-		// 状態ファイルを削除
-		if err := pausestate.Delete(); err != nil {
-			fmt.Printf("警告: 状態ファイルの削除に失敗: %v\n", err)
+		// 復元した状態を削除
+		if legacy {
+			err = pausestate.DeleteLegacy()
+		} else {
+			_, err = pausestate.Pop(ctx)
+		}
+		if err != nil {
+			fmt.Printf("警告: 状態の削除に失敗: %v\n", err)
 		}
 
 		fmt.Println("\n✓ 作業の復元が完了しました")
+
+		if remaining, err := pausestate.Stack(ctx); err == nil && len(remaining) > 0 {
+			next := remaining[len(remaining)-1]
+			fmt.Printf("残りの pause 状態: %d 件（次の git resume で %s に戻ります）\n", len(remaining), next.FromBranch)
+		}
 		return nil
 	},
 }
@@ -140,46 +195,23 @@ func switchBranchTo(branch string) error {
 	return cmd.Run()
 }
 
-// popStashRef は指定された stash 参照を復元します。
-//
-// パラメータ:
-//   - stashRef: 復元する stash の参照（SHA-1 ハッシュ）
-//              注: 現在の実装では参照は使用されず、最新の stash を pop します
-//
-// 戻り値:
-//   - error: stash の復元に失敗した場合のエラー情報
-//
-// 内部処理:
-//   1. git stash list でスタッシュの存在を確認
-//   2. git stash pop で最新のスタッシュを復元
-//
-// 備考:
-//   現在の実装では stashRef パラメータは使用されず、常に最新の stash を復元します。
-//   将来的には特定の stash を復元できるように改善される可能性があります。
-func popStashRef(stashRef string) error {
-	// stash@{0} の形式でスタッシュを検索
-	cmd := exec.Command("git", "stash", "list")
-	output, err := cmd.Output()
-	if err != nil {
-		return fmt.Errorf("スタッシュ一覧の取得に失敗: %w", err)
-	}
-
-	stashList := strings.TrimSpace(string(output))
-	if stashList == "" {
-		return fmt.Errorf("スタッシュが見つかりません")
-	}
-
-	// スタッシュを pop
-	cmd = exec.Command("git", "stash", "pop")
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("スタッシュの適用に失敗: %w", err)
+// printMissingStash は pause で保存したスタッシュが見つからない場合の説明を表示します。
+func printMissingStash(state *pausestate.PauseState) {
+	commit := state.StashCommit
+	if commit == "" {
+		commit = state.StashRef
 	}
-
-	return nil
+	fmt.Println("エラー: pause で保存したスタッシュが見つかりません")
+	fmt.Printf("  スタッシュ: %s\n", commit)
+	fmt.Printf("  メッセージ: %s\n", state.StashMessage)
+	fmt.Println("git stash drop や git stash clear で削除された可能性があります。")
+	fmt.Println("誤った変更を適用しないよう、ブランチを切り替えずに中止しました。")
+	fmt.Println("スタッシュを適用せずにブランチのみ戻すには: git resume --no-stash")
 }
 
 // init は resume コマンドを root コマンドに登録します。
 // この関数はパッケージの初期化時に自動的に呼び出されます。
 func init() {
+	resumeCmd.Flags().BoolVar(&resumeNoStash, "no-stash", false, "スタッシュを適用せずにブランチのみ戻す")
 	cmd.RootCmd.AddCommand(resumeCmd)
 }
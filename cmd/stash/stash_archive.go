@@ -0,0 +1,89 @@
+// ================================================================================
+// stash_archive.go
+// ================================================================================
+// このファイルは、削除するスタッシュのアーカイブ処理を実装しています。
+// stash-cleanup でスタッシュを削除する前に使用されます。
+//
+// 【アーカイブ方法】
+// - 参照（デフォルト）: スタッシュのコミットを refs/git-plus/stash-archive/<hash> に保存します。
+//   スタッシュ一覧から削除されてもコミットは git gc で消えず、
+//   git stash store -m "<メッセージ>" <hash> でスタッシュ一覧に戻せます。
+// - パッチファイル: 差分（untracked ファイルを含む）をパッチファイルとして保存します。
+//   git apply <パッチファイル> で変更を戻せます。
+// ================================================================================
+
+package stash
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tonbiattack/git-plus/internal/gitcmd"
+)
+
+// StashArchiveRefPrefix は、削除前のスタッシュを保存する参照の名前空間です。
+const StashArchiveRefPrefix = "refs/git-plus/stash-archive/"
+
+// archiveStash はスタッシュをアーカイブします。
+//
+// パラメータ:
+//   - info: アーカイブするスタッシュ（Commit が必要）
+//   - dir: パッチファイルの保存先（空の場合は参照として保存）
+//
+// 戻り値:
+//   - string: 作成した参照名またはパッチファイルのパス
+//   - error: アーカイブに失敗した場合のエラー情報
+func archiveStash(info StashInfo, dir string) (string, error) {
+	if info.Commit == "" {
+		return "", fmt.Errorf("%s のコミットハッシュが不明です", info.Name)
+	}
+	if dir == "" {
+		return archiveStashToRef(info)
+	}
+	return archiveStashToPatch(info, dir)
+}
+
+// archiveStashToRef はスタッシュのコミットを refs/git-plus/stash-archive/<hash> に保存します。
+// 同じスタッシュを再度アーカイブした場合は同じ参照を上書きします。
+func archiveStashToRef(info StashInfo) (string, error) {
+	ref := StashArchiveRefPrefix + info.Commit
+	if err := gitcmd.RunQuiet("update-ref", "-m", "git-plus stash-archive: "+info.Message, ref, info.Commit); err != nil {
+		return "", fmt.Errorf("参照の作成に失敗しました: %w", err)
+	}
+	return ref, nil
+}
+
+// archiveStashToPatch はスタッシュの差分をパッチファイルとして保存します。
+//
+// 内部処理:
+//  1. 保存先ディレクトリを作成
+//  2. git stash show -p --binary --include-untracked <hash> で差分を取得
+//  3. 先頭にメッセージと作成元のコミットを記載し、
+//     stash-<作成日時>-<短縮ハッシュ>.patch として書き込む
+//     （git apply はパッチの前にあるテキストを無視します）
+func archiveStashToPatch(info StashInfo, dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("ディレクトリの作成に失敗しました: %w", err)
+	}
+
+	diff, err := gitcmd.Run("stash", "show", "-p", "--binary", "--include-untracked", info.Commit)
+	if err != nil {
+		return "", fmt.Errorf("差分の取得に失敗しました: %w", err)
+	}
+
+	base, _ := gitcmd.Run("rev-parse", info.Commit+"^1")
+	header := fmt.Sprintf("stash: %s\nmessage: %s\nbase: %s\n\n", info.Commit, info.Message, strings.TrimSpace(string(base)))
+
+	path := filepath.Join(dir, archivePatchName(info))
+	if err := os.WriteFile(path, append([]byte(header), diff...), 0644); err != nil {
+		return "", fmt.Errorf("パッチファイルの書き込みに失敗しました: %w", err)
+	}
+	return path, nil
+}
+
+// archivePatchName はパッチファイル名（stash-<作成日時>-<短縮ハッシュ>.patch）を返します。
+func archivePatchName(info StashInfo) string {
+	return fmt.Sprintf("stash-%s-%s.patch", info.Time.Format("20060102-150405"), shortCommit(info.Commit))
+}
@@ -0,0 +1,122 @@
+package stash
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tonbiattack/git-plus/internal/testutil"
+)
+
+// setupArchiveRepo はアーカイブのテスト用に、追跡ファイルと untracked ファイルを含むスタッシュを作成します
+func setupArchiveRepo(t *testing.T) (*testutil.GitRepo, StashInfo) {
+	t.Helper()
+
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("a.txt", "a1\n")
+	repo.Commit("Initial commit")
+
+	repo.CreateFile("a.txt", "a2\n")
+	repo.CreateFile("new.txt", "new\n")
+	repo.MustGit("stash", "push", "-u", "-m", "archive me")
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(oldDir) })
+	if err := os.Chdir(repo.Dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	metadata, err := getStashMetadata()
+	if err != nil {
+		t.Fatalf("getStashMetadata returned error: %v", err)
+	}
+	info := metadata["stash@{0}"]
+	info.Name = "stash@{0}"
+	return repo, info
+}
+
+// TestArchiveStash_Ref は参照へのアーカイブ後、スタッシュを削除しても復元できることを確認します
+func TestArchiveStash_Ref(t *testing.T) {
+	repo, info := setupArchiveRepo(t)
+
+	ref, err := archiveStash(info, "")
+	if err != nil {
+		t.Fatalf("archiveStash returned error: %v", err)
+	}
+	if ref != StashArchiveRefPrefix+info.Commit {
+		t.Errorf("ref = %q, want %q", ref, StashArchiveRefPrefix+info.Commit)
+	}
+
+	repo.MustGit("stash", "drop")
+	if got := strings.TrimSpace(repo.MustGit("rev-parse", ref)); got != info.Commit {
+		t.Errorf("%s = %q, want %q", ref, got, info.Commit)
+	}
+
+	// 同じスタッシュを再度アーカイブしてもエラーにならない
+	if _, err := archiveStash(info, ""); err != nil {
+		t.Errorf("archiving again returned error: %v", err)
+	}
+
+	repo.MustGit("stash", "store", "-m", "restored", info.Commit)
+	repo.MustGit("stash", "pop")
+	if got := repo.ReadFile("a.txt"); got != "a2\n" {
+		t.Errorf("a.txt = %q, want %q", got, "a2\n")
+	}
+	if !repo.FileExists("new.txt") {
+		t.Error("new.txt should be restored")
+	}
+}
+
+// TestArchiveStash_Patch はパッチファイルへのアーカイブを git apply で戻せることを確認します
+func TestArchiveStash_Patch(t *testing.T) {
+	repo, info := setupArchiveRepo(t)
+	dir := filepath.Join(t.TempDir(), "archive")
+
+	path, err := archiveStash(info, dir)
+	if err != nil {
+		t.Fatalf("archiveStash returned error: %v", err)
+	}
+	if filepath.Dir(path) != dir || !strings.HasSuffix(path, info.Commit[:8]+".patch") {
+		t.Errorf("path = %q, want %s/stash-*-%s.patch", path, dir, info.Commit[:8])
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read patch: %v", err)
+	}
+	if !strings.Contains(string(content), "message: On ") || !strings.Contains(string(content), "archive me") {
+		t.Errorf("patch header should contain message:\n%s", content)
+	}
+
+	repo.MustGit("stash", "drop")
+	repo.MustGit("apply", path)
+	if got := repo.ReadFile("a.txt"); got != "a2\n" {
+		t.Errorf("a.txt = %q, want %q", got, "a2\n")
+	}
+	if got := repo.ReadFile("new.txt"); got != "new\n" {
+		t.Errorf("new.txt = %q, want %q", got, "new\n")
+	}
+}
+
+// TestArchiveStash_NoCommit はコミットハッシュが不明な場合にエラーになることを確認します
+func TestArchiveStash_NoCommit(t *testing.T) {
+	if _, err := archiveStash(StashInfo{Name: "stash@{0}"}, ""); err == nil {
+		t.Error("archiveStash should return error without commit")
+	}
+}
+
+// TestArchivePatchName はパッチファイル名をテストします
+func TestArchivePatchName(t *testing.T) {
+	info := StashInfo{
+		Commit: "0123456789abcdef0123456789abcdef01234567",
+		Time:   time.Date(2024, 3, 4, 5, 6, 7, 0, time.Local),
+	}
+	if got, want := archivePatchName(info), "stash-20240304-050607-01234567.patch"; got != want {
+		t.Errorf("archivePatchName() = %q, want %q", got, want)
+	}
+}
@@ -5,174 +5,826 @@
 // stash パッケージ内に配置され、スタッシュ関連の機能を提供します。
 //
 // 【概要】
-// stash-cleanup コマンドは、重複するスタッシュを検出して削除する機能を提供します。
-// ファイル構成と内容が完全に同一のスタッシュをグループ化し、古い重複を削除します。
+// stash-cleanup コマンドは、不要なスタッシュを検出して削除する機能を提供します。
+// 以下のカテゴリに分類して表示し、カテゴリごとに削除対象を選択できます。
+//   - 重複: ファイル構成と内容が完全に同一のスタッシュ（最新のものを残す）
+//   - 適用済み: 適用しても HEAD（または --against で指定したブランチ）に変化がないスタッシュ
+//   - 包含: 変更がすべて別のスタッシュに同じ内容で含まれているスタッシュ
+//   - 期限切れ: --older-than で指定した期間より前に作成されたスタッシュ
+//   - ブランチ削除済み: --branch-gone 指定時、作成元のブランチが存在しないスタッシュ
+//
+// 削除するスタッシュは、削除前に refs/git-plus/stash-archive/<hash> の参照
+// （--archive-dir 指定時はパッチファイル）にアーカイブします。
 //
 // 【主な機能】
 // - 全スタッシュの詳細な分析
 // - ファイル構成と内容に基づく重複検出
-// - 重複スタッシュのグループ化と表示
-// - 各グループから最新のスタッシュ（インデックスが最小）のみを保持
-// - 古い重複スタッシュの自動削除
+// - 既にコミット済みの変更だけを含むスタッシュの検出
+// - 別のスタッシュの部分集合になっているスタッシュの検出
+// - カテゴリごとの削除対象の選択（対話式、またはフラグで指定）
 // - 削除前の確認プロンプト
 //
 // 【使用例】
-//   git stash-cleanup  # 重複スタッシュを検出して削除
+//   git stash-cleanup                      # 全カテゴリを表示して対話的に選択
+//   git stash-cleanup --duplicates         # 重複のみを削除
+//   git stash-cleanup --applied --against main  # main に取り込み済みのスタッシュを削除
+//   git stash-cleanup --older-than 60d --branch-gone --yes  # 定期実行用のポリシー削除（両方に該当するもの）
 //
 // 【重複判定の仕組み】
 // 1. 各スタッシュのファイル一覧を取得
 // 2. 各ファイルの内容（worktree, index, untracked）を取得
 // 3. ファイル一覧と内容を結合してハッシュ値を計算
 // 4. 同じハッシュ値を持つスタッシュを重複と判定
+//
+// 【適用済み・包含判定の仕組み】
+// 各スタッシュについて、変更されたファイルごとに「適用後の内容」（blob ハッシュ、
+// 削除の場合は空）を取得します（追跡ファイルは stash^1..stash の差分、
+// untracked ファイルは stash^3 のツリーから取得）。
+// - 適用済み: すべてのファイルの適用後の内容が、比較先のツリーと一致する
+// - 包含: すべてのファイルの適用後の内容が、より多くのファイルを変更する別のスタッシュと一致する
 // ================================================================================
 
 package stash
 
 import (
+	"bufio"
 	"bytes"
 	"fmt"
+	"os"
 	"os/exec"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/backup"
 	"github.com/tonbiattack/git-plus/internal/gitcmd"
 	"github.com/tonbiattack/git-plus/internal/ui"
 )
 
 // StashInfo はスタッシュの詳細情報を表す構造体です。
 type StashInfo struct {
-	Index int      // スタッシュのインデックス（stash@{N} の N）
-	Name  string   // スタッシュの名前（例: stash@{0}）
-	Files []string // スタッシュに含まれるファイルのパス一覧
-	Hash  string   // スタッシュの内容を表すハッシュ値（重複判定に使用）
+	Index   int               // スタッシュのインデックス（stash@{N} の N）
+	Name    string            // スタッシュの名前（例: stash@{0}）
+	Files   []string          // スタッシュに含まれるファイルのパス一覧
+	Hash    string            // スタッシュの内容を表すハッシュ値（重複判定に使用）
+	Changes map[string]string // ファイルパス → 適用後の blob ハッシュ（削除の場合は空文字列）
+	Commit  string            // スタッシュのコミットハッシュ
+	Message string            // スタッシュのメッセージ（例: WIP on main: abc1234 message）
+	Branch  string            // スタッシュを作成したブランチ（extractBranch で抽出）
+	Time    time.Time         // スタッシュの作成日時
 }
 
+// cleanupOptions はカテゴリの検出条件です。
+type cleanupOptions struct {
+	Against    string        // 適用済み判定の比較先（空の場合は HEAD）
+	OlderThan  time.Duration // 期限切れと判定する期間（0 の場合は判定しない）
+	BranchGone bool          // ブランチ削除済みを判定するか
+	Now        time.Time     // 期限切れ判定の基準時刻
+}
+
+// cleanupCategory は stash-cleanup が削除候補として提示するカテゴリです。
+type cleanupCategory struct {
+	Key     string          // フラグ名と対応する識別子（duplicates, applied, contained, older-than, branch-gone）
+	Label   string          // 表示用のラベル
+	Targets []cleanupTarget // 削除候補
+}
+
+// cleanupTarget は削除候補のスタッシュと、候補になった理由です。
+type cleanupTarget struct {
+	Info   StashInfo
+	Reason string
+}
+
+var (
+	cleanupDuplicates bool   // 重複カテゴリを削除対象にする
+	cleanupApplied    bool   // 適用済みカテゴリを削除対象にする
+	cleanupContained  bool   // 包含カテゴリを削除対象にする
+	cleanupAgainst    string // 適用済み判定の比較先
+	cleanupOlderThan  string // 期限切れと判定する期間（例: 60d）
+	cleanupBranchGone bool   // 作成元のブランチが存在しないスタッシュを削除対象にする
+	cleanupArchiveDir string // アーカイブをパッチファイルとして保存するディレクトリ
+	cleanupYes        bool   // 確認プロンプトをスキップする
+	cleanupMatch      string // フラグで複数のカテゴリを指定した場合の組み合わせ方（all / any）
+)
+
+// フラグで複数のカテゴリを指定した場合の組み合わせ方
+const (
+	cleanupMatchAll = "all" // すべてのカテゴリに該当するスタッシュのみを削除（AND）
+	cleanupMatchAny = "any" // いずれかのカテゴリに該当するスタッシュを削除（OR）
+)
+
 // stashCleanupCmd は stash-cleanup コマンドの定義です。
-// 重複するスタッシュを検出して削除します。
+// 重複・適用済み・包含のスタッシュを検出して削除します。
 var stashCleanupCmd = &cobra.Command{
 	Use:   "stash-cleanup",
-	Short: "重複するスタッシュを検出して削除",
-	Long: `全てのスタッシュを分析し、ファイル構成と内容が完全に同一の
-スタッシュを検出します。重複するスタッシュをグループ化して表示し、
-削除確認のプロンプトを表示します。
-各重複グループから最新のスタッシュ（インデックスが最小）のみを残し、
-古い重複を削除します。`,
+	Short: "重複・適用済み・包含されたスタッシュを検出して削除",
+	Long: `全てのスタッシュを分析し、不要なスタッシュを次のカテゴリに分類して表示します。
+
+  [重複]     ファイル構成と内容が完全に同一のスタッシュ（最新のものを残す）
+  [適用済み] 適用しても HEAD（--against で変更可）に変化がないスタッシュ
+  [包含]     変更がすべて別のスタッシュに同じ内容で含まれているスタッシュ
+  [期限切れ] --older-than で指定した期間より前に作成されたスタッシュ
+  [ブランチ削除済み] --branch-gone 指定時、作成元のブランチが存在しないスタッシュ
+
+カテゴリ用のフラグを指定しない場合は、削除するカテゴリを対話的に選択します。
+削除前には確認プロンプトを表示します（--yes でスキップ）。
+
+カテゴリ用のフラグを複数指定した場合は、指定したすべての条件に該当する
+スタッシュのみを削除します（--match all、デフォルト）。
+いずれかの条件に該当するスタッシュを削除するには --match any を指定します。
+対話的に選択した場合は、選択したいずれかのカテゴリに含まれるスタッシュを削除します。
+
+削除するスタッシュは、削除前に refs/git-plus/stash-archive/<hash> に保存します。
+--archive-dir を指定した場合は、代わりにパッチファイルとして保存します。
+アーカイブに失敗したスタッシュは削除しないため、定期実行でも安全に使用できます。`,
+	Example: `  git stash-cleanup                            # 全カテゴリを表示して選択
+  git stash-cleanup --duplicates               # 重複のみを削除
+  git stash-cleanup --applied --against main   # main に取り込み済みのものを削除
+  git stash-cleanup --applied --contained --match any  # 適用済みと包含を削除
+  git stash-cleanup --older-than 60d --branch-gone --yes  # 60日より前かつブランチ削除済み
+  git stash-cleanup --older-than 90d --archive-dir ~/stash-archive --yes`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		fmt.Println("スタッシュを分析しています...")
+		return runStashCleanup()
+	},
+}
+
+// runStashCleanup は stash-cleanup コマンドのメイン処理です。
+//
+// 処理フロー:
+//  1. 全スタッシュの詳細情報（ファイル一覧、内容ハッシュ、適用後の内容、作成日時、ブランチ）を取得
+//  2. カテゴリごとに削除候補を検出して表示
+//  3. フラグまたは対話入力で削除するカテゴリを選択
+//  4. 確認後、インデックスの大きい順にアーカイブしてから削除
+func runStashCleanup() error {
+	if cleanupMatch != cleanupMatchAll && cleanupMatch != cleanupMatchAny {
+		return fmt.Errorf("--match には all または any を指定してください: %s", cleanupMatch)
+	}
+
+	opts := cleanupOptions{
+		Against:    cleanupAgainst,
+		BranchGone: cleanupBranchGone,
+		Now:        time.Now(),
+	}
+	if cleanupOlderThan != "" {
+		olderThan, err := backup.ParseAge(cleanupOlderThan)
+		if err != nil {
+			return err
+		}
+		opts.OlderThan = olderThan
+	}
+
+	fmt.Println("スタッシュを分析しています...")
+
+	// 全スタッシュの一覧を取得
+	stashes, err := getAllStashesList()
+	if err != nil {
+		return fmt.Errorf("スタッシュ一覧の取得に失敗しました: %w", err)
+	}
+
+	if len(stashes) == 0 {
+		fmt.Println("スタッシュが存在しません。")
+		return nil
+	}
+
+	fmt.Printf("合計 %d 個のスタッシュが見つかりました。\n\n", len(stashes))
+
+	metadata, err := getStashMetadata()
+	if err != nil {
+		return fmt.Errorf("スタッシュ一覧の取得に失敗しました: %w", err)
+	}
 
-		// 全スタッシュの一覧を取得
-		stashes, err := getAllStashesList()
+	// 各スタッシュの詳細情報を取得
+	stashInfos := make([]StashInfo, 0, len(stashes))
+	for i, stashName := range stashes {
+		files, err := getStashFilesList(stashName)
 		if err != nil {
-			return fmt.Errorf("スタッシュ一覧の取得に失敗しました: %w", err)
+			fmt.Printf("警告: %s のファイル一覧取得に失敗しました: %v\n", stashName, err)
+			continue
 		}
 
-		if len(stashes) == 0 {
-			fmt.Println("スタッシュが存在しません。")
-			return nil
+		hash, err := getStashHash(stashName, files)
+		if err != nil {
+			fmt.Printf("警告: %s の内容ハッシュ取得に失敗しました: %v\n", stashName, err)
+			continue
 		}
 
-		if len(stashes) == 1 {
-			fmt.Println("スタッシュが1つしかないため、圧縮の必要はありません。")
-			return nil
+		changes, err := getStashChanges(stashName)
+		if err != nil {
+			fmt.Printf("警告: %s の変更内容の取得に失敗しました: %v\n", stashName, err)
+			continue
 		}
 
-		fmt.Printf("合計 %d 個のスタッシュが見つかりました。\n\n", len(stashes))
+		info := metadata[stashName]
+		info.Index = i
+		info.Name = stashName
+		info.Files = files
+		info.Hash = hash
+		info.Changes = changes
+		stashInfos = append(stashInfos, info)
+	}
 
-		// 各スタッシュの詳細情報を取得
-		stashInfos := make([]StashInfo, 0, len(stashes))
-		for i, stashName := range stashes {
-			files, err := getStashFilesList(stashName)
-			if err != nil {
-				fmt.Printf("警告: %s のファイル一覧取得に失敗しました: %v\n", stashName, err)
-				continue
-			}
+	categories, err := detectCleanupCategories(stashInfos, opts)
+	if err != nil {
+		return err
+	}
 
-			hash, err := getStashHash(stashName, files)
-			if err != nil {
-				fmt.Printf("警告: %s の内容ハッシュ取得に失敗しました: %v\n", stashName, err)
-				continue
-			}
+	available := make([]cleanupCategory, 0, len(categories))
+	for _, c := range categories {
+		if len(c.Targets) > 0 {
+			available = append(available, c)
+		}
+	}
+	if len(available) == 0 {
+		fmt.Println("✓ 削除候補のスタッシュは見つかりませんでした。")
+		return nil
+	}
+
+	for i, c := range available {
+		fmt.Printf("%d. [%s] %d 個\n", i+1, c.Label, len(c.Targets))
+		for _, target := range c.Targets {
+			fmt.Printf("  - %s (%d ファイル): %s\n", target.Info.Name, len(target.Info.Files), target.Reason)
+		}
+		fmt.Println()
+	}
+
+	// AND の場合に候補がないカテゴリも条件として扱うため、候補がないカテゴリを含めて選択する
+	selected := selectedCategoriesFromFlags(categories)
+	matchAll := cleanupMatch == cleanupMatchAll
+	if selected == nil {
+		input := readCleanupInput("削除するカテゴリを選択してください（例: 1,3 / a=すべて / Enter=キャンセル）: ")
+		selected, err = parseCategorySelection(input, available)
+		if err != nil {
+			return err
+		}
+		matchAll = false
+	} else if matchAll && len(selected) > 1 {
+		fmt.Println("指定したすべての条件に該当するスタッシュのみを削除します（--match any でいずれかに該当するものを削除）")
+	}
+
+	toDelete := collectCleanupTargets(selected, matchAll)
+	if len(toDelete) == 0 {
+		fmt.Println("削除対象のスタッシュはありません。")
+		return nil
+	}
+
+	fmt.Printf("合計 %d 個のスタッシュを削除します。\n", len(toDelete))
+
+	if !cleanupYes && !ui.Confirm("続行しますか?", false) {
+		fmt.Println("キャンセルしました。")
+		return nil
+	}
 
-			stashInfos = append(stashInfos, StashInfo{
-				Index: i,
-				Name:  stashName,
-				Files: files,
-				Hash:  hash,
+	// 削除実行（アーカイブに失敗したスタッシュは削除しない）
+	fmt.Println("\nスタッシュを削除しています...")
+	deletedCount := 0
+	failedCount := 0
+
+	for _, stashToDelete := range toDelete {
+		location, err := archiveStash(stashToDelete, cleanupArchiveDir)
+		if err != nil {
+			fmt.Printf("✗ %s のアーカイブに失敗したため削除しません: %v\n", stashToDelete.Name, err)
+			failedCount++
+			continue
+		}
+		if err := deleteStashByIndex(stashToDelete.Index); err != nil {
+			fmt.Printf("✗ %s の削除に失敗しました: %v\n", stashToDelete.Name, err)
+			failedCount++
+		} else {
+			fmt.Printf("✓ %s を削除しました（アーカイブ: %s）\n", stashToDelete.Name, location)
+			deletedCount++
+		}
+	}
+
+	// 結果サマリー
+	fmt.Printf("\n完了: %d 個のスタッシュを削除しました", deletedCount)
+	if failedCount > 0 {
+		fmt.Printf(" (%d 個失敗)", failedCount)
+	}
+	fmt.Println()
+
+	// 残りのスタッシュ数を表示
+	remainingStashes, _ := getAllStashesList()
+	fmt.Printf("残りのスタッシュ数: %d\n", len(remainingStashes))
+
+	if deletedCount > 0 {
+		if cleanupArchiveDir == "" {
+			fmt.Println("\nアーカイブの一覧: git for-each-ref " + StashArchiveRefPrefix)
+			fmt.Println("スタッシュに戻すには: git stash store -m \"<メッセージ>\" <ハッシュ>")
+		} else {
+			fmt.Printf("\nアーカイブ先: %s\n", cleanupArchiveDir)
+			fmt.Println("変更を戻すには: git apply <パッチファイル>")
+		}
+	}
+
+	return nil
+}
+
+// detectCleanupCategories は全カテゴリの削除候補を検出します。
+//
+// パラメータ:
+//   - stashInfos: 全スタッシュの詳細情報
+//   - opts: 検出条件（適用済みの比較先、期限切れの期間、ブランチ削除済みの判定有無）
+//
+// 戻り値:
+//   - []cleanupCategory: 重複・適用済み・包含・期限切れ・ブランチ削除済みの順のカテゴリ
+//     （候補がないカテゴリも含む。期限切れとブランチ削除済みは条件の指定がある場合のみ）
+//   - error: 比較先のツリーやブランチ一覧の取得に失敗した場合のエラー情報
+func detectCleanupCategories(stashInfos []StashInfo, opts cleanupOptions) ([]cleanupCategory, error) {
+	against := opts.Against
+	if against == "" {
+		against = "HEAD"
+	}
+
+	duplicates := cleanupCategory{Key: "duplicates", Label: "重複"}
+	for _, group := range findDuplicateStashes(stashInfos) {
+		for _, info := range group[1:] {
+			duplicates.Targets = append(duplicates.Targets, cleanupTarget{
+				Info:   info,
+				Reason: fmt.Sprintf("%s と同一", group[0].Name),
 			})
 		}
+	}
+	sortCleanupTargets(duplicates.Targets)
 
-		// 重複を検出
-		duplicateGroups := findDuplicateStashes(stashInfos)
+	applied := cleanupCategory{Key: "applied", Label: "適用済み"}
+	for _, info := range stashInfos {
+		if len(info.Changes) == 0 {
+			continue
+		}
+		blobs, err := getTreeBlobs(against, sortedChangePaths(info.Changes))
+		if err != nil {
+			return nil, fmt.Errorf("%s のツリーの取得に失敗しました: %w", against, err)
+		}
+		if isStashApplied(info.Changes, blobs) {
+			applied.Targets = append(applied.Targets, cleanupTarget{
+				Info:   info,
+				Reason: fmt.Sprintf("%s に取り込み済み", against),
+			})
+		}
+	}
+
+	contained := cleanupCategory{Key: "contained", Label: "包含"}
+	contained.Targets = findContainedStashes(stashInfos)
+
+	categories := []cleanupCategory{duplicates, applied, contained}
+
+	if opts.OlderThan > 0 {
+		expired := cleanupCategory{Key: "older-than", Label: "期限切れ"}
+		expired.Targets = findExpiredStashes(stashInfos, opts.OlderThan, opts.Now)
+		categories = append(categories, expired)
+	}
 
-		if len(duplicateGroups) == 0 {
-			fmt.Println("✓ 重複するスタッシュは見つかりませんでした。")
-			return nil
+	if opts.BranchGone {
+		branches, err := getExistingBranches()
+		if err != nil {
+			return nil, fmt.Errorf("ブランチ一覧の取得に失敗しました: %w", err)
+		}
+		gone := cleanupCategory{Key: "branch-gone", Label: "ブランチ削除済み"}
+		gone.Targets = findBranchGoneStashes(stashInfos, branches)
+		categories = append(categories, gone)
+	}
+
+	return categories, nil
+}
+
+// findExpiredStashes は指定した期間より前に作成されたスタッシュを検出します。
+//
+// パラメータ:
+//   - stashInfos: 全スタッシュの詳細情報
+//   - olderThan: 期限切れと判定する期間
+//   - now: 基準時刻
+//
+// 戻り値:
+//   - []cleanupTarget: 期限切れのスタッシュ（インデックス順）
+func findExpiredStashes(stashInfos []StashInfo, olderThan time.Duration, now time.Time) []cleanupTarget {
+	var targets []cleanupTarget
+	for _, info := range stashInfos {
+		if info.Time.IsZero() || now.Sub(info.Time) < olderThan {
+			continue
+		}
+		days := int(now.Sub(info.Time).Hours() / 24)
+		targets = append(targets, cleanupTarget{
+			Info:   info,
+			Reason: fmt.Sprintf("%d 日前（%s）", days, info.Time.Local().Format("2006-01-02")),
+		})
+	}
+	sortCleanupTargets(targets)
+	return targets
+}
+
+// findBranchGoneStashes は作成元のブランチが存在しないスタッシュを検出します。
+//
+// パラメータ:
+//   - stashInfos: 全スタッシュの詳細情報
+//   - branches: 存在するブランチ名の集合（getExistingBranches の結果）
+//
+// 戻り値:
+//   - []cleanupTarget: ブランチが存在しないスタッシュ（インデックス順）
+//
+// 内部処理:
+//   ブランチ名が不明なスタッシュ（extractBranch が (unknown) を返したもの）や、
+//   detached HEAD で作成されたスタッシュ（(no branch)）は判定できないため対象外です。
+func findBranchGoneStashes(stashInfos []StashInfo, branches map[string]bool) []cleanupTarget {
+	var targets []cleanupTarget
+	for _, info := range stashInfos {
+		if info.Branch == "" || strings.HasPrefix(info.Branch, "(") {
+			continue
+		}
+		if branches[info.Branch] {
+			continue
 		}
+		targets = append(targets, cleanupTarget{
+			Info:   info,
+			Reason: fmt.Sprintf("ブランチ %s は存在しません", info.Branch),
+		})
+	}
+	sortCleanupTargets(targets)
+	return targets
+}
 
-		// 重複を表示
-		fmt.Printf("重複するスタッシュグループが %d 個見つかりました:\n\n", len(duplicateGroups))
+// getExistingBranches は存在するブランチ名の集合を取得します。
+//
+// 戻り値:
+//   - map[string]bool: ローカルブランチ名と、リモート追跡ブランチのリモート名を除いた名前の集合
+//   - error: エラーが発生した場合のエラー情報
+//
+// 内部処理:
+//   git for-each-ref で refs/heads と refs/remotes を取得します。
+//   ローカルで削除済みでもリモートに残っているブランチは「存在する」と判定します。
+func getExistingBranches() (map[string]bool, error) {
+	output, err := gitcmd.Run("for-each-ref", "--format=%(refname)", "refs/heads", "refs/remotes")
+	if err != nil {
+		return nil, err
+	}
 
-		totalToDelete := 0
-		for groupIdx, group := range duplicateGroups {
-			fmt.Printf("グループ %d: %d 個の重複\n", groupIdx+1, len(group))
-			for _, info := range group {
-				fmt.Printf("  - %s (%d ファイル)\n", info.Name, len(info.Files))
+	branches := make(map[string]bool)
+	for _, ref := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		switch {
+		case strings.HasPrefix(ref, "refs/heads/"):
+			branches[strings.TrimPrefix(ref, "refs/heads/")] = true
+		case strings.HasPrefix(ref, "refs/remotes/"):
+			parts := strings.SplitN(strings.TrimPrefix(ref, "refs/remotes/"), "/", 2)
+			if len(parts) == 2 && parts[1] != "HEAD" {
+				branches[parts[1]] = true
 			}
-			totalToDelete += len(group) - 1
-			fmt.Println()
 		}
+	}
+	return branches, nil
+}
+
+// getStashMetadata は全スタッシュのコミットハッシュ、メッセージ、作成日時、ブランチを取得します。
+//
+// 戻り値:
+//   - map[string]StashInfo: スタッシュ名（stash@{N}）→ Commit, Message, Branch, Time を設定した StashInfo
+//   - error: エラーが発生した場合のエラー情報
+//
+// 内部処理:
+//   git stash list --format=%gd%x00%H%x00%ct%x00%gs で一覧を取得します。
+func getStashMetadata() (map[string]StashInfo, error) {
+	output, err := gitcmd.Run("stash", "list", "--format=%gd%x00%H%x00%ct%x00%gs")
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := make(map[string]StashInfo)
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.SplitN(line, "\x00", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		info := StashInfo{
+			Commit:  fields[1],
+			Message: fields[3],
+			Branch:  extractBranch(fields[3]),
+		}
+		if unix, err := strconv.ParseInt(fields[2], 10, 64); err == nil {
+			info.Time = time.Unix(unix, 0)
+		}
+		metadata[fields[0]] = info
+	}
+	return metadata, nil
+}
+
+// getStashChanges はスタッシュを適用した後の各ファイルの内容（blob ハッシュ）を取得します。
+//
+// パラメータ:
+//   - stash: スタッシュ名（例: stash@{0}）
+//
+// 戻り値:
+//   - map[string]string: ファイルパス → 適用後の blob ハッシュ（削除されるファイルは空文字列）
+//   - error: エラーが発生した場合のエラー情報
+//
+// 内部処理:
+//   1. git diff --raw --no-abbrev --no-renames -z <stash>^1 <stash> で追跡ファイルの変更を取得
+//   2. untracked ファイルを含むスタッシュ（<stash>^3 が存在）の場合は
+//      git ls-tree -r -z --full-tree <stash>^3 で untracked ファイルの内容を取得
+func getStashChanges(stash string) (map[string]string, error) {
+	output, err := gitcmd.Run("diff", "--raw", "--no-abbrev", "--no-renames", "-z", stash+"^1", stash)
+	if err != nil {
+		return nil, err
+	}
+	changes := parseRawDiff(string(output))
+
+	if gitcmd.RunQuiet("rev-parse", "--verify", "--quiet", stash+"^3") == nil {
+		output, err := gitcmd.Run("ls-tree", "-r", "-z", "--full-tree", stash+"^3")
+		if err != nil {
+			return nil, err
+		}
+		for path, blob := range parseLsTree(string(output)) {
+			changes[path] = blob
+		}
+	}
 
-		fmt.Printf("合計 %d 個のスタッシュを削除します（各グループの最新のみを保持）。\n", totalToDelete)
+	return changes, nil
+}
 
-		if !ui.Confirm("続行しますか?", false) {
-			fmt.Println("キャンセルしました。")
-			return nil
+// parseRawDiff は git diff --raw -z の出力を解析し、ファイルパス → 変更後の blob ハッシュを返します。
+// 削除されたファイルは空文字列になります。
+//
+// 出力形式: ":<旧モード> <新モード> <旧ハッシュ> <新ハッシュ> <状態>\0<パス>\0"
+func parseRawDiff(output string) map[string]string {
+	changes := make(map[string]string)
+	fields := strings.Split(output, "\x00")
+	for i := 0; i+1 < len(fields); i += 2 {
+		meta := strings.Fields(strings.TrimPrefix(fields[i], ":"))
+		if len(meta) < 5 {
+			continue
 		}
+		blob := meta[3]
+		if strings.HasPrefix(meta[4], "D") {
+			blob = ""
+		}
+		changes[fields[i+1]] = blob
+	}
+	return changes
+}
 
-		// 削除実行
-		fmt.Println("\n重複スタッシュを削除しています...")
-		deletedCount := 0
-		failedCount := 0
+// parseLsTree は git ls-tree -r -z の出力を解析し、ファイルパス → blob ハッシュを返します。
+//
+// 出力形式: "<モード> <種類> <ハッシュ>\t<パス>\0"
+func parseLsTree(output string) map[string]string {
+	blobs := make(map[string]string)
+	for _, entry := range strings.Split(output, "\x00") {
+		tab := strings.Index(entry, "\t")
+		if tab < 0 {
+			continue
+		}
+		meta := strings.Fields(entry[:tab])
+		if len(meta) < 3 {
+			continue
+		}
+		blobs[entry[tab+1:]] = meta[2]
+	}
+	return blobs
+}
+
+// topPathspec はリポジトリのルートからのパスを、カレントディレクトリに関係なく
+// そのファイルだけを指す pathspec に変換します
+func topPathspec(path string) string {
+	return ":(top,literal)" + path
+}
+
+// getTreeBlobs は指定したコミット（ブランチ）のツリーから、指定したパスの blob ハッシュを取得します。
+// ツリーに存在しないパスは結果に含まれません。
+func getTreeBlobs(rev string, paths []string) (map[string]string, error) {
+	args := []string{"ls-tree", "-r", "-z", "--full-tree", rev, "--"}
+	for _, path := range paths {
+		args = append(args, topPathspec(path))
+	}
+	output, err := gitcmd.Run(args...)
+	if err != nil {
+		return nil, err
+	}
+	return parseLsTree(string(output)), nil
+}
 
-		// 削除対象のスタッシュを収集
-		toDelete := make([]StashInfo, 0)
-		for _, group := range duplicateGroups {
-			for i := 1; i < len(group); i++ {
-				toDelete = append(toDelete, group[i])
+// isStashApplied はスタッシュを適用しても比較先のツリーに変化がないかを判定します。
+//
+// パラメータ:
+//   - changes: スタッシュ適用後の各ファイルの内容（getStashChanges の結果）
+//   - blobs: 比較先のツリーの各ファイルの内容（getTreeBlobs の結果）
+//
+// 戻り値:
+//   - bool: 全ファイルの内容が一致する（削除されるファイルは比較先にも存在しない）場合は true
+func isStashApplied(changes, blobs map[string]string) bool {
+	if len(changes) == 0 {
+		return false
+	}
+	for path, blob := range changes {
+		current, exists := blobs[path]
+		if blob == "" {
+			if exists {
+				return false
 			}
+			continue
 		}
+		if !exists || current != blob {
+			return false
+		}
+	}
+	return true
+}
 
-		// インデックスの大きい順にソート
-		sort.Slice(toDelete, func(i, j int) bool {
-			return toDelete[i].Index > toDelete[j].Index
-		})
+// findContainedStashes は変更がすべて別のスタッシュに含まれているスタッシュを検出します。
+//
+// パラメータ:
+//   - stashInfos: 全スタッシュの詳細情報
+//
+// 戻り値:
+//   - []cleanupTarget: 包含されているスタッシュ（インデックス順）
+//
+// 内部処理:
+//   スタッシュ A の全ファイルについて、スタッシュ B が同じファイルを同じ内容に変更しており、
+//   かつ B の方が変更ファイルが多い場合に、A は B に包含されていると判定します。
+//   包含先が複数ある場合は、最新のもの（インデックスが最小）を理由として表示します。
+//   変更ファイルが同じ場合は重複カテゴリで扱うため、ここでは対象外です。
+func findContainedStashes(stashInfos []StashInfo) []cleanupTarget {
+	var targets []cleanupTarget
+	for _, a := range stashInfos {
+		if len(a.Changes) == 0 {
+			continue
+		}
 
-		// 削除実行
-		for _, stashToDelete := range toDelete {
-			if err := deleteStashByIndex(stashToDelete.Index); err != nil {
-				fmt.Printf("✗ %s の削除に失敗しました: %v\n", stashToDelete.Name, err)
-				failedCount++
-			} else {
-				fmt.Printf("✓ %s を削除しました\n", stashToDelete.Name)
-				deletedCount++
+		var container *StashInfo
+		for i := range stashInfos {
+			b := &stashInfos[i]
+			if b.Index == a.Index || len(b.Changes) <= len(a.Changes) {
+				continue
+			}
+			if !isChangeSubset(a.Changes, b.Changes) {
+				continue
+			}
+			if container == nil || b.Index < container.Index {
+				container = b
 			}
 		}
 
-		// 結果サマリー
-		fmt.Printf("\n完了: %d 個のスタッシュを削除しました", deletedCount)
-		if failedCount > 0 {
-			fmt.Printf(" (%d 個失敗)", failedCount)
+		if container != nil {
+			targets = append(targets, cleanupTarget{
+				Info:   a,
+				Reason: fmt.Sprintf("%s に包含", container.Name),
+			})
 		}
-		fmt.Println()
+	}
+	sortCleanupTargets(targets)
+	return targets
+}
+
+// isChangeSubset は sub の全ファイルが super に同じ内容で含まれているかを判定します。
+func isChangeSubset(sub, super map[string]string) bool {
+	for path, blob := range sub {
+		other, exists := super[path]
+		if !exists || other != blob {
+			return false
+		}
+	}
+	return true
+}
 
-		// 残りのスタッシュ数を表示
-		remainingStashes, _ := getAllStashesList()
-		fmt.Printf("残りのスタッシュ数: %d\n", len(remainingStashes))
+// sortedChangePaths は変更されたファイルのパスをソートして返します。
+func sortedChangePaths(changes map[string]string) []string {
+	paths := make([]string, 0, len(changes))
+	for path := range changes {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// sortCleanupTargets は削除候補をインデックス順に並べます。
+func sortCleanupTargets(targets []cleanupTarget) {
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].Info.Index < targets[j].Info.Index
+	})
+}
 
+// selectedCategoriesFromFlags はフラグで指定されたカテゴリを返します。
+// カテゴリ用のフラグが1つも指定されていない場合は nil を返します（対話的に選択）。
+func selectedCategoriesFromFlags(categories []cleanupCategory) []cleanupCategory {
+	flags := map[string]bool{
+		"duplicates":  cleanupDuplicates,
+		"applied":     cleanupApplied,
+		"contained":   cleanupContained,
+		"older-than":  cleanupOlderThan != "",
+		"branch-gone": cleanupBranchGone,
+	}
+	if !cleanupDuplicates && !cleanupApplied && !cleanupContained && !flags["older-than"] && !cleanupBranchGone {
 		return nil
-	},
+	}
+	selected := make([]cleanupCategory, 0, len(categories))
+	for _, c := range categories {
+		if flags[c.Key] {
+			selected = append(selected, c)
+		}
+	}
+	return selected
+}
+
+// parseCategorySelection はカテゴリ選択の入力を解析します。
+//
+// パラメータ:
+//   - input: ユーザーの入力（例: "1,3"、"a"、空文字列）
+//   - categories: 表示したカテゴリ（番号は1始まり）
+//
+// 戻り値:
+//   - []cleanupCategory: 選択されたカテゴリ（空文字列の場合は空）
+//   - error: 無効な番号が含まれる場合のエラー情報
+func parseCategorySelection(input string, categories []cleanupCategory) ([]cleanupCategory, error) {
+	indexes, err := parseNumberSelection(input, len(categories))
+	if err != nil {
+		return nil, err
+	}
+	selected := make([]cleanupCategory, 0, len(indexes))
+	for _, i := range indexes {
+		selected = append(selected, categories[i])
+	}
+	return selected, nil
+}
+
+// parseNumberSelection は "1,3" や "a" 形式の番号選択の入力を解析します。
+//
+// パラメータ:
+//   - input: ユーザーの入力（例: "1,3"、"a"、空文字列）。全角数字も受け付けます
+//   - count: 選択肢の数（番号は1始まり）
+//
+// 戻り値:
+//   - []int: 選択された選択肢の位置（0始まり、入力順、重複なし。空文字列の場合は空）
+//   - error: 無効な番号が含まれる場合のエラー情報
+func parseNumberSelection(input string, count int) ([]int, error) {
+	input = strings.TrimSpace(strings.ToLower(input))
+	switch input {
+	case "":
+		return nil, nil
+	case "a", "all":
+		all := make([]int, count)
+		for i := range all {
+			all[i] = i
+		}
+		return all, nil
+	}
+
+	seen := make(map[int]bool)
+	var selected []int
+	for _, part := range strings.Split(input, ",") {
+		n, err := strconv.Atoi(ui.NormalizeNumberInput(strings.TrimSpace(part)))
+		if err != nil || n < 1 || n > count {
+			return nil, fmt.Errorf("無効な番号です: %s", strings.TrimSpace(part))
+		}
+		if seen[n] {
+			continue
+		}
+		seen[n] = true
+		selected = append(selected, n-1)
+	}
+	return selected, nil
+}
+
+// collectCleanupTargets は選択されたカテゴリの削除候補をまとめます。
+// matchAll が true の場合はすべてのカテゴリに含まれるスタッシュのみ、false の場合は
+// いずれかのカテゴリに含まれるスタッシュを削除対象にします。
+// 複数のカテゴリに含まれるスタッシュは1回だけ削除対象にし、
+// インデックスの大きい順に並べます（削除によるインデックスの繰り上がりを避けるため）。
+func collectCleanupTargets(categories []cleanupCategory, matchAll bool) []StashInfo {
+	counts := make(map[int]int)
+	for _, c := range categories {
+		seenInCategory := make(map[int]bool)
+		for _, target := range c.Targets {
+			if !seenInCategory[target.Info.Index] {
+				seenInCategory[target.Info.Index] = true
+				counts[target.Info.Index]++
+			}
+		}
+	}
+
+	seen := make(map[int]bool)
+	var toDelete []StashInfo
+	for _, c := range categories {
+		for _, target := range c.Targets {
+			if seen[target.Info.Index] {
+				continue
+			}
+			if matchAll && counts[target.Info.Index] < len(categories) {
+				continue
+			}
+			seen[target.Info.Index] = true
+			toDelete = append(toDelete, target.Info)
+		}
+	}
+	sort.Slice(toDelete, func(i, j int) bool {
+		return toDelete[i].Index > toDelete[j].Index
+	})
+	return toDelete
+}
+
+// readCleanupInput はプロンプトを表示して1行読み込みます。
+func readCleanupInput(prompt string) string {
+	fmt.Print(prompt)
+	reader := bufio.NewReader(os.Stdin)
+	line, _ := reader.ReadString('\n')
+	return strings.TrimSpace(line)
 }
 
 // getAllStashesList は全てのスタッシュの名前一覧を取得します。
@@ -394,5 +1046,14 @@ func deleteStashByIndex(index int) error {
 // init は stash-cleanup コマンドを root コマンドに登録します。
 // この関数はパッケージの初期化時に自動的に呼び出されます。
 func init() {
+	stashCleanupCmd.Flags().BoolVar(&cleanupDuplicates, "duplicates", false, "重複カテゴリのスタッシュを削除対象にする")
+	stashCleanupCmd.Flags().BoolVar(&cleanupApplied, "applied", false, "適用済みカテゴリのスタッシュを削除対象にする")
+	stashCleanupCmd.Flags().BoolVar(&cleanupContained, "contained", false, "包含カテゴリのスタッシュを削除対象にする")
+	stashCleanupCmd.Flags().StringVar(&cleanupAgainst, "against", "HEAD", "適用済み判定の比較先（ブランチやコミット）")
+	stashCleanupCmd.Flags().StringVar(&cleanupOlderThan, "older-than", "", "指定した期間より前に作成されたスタッシュを削除対象にする（例: 60d, 2w）")
+	stashCleanupCmd.Flags().BoolVar(&cleanupBranchGone, "branch-gone", false, "作成元のブランチが存在しないスタッシュを削除対象にする")
+	stashCleanupCmd.Flags().StringVar(&cleanupArchiveDir, "archive-dir", "", "削除前のアーカイブを参照ではなくパッチファイルとしてこのディレクトリに保存する")
+	stashCleanupCmd.Flags().BoolVarP(&cleanupYes, "yes", "y", false, "確認プロンプトをスキップする（定期実行用）")
+	stashCleanupCmd.Flags().StringVar(&cleanupMatch, "match", cleanupMatchAll, "カテゴリ用のフラグを複数指定した場合の組み合わせ方（all=すべてに該当、any=いずれかに該当）")
 	cmd.RootCmd.AddCommand(stashCleanupCmd)
 }
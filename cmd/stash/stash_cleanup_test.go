@@ -2,7 +2,9 @@ package stash
 
 import (
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/tonbiattack/git-plus/cmd"
 	"github.com/tonbiattack/git-plus/internal/testutil"
@@ -221,3 +223,431 @@ func TestFindDuplicateStashes_Sorting(t *testing.T) {
 		t.Errorf("Third item index = %d, want 2", group[2].Index)
 	}
 }
+
+// TestIsStashApplied は適用済み判定をテストします
+func TestIsStashApplied(t *testing.T) {
+	tests := []struct {
+		name     string
+		changes  map[string]string
+		blobs    map[string]string
+		expected bool
+	}{
+		{
+			name:     "全ファイルが一致",
+			changes:  map[string]string{"a.txt": "111", "b.txt": "222"},
+			blobs:    map[string]string{"a.txt": "111", "b.txt": "222"},
+			expected: true,
+		},
+		{
+			name:     "内容が異なるファイルがある",
+			changes:  map[string]string{"a.txt": "111", "b.txt": "222"},
+			blobs:    map[string]string{"a.txt": "111", "b.txt": "333"},
+			expected: false,
+		},
+		{
+			name:     "比較先に存在しないファイルがある",
+			changes:  map[string]string{"a.txt": "111"},
+			blobs:    map[string]string{},
+			expected: false,
+		},
+		{
+			name:     "削除済みのファイル",
+			changes:  map[string]string{"a.txt": ""},
+			blobs:    map[string]string{},
+			expected: true,
+		},
+		{
+			name:     "削除されるファイルが比較先に残っている",
+			changes:  map[string]string{"a.txt": ""},
+			blobs:    map[string]string{"a.txt": "111"},
+			expected: false,
+		},
+		{
+			name:     "変更なし",
+			changes:  map[string]string{},
+			blobs:    map[string]string{"a.txt": "111"},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isStashApplied(tt.changes, tt.blobs); got != tt.expected {
+				t.Errorf("isStashApplied() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestFindContainedStashes は包含判定をテストします
+func TestFindContainedStashes(t *testing.T) {
+	infos := []StashInfo{
+		{Index: 0, Name: "stash@{0}", Changes: map[string]string{"a.txt": "111"}},
+		{Index: 1, Name: "stash@{1}", Changes: map[string]string{"a.txt": "111", "b.txt": "222"}},
+		{Index: 2, Name: "stash@{2}", Changes: map[string]string{"a.txt": "111", "b.txt": "222", "c.txt": "333"}},
+		{Index: 3, Name: "stash@{3}", Changes: map[string]string{"a.txt": "999"}},
+		{Index: 4, Name: "stash@{4}", Changes: map[string]string{"a.txt": "111", "b.txt": "222"}},
+	}
+
+	targets := findContainedStashes(infos)
+
+	want := map[int]string{
+		0: "stash@{1} に包含",
+		1: "stash@{2} に包含",
+		4: "stash@{2} に包含",
+	}
+	if len(targets) != len(want) {
+		t.Fatalf("findContainedStashes() returned %d targets, want %d: %+v", len(targets), len(want), targets)
+	}
+	for i, target := range targets {
+		reason, ok := want[target.Info.Index]
+		if !ok {
+			t.Errorf("unexpected target %s", target.Info.Name)
+			continue
+		}
+		if target.Reason != reason {
+			t.Errorf("%s reason = %q, want %q", target.Info.Name, target.Reason, reason)
+		}
+		if i > 0 && targets[i-1].Info.Index > target.Info.Index {
+			t.Errorf("targets should be sorted by index: %+v", targets)
+		}
+	}
+}
+
+// TestParseCategorySelection はカテゴリ選択の入力解析をテストします
+func TestParseCategorySelection(t *testing.T) {
+	categories := []cleanupCategory{
+		{Key: "duplicates"},
+		{Key: "applied"},
+		{Key: "contained"},
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{name: "空入力", input: "", want: nil},
+		{name: "すべて", input: "a", want: []string{"duplicates", "applied", "contained"}},
+		{name: "複数指定", input: "1, 3", want: []string{"duplicates", "contained"}},
+		{name: "重複した番号", input: "2,2", want: []string{"applied"}},
+		{name: "全角数字", input: "２", want: []string{"applied"}},
+		{name: "範囲外", input: "4", wantErr: true},
+		{name: "数字以外", input: "x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := parseCategorySelection(tt.input, categories)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseCategorySelection(%q) should return error", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseCategorySelection(%q) returned error: %v", tt.input, err)
+			}
+			var keys []string
+			for _, c := range selected {
+				keys = append(keys, c.Key)
+			}
+			if strings.Join(keys, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("parseCategorySelection(%q) = %v, want %v", tt.input, keys, tt.want)
+			}
+		})
+	}
+}
+
+// TestCollectCleanupTargets は複数カテゴリの削除対象が重複なく降順に並ぶことを確認します
+func TestCollectCleanupTargets(t *testing.T) {
+	categories := []cleanupCategory{
+		{Key: "duplicates", Targets: []cleanupTarget{{Info: StashInfo{Index: 1}}, {Info: StashInfo{Index: 3}}}},
+		{Key: "applied", Targets: []cleanupTarget{{Info: StashInfo{Index: 3}}, {Info: StashInfo{Index: 2}}}},
+	}
+
+	toDelete := collectCleanupTargets(categories, false)
+
+	var indexes []int
+	for _, info := range toDelete {
+		indexes = append(indexes, info.Index)
+	}
+	if len(indexes) != 3 || indexes[0] != 3 || indexes[1] != 2 || indexes[2] != 1 {
+		t.Errorf("collectCleanupTargets() indexes = %v, want [3 2 1]", indexes)
+	}
+}
+
+// TestDetectCleanupCategories は実際のリポジトリで適用済み・包含のスタッシュを検出できることを確認します
+func TestDetectCleanupCategories(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+
+	repo.CreateFile("a.txt", "a1")
+	repo.CreateFile("b.txt", "b1")
+	repo.Commit("Initial commit")
+
+	// 後でコミットされる変更（適用済みになる）
+	repo.CreateFile("a.txt", "a-committed")
+	repo.StashPush("applied")
+	repo.CreateFile("a.txt", "a-committed")
+	repo.Commit("Commit same change")
+
+	// 2ファイルを変更するスタッシュと、その一部だけを含むスタッシュ
+	repo.CreateFile("a.txt", "a2")
+	repo.CreateFile("b.txt", "b2")
+	repo.StashPush("big")
+	repo.CreateFile("a.txt", "a2")
+	repo.StashPush("small")
+
+	// untracked ファイルを含むスタッシュ
+	repo.CreateFile("new.txt", "new")
+	repo.MustGit("stash", "push", "-u", "-m", "untracked")
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer func() { _ = os.Chdir(oldDir) }()
+
+	if err := os.Chdir(repo.Dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	stashes, err := getAllStashesList()
+	if err != nil {
+		t.Fatalf("getAllStashesList returned error: %v", err)
+	}
+
+	var infos []StashInfo
+	for i, name := range stashes {
+		changes, err := getStashChanges(name)
+		if err != nil {
+			t.Fatalf("getStashChanges(%s) returned error: %v", name, err)
+		}
+		infos = append(infos, StashInfo{Index: i, Name: name, Hash: name, Changes: changes})
+	}
+
+	// stash@{0}: untracked, stash@{1}: small, stash@{2}: big, stash@{3}: applied
+	if _, ok := infos[0].Changes["new.txt"]; !ok {
+		t.Errorf("untracked file should be included in changes: %v", infos[0].Changes)
+	}
+
+	categories, err := detectCleanupCategories(infos, cleanupOptions{})
+	if err != nil {
+		t.Fatalf("detectCleanupCategories returned error: %v", err)
+	}
+
+	got := make(map[string][]int)
+	for _, c := range categories {
+		for _, target := range c.Targets {
+			got[c.Key] = append(got[c.Key], target.Info.Index)
+		}
+	}
+
+	if len(got["duplicates"]) != 0 {
+		t.Errorf("duplicates = %v, want none", got["duplicates"])
+	}
+	if len(got["applied"]) != 1 || got["applied"][0] != 3 {
+		t.Errorf("applied = %v, want [3]", got["applied"])
+	}
+	if len(got["contained"]) != 1 || got["contained"][0] != 1 {
+		t.Errorf("contained = %v, want [1]", got["contained"])
+	}
+}
+
+// TestFindExpiredStashes は期限切れ判定をテストします
+func TestFindExpiredStashes(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	infos := []StashInfo{
+		{Index: 0, Name: "stash@{0}", Time: now.Add(-24 * time.Hour)},
+		{Index: 1, Name: "stash@{1}", Time: now.Add(-60 * 24 * time.Hour)},
+		{Index: 2, Name: "stash@{2}", Time: now.Add(-90 * 24 * time.Hour)},
+		{Index: 3, Name: "stash@{3}"},
+	}
+
+	targets := findExpiredStashes(infos, 60*24*time.Hour, now)
+
+	if len(targets) != 2 {
+		t.Fatalf("findExpiredStashes() returned %d targets, want 2: %+v", len(targets), targets)
+	}
+	if targets[0].Info.Index != 1 || targets[1].Info.Index != 2 {
+		t.Errorf("findExpiredStashes() indexes = [%d %d], want [1 2]", targets[0].Info.Index, targets[1].Info.Index)
+	}
+	if !strings.HasPrefix(targets[1].Reason, "90 日前") {
+		t.Errorf("reason = %q, want prefix %q", targets[1].Reason, "90 日前")
+	}
+}
+
+// TestFindBranchGoneStashes はブランチ削除済みの判定をテストします
+func TestFindBranchGoneStashes(t *testing.T) {
+	branches := map[string]bool{"main": true, "feature/alive": true}
+	infos := []StashInfo{
+		{Index: 0, Branch: "main"},
+		{Index: 1, Branch: "feature/gone"},
+		{Index: 2, Branch: "(unknown)"},
+		{Index: 3, Branch: "(no branch)"},
+		{Index: 4, Branch: "feature/alive"},
+	}
+
+	targets := findBranchGoneStashes(infos, branches)
+
+	if len(targets) != 1 || targets[0].Info.Index != 1 {
+		t.Fatalf("findBranchGoneStashes() = %+v, want only index 1", targets)
+	}
+	if !strings.Contains(targets[0].Reason, "feature/gone") {
+		t.Errorf("reason = %q, should contain branch name", targets[0].Reason)
+	}
+}
+
+// TestGetStashMetadataAndExistingBranches は実際のリポジトリでメタデータとブランチ一覧を取得できることを確認します
+func TestGetStashMetadataAndExistingBranches(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+
+	repo.CreateFile("a.txt", "a1")
+	repo.Commit("Initial commit")
+	defaultBranch := repo.CurrentBranch()
+
+	repo.CreateAndCheckoutBranch("feature/temp")
+	repo.CreateFile("a.txt", "temp")
+	repo.StashPush("temp work")
+	repo.CheckoutBranch(defaultBranch)
+	repo.MustGit("branch", "-D", "feature/temp")
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer func() { _ = os.Chdir(oldDir) }()
+
+	if err := os.Chdir(repo.Dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	metadata, err := getStashMetadata()
+	if err != nil {
+		t.Fatalf("getStashMetadata returned error: %v", err)
+	}
+	info, ok := metadata["stash@{0}"]
+	if !ok {
+		t.Fatalf("stash@{0} not found in metadata: %+v", metadata)
+	}
+	if info.Branch != "feature/temp" {
+		t.Errorf("Branch = %q, want %q", info.Branch, "feature/temp")
+	}
+	if info.Commit != strings.TrimSpace(repo.MustGit("rev-parse", "stash@{0}")) {
+		t.Errorf("Commit = %q, want stash@{0} hash", info.Commit)
+	}
+	if info.Time.IsZero() || time.Since(info.Time) > time.Hour {
+		t.Errorf("Time = %v, want recent time", info.Time)
+	}
+
+	branches, err := getExistingBranches()
+	if err != nil {
+		t.Fatalf("getExistingBranches returned error: %v", err)
+	}
+	if !branches[defaultBranch] || branches["feature/temp"] {
+		t.Errorf("getExistingBranches() = %v, want %s only", branches, defaultBranch)
+	}
+
+	gone := findBranchGoneStashes([]StashInfo{info}, branches)
+	if len(gone) != 1 {
+		t.Errorf("stash of deleted branch should be detected: %+v", gone)
+	}
+}
+
+// TestParseNumberSelection は番号選択の入力解析をテストします
+func TestParseNumberSelection(t *testing.T) {
+	indexes, err := parseNumberSelection("3,1", 3)
+	if err != nil {
+		t.Fatalf("parseNumberSelection returned error: %v", err)
+	}
+	if len(indexes) != 2 || indexes[0] != 2 || indexes[1] != 0 {
+		t.Errorf("parseNumberSelection(\"3,1\") = %v, want [2 0]", indexes)
+	}
+
+	all, err := parseNumberSelection("all", 3)
+	if err != nil || len(all) != 3 {
+		t.Errorf("parseNumberSelection(\"all\") = %v, %v", all, err)
+	}
+
+	if _, err := parseNumberSelection("0", 3); err == nil {
+		t.Error("parseNumberSelection(\"0\") should return error")
+	}
+}
+
+// TestCollectCleanupTargets_MatchAll は --match all ですべてのカテゴリに含まれるスタッシュのみが対象になることを確認します
+func TestCollectCleanupTargets_MatchAll(t *testing.T) {
+	categories := []cleanupCategory{
+		{Key: "older-than", Targets: []cleanupTarget{{Info: StashInfo{Index: 1}}, {Info: StashInfo{Index: 3}}}},
+		{Key: "branch-gone", Targets: []cleanupTarget{{Info: StashInfo{Index: 0}}, {Info: StashInfo{Index: 3}}}},
+	}
+
+	toDelete := collectCleanupTargets(categories, true)
+	if len(toDelete) != 1 || toDelete[0].Index != 3 {
+		t.Errorf("collectCleanupTargets(all) = %+v, want only index 3", toDelete)
+	}
+
+	// 候補がないカテゴリが条件に含まれる場合は何も削除しない
+	categories = append(categories, cleanupCategory{Key: "applied"})
+	if toDelete := collectCleanupTargets(categories, true); len(toDelete) != 0 {
+		t.Errorf("collectCleanupTargets(all) with empty category = %+v, want none", toDelete)
+	}
+}
+
+// TestStashCleanup_OlderThanAndBranchGone は --older-than と --branch-gone を組み合わせた削除を確認します
+func TestStashCleanup_OlderThanAndBranchGone(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("a.txt", "base")
+	repo.Commit("Initial commit")
+	base := repo.CurrentBranch()
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer func() { _ = os.Chdir(oldDir) }()
+	if err := os.Chdir(repo.Dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	// 古いスタッシュ（ブランチは存在する）
+	t.Setenv("GIT_COMMITTER_DATE", "2020-01-01T00:00:00Z")
+	repo.CreateFile("a.txt", "old work")
+	repo.StashPush("old work")
+	t.Setenv("GIT_COMMITTER_DATE", "")
+
+	// 今日作成した、削除済みのブランチのスタッシュ
+	repo.CreateAndCheckoutBranch("feature/done")
+	repo.CreateFile("a.txt", "today")
+	repo.StashPush("today")
+	repo.CheckoutBranch(base)
+	repo.MustGit("branch", "-D", "feature/done")
+
+	defer func() {
+		cleanupOlderThan, cleanupBranchGone, cleanupYes, cleanupMatch = "", false, false, cleanupMatchAll
+	}()
+	cleanupOlderThan, cleanupBranchGone, cleanupYes = "60d", true, true
+
+	// --match all（デフォルト）: 両方の条件に該当するスタッシュはないため削除しない
+	cleanupMatch = cleanupMatchAll
+	if err := runStashCleanup(); err != nil {
+		t.Fatalf("runStashCleanup() returned error: %v", err)
+	}
+	if count := len(strings.Fields(repo.MustGit("stash", "list", "--format=%H"))); count != 2 {
+		t.Errorf("match all: stash count = %d, want 2", count)
+	}
+
+	// --match any: いずれかの条件に該当するスタッシュをすべて削除する
+	cleanupMatch = cleanupMatchAny
+	if err := runStashCleanup(); err != nil {
+		t.Fatalf("runStashCleanup() returned error: %v", err)
+	}
+	if count := len(strings.Fields(repo.MustGit("stash", "list", "--format=%H"))); count != 0 {
+		t.Errorf("match any: stash count = %d, want 0", count)
+	}
+
+	cleanupMatch = "both"
+	if err := runStashCleanup(); err == nil {
+		t.Error("runStashCleanup() should reject an invalid --match value")
+	}
+}
@@ -0,0 +1,120 @@
+// ================================================================================
+// stash_export.go
+// ================================================================================
+// このファイルは git の拡張コマンド stash-export コマンドを実装しています。
+// stash パッケージ内に配置され、スタッシュ関連の機能を提供します。
+//
+// 【概要】
+// stash-export コマンドは、スタッシュを1つのアーカイブファイル（JSON）に書き出します。
+// 書き出したファイルは、別のマシンや他の開発者のリポジトリで stash-import を使って
+// 本物のスタッシュとして取り込めます。
+//
+// 【主な機能】
+// - 指定したスタッシュ（省略時はすべて）の書き出し
+// - ステージ済みの変更、未ステージの変更、untracked ファイルをすべて保存
+// - スタッシュのメッセージ、作成日時、ベースコミットを保存
+//
+// 【使用例】
+//   git stash-export                         # すべてのスタッシュを書き出し
+//   git stash-export 0 2 -o work.json        # stash@{0} と stash@{2} を書き出し
+//
+// 【内部仕様】
+// - アーカイブの形式は internal/stashbundle パッケージを参照してください
+// - 番号のみの指定（例: 2）は stash@{2} として扱います
+// ================================================================================
+
+package stash
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/stashbundle"
+)
+
+// stashExportOutput は -o/--output フラグの値です（書き出し先のファイル）。
+var stashExportOutput string
+
+// stashExportCmd は stash-export コマンドの定義です。
+var stashExportCmd = &cobra.Command{
+	Use:   "stash-export [stash...]",
+	Short: "スタッシュをファイルに書き出して持ち運べるようにする",
+	Long: `スタッシュを1つのアーカイブファイル（JSON）に書き出します。
+ステージ済みの変更、未ステージの変更、untracked ファイル、メッセージ、作成日時、
+ベースコミットを保存するため、別のマシンや他の開発者のリポジトリで
+git stash-import を使って本物のスタッシュとして取り込めます。
+
+スタッシュは stash@{N}、番号（N）、コミットハッシュで指定できます。
+省略した場合はすべてのスタッシュを書き出します。`,
+	Example: `  git stash-export
+  git stash-export 0 2 -o work.json
+  git stash-export stash@{1} -o ~/share/login-fix.json`,
+	RunE: func(c *cobra.Command, args []string) error {
+		refs := make([]string, 0, len(args))
+		for _, arg := range args {
+			refs = append(refs, normalizeStashRef(arg))
+		}
+		if len(refs) == 0 {
+			all, err := getAllStashesList()
+			if err != nil {
+				return fmt.Errorf("スタッシュ一覧の取得に失敗しました: %w", err)
+			}
+			refs = all
+		}
+		if len(refs) == 0 {
+			fmt.Println("スタッシュが存在しません。")
+			return nil
+		}
+
+		entries := make([]stashbundle.Entry, 0, len(refs))
+		for _, ref := range refs {
+			entry, err := stashbundle.Collect(ref)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, entry)
+			fmt.Printf("✓ %s: %s%s\n", ref, entry.Message, untrackedSuffix(entry))
+		}
+
+		output := stashExportOutput
+		if output == "" {
+			output = fmt.Sprintf("stashes-%s.json", time.Now().Format("20060102-150405"))
+		}
+		if err := stashbundle.Write(output, entries); err != nil {
+			return err
+		}
+
+		fmt.Printf("\n✓ %d 個のスタッシュを %s に書き出しました\n", len(entries), output)
+		fmt.Printf("取り込むには: git stash-import %s\n", output)
+		return nil
+	},
+}
+
+// stashIndexPattern は番号のみのスタッシュ指定（例: 2）にマッチします。
+var stashIndexPattern = regexp.MustCompile(`^[0-9]+$`)
+
+// normalizeStashRef は番号のみの指定を stash@{N} に変換します。それ以外はそのまま返します。
+func normalizeStashRef(arg string) string {
+	if stashIndexPattern.MatchString(arg) {
+		return fmt.Sprintf("stash@{%s}", arg)
+	}
+	return arg
+}
+
+// untrackedSuffix は untracked ファイルを含むエントリの表示用の注記を返します。
+func untrackedSuffix(entry stashbundle.Entry) string {
+	if entry.UntrackedPatch != "" {
+		return "（untracked ファイルを含む）"
+	}
+	return ""
+}
+
+// init は stash-export コマンドを root コマンドに登録します。
+// この関数はパッケージの初期化時に自動的に呼び出されます。
+func init() {
+	stashExportCmd.Flags().StringVarP(&stashExportOutput, "output", "o", "", "書き出し先のファイル（デフォルト: stashes-<日時>.json）")
+	cmd.RootCmd.AddCommand(stashExportCmd)
+}
@@ -0,0 +1,104 @@
+package stash
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/stashbundle"
+	"github.com/tonbiattack/git-plus/internal/testutil"
+)
+
+// TestStashExportCmd_CommandSetup はstash-exportコマンドの設定をテストします
+func TestStashExportCmd_CommandSetup(t *testing.T) {
+	if stashExportCmd.Use != "stash-export [stash...]" {
+		t.Errorf("stashExportCmd.Use = %q, want %q", stashExportCmd.Use, "stash-export [stash...]")
+	}
+	if stashExportCmd.Short == "" {
+		t.Error("stashExportCmd.Short should not be empty")
+	}
+	if stashExportCmd.Flags().Lookup("output") == nil {
+		t.Error("stashExportCmd should have --output flag")
+	}
+}
+
+// TestStashExportCmd_InRootCmd はstash-exportコマンドがrootCmdに登録されていることを確認します
+func TestStashExportCmd_InRootCmd(t *testing.T) {
+	found := false
+	for _, c := range cmd.RootCmd.Commands() {
+		if c.Name() == "stash-export" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("stashExportCmd should be registered in rootCmd")
+	}
+}
+
+// TestNormalizeStashRef はスタッシュ指定の正規化をテストします
+func TestNormalizeStashRef(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"0", "stash@{0}"},
+		{"12", "stash@{12}"},
+		{"stash@{1}", "stash@{1}"},
+		{"abc1234", "abc1234"},
+	}
+	for _, tt := range tests {
+		if got := normalizeStashRef(tt.input); got != tt.expected {
+			t.Errorf("normalizeStashRef(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+// TestStashExport_Selected は指定したスタッシュのみを書き出すことを確認します
+func TestStashExport_Selected(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("a.txt", "a1\n")
+	repo.Commit("Initial commit")
+	repo.CreateFile("a.txt", "first\n")
+	repo.StashPush("first")
+	repo.CreateFile("a.txt", "second\n")
+	repo.StashPush("second")
+	chdirStashRepo(t, repo.Dir)
+
+	output := filepath.Join(t.TempDir(), "export.json")
+	stashExportOutput = output
+	defer func() { stashExportOutput = "" }()
+
+	if err := stashExportCmd.RunE(stashExportCmd, []string{"1"}); err != nil {
+		t.Fatalf("stash-export returned error: %v", err)
+	}
+
+	bundle, err := stashbundle.Read(output)
+	if err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+	if len(bundle.Stashes) != 1 || !strings.HasSuffix(bundle.Stashes[0].Message, ": first") {
+		t.Errorf("exported stashes = %+v, want only \"first\"", bundle.Stashes)
+	}
+}
+
+// TestStashExport_NoStash はスタッシュがない場合にファイルを作成しないことを確認します
+func TestStashExport_NoStash(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("a.txt", "a1\n")
+	repo.Commit("Initial commit")
+	chdirStashRepo(t, repo.Dir)
+
+	output := filepath.Join(t.TempDir(), "export.json")
+	stashExportOutput = output
+	defer func() { stashExportOutput = "" }()
+
+	if err := stashExportCmd.RunE(stashExportCmd, nil); err != nil {
+		t.Fatalf("stash-export returned error: %v", err)
+	}
+	if _, err := os.Stat(output); !os.IsNotExist(err) {
+		t.Error("export file should not be created without stashes")
+	}
+}
@@ -0,0 +1,369 @@
+// ================================================================================
+// stash_grep.go
+// ================================================================================
+// このファイルは git の拡張コマンド stash-grep コマンドを実装しています。
+// stash パッケージ内に配置され、スタッシュ関連の機能を提供します。
+//
+// 【概要】
+// stash-grep コマンドは、すべてのスタッシュの差分の内容と untracked ファイルの内容を
+// 正規表現で検索し、一致したスタッシュをファイル名・行番号付きで表示します。
+// 一致したスタッシュを選択すると、stash-select と同じ操作メニューを表示します。
+//
+// 【主な機能】
+// - 正規表現（Go の regexp 構文）による検索、-i で大文字小文字を区別しない
+// - 差分の追加行（+）と削除行（-）、untracked ファイルの全行を検索
+// - 一致した行のファイル名と行番号、-C で前後の行を表示
+// - 一致したスタッシュを選択して apply / pop / files などの操作を実行
+//
+// 【使用例】
+//   git stash-grep retry                  # "retry" を含むスタッシュを検索
+//   git stash-grep -i 'func\s+retry' -C 2 # 前後2行を表示
+//   git stash-grep TODO --no-select       # 一覧の表示のみ
+//
+// 【内部仕様】
+// - 差分は git diff <stash>^1 <stash> から取得し、追加行は変更後の行番号、
+//   削除行は変更前の行番号を表示します
+// - untracked ファイルは <stash>^3 から読み込みます（バイナリファイルは対象外）
+// ================================================================================
+
+package stash
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/gitcmd"
+	"github.com/tonbiattack/git-plus/internal/ui"
+)
+
+var (
+	stashGrepIgnoreCase bool // -i/--ignore-case: 大文字小文字を区別しない
+	stashGrepContext    int  // -C/--context: 一致した行の前後に表示する行数
+	stashGrepNoSelect   bool // --no-select: 検索結果の表示のみで操作メニューを表示しない
+)
+
+// grepLine は検索対象の1行を表します。
+type grepLine struct {
+	Line int    // 行番号（追加行・untracked は変更後、削除行は変更前）
+	Kind byte   // '+'（追加）、'-'（削除）、' '（差分の前後の行）、'?'（untracked ファイル）
+	Text string // 行の内容（先頭の +/-/空白は含まない）
+	Hunk int    // 差分のハンク番号（前後の行の表示範囲の判定に使用）
+}
+
+// grepFile はスタッシュ内の1ファイルの検索結果を表します。
+type grepFile struct {
+	Path    string
+	Lines   []grepLine
+	Matches []int // Lines のうち一致した行の位置
+}
+
+// grepResult はスタッシュ1件の検索結果を表します。
+type grepResult struct {
+	Stash StashEntry
+	Files []grepFile
+}
+
+// stashGrepCmd は stash-grep コマンドの定義です。
+var stashGrepCmd = &cobra.Command{
+	Use:   "stash-grep <pattern>",
+	Short: "スタッシュの差分と untracked ファイルの内容を正規表現で検索",
+	Long: `すべてのスタッシュの差分（追加行・削除行）と untracked ファイルの内容を
+正規表現で検索し、一致したスタッシュをファイル名と行番号付きで表示します。
+
+一致したスタッシュの番号を入力すると、git stash-select と同じ操作メニュー
+（apply / pop / files / branch / rename / drop / show）を表示します。
+
+パターンは Go の正規表現構文です。-i で大文字小文字を区別せずに検索します。`,
+	Example: `  git stash-grep retry
+  git stash-grep -i 'func\s+retry' -C 2
+  git stash-grep TODO --no-select`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(c *cobra.Command, args []string) error {
+		pattern := args[0]
+		if stashGrepIgnoreCase {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("正規表現が不正です: %w", err)
+		}
+
+		stashes, err := getStashList()
+		if err != nil {
+			return fmt.Errorf("スタッシュ一覧の取得に失敗しました: %w", err)
+		}
+		if len(stashes) == 0 {
+			fmt.Println("スタッシュが存在しません。")
+			return nil
+		}
+
+		var results []grepResult
+		for _, stash := range stashes {
+			files, err := grepStash(stash.Ref, re)
+			if err != nil {
+				fmt.Printf("警告: %s の検索に失敗しました: %v\n", stash.Ref, err)
+				continue
+			}
+			if len(files) > 0 {
+				results = append(results, grepResult{Stash: stash, Files: files})
+			}
+		}
+
+		if len(results) == 0 {
+			fmt.Printf("%q に一致するスタッシュは見つかりませんでした（%d 個を検索）。\n", args[0], len(stashes))
+			return nil
+		}
+
+		fmt.Printf("%q に一致するスタッシュ: %d 個（%d 個中）\n\n", args[0], len(results), len(stashes))
+		for i, result := range results {
+			printGrepResult(i+1, result, stashGrepContext)
+		}
+
+		if stashGrepNoSelect {
+			return nil
+		}
+
+		reader := bufio.NewReader(os.Stdin)
+		input := promptLine(reader, "操作するスタッシュを選択してください (番号を入力、Enterで終了): ")
+		if input == "" {
+			return nil
+		}
+		n, err := strconv.Atoi(ui.NormalizeNumberInput(input))
+		if err != nil || n < 1 || n > len(results) {
+			return fmt.Errorf("無効な番号です: %s", input)
+		}
+
+		selected := results[n-1].Stash
+		fmt.Printf("\n選択されたスタッシュ: %s\n", selected.Ref)
+		fmt.Printf("メッセージ: %s\n", selected.Message)
+		fmt.Printf("ブランチ: %s\n", selected.Branch)
+		return runStashAction(reader, selected)
+	},
+}
+
+// printGrepResult はスタッシュ1件の検索結果を表示します。
+func printGrepResult(number int, result grepResult, context int) {
+	fmt.Printf("%d. %s  [%s] %s\n", number, result.Stash.Ref, result.Stash.Branch, result.Stash.Message)
+	for _, file := range result.Files {
+		label := ""
+		if len(file.Lines) > 0 && file.Lines[0].Kind == '?' {
+			label = " (untracked)"
+		}
+		fmt.Printf("   %s%s\n", file.Path, label)
+		for _, block := range grepContextBlocks(file, context) {
+			if block.separator {
+				fmt.Println("     --")
+			}
+			for _, i := range block.lines {
+				line := file.Lines[i]
+				kind := string(line.Kind)
+				if line.Kind == '?' {
+					kind = " "
+				}
+				marker := " "
+				if block.matched[i] {
+					marker = ">"
+				}
+				fmt.Printf("   %s %5d %s %s\n", marker, line.Line, kind, line.Text)
+			}
+		}
+	}
+	fmt.Println()
+}
+
+// grepBlock は連続して表示する行のまとまりです。
+type grepBlock struct {
+	lines     []int
+	matched   map[int]bool
+	separator bool // 直前のまとまりとの間に区切りを表示するか
+}
+
+// grepContextBlocks は一致した行とその前後 context 行を、重なりをまとめて返します。
+// 差分の場合、前後の行は同じハンク内に限ります。
+func grepContextBlocks(file grepFile, context int) []grepBlock {
+	matched := make(map[int]bool, len(file.Matches))
+	for _, m := range file.Matches {
+		matched[m] = true
+	}
+
+	var blocks []grepBlock
+	last := -1
+	for _, m := range file.Matches {
+		start := m
+		for start > 0 && m-start < context && file.Lines[start-1].Hunk == file.Lines[m].Hunk {
+			start--
+		}
+		end := m
+		for end < len(file.Lines)-1 && end-m < context && file.Lines[end+1].Hunk == file.Lines[m].Hunk {
+			end++
+		}
+		if start <= last {
+			start = last + 1
+		}
+		if start > end {
+			continue
+		}
+
+		if len(blocks) > 0 && start == last+1 && file.Lines[start].Hunk == file.Lines[last].Hunk {
+			for i := start; i <= end; i++ {
+				blocks[len(blocks)-1].lines = append(blocks[len(blocks)-1].lines, i)
+			}
+		} else {
+			block := grepBlock{matched: matched, separator: len(blocks) > 0}
+			for i := start; i <= end; i++ {
+				block.lines = append(block.lines, i)
+			}
+			blocks = append(blocks, block)
+		}
+		last = end
+	}
+	return blocks
+}
+
+// grepStash はスタッシュの差分と untracked ファイルを検索します。
+//
+// パラメータ:
+//   - ref: スタッシュの参照（例: stash@{0}）
+//   - re: 検索する正規表現
+//
+// 戻り値:
+//   - []grepFile: 一致した行があるファイル（差分のファイル、untracked ファイルの順）
+//   - error: git コマンドの実行に失敗した場合のエラー情報
+func grepStash(ref string, re *regexp.Regexp) ([]grepFile, error) {
+	output, err := gitcmd.Run("-c", "core.quotepath=false", "diff", "--no-color", "--no-ext-diff",
+		"--src-prefix=a/", "--dst-prefix=b/", ref+"^1", ref)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []grepFile
+	for _, file := range parseGrepDiff(string(output)) {
+		if matchGrepFile(&file, re) {
+			results = append(results, file)
+		}
+	}
+
+	untracked, err := getStashUntrackedFiles(ref)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range sortedKeys(untracked) {
+		content, err := gitcmd.Run("show", ref+"^3:"+path)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.IndexByte(content, 0) >= 0 {
+			continue // バイナリファイル
+		}
+		file := grepFile{Path: path}
+		for i, text := range strings.Split(strings.TrimSuffix(string(content), "\n"), "\n") {
+			file.Lines = append(file.Lines, grepLine{Line: i + 1, Kind: '?', Text: text})
+		}
+		if matchGrepFile(&file, re) {
+			results = append(results, file)
+		}
+	}
+
+	return results, nil
+}
+
+// matchGrepFile はファイルの各行を検索し、一致した行の位置を Matches に設定します。
+// 差分の前後の行（変更されていない行）は検索対象外です。
+func matchGrepFile(file *grepFile, re *regexp.Regexp) bool {
+	file.Matches = nil
+	for i, line := range file.Lines {
+		if line.Kind == ' ' {
+			continue
+		}
+		if re.MatchString(line.Text) {
+			file.Matches = append(file.Matches, i)
+		}
+	}
+	return len(file.Matches) > 0
+}
+
+// hunkHeaderPattern は差分のハンクヘッダ（@@ -1,2 +3,4 @@）にマッチします。
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -(\d+)(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// parseGrepDiff は git diff の出力をファイルごとの行に分解します。
+//
+// 追加行と前後の行には変更後の行番号、削除行には変更前の行番号を付けます。
+// バイナリファイルの差分は行を持たないため結果に含まれません。
+func parseGrepDiff(output string) []grepFile {
+	var files []grepFile
+	var current *grepFile
+	oldLine, newLine, hunk := 0, 0, 0
+	inHunk := false
+
+	for _, line := range strings.Split(output, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			files = append(files, grepFile{})
+			current = &files[len(files)-1]
+			inHunk = false
+		case current == nil:
+			continue
+		case !inHunk && strings.HasPrefix(line, "--- a/"):
+			current.Path = strings.TrimSuffix(strings.TrimPrefix(line, "--- a/"), "\t")
+		case !inHunk && strings.HasPrefix(line, "+++ b/"):
+			current.Path = strings.TrimSuffix(strings.TrimPrefix(line, "+++ b/"), "\t")
+		case strings.HasPrefix(line, "@@"):
+			m := hunkHeaderPattern.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			oldLine, _ = strconv.Atoi(m[1])
+			newLine, _ = strconv.Atoi(m[2])
+			hunk++
+			inHunk = true
+		case !inHunk:
+			continue
+		case strings.HasPrefix(line, "+"):
+			current.Lines = append(current.Lines, grepLine{Line: newLine, Kind: '+', Text: line[1:], Hunk: hunk})
+			newLine++
+		case strings.HasPrefix(line, "-"):
+			current.Lines = append(current.Lines, grepLine{Line: oldLine, Kind: '-', Text: line[1:], Hunk: hunk})
+			oldLine++
+		case strings.HasPrefix(line, " "):
+			current.Lines = append(current.Lines, grepLine{Line: newLine, Kind: ' ', Text: line[1:], Hunk: hunk})
+			oldLine++
+			newLine++
+		}
+	}
+
+	// ファイル名がないもの（モード変更のみなど）を除く
+	result := files[:0]
+	for _, f := range files {
+		if f.Path != "" && len(f.Lines) > 0 {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// sortedKeys は集合のキーをソートして返します。
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// init は stash-grep コマンドを root コマンドに登録します。
+// この関数はパッケージの初期化時に自動的に呼び出されます。
+func init() {
+	stashGrepCmd.Flags().BoolVarP(&stashGrepIgnoreCase, "ignore-case", "i", false, "大文字小文字を区別しない")
+	stashGrepCmd.Flags().IntVarP(&stashGrepContext, "context", "C", 0, "一致した行の前後に表示する行数")
+	stashGrepCmd.Flags().BoolVar(&stashGrepNoSelect, "no-select", false, "検索結果の表示のみ行い、操作メニューを表示しない")
+	cmd.RootCmd.AddCommand(stashGrepCmd)
+}
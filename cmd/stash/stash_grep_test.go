@@ -0,0 +1,213 @@
+package stash
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/testutil"
+)
+
+// TestStashGrepCmd_CommandSetup はstash-grepコマンドの設定をテストします
+func TestStashGrepCmd_CommandSetup(t *testing.T) {
+	if stashGrepCmd.Use != "stash-grep <pattern>" {
+		t.Errorf("stashGrepCmd.Use = %q, want %q", stashGrepCmd.Use, "stash-grep <pattern>")
+	}
+	if stashGrepCmd.Short == "" {
+		t.Error("stashGrepCmd.Short should not be empty")
+	}
+	for _, name := range []string{"ignore-case", "context", "no-select"} {
+		if stashGrepCmd.Flags().Lookup(name) == nil {
+			t.Errorf("stashGrepCmd should have --%s flag", name)
+		}
+	}
+	if err := stashGrepCmd.Args(stashGrepCmd, []string{}); err == nil {
+		t.Error("stash-grep should require a pattern")
+	}
+}
+
+// TestStashGrepCmd_InRootCmd はstash-grepコマンドがrootCmdに登録されていることを確認します
+func TestStashGrepCmd_InRootCmd(t *testing.T) {
+	found := false
+	for _, c := range cmd.RootCmd.Commands() {
+		if c.Name() == "stash-grep" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("stashGrepCmd should be registered in rootCmd")
+	}
+}
+
+// TestParseGrepDiff は差分の解析と行番号をテストします
+func TestParseGrepDiff(t *testing.T) {
+	diff := `diff --git a/retry.go b/retry.go
+index 1111111..2222222 100644
+--- a/retry.go
++++ b/retry.go
+@@ -10,3 +10,4 @@ package main
+ func a() {}
+-func old() {}
++func retry() {}
++func retry2() {}
+ func b() {}
+diff --git a/gone.txt b/gone.txt
+deleted file mode 100644
+index 3333333..0000000
+--- a/gone.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-removed line
+diff --git a/image.png b/image.png
+index 4444444..5555555 100644
+Binary files a/image.png and b/image.png differ
+`
+	files := parseGrepDiff(diff)
+	if len(files) != 2 {
+		t.Fatalf("parseGrepDiff() returned %d files, want 2: %+v", len(files), files)
+	}
+
+	retry := files[0]
+	if retry.Path != "retry.go" {
+		t.Errorf("Path = %q, want %q", retry.Path, "retry.go")
+	}
+	want := []grepLine{
+		{Line: 10, Kind: ' ', Text: "func a() {}", Hunk: 1},
+		{Line: 11, Kind: '-', Text: "func old() {}", Hunk: 1},
+		{Line: 11, Kind: '+', Text: "func retry() {}", Hunk: 1},
+		{Line: 12, Kind: '+', Text: "func retry2() {}", Hunk: 1},
+		{Line: 13, Kind: ' ', Text: "func b() {}", Hunk: 1},
+	}
+	if len(retry.Lines) != len(want) {
+		t.Fatalf("Lines = %+v, want %+v", retry.Lines, want)
+	}
+	for i := range want {
+		if retry.Lines[i] != want[i] {
+			t.Errorf("Lines[%d] = %+v, want %+v", i, retry.Lines[i], want[i])
+		}
+	}
+
+	if files[1].Path != "gone.txt" || files[1].Lines[0].Kind != '-' || files[1].Lines[0].Line != 1 {
+		t.Errorf("deleted file = %+v", files[1])
+	}
+}
+
+// TestMatchGrepFile は前後の行が検索対象外であることを確認します
+func TestMatchGrepFile(t *testing.T) {
+	file := grepFile{Lines: []grepLine{
+		{Kind: ' ', Text: "retry context"},
+		{Kind: '+', Text: "added retry"},
+		{Kind: '-', Text: "removed"},
+	}}
+	if !matchGrepFile(&file, regexp.MustCompile("retry")) {
+		t.Fatal("matchGrepFile should match added line")
+	}
+	if len(file.Matches) != 1 || file.Matches[0] != 1 {
+		t.Errorf("Matches = %v, want [1]", file.Matches)
+	}
+	if matchGrepFile(&file, regexp.MustCompile("context")) {
+		t.Error("context lines should not be searched")
+	}
+}
+
+// TestGrepContextBlocks は前後の行の範囲とまとめ方をテストします
+func TestGrepContextBlocks(t *testing.T) {
+	file := grepFile{Lines: []grepLine{
+		{Hunk: 1}, {Hunk: 1}, {Hunk: 1}, {Hunk: 1}, {Hunk: 1},
+		{Hunk: 2}, {Hunk: 2}, {Hunk: 2},
+	}}
+
+	tests := []struct {
+		name    string
+		matches []int
+		context int
+		want    [][]int
+	}{
+		{name: "前後なし", matches: []int{1, 3}, context: 0, want: [][]int{{1}, {3}}},
+		{name: "重なりをまとめる", matches: []int{1, 3}, context: 1, want: [][]int{{0, 1, 2, 3, 4}}},
+		{name: "ハンクをまたがない", matches: []int{4, 5}, context: 2, want: [][]int{{2, 3, 4}, {5, 6, 7}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file.Matches = tt.matches
+			blocks := grepContextBlocks(file, tt.context)
+			if len(blocks) != len(tt.want) {
+				t.Fatalf("blocks = %+v, want %v", blocks, tt.want)
+			}
+			for i, block := range blocks {
+				if len(block.lines) != len(tt.want[i]) {
+					t.Errorf("block[%d] = %v, want %v", i, block.lines, tt.want[i])
+					continue
+				}
+				for j := range block.lines {
+					if block.lines[j] != tt.want[i][j] {
+						t.Errorf("block[%d] = %v, want %v", i, block.lines, tt.want[i])
+						break
+					}
+				}
+				if block.separator != (i > 0) {
+					t.Errorf("block[%d].separator = %v", i, block.separator)
+				}
+			}
+		})
+	}
+}
+
+// TestGrepStash は実際のスタッシュの差分と untracked ファイルを検索できることを確認します
+func TestGrepStash(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("main.go", "package main\n\nfunc main() {}\n")
+	repo.Commit("Initial commit")
+
+	repo.CreateFile("main.go", "package main\n\nfunc main() {}\n\nfunc RetryRequest() {}\n")
+	repo.CreateFile("notes/todo.txt", "line1\nretry later\n")
+	repo.MustGit("stash", "push", "-u", "-m", "retry work")
+
+	repo.CreateFile("main.go", "package main\n\nfunc main() { println() }\n")
+	repo.StashPush("other work")
+	chdirStashRepo(t, repo.Dir)
+
+	files, err := grepStash("stash@{1}", regexp.MustCompile("(?i)retry"))
+	if err != nil {
+		t.Fatalf("grepStash returned error: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("grepStash() returned %d files, want 2: %+v", len(files), files)
+	}
+	if files[0].Path != "main.go" || files[0].Lines[files[0].Matches[0]].Line != 5 {
+		t.Errorf("tracked match = %+v", files[0])
+	}
+	if files[1].Path != "notes/todo.txt" || files[1].Lines[files[1].Matches[0]].Line != 2 || files[1].Lines[0].Kind != '?' {
+		t.Errorf("untracked match = %+v", files[1])
+	}
+
+	files, err = grepStash("stash@{0}", regexp.MustCompile("(?i)retry"))
+	if err != nil {
+		t.Fatalf("grepStash returned error: %v", err)
+	}
+	if len(files) != 0 {
+		t.Errorf("stash@{0} should not match: %+v", files)
+	}
+}
+
+// TestStashGrepCmd_NoSelect は --no-select で結果の表示のみ行うことを確認します
+func TestStashGrepCmd_NoSelect(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("a.txt", "a\n")
+	repo.Commit("Initial commit")
+	repo.CreateFile("a.txt", "retry\n")
+	repo.StashPush("work")
+	chdirStashRepo(t, repo.Dir)
+
+	stashGrepNoSelect = true
+	defer func() { stashGrepNoSelect = false }()
+
+	if err := stashGrepCmd.RunE(stashGrepCmd, []string{"retry"}); err != nil {
+		t.Errorf("stash-grep returned error: %v", err)
+	}
+	if err := stashGrepCmd.RunE(stashGrepCmd, []string{"("}); err == nil {
+		t.Error("stash-grep should return error for invalid regexp")
+	}
+}
@@ -0,0 +1,117 @@
+// ================================================================================
+// stash_import.go
+// ================================================================================
+// このファイルは git の拡張コマンド stash-import コマンドを実装しています。
+// stash パッケージ内に配置され、スタッシュ関連の機能を提供します。
+//
+// 【概要】
+// stash-import コマンドは、stash-export で書き出したアーカイブファイルから
+// スタッシュを再作成し、git stash list に追加します。
+//
+// 【主な機能】
+// - ステージ済みの変更、未ステージの変更、untracked ファイルを含めてスタッシュを再作成
+// - メッセージと作成日時を書き出し元と同じにする
+// - ベースコミットがローカルにない場合の警告と、代わりのベースでの復元
+//
+// 【使用例】
+//   git stash-import work.json                  # スタッシュを取り込む
+//   git stash-import work.json --base main      # ベースがない場合は main を基準にする
+//
+// 【内部仕様】
+// - 書き出し時の順序（stash@{0} が先頭）を保つため、古いものから git stash store します
+// - ベースコミットがない場合は --base（省略時は HEAD）のツリーに差分を適用します。
+//   差分を適用できない場合、そのスタッシュは取り込みません
+// ================================================================================
+
+package stash
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/stashbundle"
+)
+
+// stashImportBase は --base フラグの値です（ベースコミットがない場合の代わりのベース）。
+var stashImportBase string
+
+// stashImportCmd は stash-import コマンドの定義です。
+var stashImportCmd = &cobra.Command{
+	Use:   "stash-import <file>",
+	Short: "stash-export で書き出したスタッシュを取り込む",
+	Long: `git stash-export で書き出したアーカイブファイルからスタッシュを再作成し、
+git stash list に追加します。メッセージ、作成日時、ステージ済みの変更、
+untracked ファイルも書き出し元と同じ状態で復元します。
+
+スタッシュのベースコミットがこのリポジトリにない場合は警告を表示し、
+--base で指定したコミット（省略時は HEAD）を基準に差分を適用します。
+正確に復元するには、先にベースコミットを含むブランチを fetch してください。`,
+	Example: `  git stash-import stashes-20260102-030405.json
+  git stash-import work.json --base origin/main`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(c *cobra.Command, args []string) error {
+		bundle, err := stashbundle.Read(args[0])
+		if err != nil {
+			return err
+		}
+		if len(bundle.Stashes) == 0 {
+			fmt.Println("アーカイブにスタッシュが含まれていません。")
+			return nil
+		}
+
+		fmt.Printf("%d 個のスタッシュを取り込みます（書き出し日時: %s）\n\n", len(bundle.Stashes), bundle.Exported.Local().Format("2006-01-02 15:04"))
+
+		importedCount := 0
+		failedCount := 0
+
+		// stash@{0} が先頭になるよう、古いものから順に追加する
+		for i := len(bundle.Stashes) - 1; i >= 0; i-- {
+			entry := bundle.Stashes[i]
+			base := ""
+			if !stashbundle.BaseExists(entry) {
+				base = stashImportBase
+				if base == "" {
+					base = "HEAD"
+				}
+				fmt.Printf("警告: ベースコミット %s（%s）がこのリポジトリにありません\n", shortCommit(entry.Base), entry.BaseSubject)
+				fmt.Printf("  %s を基準に復元します: %s\n", base, entry.Message)
+			}
+
+			if _, err := stashbundle.Restore(entry, base); err != nil {
+				fmt.Printf("✗ 取り込みに失敗しました: %s\n  %v\n", entry.Message, err)
+				failedCount++
+				continue
+			}
+			fmt.Printf("✓ %s%s\n", entry.Message, untrackedSuffix(entry))
+			importedCount++
+		}
+
+		fmt.Printf("\n完了: %d 個のスタッシュを取り込みました", importedCount)
+		if failedCount > 0 {
+			fmt.Printf(" (%d 個失敗)", failedCount)
+		}
+		fmt.Println()
+
+		if failedCount > 0 {
+			fmt.Println("ベースコミットを含むブランチを fetch するか、--base で差分を適用できるコミットを指定してください。")
+			return fmt.Errorf("%d 個のスタッシュを取り込めませんでした", failedCount)
+		}
+		return nil
+	},
+}
+
+// shortCommit はコミットハッシュの先頭8文字を返します。
+func shortCommit(commit string) string {
+	if len(commit) > 8 {
+		return commit[:8]
+	}
+	return commit
+}
+
+// init は stash-import コマンドを root コマンドに登録します。
+// この関数はパッケージの初期化時に自動的に呼び出されます。
+func init() {
+	stashImportCmd.Flags().StringVar(&stashImportBase, "base", "", "ベースコミットがない場合に代わりに使用するコミット（デフォルト: HEAD）")
+	cmd.RootCmd.AddCommand(stashImportCmd)
+}
@@ -0,0 +1,131 @@
+package stash
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/testutil"
+)
+
+// TestStashImportCmd_CommandSetup はstash-importコマンドの設定をテストします
+func TestStashImportCmd_CommandSetup(t *testing.T) {
+	if stashImportCmd.Use != "stash-import <file>" {
+		t.Errorf("stashImportCmd.Use = %q, want %q", stashImportCmd.Use, "stash-import <file>")
+	}
+	if stashImportCmd.Short == "" {
+		t.Error("stashImportCmd.Short should not be empty")
+	}
+	if stashImportCmd.Flags().Lookup("base") == nil {
+		t.Error("stashImportCmd should have --base flag")
+	}
+	if err := stashImportCmd.Args(stashImportCmd, []string{}); err == nil {
+		t.Error("stash-import should require a file argument")
+	}
+}
+
+// TestStashImportCmd_InRootCmd はstash-importコマンドがrootCmdに登録されていることを確認します
+func TestStashImportCmd_InRootCmd(t *testing.T) {
+	found := false
+	for _, c := range cmd.RootCmd.Commands() {
+		if c.Name() == "stash-import" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("stashImportCmd should be registered in rootCmd")
+	}
+}
+
+// TestStashExportImport_RoundTrip は書き出したスタッシュを別のリポジトリに同じ順序で取り込めることを確認します
+func TestStashExportImport_RoundTrip(t *testing.T) {
+	src := testutil.NewGitRepo(t)
+	src.CreateFile("a.txt", "a1\n")
+	src.Commit("Initial commit")
+	src.CreateFile("a.txt", "older\n")
+	src.StashPush("older")
+	src.CreateFile("a.txt", "newer\n")
+	src.CreateFile("untracked.txt", "u\n")
+	src.MustGit("stash", "push", "-u", "-m", "newer")
+	chdirStashRepo(t, src.Dir)
+
+	output := filepath.Join(t.TempDir(), "stashes.json")
+	stashExportOutput = output
+	defer func() { stashExportOutput = "" }()
+	if err := stashExportCmd.RunE(stashExportCmd, nil); err != nil {
+		t.Fatalf("stash-export returned error: %v", err)
+	}
+
+	// 同じ履歴を持つクローン（ベースコミットが存在する）
+	dst := testutil.NewGitRepo(t)
+	dst.MustGit("fetch", src.Dir, "HEAD")
+	dst.MustGit("reset", "--hard", "FETCH_HEAD")
+	chdirStashRepo(t, dst.Dir)
+
+	if err := stashImportCmd.RunE(stashImportCmd, []string{output}); err != nil {
+		t.Fatalf("stash-import returned error: %v", err)
+	}
+
+	stashes, err := getStashList()
+	if err != nil {
+		t.Fatalf("getStashList returned error: %v", err)
+	}
+	if len(stashes) != 2 {
+		t.Fatalf("stash count = %d, want 2", len(stashes))
+	}
+	if !strings.HasSuffix(stashes[0].Message, ": newer") || !strings.HasSuffix(stashes[1].Message, ": older") {
+		t.Errorf("stash order = [%s, %s], want [newer, older]", stashes[0].Message, stashes[1].Message)
+	}
+
+	dst.MustGit("stash", "pop")
+	if got := dst.ReadFile("a.txt"); got != "newer\n" {
+		t.Errorf("a.txt = %q, want %q", got, "newer\n")
+	}
+	if got := dst.ReadFile("untracked.txt"); got != "u\n" {
+		t.Errorf("untracked.txt = %q, want %q", got, "u\n")
+	}
+}
+
+// TestStashImport_MissingBase はベースコミットがない場合に HEAD を基準に取り込み、
+// 差分を適用できない場合はエラーになることを確認します
+func TestStashImport_MissingBase(t *testing.T) {
+	src := testutil.NewGitRepo(t)
+	src.CreateFile("a.txt", "a1\n")
+	src.Commit("Initial commit")
+	src.CreateFile("a.txt", "changed\n")
+	src.StashPush("work")
+	chdirStashRepo(t, src.Dir)
+
+	output := filepath.Join(t.TempDir(), "stashes.json")
+	stashExportOutput = output
+	defer func() { stashExportOutput = "" }()
+	if err := stashExportCmd.RunE(stashExportCmd, nil); err != nil {
+		t.Fatalf("stash-export returned error: %v", err)
+	}
+
+	// 同じ内容だが履歴が異なるリポジトリ: HEAD を基準に取り込める
+	same := testutil.NewGitRepo(t)
+	same.CreateFile("a.txt", "a1\n")
+	same.Commit("Unrelated history")
+	chdirStashRepo(t, same.Dir)
+	if err := stashImportCmd.RunE(stashImportCmd, []string{output}); err != nil {
+		t.Fatalf("stash-import returned error: %v", err)
+	}
+	if stashes, _ := getAllStashesList(); len(stashes) != 1 {
+		t.Errorf("stash count = %d, want 1", len(stashes))
+	}
+
+	// 内容が異なるリポジトリ: 差分を適用できないためエラー
+	different := testutil.NewGitRepo(t)
+	different.CreateFile("a.txt", "something else\n")
+	different.Commit("Different content")
+	chdirStashRepo(t, different.Dir)
+	if err := stashImportCmd.RunE(stashImportCmd, []string{output}); err == nil {
+		t.Error("stash-import should fail when the patch does not apply")
+	}
+	if stashes, _ := getAllStashesList(); len(stashes) != 0 {
+		t.Errorf("stash count = %d, want 0", len(stashes))
+	}
+}
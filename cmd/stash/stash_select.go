@@ -2,8 +2,11 @@ package stash
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -26,7 +29,11 @@ var stashSelectCmd = &cobra.Command{
 	Short: "インタラクティブにスタッシュを選択・操作",
 	Long: `スタッシュされている変更を一覧表示し、インタラクティブに選択して操作できます。
 各スタッシュのファイル一覧を確認しながら、apply（適用）、pop（適用して削除）、
-drop（削除）、show（差分表示）などの操作を実行できます。`,
+drop（削除）、show（差分表示）などの操作を実行できます。
+
+選択したファイルのみの適用、スタッシュ作成時のコミットから新しいブランチを
+作成しての適用（git stash branch）、スタッシュのメッセージ変更もできます。
+apply / pop / ファイル単位の適用の前には、現在の作業ツリーと競合しないかを確認します。`,
 	Example: `  git stash-select`,
 	RunE: func(c *cobra.Command, args []string) error {
 		// スタッシュ一覧を取得
@@ -100,59 +107,7 @@ drop（削除）、show（差分表示）などの操作を実行できます。
 			}
 		}
 
-		// 操作メニューを表示
-		fmt.Println("\n操作を選択してください:")
-		fmt.Println("  [a]pply  - スタッシュを適用（スタッシュは残す）")
-		fmt.Println("  [p]op    - スタッシュを適用して削除")
-		fmt.Println("  [d]rop   - スタッシュを削除")
-		fmt.Println("  [s]how   - 差分を表示")
-		fmt.Println("  [c]ancel - キャンセル")
-		fmt.Print("\n選択 (a/p/d/s/c): ")
-
-		action, err := reader.ReadString('\n')
-		if err != nil {
-			return fmt.Errorf("入力の読み込みに失敗しました: %w", err)
-		}
-
-		action = strings.TrimSpace(strings.ToLower(action))
-
-		switch action {
-		case "a", "apply":
-			fmt.Printf("\nスタッシュを適用しています: %s\n", selectedStash.Ref)
-			if err := applyStash(selectedStash.Ref); err != nil {
-				return fmt.Errorf("スタッシュの適用に失敗しました: %w", err)
-			}
-			fmt.Println("✓ スタッシュを適用しました")
-
-		case "p", "pop":
-			fmt.Printf("\nスタッシュを適用して削除しています: %s\n", selectedStash.Ref)
-			if err := popStash(selectedStash.Ref); err != nil {
-				return fmt.Errorf("スタッシュのpopに失敗しました: %w", err)
-			}
-			fmt.Println("✓ スタッシュを適用して削除しました")
-
-		case "d", "drop":
-			fmt.Printf("\nスタッシュを削除しています: %s\n", selectedStash.Ref)
-			if err := dropStash(selectedStash.Ref); err != nil {
-				return fmt.Errorf("スタッシュの削除に失敗しました: %w", err)
-			}
-			fmt.Println("✓ スタッシュを削除しました")
-
-		case "s", "show":
-			fmt.Printf("\nスタッシュの差分を表示しています: %s\n\n", selectedStash.Ref)
-			if err := showStash(selectedStash.Ref); err != nil {
-				return fmt.Errorf("スタッシュの表示に失敗しました: %w", err)
-			}
-
-		case "c", "cancel", "":
-			fmt.Println("キャンセルしました。")
-			return nil
-
-		default:
-			return fmt.Errorf("無効な操作です: %s", action)
-		}
-
-		return nil
+		return runStashAction(reader, selectedStash)
 	},
 }
 
@@ -239,6 +194,456 @@ func getStashFiles(ref string) ([]string, error) {
 	return files, nil
 }
 
+// runStashAction は選択したスタッシュの操作メニューを表示し、選択された操作を実行します
+func runStashAction(reader *bufio.Reader, stash StashEntry) error {
+	fmt.Println("\n操作を選択してください:")
+	fmt.Println("  [a]pply  - スタッシュを適用（スタッシュは残す）")
+	fmt.Println("  [p]op    - スタッシュを適用して削除")
+	fmt.Println("  [f]iles  - 選択したファイルのみ適用（スタッシュは残す）")
+	fmt.Println("  [b]ranch - スタッシュ作成時のコミットから新しいブランチを作成して適用")
+	fmt.Println("  [r]ename - スタッシュのメッセージを変更")
+	fmt.Println("  [d]rop   - スタッシュを削除")
+	fmt.Println("  [s]how   - 差分を表示")
+	fmt.Println("  [c]ancel - キャンセル")
+
+	action := strings.ToLower(promptLine(reader, "\n選択 (a/p/f/b/r/d/s/c): "))
+
+	switch action {
+	case "a", "apply":
+		if !confirmStashConflicts(reader, stash.Ref, nil) {
+			fmt.Println("キャンセルしました。")
+			return nil
+		}
+		fmt.Printf("\nスタッシュを適用しています: %s\n", stash.Ref)
+		if err := applyStash(stash.Ref); err != nil {
+			return fmt.Errorf("スタッシュの適用に失敗しました: %w", err)
+		}
+		fmt.Println("✓ スタッシュを適用しました")
+
+	case "p", "pop":
+		if !confirmStashConflicts(reader, stash.Ref, nil) {
+			fmt.Println("キャンセルしました。")
+			return nil
+		}
+		fmt.Printf("\nスタッシュを適用して削除しています: %s\n", stash.Ref)
+		if err := popStash(stash.Ref); err != nil {
+			return fmt.Errorf("スタッシュのpopに失敗しました: %w", err)
+		}
+		fmt.Println("✓ スタッシュを適用して削除しました")
+
+	case "f", "files":
+		return runApplyStashFiles(reader, stash)
+
+	case "b", "branch":
+		name := promptLine(reader, "作成するブランチ名を入力してください (Enterでキャンセル): ")
+		if name == "" {
+			fmt.Println("キャンセルしました。")
+			return nil
+		}
+		fmt.Printf("\nブランチ %s を作成してスタッシュを適用しています: %s\n", name, stash.Ref)
+		if err := branchStash(name, stash.Ref); err != nil {
+			return fmt.Errorf("スタッシュからのブランチ作成に失敗しました: %w", err)
+		}
+		fmt.Printf("✓ ブランチ %s を作成してスタッシュを適用しました\n", name)
+
+	case "r", "rename":
+		fmt.Printf("現在のメッセージ: %s\n", stash.Message)
+		message := promptLine(reader, "新しいメッセージを入力してください (Enterでキャンセル): ")
+		if message == "" {
+			fmt.Println("キャンセルしました。")
+			return nil
+		}
+		newMessage, err := renameStash(stash, message)
+		if err != nil {
+			return fmt.Errorf("スタッシュのメッセージ変更に失敗しました: %w", err)
+		}
+		fmt.Printf("✓ メッセージを変更しました: %s\n", newMessage)
+		fmt.Println("  （変更したスタッシュは stash@{0} に移動します）")
+
+	case "d", "drop":
+		fmt.Printf("\nスタッシュを削除しています: %s\n", stash.Ref)
+		if err := dropStash(stash.Ref); err != nil {
+			return fmt.Errorf("スタッシュの削除に失敗しました: %w", err)
+		}
+		fmt.Println("✓ スタッシュを削除しました")
+
+	case "s", "show":
+		fmt.Printf("\nスタッシュの差分を表示しています: %s\n\n", stash.Ref)
+		if err := showStash(stash.Ref); err != nil {
+			return fmt.Errorf("スタッシュの表示に失敗しました: %w", err)
+		}
+
+	case "c", "cancel", "":
+		fmt.Println("キャンセルしました。")
+		return nil
+
+	default:
+		return fmt.Errorf("無効な操作です: %s", action)
+	}
+
+	return nil
+}
+
+// runApplyStashFiles はスタッシュのファイル一覧から選択したファイルのみを適用します
+func runApplyStashFiles(reader *bufio.Reader, stash StashEntry) error {
+	changes, err := getStashChanges(stash.Ref)
+	if err != nil {
+		return fmt.Errorf("スタッシュのファイル一覧の取得に失敗しました: %w", err)
+	}
+	files := sortedChangePaths(changes)
+	if len(files) == 0 {
+		fmt.Println("適用できるファイルがありません。")
+		return nil
+	}
+
+	fmt.Println("\nファイル一覧:")
+	for i, file := range files {
+		fmt.Printf("  %d. %s\n", i+1, file)
+	}
+
+	indexes, err := parseNumberSelection(promptLine(reader, "適用するファイルを選択してください（例: 1,3 / a=すべて / Enter=キャンセル）: "), len(files))
+	if err != nil {
+		return err
+	}
+	if len(indexes) == 0 {
+		fmt.Println("キャンセルしました。")
+		return nil
+	}
+	selected := make([]string, 0, len(indexes))
+	for _, i := range indexes {
+		selected = append(selected, files[i])
+	}
+
+	if !confirmStashConflicts(reader, stash.Ref, selected) {
+		fmt.Println("キャンセルしました。")
+		return nil
+	}
+
+	fmt.Printf("\n%d 個のファイルを適用しています: %s\n", len(selected), stash.Ref)
+	if err := applyStashFiles(stash.Ref, selected); err != nil {
+		return fmt.Errorf("ファイルの適用に失敗しました: %w", err)
+	}
+	for _, file := range selected {
+		fmt.Printf("✓ %s\n", file)
+	}
+	fmt.Println("✓ 選択したファイルを適用しました（スタッシュは残っています）")
+	return nil
+}
+
+// confirmStashConflicts は適用時の競合を事前に確認し、競合がある場合は続行するかを確認します
+//
+// files が空の場合はスタッシュ全体を対象にします。
+// 競合が検出されなかった場合は確認せずに true を返します。
+func confirmStashConflicts(reader *bufio.Reader, ref string, files []string) bool {
+	conflicts, err := previewStashConflicts(ref, files)
+	if err != nil {
+		fmt.Printf("警告: 競合の事前確認に失敗しました: %v\n", err)
+		return confirmLine(reader, "適用を続行しますか?")
+	}
+	if len(conflicts) == 0 {
+		fmt.Println("✓ 現在の作業ツリーとの競合は検出されませんでした")
+		return true
+	}
+
+	fmt.Println("\n現在の作業ツリーと競合する可能性があります:")
+	for _, c := range conflicts {
+		fmt.Printf("  - %s: %s\n", c.Path, c.Reason)
+	}
+	return confirmLine(reader, "適用を続行しますか?")
+}
+
+// stashConflict は適用時に競合する可能性があるファイルと理由を表します
+type stashConflict struct {
+	Path   string
+	Reason string
+}
+
+// previewStashConflicts はスタッシュを現在の作業ツリーに適用した場合の競合を事前に確認します
+//
+// 以下の場合を競合として報告します（作業ツリーは変更しません）:
+//   - スタッシュが変更するファイルに、コミットしていない変更がある
+//   - スタッシュの untracked ファイルと同じパスのファイルが既に存在する
+//   - スタッシュ作成後に HEAD 側でも変更されており、差分をそのまま適用できない
+//     （3-way マージが必要で、競合マーカーが発生する可能性がある）
+func previewStashConflicts(ref string, files []string) ([]stashConflict, error) {
+	changes, err := getStashChanges(ref)
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := getStashUntrackedFiles(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := files
+	if len(paths) == 0 {
+		paths = sortedChangePaths(changes)
+	}
+
+	dirty, err := getDirtyFiles()
+	if err != nil {
+		return nil, err
+	}
+	root, err := getRepoRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	var conflicts []stashConflict
+	for _, path := range paths {
+		if untracked[path] {
+			if _, err := os.Lstat(filepath.Join(root, path)); err == nil {
+				conflicts = append(conflicts, stashConflict{Path: path, Reason: "同じパスのファイルが既に存在します"})
+			}
+			continue
+		}
+		if dirty[path] {
+			conflicts = append(conflicts, stashConflict{Path: path, Reason: "コミットしていない変更があります"})
+			continue
+		}
+		if !canApplyStashPatch(root, ref, path) {
+			conflicts = append(conflicts, stashConflict{Path: path, Reason: "スタッシュ作成後に変更されています（3-way マージが必要）"})
+		}
+	}
+	return conflicts, nil
+}
+
+// getStashUntrackedFiles はスタッシュに含まれる untracked ファイルの集合を取得します
+func getStashUntrackedFiles(ref string) (map[string]bool, error) {
+	untracked := make(map[string]bool)
+	if gitcmd.RunQuiet("rev-parse", "--verify", "--quiet", ref+"^3") != nil {
+		return untracked, nil
+	}
+	output, err := gitcmd.Run("ls-tree", "-r", "-z", "--full-tree", "--name-only", ref+"^3")
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range strings.Split(string(output), "\x00") {
+		if path != "" {
+			untracked[path] = true
+		}
+	}
+	return untracked, nil
+}
+
+// getDirtyFiles はコミットしていない変更（ステージ済みを含む）があるファイルの集合を取得します
+func getDirtyFiles() (map[string]bool, error) {
+	output, err := gitcmd.Run("diff", "HEAD", "--name-only", "-z")
+	if err != nil {
+		return nil, err
+	}
+	dirty := make(map[string]bool)
+	for _, path := range strings.Split(string(output), "\x00") {
+		if path != "" {
+			dirty[path] = true
+		}
+	}
+	return dirty, nil
+}
+
+// canApplyStashPatch はスタッシュの指定したファイルの差分を、作業ツリーにそのまま適用できるかを確認します
+func canApplyStashPatch(root, ref, path string) bool {
+	patch, err := gitcmd.Run("diff", "--binary", ref+"^1", ref, "--", topPathspec(path))
+	if err != nil {
+		return false
+	}
+	if len(patch) == 0 {
+		return true
+	}
+	return runGitApply(root, patch, "--check") == nil
+}
+
+// applyStashFiles はスタッシュのうち指定したファイルのみを作業ツリーに適用します
+//
+// 追跡ファイルはスタッシュ作成時のコミットとの差分を git apply で適用し、
+// そのまま適用できない場合は git apply --3way で適用します。
+// untracked ファイルはスタッシュの内容で作成します（未追跡のまま）。
+func applyStashFiles(ref string, files []string) error {
+	untracked, err := getStashUntrackedFiles(ref)
+	if err != nil {
+		return err
+	}
+	root, err := getRepoRoot()
+	if err != nil {
+		return err
+	}
+
+	args := []string{"diff", "--binary", ref + "^1", ref, "--"}
+	tracked := 0
+	for _, file := range files {
+		if untracked[file] {
+			if err := restoreUntrackedFile(root, ref, file); err != nil {
+				return err
+			}
+			continue
+		}
+		args = append(args, topPathspec(file))
+		tracked++
+	}
+	if tracked == 0 {
+		return nil
+	}
+
+	patch, err := gitcmd.Run(args...)
+	if err != nil {
+		return err
+	}
+	if len(patch) == 0 {
+		return nil
+	}
+	if runGitApply(root, patch) == nil {
+		return nil
+	}
+	return runGitApply(root, patch, "--3way")
+}
+
+// restoreUntrackedFile はスタッシュに保存された untracked ファイルを作業ツリーに作成します
+// スタッシュのツリーのモードに合わせて、実行ファイルは実行権限付きで、シンボリックリンクはリンクとして作成します
+func restoreUntrackedFile(root, ref, path string) error {
+	fullPath := filepath.Join(root, path)
+	if _, err := os.Lstat(fullPath); err == nil {
+		return fmt.Errorf("%s は既に存在します", path)
+	}
+	output, err := gitcmd.Run("ls-tree", "--full-tree", ref+"^3", "--", topPathspec(path))
+	if err != nil {
+		return err
+	}
+	mode, _, _ := strings.Cut(strings.TrimSpace(string(output)), " ")
+	content, err := gitcmd.Run("show", ref+"^3:"+path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return err
+	}
+
+	switch mode {
+	case "120000":
+		// シンボリックリンクの blob はリンク先のパス
+		return os.Symlink(string(content), fullPath)
+	case "100755":
+		return os.WriteFile(fullPath, content, 0755)
+	default:
+		return os.WriteFile(fullPath, content, 0644)
+	}
+}
+
+// runGitApply はパッチを標準入力から git apply に渡して、リポジトリのルートで実行します
+// （サブディレクトリで実行すると、ディレクトリ外のファイルへの変更が無視されるため）
+func runGitApply(root string, patch []byte, args ...string) error {
+	cmdArgs := append([]string{"apply"}, args...)
+	c := exec.Command("git", cmdArgs...)
+	c.Dir = root
+	c.Stdin = bytes.NewReader(patch)
+	var stderr bytes.Buffer
+	c.Stderr = &stderr
+	if err := c.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// getRepoRoot はリポジトリのルートディレクトリを取得します
+func getRepoRoot() (string, error) {
+	output, err := gitcmd.Run("rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// branchStash はスタッシュ作成時のコミットから新しいブランチを作成し、スタッシュを適用します
+// 適用に成功するとスタッシュは削除されます（git stash branch）
+func branchStash(name, ref string) error {
+	return gitcmd.RunWithIO("stash", "branch", name, ref)
+}
+
+// renameStash はスタッシュのメッセージを変更します
+//
+// git にはスタッシュのメッセージを変更する機能がないため、同じコミットを新しいメッセージで
+// git stash store してから元のエントリを削除します。変更後のスタッシュは stash@{0} になります。
+// 元のエントリはインデックスではなくコミットハッシュで探します（stash@{0} を変更する場合、
+// 同じコミットの store では一覧にエントリが追加されず、インデックスがずれないため）。
+// 戻り値は保存したメッセージです。
+func renameStash(stash StashEntry, message string) (string, error) {
+	output, err := gitcmd.Run("rev-parse", stash.Ref)
+	if err != nil {
+		return "", err
+	}
+	commit := strings.TrimSpace(string(output))
+	newMessage := buildStashMessage(stash.Branch, message)
+
+	// 先に保存してから削除することで、途中で失敗してもスタッシュを失わない
+	if err := gitcmd.RunQuiet("stash", "store", "-m", newMessage, commit); err != nil {
+		return "", err
+	}
+
+	refs, err := stashRefsByCommit(commit)
+	if err != nil {
+		return "", err
+	}
+	for _, ref := range refs {
+		if ref != "stash@{0}" {
+			// store で追加したエントリ（stash@{0}）以外が元のエントリ
+			if err := dropStash(ref); err != nil {
+				return "", err
+			}
+			return newMessage, nil
+		}
+	}
+
+	// 元のエントリが stash@{0} だったため store でエントリが追加されなかった。
+	// 削除してから新しいメッセージで保存し直す
+	if err := dropStash("stash@{0}"); err != nil {
+		return "", err
+	}
+	if err := gitcmd.RunQuiet("stash", "store", "-m", newMessage, commit); err != nil {
+		return "", fmt.Errorf("スタッシュの保存に失敗しました（git stash store -m %q %s で戻せます）: %w", stash.Message, commit, err)
+	}
+	return newMessage, nil
+}
+
+// stashRefsByCommit は指定したコミットを指すスタッシュの参照（stash@{N}）を新しい順に返します
+func stashRefsByCommit(commit string) ([]string, error) {
+	output, err := gitcmd.Run("stash", "list", "--format=%gd %H")
+	if err != nil {
+		return nil, err
+	}
+	var refs []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		ref, hash, ok := strings.Cut(line, " ")
+		if ok && hash == commit {
+			refs = append(refs, ref)
+		}
+	}
+	return refs, nil
+}
+
+// buildStashMessage は git stash push -m と同じ "On <ブランチ>: <メッセージ>" 形式のメッセージを作成します
+// ブランチが不明な場合はメッセージのみを返します
+func buildStashMessage(branch, message string) string {
+	if branch == "" || branch == "(unknown)" {
+		return message
+	}
+	return fmt.Sprintf("On %s: %s", branch, message)
+}
+
+// promptLine はプロンプトを表示して1行読み込みます
+func promptLine(reader *bufio.Reader, prompt string) string {
+	fmt.Print(prompt)
+	line, _ := reader.ReadString('\n')
+	return strings.TrimSpace(line)
+}
+
+// confirmLine は y/N の確認を行います（Enter は No）
+func confirmLine(reader *bufio.Reader, prompt string) bool {
+	answer := strings.ToLower(promptLine(reader, prompt+" (y/N): "))
+	return answer == "y" || answer == "yes"
+}
+
 // applyStash はスタッシュを適用します（削除しない）
 func applyStash(ref string) error {
 	return gitcmd.RunWithIO("stash", "apply", ref)
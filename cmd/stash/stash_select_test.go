@@ -1,9 +1,15 @@
 package stash
 
 import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/testutil"
 )
 
 // TestStashSelectCmd_CommandSetup はstash-selectコマンドの設定をテストします
@@ -95,3 +101,285 @@ func TestExtractBranch(t *testing.T) {
 		})
 	}
 }
+
+// chdirStashRepo はテスト用リポジトリに移動し、テスト終了時に元のディレクトリに戻します
+func chdirStashRepo(t *testing.T, dir string) {
+	t.Helper()
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(oldDir) })
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+}
+
+// setupSelectRepo は2つの追跡ファイルと1つの untracked ファイルを変更したスタッシュを作成します
+func setupSelectRepo(t *testing.T) *testutil.GitRepo {
+	t.Helper()
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("a.txt", "a1\n")
+	repo.CreateFile("dir/b.txt", "b1\n")
+	repo.Commit("Initial commit")
+
+	repo.CreateFile("a.txt", "a2\n")
+	repo.CreateFile("dir/b.txt", "b2\n")
+	repo.CreateFile("dir/new.txt", "new\n")
+	repo.MustGit("stash", "push", "-u", "-m", "work")
+	chdirStashRepo(t, repo.Dir)
+	return repo
+}
+
+// TestApplyStashFiles は選択したファイルのみが適用されることを確認します
+func TestApplyStashFiles(t *testing.T) {
+	repo := setupSelectRepo(t)
+
+	// サブディレクトリから実行してもリポジトリ全体のパスで適用される
+	chdirStashRepo(t, repo.Path("dir"))
+
+	if err := applyStashFiles("stash@{0}", []string{"a.txt", "dir/new.txt"}); err != nil {
+		t.Fatalf("applyStashFiles returned error: %v", err)
+	}
+
+	if got := repo.ReadFile("a.txt"); got != "a2\n" {
+		t.Errorf("a.txt = %q, want %q", got, "a2\n")
+	}
+	if got := repo.ReadFile("dir/b.txt"); got != "b1\n" {
+		t.Errorf("dir/b.txt = %q, should not be changed", got)
+	}
+	if got := repo.ReadFile("dir/new.txt"); got != "new\n" {
+		t.Errorf("dir/new.txt = %q, want %q", got, "new\n")
+	}
+
+	// スタッシュは残る
+	if stashes, _ := getAllStashesList(); len(stashes) != 1 {
+		t.Errorf("stash should remain, got %d stashes", len(stashes))
+	}
+}
+
+// TestPreviewStashConflicts は競合の事前確認をテストします
+func TestPreviewStashConflicts(t *testing.T) {
+	repo := setupSelectRepo(t)
+
+	conflicts, err := previewStashConflicts("stash@{0}", nil)
+	if err != nil {
+		t.Fatalf("previewStashConflicts returned error: %v", err)
+	}
+	if len(conflicts) != 0 {
+		t.Errorf("clean tree should have no conflicts: %+v", conflicts)
+	}
+
+	// a.txt: 未コミットの変更、dir/new.txt: 既に存在、dir/b.txt: HEAD 側でも変更
+	repo.CreateFile("dir/b.txt", "b-committed\n")
+	repo.Commit("Change b")
+	repo.CreateFile("a.txt", "local\n")
+	repo.CreateFile("dir/new.txt", "exists\n")
+
+	conflicts, err = previewStashConflicts("stash@{0}", nil)
+	if err != nil {
+		t.Fatalf("previewStashConflicts returned error: %v", err)
+	}
+	got := make(map[string]string)
+	for _, c := range conflicts {
+		got[c.Path] = c.Reason
+	}
+	for _, path := range []string{"a.txt", "dir/b.txt", "dir/new.txt"} {
+		if _, ok := got[path]; !ok {
+			t.Errorf("%s should be reported as conflict: %+v", path, conflicts)
+		}
+	}
+
+	// ファイルを指定した場合はそのファイルのみ確認する
+	conflicts, err = previewStashConflicts("stash@{0}", []string{"dir/b.txt"})
+	if err != nil {
+		t.Fatalf("previewStashConflicts returned error: %v", err)
+	}
+	if len(conflicts) != 1 || conflicts[0].Path != "dir/b.txt" {
+		t.Errorf("previewStashConflicts(dir/b.txt) = %+v", conflicts)
+	}
+}
+
+// TestRestoreUntrackedFile_Mode は untracked ファイルの実行権限とシンボリックリンクが復元されることを確認します
+func TestRestoreUntrackedFile_Mode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("ファイルモードとシンボリックリンクは Windows ではテストしない")
+	}
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("a.txt", "a\n")
+	repo.Commit("Initial commit")
+	chdirStashRepo(t, repo.Dir)
+
+	repo.CreateFile("bin/run.sh", "#!/bin/sh\n")
+	if err := os.Chmod(repo.Path("bin/run.sh"), 0755); err != nil {
+		t.Fatalf("Chmod failed: %v", err)
+	}
+	if err := os.Symlink("run.sh", repo.Path("bin/link")); err != nil {
+		t.Fatalf("Symlink failed: %v", err)
+	}
+	repo.MustGit("stash", "push", "-u", "-m", "modes")
+
+	root, err := filepath.EvalSymlinks(repo.Dir)
+	if err != nil {
+		t.Fatalf("EvalSymlinks failed: %v", err)
+	}
+	for _, path := range []string{"bin/run.sh", "bin/link"} {
+		if err := restoreUntrackedFile(root, "stash@{0}", path); err != nil {
+			t.Fatalf("restoreUntrackedFile(%s) returned error: %v", path, err)
+		}
+	}
+
+	info, err := os.Stat(repo.Path("bin/run.sh"))
+	if err != nil || info.Mode().Perm()&0100 == 0 {
+		t.Errorf("bin/run.sh should be executable: %v, %v", info, err)
+	}
+	target, err := os.Readlink(repo.Path("bin/link"))
+	if err != nil || target != "run.sh" {
+		t.Errorf("bin/link = %q, %v; want a symlink to run.sh", target, err)
+	}
+}
+
+// TestRenameStash はスタッシュのメッセージ変更をテストします
+func TestRenameStash(t *testing.T) {
+	repo := setupSelectRepo(t)
+	repo.CreateFile("a.txt", "second\n")
+	repo.StashPush("second")
+
+	stashes, err := getStashList()
+	if err != nil {
+		t.Fatalf("getStashList returned error: %v", err)
+	}
+	// stash@{1} は "work"
+	target := stashes[1]
+	commit := strings.TrimSpace(repo.MustGit("rev-parse", target.Ref))
+
+	newMessage, err := renameStash(target, "renamed")
+	if err != nil {
+		t.Fatalf("renameStash returned error: %v", err)
+	}
+	want := "On " + repo.CurrentBranch() + ": renamed"
+	if newMessage != want {
+		t.Errorf("renameStash() = %q, want %q", newMessage, want)
+	}
+
+	stashes, err = getStashList()
+	if err != nil {
+		t.Fatalf("getStashList returned error: %v", err)
+	}
+	if len(stashes) != 2 {
+		t.Fatalf("stash count = %d, want 2", len(stashes))
+	}
+	if stashes[0].Message != want || stashes[0].Branch != repo.CurrentBranch() {
+		t.Errorf("stash@{0} = %+v, want message %q", stashes[0], want)
+	}
+	if got := strings.TrimSpace(repo.MustGit("rev-parse", "stash@{0}")); got != commit {
+		t.Errorf("stash@{0} commit = %s, want %s", got, commit)
+	}
+	if !strings.HasSuffix(stashes[1].Message, ": second") {
+		t.Errorf("stash@{1} message = %q, want the other stash", stashes[1].Message)
+	}
+}
+
+// TestRenameStash_Top は最新のスタッシュ（stash@{0}）のメッセージ変更をテストします
+func TestRenameStash_Top(t *testing.T) {
+	repo := setupSelectRepo(t)
+	repo.CreateFile("a.txt", "second\n")
+	repo.StashPush("second")
+
+	stashes, err := getStashList()
+	if err != nil {
+		t.Fatalf("getStashList returned error: %v", err)
+	}
+	commit := strings.TrimSpace(repo.MustGit("rev-parse", "stash@{0}"))
+	other := strings.TrimSpace(repo.MustGit("rev-parse", "stash@{1}"))
+
+	if _, err := renameStash(stashes[0], "renamed"); err != nil {
+		t.Fatalf("renameStash returned error: %v", err)
+	}
+
+	stashes, err = getStashList()
+	if err != nil {
+		t.Fatalf("getStashList returned error: %v", err)
+	}
+	if len(stashes) != 2 {
+		t.Fatalf("stash count = %d, want 2", len(stashes))
+	}
+	if want := "On " + repo.CurrentBranch() + ": renamed"; stashes[0].Message != want {
+		t.Errorf("stash@{0} message = %q, want %q", stashes[0].Message, want)
+	}
+	if got := strings.TrimSpace(repo.MustGit("rev-parse", "stash@{0}")); got != commit {
+		t.Errorf("stash@{0} commit = %s, want %s", got, commit)
+	}
+	if got := strings.TrimSpace(repo.MustGit("rev-parse", "stash@{1}")); got != other {
+		t.Errorf("stash@{1} commit = %s, want the other stash %s", got, other)
+	}
+}
+
+// TestBuildStashMessage はスタッシュメッセージの作成をテストします
+func TestBuildStashMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		branch  string
+		message string
+		want    string
+	}{
+		{name: "ブランチあり", branch: "feature/x", message: "msg", want: "On feature/x: msg"},
+		{name: "ブランチ不明", branch: "(unknown)", message: "msg", want: "msg"},
+		{name: "ブランチ空", branch: "", message: "msg", want: "msg"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildStashMessage(tt.branch, tt.message); got != tt.want {
+				t.Errorf("buildStashMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRunStashAction_Files は操作メニューからファイル単位の適用ができることを確認します
+func TestRunStashAction_Files(t *testing.T) {
+	repo := setupSelectRepo(t)
+
+	stashes, err := getStashList()
+	if err != nil {
+		t.Fatalf("getStashList returned error: %v", err)
+	}
+
+	// ファイル一覧はソート順: a.txt, dir/b.txt, dir/new.txt
+	reader := bufio.NewReader(strings.NewReader("f\n2\n"))
+	if err := runStashAction(reader, stashes[0]); err != nil {
+		t.Fatalf("runStashAction returned error: %v", err)
+	}
+
+	if got := repo.ReadFile("dir/b.txt"); got != "b2\n" {
+		t.Errorf("dir/b.txt = %q, want %q", got, "b2\n")
+	}
+	if got := repo.ReadFile("a.txt"); got != "a1\n" {
+		t.Errorf("a.txt = %q, should not be changed", got)
+	}
+}
+
+// TestRunStashAction_Branch は操作メニューから git stash branch を実行できることを確認します
+func TestRunStashAction_Branch(t *testing.T) {
+	repo := setupSelectRepo(t)
+
+	stashes, err := getStashList()
+	if err != nil {
+		t.Fatalf("getStashList returned error: %v", err)
+	}
+
+	reader := bufio.NewReader(strings.NewReader("b\nfrom-stash\n"))
+	if err := runStashAction(reader, stashes[0]); err != nil {
+		t.Fatalf("runStashAction returned error: %v", err)
+	}
+
+	if got := repo.CurrentBranch(); got != "from-stash" {
+		t.Errorf("current branch = %q, want %q", got, "from-stash")
+	}
+	if got := repo.ReadFile("a.txt"); got != "a2\n" {
+		t.Errorf("a.txt = %q, want %q", got, "a2\n")
+	}
+	if stashes, _ := getAllStashesList(); len(stashes) != 0 {
+		t.Errorf("stash should be dropped after stash branch, got %d", len(stashes))
+	}
+}
@@ -0,0 +1,92 @@
+// ================================================================================
+// hotfix.go
+// ================================================================================
+// このファイルは git の拡張コマンド hotfix コマンド（ホットフィックスブランチの運用）を実装しています。
+//
+// 【概要】
+// hotfix start で最新タグから hotfix/<バージョン> を作成し、
+// hotfix finish で release finish と同じ手順（タグの作成、メインブランチと develop への
+// マージ、プッシュ）を実行します。完了処理の共通部分は release_flow.go にあります。
+//
+// 【使用例】
+//   git hotfix start                  # v1.2.3 から hotfix/1.2.4 を作成
+//   git hotfix start 1.2.5            # バージョンを指定
+//   git hotfix finish                 # 現在のホットフィックスブランチを完了
+//   git hotfix finish --continue      # コンフリクト解決後に再開
+// ================================================================================
+
+package tag
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tonbiattack/git-plus/cmd"
+)
+
+// hotfixCmd はホットフィックスブランチを運用するコマンドです。
+var hotfixCmd = &cobra.Command{
+	Use:   "hotfix",
+	Short: "ホットフィックスブランチの作成と完了",
+	Long: `ホットフィックスブランチ（hotfix/<バージョン>）を作成・完了します。
+
+  hotfix start [バージョン]   最新タグから hotfix/<バージョン> を作成（省略時は patch）
+  hotfix finish [ブランチ]    タグを作成し、メインブランチと develop にマージしてプッシュ`,
+}
+
+// hotfixStartCmd はホットフィックスブランチを作成するコマンドです。
+var hotfixStartCmd = &cobra.Command{
+	Use:   "start [バージョン]",
+	Short: "最新タグから hotfix/<バージョン> を作成",
+	Long: `最新タグから hotfix/<バージョン> ブランチを作成して切り替えます。
+バージョンを省略すると最新タグのパッチバージョンを上げます（v1.2.3 → hotfix/1.2.4）。`,
+	Example: `  git hotfix start          # v1.2.3 → hotfix/1.2.4
+  git hotfix start 1.2.5    # hotfix/1.2.5 を作成`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(c *cobra.Command, args []string) error {
+		mainBranch, err := flowMainBranch()
+		if err != nil {
+			return err
+		}
+		latest, err := latestFlowTag(mainBranch)
+		if err != nil {
+			if errors.Is(err, errNoGitTags) {
+				return fmt.Errorf("タグがないため、ホットフィックスを開始できません")
+			}
+			return err
+		}
+
+		arg := "patch"
+		if len(args) > 0 {
+			arg = args[0]
+		}
+		version, err := resolveFlowVersion(arg, mainBranch)
+		if err != nil {
+			return err
+		}
+		return startFlowBranch("hotfix/"+version.String(), latest)
+	},
+}
+
+// hotfixFinishCmd はホットフィックスブランチを完了するコマンドです。
+var hotfixFinishCmd = &cobra.Command{
+	Use:   "finish [ブランチ]",
+	Short: "タグを作成し、メインブランチと develop にマージしてプッシュ",
+	Long: `ホットフィックスブランチを完了します。手順は release finish と同じです。
+コンフリクトが発生した場合は解決後に --continue で再開できます。`,
+	Example: `  git hotfix finish                 # 現在のホットフィックスブランチを完了
+  git hotfix finish --no-push       # プッシュしない
+  git hotfix finish --continue      # コンフリクト解決後に再開`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(c *cobra.Command, args []string) error {
+		return finishFlowBranch("hotfix", args)
+	},
+}
+
+// init はコマンドの初期化を行います。
+func init() {
+	addFlowFinishFlags(hotfixFinishCmd)
+	hotfixCmd.AddCommand(hotfixStartCmd, hotfixFinishCmd)
+	cmd.RootCmd.AddCommand(hotfixCmd)
+}
@@ -0,0 +1,53 @@
+package tag
+
+import (
+	"testing"
+
+	"github.com/tonbiattack/git-plus/cmd"
+)
+
+// TestHotfixCmd_CommandSetup はhotfixコマンドの設定をテストします
+func TestHotfixCmd_CommandSetup(t *testing.T) {
+	names := map[string]bool{}
+	for _, c := range hotfixCmd.Commands() {
+		names[c.Name()] = true
+	}
+	if !names["start"] || !names["finish"] {
+		t.Errorf("hotfix should have start and finish subcommands, got %v", names)
+	}
+
+	for _, name := range []string{"message", "sign", "no-push", "keep-branch", "continue", "abort"} {
+		if hotfixFinishCmd.Flags().Lookup(name) == nil {
+			t.Errorf("Flag %q not found on hotfix finish", name)
+		}
+	}
+
+	found := false
+	for _, c := range cmd.RootCmd.Commands() {
+		if c.Name() == "hotfix" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("hotfix command should be registered to RootCmd")
+	}
+}
+
+// TestHotfixStartCmd はホットフィックスブランチの作成をテストします
+func TestHotfixStartCmd(t *testing.T) {
+	repo := setupReleaseFlowRepo(t)
+	repo.CheckoutBranch("develop")
+
+	if err := hotfixStartCmd.RunE(hotfixStartCmd, nil); err != nil {
+		t.Fatalf("hotfix start error = %v", err)
+	}
+	if got := repo.CurrentBranch(); got != "hotfix/1.2.4" {
+		t.Errorf("current branch = %q, want hotfix/1.2.4", got)
+	}
+	base := repo.MustGit("rev-parse", "HEAD")
+	tag := repo.MustGit("rev-parse", "v1.2.3^{commit}")
+	if base != tag {
+		t.Errorf("hotfix branch should start from v1.2.3")
+	}
+}
@@ -9,17 +9,28 @@
 // 次のバージョンを計算します。
 //
 // 【主な機能】
-// - セマンティックバージョニング（major.minor.patch）のサポート
-// - バージョンタイプの対話的選択（major/minor/patch）
+// - セマンティックバージョニング 2.0（プレリリース・ビルドメタデータを含む）のサポート
+// - バージョンタイプの対話的選択（major/minor/patch/prerelease/release）
 // - バージョンタイプの短縮形と別名のサポート
 //   - major/m/breaking: メジャーバージョンアップ（破壊的変更）
 //   - minor/n/feature/f: マイナーバージョンアップ（機能追加）
 //   - patch/p/bug/b/fix: パッチバージョンアップ（バグ修正）
+//   - premajor/preminor/prepatch: 次のバージョンのプレリリースを開始
+//   - prerelease/pre: プレリリース番号を進める（rc.1 → rc.2）
+//   - release: プレリリースを正式版にする（v1.4.0-rc.2 → v1.4.0）
+//   - auto: 前回のタグ以降の Conventional Commits から推定（new_tag_auto.go）
+// - プレリリース識別子の指定（--preid オプション、デフォルト: rc）
+// - モノレポ向けのプレフィックス付きタグ（--prefix オプション、例: api/v1.2.0）
+// - コンポーネントのパスに変更があるかの確認（--path オプションまたは git config）
 // - タグメッセージの指定（-m オプション）
+// - 署名付きタグの作成（--sign オプション、gpg.format と user.signingkey に従う）
+// - バージョンファイルの更新とリリースコミットの作成（--version-file オプションまたは git config、new_tag_version_files.go）
 // - 作成後の自動プッシュ（--push オプション）
 // - プッシュ後の自動リリース作成（--release オプション）
 // - リリースのドラフト作成（--release-draft オプション）
 // - プレリリース作成（--release-prerelease オプション）
+// - ローカルで生成したリリースノートをリリースの本文に使用（--local-notes オプション）
+// - リリースへのビルド成果物と checksums.txt の添付（--asset オプション）
 // - ドライラン（--dry-run オプション）
 //
 // 【使用例】
@@ -29,13 +40,34 @@
 //   git new-tag major                # 破壊的変更
 //   git new-tag feature --push       # 作成してプッシュ
 //   git new-tag bug -m "Fix issue"   # メッセージ付きで作成
+//   git new-tag minor --sign         # 署名付きタグを作成
+//   git new-tag minor --version-file package.json:json=version  # package.json を更新してからタグを作成
 //   git new-tag minor --dry-run      # 確認のみ
+//   git new-tag preminor             # v1.3.0-rc.0 を作成
+//   git new-tag prerelease           # v1.3.0-rc.0 → v1.3.0-rc.1
+//   git new-tag release              # v1.3.0-rc.1 → v1.3.0
+//   git new-tag auto --dry-run       # コミットから推定したバージョンを表示
+//   git new-tag minor --prefix api/  # api/v1.2.0 → api/v1.3.0
 //   git new-tag feature --push --release              # タグ作成、プッシュ、リリース作成
 //   git new-tag bug --push --release --release-draft  # ドラフトリリースとして作成
+//   git new-tag minor --push --release --local-notes  # release-notes --local と同じ本文で作成
+//   git new-tag minor --push --release --asset 'dist/*'  # dist 配下のファイルを添付
 //
 // 【バージョン形式】
-// - 形式: v<major>.<minor>.<patch>
+// - 形式: v<major>.<minor>.<patch>[-<prerelease>][+<build>]
 // - 例: v1.2.3 → v1.3.0 (minor), v1.2.4 (patch), v2.0.0 (major)
+// - 例: v1.2.3 → v1.3.0-rc.0 (preminor), v1.3.0-rc.0 → v1.3.0-rc.1 (prerelease)
+// - 例: v1.3.0-rc.1 → v1.3.0 (release / minor)
+// - 最新タグは HEAD から到達可能なタグのうち SemVer の優先順位が最も高いものです
+//   （ビルドメタデータは優先順位に影響せず、新しいタグには引き継ぎません）
+//
+// 【プレフィックス付きタグ】
+// - --prefix api/ を指定すると api/ で始まるタグだけを対象に最新タグを探し、
+//   api/v1.3.0 のように同じプレフィックスを付けたタグを作成します
+// - コンポーネントのパスは git config で設定できます（複数指定可）
+//   git config --add gitplus-tag.api/.path services/api
+// - パスが設定されている場合、前回のタグ以降にそのパスを変更したコミットがなければ
+//   警告を表示し、タグを作成するか確認します
 // ================================================================================
 
 package tag
@@ -45,26 +77,36 @@ import (
 	"errors"
 	"fmt"
 	"os/exec"
-	"regexp"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/releaseassets"
+	"github.com/tonbiattack/git-plus/internal/releasenotes"
+	"github.com/tonbiattack/git-plus/internal/semver"
 	"github.com/tonbiattack/git-plus/internal/ui"
+	"github.com/tonbiattack/git-plus/internal/versionfile"
 )
 
 const initialVersionTag = "v0.0.0"
 
 var (
-	tagMessage           string // タグメッセージ（アノテーテッドタグ用）
-	tagPush              bool   // 作成後に自動的にリモートへプッシュするフラグ
-	tagDryRun            bool   // 実際には作成せず、次のバージョンだけを表示するフラグ
-	tagRelease           bool   // プッシュ後に自動的にGitHubリリースを作成するフラグ
-	tagReleaseDraft      bool   // リリースをドラフトとして作成するフラグ
-	tagReleasePrerelease bool   // リリースをプレリリースとして作成するフラグ
-	tagReleaseNote       string // リリースノートに追加する1行（例: 2026-02-08 / PROJ-1234）
+	tagMessage           string   // タグメッセージ（アノテーテッドタグ用）
+	tagPush              bool     // 作成後に自動的にリモートへプッシュするフラグ
+	tagDryRun            bool     // 実際には作成せず、次のバージョンだけを表示するフラグ
+	tagRelease           bool     // プッシュ後に自動的にGitHubリリースを作成するフラグ
+	tagReleaseDraft      bool     // リリースをドラフトとして作成するフラグ
+	tagReleasePrerelease bool     // リリースをプレリリースとして作成するフラグ
+	tagReleaseNote       string   // リリースノートに追加する1行（例: 2026-02-08 / PROJ-1234）
+	tagLocalNotes        bool     // リリースの本文をローカルで生成するフラグ
+	tagSign              bool     // 署名付きタグを作成するフラグ
+	tagPreid             string   // プレリリース識別子（例: rc, beta）
+	tagPrefix            string   // タグの名前空間を表すプレフィックス（例: api/）
+	tagPaths             []string // 変更を確認するコンポーネントのパス
+	tagVersionFiles      []string // バージョンを書き換えるファイルのルール
+	tagAssets            []string // リリースに添付するファイルの glob パターン
+	tagReplaceAssets     bool     // 既存のアセットを置き換えるフラグ
 	errNoGitTags         = errors.New("git repository has no tags")
 )
 
@@ -84,18 +126,29 @@ var newTagCmd = &cobra.Command{
   git new-tag major                # 破壊的変更
   git new-tag feature --push       # 作成してプッシュ
   git new-tag bug -m "Fix issue"   # メッセージ付きで作成
+  git new-tag minor --sign         # 署名付きタグを作成
   git new-tag minor --dry-run      # 確認のみ
+  git new-tag minor --version-file VERSION --version-file package.json:json=version
+  git new-tag preminor             # v1.3.0-rc.0 を作成
+  git new-tag prerelease           # v1.3.0-rc.0 → v1.3.0-rc.1
+  git new-tag premajor --preid beta                 # v2.0.0-beta.0 を作成
+  git new-tag release              # v1.3.0-rc.1 → v1.3.0
+  git new-tag auto                 # コミットから推定（feat → minor, fix → patch）
+  git new-tag auto --dry-run       # 推定したバージョンを表示するのみ
+  git new-tag minor --prefix api/  # api/v1.2.0 → api/v1.3.0
+  git new-tag patch --prefix web/ --path apps/web   # apps/web の変更を確認
   git new-tag feature --push --release              # タグ作成、プッシュ、リリース作成
   git new-tag bug --push --release --release-draft  # ドラフトリリースとして作成
-  git new-tag minor --push --release --release-note "2026-02-08 / PROJ-1234"`,
+  git new-tag minor --push --release --release-note "2026-02-08 / PROJ-1234"
+  git new-tag minor --push --release --asset 'dist/*.tar.gz' --asset 'dist/*.zip'`,
 	RunE: func(c *cobra.Command, args []string) error {
 		// 最新タグを取得
 		hasExistingTag := true
-		currentTag, err := getLatestTag()
+		currentTag, err := getLatestTag(tagPrefix)
 		if err != nil {
 			if errors.Is(err, errNoGitTags) {
-				fmt.Printf("既存のタグが見つからないため、初期タグ %s から新しいタグを計算します。\n", initialVersionTag)
-				currentTag = initialVersionTag
+				currentTag = tagPrefix + initialVersionTag
+				fmt.Printf("既存のタグが見つからないため、初期タグ %s から新しいタグを計算します。\n", currentTag)
 				hasExistingTag = false
 			} else {
 				fmt.Println("エラー: 最新タグの取得に失敗しました")
@@ -104,7 +157,7 @@ var newTagCmd = &cobra.Command{
 		}
 
 		// バージョンを解析
-		major, minor, patch, err := extractVersion(currentTag)
+		current, err := extractVersion(currentTag, tagPrefix)
 		if err != nil {
 			fmt.Printf("エラー: バージョンの解析に失敗: %v\n", err)
 			fmt.Printf("現在のタグ: %s\n", currentTag)
@@ -115,46 +168,122 @@ var newTagCmd = &cobra.Command{
 
 		var versionType string
 
+		// 前回のタグ以降のコミットを対象に推定する
+		previousTag := ""
+		if hasExistingTag {
+			previousTag = currentTag
+		}
+
 		// 引数の解析
 		if len(args) == 0 {
-			// 対話的モード
-			versionType = interactiveVersionSelection(major, minor, patch)
+			// 対話的モード（推定できた場合は推奨として表示）
+			suggested := suggestVersionType(previousTag, componentPaths(tagPrefix))
+			versionType = interactiveVersionSelection(current, tagPrefix, currentTag, tagPreid, suggested)
 		} else {
 			// コマンドライン引数からタイプを取得
 			versionType = normalizeVersionTypeName(args[0])
 			if versionType == "" {
-				return fmt.Errorf("無効なバージョンタイプ: %s\n使用可能なタイプ: major, minor, patch, feature, bug, fix, m, n, p, f, b", args[0])
+				return fmt.Errorf("無効なバージョンタイプ: %s\n使用可能なタイプ: major, minor, patch, premajor, preminor, prepatch, prerelease, release, auto, feature, bug, fix, pre, m, n, p, f, b", args[0])
+			}
+		}
+
+		// auto の場合はコミットから推定
+		if versionType == autoVersionType {
+			versionType, err = resolveAutoVersionType(previousTag, componentPaths(tagPrefix))
+			if err != nil {
+				return err
+			}
+			if versionType == "" {
+				fmt.Println("新しいタグは作成しません")
+				return nil
 			}
 		}
 
 		// 新しいバージョンを計算
-		newMajor, newMinor, newPatch := computeNewVersion(major, minor, patch, versionType)
-		newTag := fmt.Sprintf("v%d.%d.%d", newMajor, newMinor, newPatch)
+		newVersion, err := computeNewVersion(current, versionType, tagPreid)
+		if err != nil {
+			return err
+		}
+		newTag := formatTagName(tagPrefix, currentTag, newVersion)
 		versionTypeDisplay := strings.ToUpper(versionType)
 		resolvedMessage := resolveTagMessage(newTag, tagMessage)
 
 		fmt.Printf("新しいタグ: %s (%s)\n", newTag, versionTypeDisplay)
 		fmt.Printf("メッセージ: %s\n", resolvedMessage)
+		if tagSign {
+			signing, err := loadSigningConfig()
+			if err != nil {
+				return err
+			}
+			fmt.Printf("署名: %s\n", signing.Describe())
+		}
+
+		// バージョンファイルの書き換え内容を計算
+		rules, err := versionFileRules(tagPrefix)
+		if err != nil {
+			return err
+		}
+		var repoRoot string
+		var versionChanges []versionfile.Change
+		if len(rules) > 0 {
+			repoRoot, versionChanges, err = planVersionFiles(rules, newVersion.String())
+			if err != nil {
+				return fmt.Errorf("バージョンファイルの確認に失敗: %w", err)
+			}
+			printVersionFileChanges(versionChanges)
+		}
+
+		// リリースに添付するアセットを確認（タグを作成する前にパターンの誤りを検出する）
+		var assets []releaseassets.Asset
+		if len(tagAssets) > 0 {
+			assets, err = releaseassets.Collect(tagAssets)
+			if err != nil {
+				return fmt.Errorf("アセットの確認に失敗: %w", err)
+			}
+			fmt.Print(releaseassets.Describe(assets))
+		}
 
 		// 直前タグとの差分リンクを表示（GitHubリポジトリの場合のみ）
 		if hasExistingTag {
 			printGitHubCompareLink(currentTag, newTag)
 		}
 
+		// コンポーネントのパスに変更があるかを確認
+		hasComponentChanges := true
+		if hasExistingTag {
+			hasComponentChanges, err = checkComponentChanges(currentTag, componentPaths(tagPrefix))
+			if err != nil {
+				return err
+			}
+		}
+
 		// --dry-run の場合はここで終了
 		if tagDryRun {
 			fmt.Println("(--dry-run のため、タグは作成されません)")
 			return nil
 		}
 
+		if !hasComponentChanges && !ui.Confirm("変更がありませんが、タグを作成しますか？", false) {
+			fmt.Println("キャンセルしました")
+			return nil
+		}
+
 		// 確認プロンプト
 		if !ui.Confirm("タグを作成しますか？", true) {
 			fmt.Println("キャンセルしました")
 			return nil
 		}
 
+		// バージョンファイルを更新してリリースコミットを作成
+		if len(versionChanges) > 0 {
+			if err := commitVersionFiles(repoRoot, versionChanges, newTag, tagSign); err != nil {
+				return fmt.Errorf("リリースコミットの作成に失敗: %w", err)
+			}
+			fmt.Printf("✓ リリースコミットを作成しました: %s\n", releaseCommitMessage(newTag))
+		}
+
 		// タグを作成
-		if err := makeTag(newTag, resolvedMessage); err != nil {
+		if err := makeTag(newTag, resolvedMessage, tagSign); err != nil {
 			return fmt.Errorf("タグの作成に失敗: %w", err)
 		}
 
@@ -168,6 +297,12 @@ var newTagCmd = &cobra.Command{
 		}
 
 		if shouldPush {
+			if len(versionChanges) > 0 {
+				if err := pushReleaseCommit(); err != nil {
+					return fmt.Errorf("リリースコミットのプッシュに失敗: %w", err)
+				}
+				fmt.Println("✓ リリースコミットをプッシュしました")
+			}
 			if err := pushTagToRemote(newTag); err != nil {
 				return fmt.Errorf("タグのプッシュに失敗: %w", err)
 			}
@@ -189,13 +324,33 @@ var newTagCmd = &cobra.Command{
 				}
 
 				fmt.Printf("\nGitHubリリースを作成中...\n")
-				if err := createReleaseFromTag(newTag, tagReleaseDraft, tagReleasePrerelease); err != nil {
+				// プレリリース版のタグは常にプレリリースとして作成する
+				prerelease := tagReleasePrerelease || newVersion.IsPrerelease()
+				var notes string
+				if tagLocalNotes {
+					_, generated, err := releasenotes.Generate("", newTag, "")
+					if err != nil {
+						return fmt.Errorf("リリースノートの生成に失敗: %w", err)
+					}
+					notes = generated
+				}
+				if err := createReleaseFromTag(newTag, tagReleaseDraft, prerelease, notes); err != nil {
 					return fmt.Errorf("リリースの作成に失敗: %w", err)
 				}
 				if err := prependReleaseNoteIfNeeded(newTag, tagReleaseNote); err != nil {
 					return fmt.Errorf("リリースノートの更新に失敗: %w", err)
 				}
 				fmt.Printf("✓ GitHubリリースを作成しました\n")
+				if len(assets) > 0 {
+					results, err := releaseassets.Upload(newTag, assets, tagReplaceAssets)
+					if err != nil {
+						return err
+					}
+					fmt.Println("アセット:")
+					for _, result := range results {
+						fmt.Printf("  %s\n", result)
+					}
+				}
 				fmt.Printf("詳細を確認するには: gh release view %s --web\n", newTag)
 			}
 		}
@@ -206,16 +361,43 @@ var newTagCmd = &cobra.Command{
 
 // getLatestTag は最新のタグを取得します。
 //
+// パラメータ:
+//   - prefix: タグの名前空間を表すプレフィックス（例: api/）。空の場合はプレフィックスなし
+//
 // 戻り値:
-//   - string: 最新のタグ名（例: v1.2.3）
+//   - string: 最新のタグ名（例: v1.2.3、v1.4.0-rc.1、api/v1.2.0）
 //   - error: エラーが発生した場合のエラー情報
+//     （対象のタグが1つもない場合は errNoGitTags）
 //
 // 内部処理:
 //
-//	git describe --tags --abbrev=0 コマンドで最新のタグを取得します。
-func getLatestTag() (string, error) {
+//	git tag --merged HEAD で HEAD から到達可能なタグを取得し、
+//	プレフィックスを除いた部分の SemVer の優先順位が最も高いタグを返します。
+//	プレフィックスなしで SemVer として解析できるタグがない場合は、従来どおり
+//	git describe --tags --abbrev=0 で最も近いタグを返します。
+func getLatestTag(prefix string) (string, error) {
+	output, err := exec.Command("git", "tag", "--merged", "HEAD").Output()
+	if err == nil {
+		if latest := latestSemverTag(strings.Fields(string(output)), prefix); latest != "" {
+			return latest, nil
+		}
+	}
+
+	if prefix != "" {
+		// git describe はプレフィックスを区別できないため使用しない
+		if err != nil {
+			return "", err
+		}
+		for _, tag := range strings.Fields(string(output)) {
+			if strings.HasPrefix(tag, prefix) {
+				return "", fmt.Errorf("プレフィックス %s のタグを SemVer として解析できません: %s", prefix, tag)
+			}
+		}
+		return "", errNoGitTags
+	}
+
 	cmd := exec.Command("git", "describe", "--tags", "--abbrev=0")
-	output, err := cmd.Output()
+	output, err = cmd.Output()
 	if err != nil {
 		if isNoTagsDescribeError(err) {
 			return "", errNoGitTags
@@ -225,6 +407,119 @@ func getLatestTag() (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
+// latestSemverTag はタグ一覧から SemVer の優先順位が最も高いタグを返します。
+//
+// パラメータ:
+//   - tags: タグ名の一覧
+//   - prefix: タグの名前空間を表すプレフィックス（一致しないタグは無視）
+//
+// 戻り値:
+//   - string: 最も新しいタグ（SemVer のタグがない場合は空文字列）
+func latestSemverTag(tags []string, prefix string) string {
+	latest := ""
+	for _, tag := range tags {
+		if _, err := semver.ParseTag(tag, prefix); err != nil {
+			continue
+		}
+		if latest == "" || semver.CompareTagsWithPrefix(tag, latest, prefix) > 0 {
+			latest = tag
+		}
+	}
+	return latest
+}
+
+// formatTagName は新しいバージョンのタグ名を生成します。
+//
+// パラメータ:
+//   - prefix: タグの名前空間を表すプレフィックス
+//   - currentTag: 現在のタグ名（v の有無を引き継ぐために使用）
+//   - version: 新しいバージョン
+//
+// 戻り値:
+//   - string: タグ名（例: v1.3.0、api/v1.3.0、web-2.0.0）
+func formatTagName(prefix, currentTag string, version semver.Version) string {
+	v := ""
+	if strings.HasPrefix(strings.TrimPrefix(currentTag, prefix), "v") {
+		v = "v"
+	}
+	return prefix + v + version.String()
+}
+
+// componentPaths は変更を確認するコンポーネントのパスを返します。
+//
+// パラメータ:
+//   - prefix: タグの名前空間を表すプレフィックス
+//
+// 戻り値:
+//   - []string: --path で指定したパス。未指定の場合は
+//     git config gitplus-tag.<prefix>.path の値（プレフィックスなしの場合は nil）
+func componentPaths(prefix string) []string {
+	if len(tagPaths) > 0 {
+		return tagPaths
+	}
+	if prefix == "" {
+		return nil
+	}
+
+	output, err := exec.Command("git", "config", "--get-all", componentPathConfigKey(prefix)).Output()
+	if err != nil {
+		return nil
+	}
+	return strings.Fields(string(output))
+}
+
+// componentPathConfigKey はコンポーネントのパスを設定する git config のキーを返します。
+func componentPathConfigKey(prefix string) string {
+	return fmt.Sprintf("gitplus-tag.%s.path", prefix)
+}
+
+// checkComponentChanges は前回のタグ以降にコンポーネントのパスが変更されたかを確認します。
+//
+// パラメータ:
+//   - fromTag: 前回のタグ
+//   - paths: コンポーネントのパス（リポジトリのルートからの相対パス）
+//
+// 戻り値:
+//   - bool: 変更がある場合、またはパスが指定されていない場合は true
+//   - error: コミットの取得に失敗した場合のエラー情報
+//
+// 内部処理:
+//
+//	git log <fromTag>..HEAD -- <paths> で対象のコミットを取得し、
+//	件数と先頭のコミットを表示します。
+func checkComponentChanges(fromTag string, paths []string) (bool, error) {
+	if len(paths) == 0 {
+		return true, nil
+	}
+
+	args := []string{"log", "--format=%h %s", fromTag + "..HEAD", "--"}
+	for _, path := range paths {
+		args = append(args, ":(top)"+path)
+	}
+	output, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return false, fmt.Errorf("コンポーネントの変更の確認に失敗しました: %w", err)
+	}
+
+	fmt.Printf("コンポーネントのパス: %s\n", strings.Join(paths, ", "))
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		fmt.Printf("警告: %s 以降、コンポーネントのパスを変更したコミットがありません\n", fromTag)
+		return false, nil
+	}
+
+	fmt.Printf("✓ %s 以降、%d 個のコミットがコンポーネントを変更しています\n", fromTag, len(lines))
+	const maxShown = 5
+	for i, line := range lines {
+		if i == maxShown {
+			fmt.Printf("  ... 他 %d 個\n", len(lines)-maxShown)
+			break
+		}
+		fmt.Printf("  %s\n", line)
+	}
+	return true, nil
+}
+
 // isNoTagsDescribeError は git describe の結果が「タグが存在しない」場合を判定します。
 //
 // パラメータ:
@@ -245,44 +540,22 @@ func isNoTagsDescribeError(err error) bool {
 	return false
 }
 
-// extractVersion はタグからバージョン番号を抽出します。
+// extractVersion はタグからバージョンを抽出します。
 //
 // パラメータ:
-//   - tag: タグ名（例: v1.2.3 または 1.2.3）
+//   - tag: タグ名（例: v1.2.3、1.2.3、v1.4.0-rc.1、1.2.3+build.5、api/v1.2.0）
+//   - prefix: タグの名前空間を表すプレフィックス（例: api/）
 //
 // 戻り値:
-//   - major: メジャーバージョン
-//   - minor: マイナーバージョン
-//   - patch: パッチバージョン
-//   - err: 解析に失敗した場合のエラー情報
+//   - semver.Version: 解析したバージョン
+//   - error: 解析に失敗した場合のエラー情報
 //
 // 内部処理:
 //
-//	正規表現を使用してバージョン番号を抽出します。
+//	プレフィックスを除いた部分を SemVer 2.0 の形式として解析します。
 //	v プレフィックスの有無に対応しています。
-func extractVersion(tag string) (major, minor, patch int, err error) {
-	re := regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)`)
-	matches := re.FindStringSubmatch(tag)
-	if len(matches) != 4 {
-		return 0, 0, 0, fmt.Errorf("無効なバージョン形式: %s", tag)
-	}
-
-	major, err = strconv.Atoi(matches[1])
-	if err != nil {
-		return 0, 0, 0, err
-	}
-
-	minor, err = strconv.Atoi(matches[2])
-	if err != nil {
-		return 0, 0, 0, err
-	}
-
-	patch, err = strconv.Atoi(matches[3])
-	if err != nil {
-		return 0, 0, 0, err
-	}
-
-	return major, minor, patch, nil
+func extractVersion(tag, prefix string) (semver.Version, error) {
+	return semver.ParseTag(tag, prefix)
 }
 
 // normalizeVersionTypeName はバージョンタイプ名を正規化します。
@@ -291,13 +564,18 @@ func extractVersion(tag string) (major, minor, patch int, err error) {
 //   - input: ユーザーが入力したバージョンタイプ
 //
 // 戻り値:
-//   - string: 正規化されたバージョンタイプ（"major", "minor", "patch"）
+//   - string: 正規化されたバージョンタイプ（"major", "minor", "patch",
+//     "premajor", "preminor", "prepatch", "prerelease", "release", "auto"）
 //     無効な入力の場合は空文字列
 //
 // サポートする入力:
 //   - major: major, m, breaking
 //   - minor: minor, n, feature, f
 //   - patch: patch, p, bug, b, fix
+//   - premajor, preminor, prepatch: そのまま
+//   - prerelease: prerelease, pre
+//   - release: release
+//   - auto: auto
 func normalizeVersionTypeName(input string) string {
 	input = strings.ToLower(strings.TrimSpace(input))
 
@@ -308,76 +586,115 @@ func normalizeVersionTypeName(input string) string {
 		return "minor"
 	case "patch", "p", "bug", "b", "fix":
 		return "patch"
+	case "premajor", "preminor", "prepatch", "release", autoVersionType:
+		return input
+	case "prerelease", "pre":
+		return "prerelease"
 	default:
 		return ""
 	}
 }
 
-// computeNewVersion は新しいバージョン番号を計算します。
+// computeNewVersion は新しいバージョンを計算します。
 //
 // パラメータ:
-//   - major: 現在のメジャーバージョン
-//   - minor: 現在のマイナーバージョン
-//   - patch: 現在のパッチバージョン
-//   - versionType: バージョンアップのタイプ（"major", "minor", "patch"）
+//   - current: 現在のバージョン
+//   - versionType: バージョンアップのタイプ（normalizeVersionTypeName の戻り値）
+//   - preid: プレリリース識別子（空の場合は現在の識別子または rc）
 //
 // 戻り値:
-//   - newMajor: 新しいメジャーバージョン
-//   - newMinor: 新しいマイナーバージョン
-//   - newPatch: 新しいパッチバージョン
+//   - semver.Version: 新しいバージョン
+//   - error: 計算できない場合のエラー情報（通常版への release など）
 //
 // ルール:
 //   - major: メジャーを+1、マイナーとパッチを0にリセット
 //   - minor: マイナーを+1、パッチを0にリセット
 //   - patch: パッチを+1
-func computeNewVersion(major, minor, patch int, versionType string) (newMajor, newMinor, newPatch int) {
-	switch versionType {
-	case "major":
-		return major + 1, 0, 0
-	case "minor":
-		return major, minor + 1, 0
-	case "patch":
-		return major, minor, patch + 1
-	default:
-		return major, minor, patch + 1
+//   - 現在がプレリリースで、そのタイプの正式版が未リリースの場合は正式版にする
+//     （v2.0.0-rc.1 に major → v2.0.0）
+//   - premajor/preminor/prepatch: 上記の次バージョンに <preid>.0 を付ける
+//   - prerelease: プレリリース番号を+1（通常版の場合は prepatch と同じ）
+//   - release: プレリリース部分を除去
+//   - 不明なタイプは patch として扱う
+func computeNewVersion(current semver.Version, versionType, preid string) (semver.Version, error) {
+	if normalizeVersionTypeName(versionType) == "" {
+		versionType = "patch"
+	}
+	return semver.Bump(current, versionType, preid)
+}
+
+// versionPreview は選択肢に表示する新しいタグ名を返します（作成できない場合は "-"）。
+// 実際に作成するタグと同じく formatTagName でプレフィックスと v の有無を決めます。
+func versionPreview(current semver.Version, versionType, prefix, currentTag, preid string) string {
+	v, err := computeNewVersion(current, versionType, preid)
+	if err != nil {
+		return "-"
 	}
+	return formatTagName(prefix, currentTag, v)
 }
 
 // interactiveVersionSelection は対話的にバージョンタイプを選択します。
 //
 // パラメータ:
-//   - major: 現在のメジャーバージョン
-//   - minor: 現在のマイナーバージョン
-//   - patch: 現在のパッチバージョン
+//   - current: 現在のバージョン
+//   - prefix: タグの名前空間を表すプレフィックス（新しいタグ名の表示に使用）
+//   - currentTag: 現在のタグ名（v を付けるかどうかの判定に使用）
+//   - preid: プレリリース識別子（選択肢の表示に使用）
+//   - suggested: コミットから推定したバージョンタイプ（推定できない場合は空文字列）
 //
 // 戻り値:
-//   - string: 選択されたバージョンタイプ（"major", "minor", "patch"）
+//   - string: 選択されたバージョンタイプ
 //
 // 内部処理:
 //
 //	各バージョンタイプの説明と新しいバージョンの例を表示し、
-//	ユーザーに選択を促します。無効な選択の場合は patch をデフォルトとします。
-func interactiveVersionSelection(major, minor, patch int) string {
+//	ユーザーに選択を促します。現在のタグがプレリリースの場合のみ
+//	release を選択肢に含めます。推定したタイプには「推奨」と表示し、
+//	何も入力しなかった場合はそのタイプを使用します。
+//	無効な選択の場合は patch をデフォルトとします。
+func interactiveVersionSelection(current semver.Version, prefix, currentTag, preid, suggested string) string {
+	type versionOption struct {
+		versionType string
+		description string
+	}
+	options := []versionOption{
+		{"major", "破壊的変更"},
+		{"minor", "機能追加"},
+		{"patch", "バグ修正"},
+		{"prerelease", "プレリリース"},
+	}
+	if current.IsPrerelease() {
+		options = append(options, versionOption{"release", "正式版にする"})
+	}
+
 	fmt.Println("\n新しいタグのタイプを選択してください:")
-	fmt.Printf("  [1] major   - v%d.0.0 (破壊的変更)\n", major+1)
-	fmt.Printf("  [2] minor   - v%d.%d.0 (機能追加)\n", major, minor+1)
-	fmt.Printf("  [3] patch   - v%d.%d.%d (バグ修正)\n", major, minor, patch+1)
-	fmt.Print("選択 (1-3): ")
+	for i, opt := range options {
+		preview := versionPreview(current, opt.versionType, prefix, currentTag, preid)
+		mark := ""
+		if opt.versionType == suggested {
+			mark = " ← 推奨"
+		}
+		fmt.Printf("  [%d] %-10s - %s (%s)%s\n", i+1, opt.versionType, preview, opt.description, mark)
+	}
+	if suggested != "" {
+		fmt.Printf("選択 (1-%d, Enterで %s): ", len(options), suggested)
+	} else {
+		fmt.Printf("選択 (1-%d): ", len(options))
+	}
 
 	var input string
 	_, _ = fmt.Scanln(&input)
+	if strings.TrimSpace(input) == "" && suggested != "" {
+		return suggested
+	}
 
-	switch ui.NormalizeNumberInput(input) {
-	case "1":
-		return "major"
-	case "2":
-		return "minor"
-	case "3":
-		return "patch"
-	default:
-		fmt.Println("無効な選択です。patch を使用します。")
-		return "patch"
+	for i, opt := range options {
+		if ui.NormalizeNumberInput(input) == fmt.Sprint(i+1) {
+			return opt.versionType
+		}
 	}
+	fmt.Println("無効な選択です。patch を使用します。")
+	return "patch"
 }
 
 // makeTag は指定されたタグをアノテーテッドタグとして作成します。
@@ -385,16 +702,29 @@ func interactiveVersionSelection(major, minor, patch int) string {
 // パラメータ:
 //   - tag: 作成するタグ名（例: v1.2.3）
 //   - message: タグメッセージ（空文字列は不可）
+//   - sign: 署名付きタグを作成するかどうか
 //
 // 戻り値:
-//   - error: タグの作成に失敗した場合のエラー情報
+//   - error: タグの作成に失敗した場合のエラー情報（git の出力を含む）
 //
 // 内部処理:
 //
 //	git tag -a <tag> -m <message> でアノテーテッドタグを作成します。
-func makeTag(tag, message string) error {
-	cmd := exec.Command("git", "tag", "-a", tag, "-m", message)
-	return cmd.Run()
+//	sign が true の場合は git tag -s で作成し、署名の形式と鍵は git の設定
+//	（gpg.format、user.signingkey）に従います。
+func makeTag(tag, message string, sign bool) error {
+	flag := "-a"
+	if sign {
+		flag = "-s"
+	}
+	output, err := exec.Command("git", "tag", flag, tag, "-m", message).CombinedOutput()
+	if err != nil {
+		if text := strings.TrimSpace(string(output)); text != "" {
+			return fmt.Errorf("%w\n%s", err, text)
+		}
+		return err
+	}
+	return nil
 }
 
 // pushTagToRemote は指定されたタグをリモートリポジトリにプッシュします。
@@ -426,6 +756,7 @@ func checkGitHubCLIInstalled() bool {
 //   - tag: リリースを作成するタグ
 //   - draft: ドラフトとして作成するかどうか
 //   - prerelease: プレリリースとして作成するかどうか
+//   - notes: リリースの本文（空の場合は自動生成）
 //
 // 戻り値:
 //   - error: エラーが発生した場合のエラー情報
@@ -433,9 +764,15 @@ func checkGitHubCLIInstalled() bool {
 // 内部処理:
 //
 //	gh release create コマンドで GitHub リリースを作成します。
-//	--generate-notes オプションで自動的にリリースノートを生成します。
-func createReleaseFromTag(tag string, draft, prerelease bool) error {
-	args := []string{"release", "create", tag, "--generate-notes"}
+//	本文が指定されている場合は --notes-file - で標準入力から渡し、
+//	指定されていない場合は --generate-notes オプションで自動的にリリースノートを生成します。
+func createReleaseFromTag(tag string, draft, prerelease bool, notes string) error {
+	args := []string{"release", "create", tag}
+	if notes != "" {
+		args = append(args, "--notes-file", "-")
+	} else {
+		args = append(args, "--generate-notes")
+	}
 
 	if draft {
 		args = append(args, "--draft")
@@ -445,6 +782,9 @@ func createReleaseFromTag(tag string, draft, prerelease bool) error {
 	}
 
 	cmd := exec.Command("gh", args...)
+	if notes != "" {
+		cmd.Stdin = strings.NewReader(notes)
+	}
 
 	// 出力をキャプチャして表示
 	var stdout, stderr bytes.Buffer
@@ -620,6 +960,10 @@ func parseGitHubOriginURL(originURL string) (string, string, error) {
 //	--release: プッシュ後に自動的にGitHubリリースを作成
 //	--release-draft: リリースをドラフトとして作成
 //	--release-prerelease: リリースをプレリリースとして作成
+//	--preid: プレリリース識別子（premajor/preminor/prepatch/prerelease で使用）
+//	--prefix: タグの名前空間を表すプレフィックス（例: api/）
+//	--path: 変更を確認するコンポーネントのパス（複数指定可）
+//	--version-file: バージョンを書き換えるファイルのルール（複数指定可）
 func init() {
 	newTagCmd.Flags().StringVarP(&tagMessage, "message", "m", "", "タグメッセージを指定（未指定時はデフォルトメッセージ）")
 	newTagCmd.Flags().BoolVarP(&tagPush, "push", "p", false, "作成後に自動的にリモートへプッシュ")
@@ -627,6 +971,14 @@ func init() {
 	newTagCmd.Flags().BoolVarP(&tagRelease, "release", "r", false, "プッシュ後に自動的にGitHubリリースを作成")
 	newTagCmd.Flags().BoolVarP(&tagReleaseDraft, "release-draft", "D", false, "リリースをドラフトとして作成")
 	newTagCmd.Flags().BoolVarP(&tagReleasePrerelease, "release-prerelease", "P", false, "リリースをプレリリースとして作成")
+	newTagCmd.Flags().StringVar(&tagPreid, "preid", "", "プレリリース識別子（例: rc, beta。未指定時は現在の識別子または rc）")
+	newTagCmd.Flags().StringVar(&tagPrefix, "prefix", "", "タグの名前空間を表すプレフィックス（例: api/）")
+	newTagCmd.Flags().StringArrayVar(&tagPaths, "path", nil, "前回のタグ以降に変更があるかを確認するパス（複数指定可）")
 	newTagCmd.Flags().StringVar(&tagReleaseNote, "release-note", "", "リリースノートに追加する1行（例: 2026-02-08 / PROJ-1234）")
+	newTagCmd.Flags().BoolVar(&tagLocalNotes, "local-notes", false, "リリースの本文をローカルで生成（release-notes --local と同じ内容）")
+	newTagCmd.Flags().StringArrayVar(&tagVersionFiles, "version-file", nil, "バージョンを書き換えるファイルのルール（例: VERSION, package.json:json=version。複数指定可）")
+	newTagCmd.Flags().BoolVarP(&tagSign, "sign", "s", false, "署名付きタグを作成（gpg.format と user.signingkey に従う）")
+	newTagCmd.Flags().StringArrayVar(&tagAssets, "asset", nil, "リリースに添付するファイルの glob パターン（例: 'dist/*.tar.gz'。複数指定可、checksums.txt を自動生成）")
+	newTagCmd.Flags().BoolVar(&tagReplaceAssets, "replace-assets", false, "同じ名前のアセットが既にある場合に置き換える（未指定時はスキップ）")
 	cmd.RootCmd.AddCommand(newTagCmd)
 }
@@ -0,0 +1,209 @@
+// ================================================================================
+// new_tag_auto.go
+// ================================================================================
+// このファイルは new-tag auto（コミットからのバージョンタイプの推定）を実装しています。
+//
+// 【概要】
+// 前回のタグから HEAD までのコミットを Conventional Commits として解析し、
+// 次のバージョンで上げるべき部分（major/minor/patch）を推定します。
+//
+// 【推定ルール】
+// - BREAKING CHANGE フッター、または type! の ! がある: major
+// - feat: minor
+// - fix, perf: patch
+// - その他（docs, chore など）や規約に沿わないコミット: バージョンアップの対象外
+// 最も大きいバージョンアップを採用し、その決定に使用したコミットを表示します。
+//
+// 【使用例】
+//   git new-tag auto                  # コミットから推定してタグを作成
+//   git new-tag auto --dry-run        # 推定したバージョンを表示するのみ（CI 向け）
+//   git new-tag auto --prefix api/    # api/ の前回のタグ以降、コンポーネントのパスのコミットから推定
+// ================================================================================
+
+package tag
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/tonbiattack/git-plus/internal/conventional"
+)
+
+// autoVersionType は new-tag auto を表すバージョンタイプです。
+const autoVersionType = "auto"
+
+// bumpRank はバージョンタイプの大きさ（大きいほど影響が大きい）です。
+var bumpRank = map[string]int{
+	"":      0,
+	"patch": 1,
+	"minor": 2,
+	"major": 3,
+}
+
+// bumpCommit はバージョンタイプの推定に使用したコミット1件を表す構造体です。
+type bumpCommit struct {
+	Hash    string // コミットハッシュ
+	Subject string // コミットメッセージの1行目
+	Bump    string // このコミットが必要とするバージョンアップ（major/minor/patch、対象外は空文字列）
+}
+
+// inferVersionType はコミット一覧からバージョンタイプを推定します。
+//
+// パラメータ:
+//   - commits: 前回のタグ以降のコミット
+//
+// 戻り値:
+//   - string: 推定したバージョンタイプ（対象のコミットがない場合は空文字列）
+//   - []bumpCommit: 決定に使用したコミット（推定したタイプと同じバージョンアップを必要とするもの）
+func inferVersionType(commits []bumpCommit) (string, []bumpCommit) {
+	versionType := ""
+	for _, c := range commits {
+		if bumpRank[c.Bump] > bumpRank[versionType] {
+			versionType = c.Bump
+		}
+	}
+	if versionType == "" {
+		return "", nil
+	}
+
+	var drivers []bumpCommit
+	for _, c := range commits {
+		if c.Bump == versionType {
+			drivers = append(drivers, c)
+		}
+	}
+	return versionType, drivers
+}
+
+// classifyCommit はコミットメッセージが必要とするバージョンアップを判定します。
+//
+// パラメータ:
+//   - message: コミットメッセージ全体
+//
+// 戻り値:
+//   - string: major, minor, patch のいずれか（対象外の場合は空文字列）
+func classifyCommit(message string) string {
+	if conventional.IsExempt(message) {
+		return ""
+	}
+	commit, err := conventional.Parse(message)
+	if err != nil {
+		return ""
+	}
+
+	switch {
+	case commit.Breaking:
+		return "major"
+	case commit.Type == "feat":
+		return "minor"
+	case commit.Type == "fix" || commit.Type == "perf":
+		return "patch"
+	default:
+		return ""
+	}
+}
+
+// getCommitsSinceTag は前回のタグから HEAD までのコミットを取得します。
+//
+// パラメータ:
+//   - fromTag: 前回のタグ（空の場合は HEAD までの全コミット）
+//   - paths: 対象とするパス（空の場合はすべてのコミット）
+//
+// 戻り値:
+//   - []bumpCommit: コミット一覧（新しい順、マージコミットを除く）
+//   - error: git log の実行に失敗した場合のエラー情報
+//
+// 内部処理:
+//
+//	git log --no-merges --format=%H%x00%B%x1e <fromTag>..HEAD -- <paths> を実行し、
+//	レコード区切り \x1e とフィールド区切り \x00 で複数行のメッセージを分割します。
+func getCommitsSinceTag(fromTag string, paths []string) ([]bumpCommit, error) {
+	revRange := "HEAD"
+	if fromTag != "" {
+		revRange = fromTag + "..HEAD"
+	}
+	args := []string{"log", "--no-merges", "--format=%H%x00%B%x1e", revRange, "--"}
+	for _, path := range paths {
+		args = append(args, ":(top)"+path)
+	}
+
+	output, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("コミットの取得に失敗しました: %w", err)
+	}
+
+	var commits []bumpCommit
+	for _, record := range bytes.Split(output, []byte{0x1e}) {
+		rec := strings.TrimLeft(string(record), "\n")
+		parts := strings.SplitN(rec, "\x00", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		message := strings.TrimSpace(parts[1])
+		commits = append(commits, bumpCommit{
+			Hash:    strings.TrimSpace(parts[0]),
+			Subject: strings.SplitN(message, "\n", 2)[0],
+			Bump:    classifyCommit(message),
+		})
+	}
+	return commits, nil
+}
+
+// resolveAutoVersionType は前回のタグ以降のコミットからバージョンタイプを推定し、結果を表示します。
+//
+// パラメータ:
+//   - fromTag: 前回のタグ（タグがない場合は空文字列）
+//   - paths: 対象とするコンポーネントのパス
+//
+// 戻り値:
+//   - string: 推定したバージョンタイプ（対象のコミットがない場合は空文字列）
+//   - error: コミットの取得に失敗した場合のエラー情報
+func resolveAutoVersionType(fromTag string, paths []string) (string, error) {
+	commits, err := getCommitsSinceTag(fromTag, paths)
+	if err != nil {
+		return "", err
+	}
+
+	versionType, drivers := inferVersionType(commits)
+	if versionType == "" {
+		fmt.Printf("%d 個のコミットにバージョンアップが必要なもの（feat, fix, perf, BREAKING CHANGE）がありません\n", len(commits))
+		return "", nil
+	}
+
+	fmt.Printf("コミットから推定したバージョンタイプ: %s (%d 個のコミット中)\n", strings.ToUpper(versionType), len(commits))
+	fmt.Println("決定に使用したコミット:")
+	for _, c := range drivers {
+		fmt.Printf("  %s %s\n", shortHash(c.Hash), c.Subject)
+	}
+	return versionType, nil
+}
+
+// suggestVersionType は対話的モードで推奨として表示するバージョンタイプを推定します。
+// 推定は補助的なものなので、コミットの取得に失敗しても警告を表示して空文字列を返し、
+// 推定の詳細（決定に使用したコミット）は表示しません。
+//
+// パラメータ:
+//   - fromTag: 前回のタグ（タグがない場合は空文字列）
+//   - paths: 対象とするコンポーネントのパス
+//
+// 戻り値:
+//   - string: 推定したバージョンタイプ（推定できない場合は空文字列）
+func suggestVersionType(fromTag string, paths []string) string {
+	commits, err := getCommitsSinceTag(fromTag, paths)
+	if err != nil {
+		fmt.Printf("警告: コミットからバージョンタイプを推定できませんでした: %v\n", err)
+		return ""
+	}
+	versionType, _ := inferVersionType(commits)
+	return versionType
+}
+
+// shortHash はコミットハッシュの先頭7文字を返します。
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
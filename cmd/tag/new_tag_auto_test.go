@@ -0,0 +1,183 @@
+package tag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tonbiattack/git-plus/internal/testutil"
+)
+
+// TestClassifyCommit はコミットメッセージごとのバージョンアップの判定をテストします
+func TestClassifyCommit(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected string
+	}{
+		{name: "feat", message: "feat: 検索を追加", expected: "minor"},
+		{name: "スコープ付き feat", message: "feat(api): 検索を追加", expected: "minor"},
+		{name: "fix", message: "fix: タイムアウトを修正", expected: "patch"},
+		{name: "perf", message: "perf: キャッシュを追加", expected: "patch"},
+		{name: "! 付き", message: "refactor(core)!: 設定形式を変更", expected: "major"},
+		{name: "BREAKING CHANGE フッター", message: "feat: 新しい設定\n\nBREAKING CHANGE: 旧形式は使えません", expected: "major"},
+		{name: "docs", message: "docs: README を更新", expected: ""},
+		{name: "chore", message: "chore: 依存関係を更新", expected: ""},
+		{name: "規約外", message: "Update README", expected: ""},
+		{name: "fixup", message: "fixup! feat: 検索を追加", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyCommit(tt.message); got != tt.expected {
+				t.Errorf("classifyCommit(%q) = %q, want %q", tt.message, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestInferVersionType は最も大きいバージョンアップと決定に使用したコミットをテストします
+func TestInferVersionType(t *testing.T) {
+	tests := []struct {
+		name            string
+		bumps           []string
+		expected        string
+		expectedDrivers int
+	}{
+		{name: "fix のみ", bumps: []string{"patch", "", "patch"}, expected: "patch", expectedDrivers: 2},
+		{name: "feat と fix", bumps: []string{"patch", "minor", "patch"}, expected: "minor", expectedDrivers: 1},
+		{name: "破壊的変更", bumps: []string{"minor", "major", "patch", "major"}, expected: "major", expectedDrivers: 2},
+		{name: "対象外のみ", bumps: []string{"", ""}, expected: "", expectedDrivers: 0},
+		{name: "コミットなし", bumps: nil, expected: "", expectedDrivers: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commits := make([]bumpCommit, len(tt.bumps))
+			for i, b := range tt.bumps {
+				commits[i] = bumpCommit{Hash: strings.Repeat("a", 40), Subject: "subject", Bump: b}
+			}
+
+			got, drivers := inferVersionType(commits)
+			if got != tt.expected {
+				t.Errorf("inferVersionType = %q, want %q", got, tt.expected)
+			}
+			if len(drivers) != tt.expectedDrivers {
+				t.Errorf("drivers = %d, want %d", len(drivers), tt.expectedDrivers)
+			}
+			for _, d := range drivers {
+				if d.Bump != tt.expected {
+					t.Errorf("driver bump = %q, want %q", d.Bump, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+// TestGetCommitsSinceTag は前回のタグ以降のコミットを取得できることを確認します
+func TestGetCommitsSinceTag(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("README.md", "# Test")
+	repo.Commit("feat: 最初の機能")
+	repo.CreateLightweightTag("v1.0.0")
+
+	repo.CreateFile("services/api/main.go", "package main")
+	repo.Commit("fix(api): バグを修正\n\n詳細な説明")
+	repo.CreateFile("docs/guide.md", "# Guide")
+	repo.Commit("feat!: 新しいガイド")
+	chdirTagRepo(t, repo.Dir)
+
+	commits, err := getCommitsSinceTag("v1.0.0", nil)
+	if err != nil {
+		t.Fatalf("getCommitsSinceTag returned error: %v", err)
+	}
+	if len(commits) != 2 {
+		t.Fatalf("got %d commits, want 2", len(commits))
+	}
+	if commits[0].Subject != "feat!: 新しいガイド" || commits[0].Bump != "major" {
+		t.Errorf("commits[0] = %+v", commits[0])
+	}
+	if commits[1].Subject != "fix(api): バグを修正" || commits[1].Bump != "patch" {
+		t.Errorf("commits[1] = %+v", commits[1])
+	}
+	if len(commits[0].Hash) != 40 {
+		t.Errorf("Hash = %q, want full hash", commits[0].Hash)
+	}
+
+	// パスを指定するとそのパスを変更したコミットのみ
+	commits, err = getCommitsSinceTag("v1.0.0", []string{"services/api"})
+	if err != nil {
+		t.Fatalf("getCommitsSinceTag returned error: %v", err)
+	}
+	if len(commits) != 1 || commits[0].Bump != "patch" {
+		t.Errorf("commits with path = %+v", commits)
+	}
+
+	// タグがない場合は全コミット
+	commits, err = getCommitsSinceTag("", nil)
+	if err != nil {
+		t.Fatalf("getCommitsSinceTag returned error: %v", err)
+	}
+	if len(commits) != 3 {
+		t.Errorf("got %d commits, want 3", len(commits))
+	}
+}
+
+// TestSuggestVersionType は推奨のバージョンタイプの推定が失敗しても処理を止めないことを確認します
+func TestSuggestVersionType(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("README.md", "# Test")
+	repo.Commit("chore: 初期化")
+	repo.CreateLightweightTag("v1.0.0")
+	repo.CreateFile("a.txt", "a")
+	repo.Commit("feat: 機能を追加")
+	chdirTagRepo(t, repo.Dir)
+
+	if got := suggestVersionType("v1.0.0", nil); got != "minor" {
+		t.Errorf("suggestVersionType() = %q, want minor", got)
+	}
+
+	// コミットを取得できない場合はエラーにせず、推奨なしにする
+	if got := suggestVersionType("no-such-tag", nil); got != "" {
+		t.Errorf("suggestVersionType() with unknown tag = %q, want empty", got)
+	}
+}
+
+// TestNewTagCmd_AutoDryRun は new-tag auto --dry-run でタグが作成されないことを確認します
+func TestNewTagCmd_AutoDryRun(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("README.md", "# Test")
+	repo.Commit("Initial commit")
+	repo.CreateTag("v1.2.3", "Release v1.2.3")
+	repo.CreateFile("search.go", "package main")
+	repo.Commit("feat: 検索を追加")
+	chdirTagRepo(t, repo.Dir)
+
+	tagDryRun = true
+	defer func() { tagDryRun = false }()
+
+	if err := newTagCmd.RunE(newTagCmd, []string{"auto"}); err != nil {
+		t.Fatalf("RunE returned error: %v", err)
+	}
+	if strings.TrimSpace(repo.MustGit("tag", "-l", "v1.3.0")) != "" {
+		t.Error("--dry-run should not create a tag")
+	}
+}
+
+// TestResolveAutoVersionType はコミットがない場合に空文字列を返すことを確認します
+func TestResolveAutoVersionType(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("README.md", "# Test")
+	repo.Commit("Initial commit")
+	repo.CreateLightweightTag("v1.0.0")
+	repo.CreateFile("README.md", "# Test\n")
+	repo.Commit("docs: README を更新")
+	chdirTagRepo(t, repo.Dir)
+
+	got, err := resolveAutoVersionType("v1.0.0", nil)
+	if err != nil {
+		t.Fatalf("resolveAutoVersionType returned error: %v", err)
+	}
+	if got != "" {
+		t.Errorf("resolveAutoVersionType = %q, want empty", got)
+	}
+}
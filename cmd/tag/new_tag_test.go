@@ -2,10 +2,13 @@ package tag
 
 import (
 	"errors"
+	"os"
 	"os/exec"
 	"testing"
 
 	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/semver"
+	"github.com/tonbiattack/git-plus/internal/testutil"
 )
 
 // TestNewTagCmdDefinition はnew-tagコマンドの定義をテストします
@@ -49,6 +52,11 @@ func TestNewTagCmdFlags(t *testing.T) {
 		{"release-draft flag", "release-draft", "D"},
 		{"release-prerelease flag", "release-prerelease", "P"},
 		{"release-note flag", "release-note", ""},
+		{"local-notes flag", "local-notes", ""},
+		{"sign flag", "sign", "s"},
+		{"version-file flag", "version-file", ""},
+		{"asset flag", "asset", ""},
+		{"replace-assets flag", "replace-assets", ""},
 	}
 
 	for _, tt := range tests {
@@ -108,11 +116,32 @@ func TestExtractVersion(t *testing.T) {
 			expectedPatch: 1,
 			expectError:   false,
 		},
+		{
+			name:          "プレリリース",
+			tag:           "v1.4.0-rc.1",
+			expectedMajor: 1,
+			expectedMinor: 4,
+			expectedPatch: 0,
+			expectError:   false,
+		},
+		{
+			name:          "ビルドメタデータ",
+			tag:           "1.2.3+build.5",
+			expectedMajor: 1,
+			expectedMinor: 2,
+			expectedPatch: 3,
+			expectError:   false,
+		},
 		{
 			name:        "無効な形式",
 			tag:         "invalid",
 			expectError: true,
 		},
+		{
+			name:        "先頭ゼロ",
+			tag:         "v01.2.3",
+			expectError: true,
+		},
 		{
 			name:        "不完全なバージョン",
 			tag:         "v1.2",
@@ -127,7 +156,7 @@ func TestExtractVersion(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			major, minor, patch, err := extractVersion(tt.tag)
+			version, err := extractVersion(tt.tag, "")
 
 			if tt.expectError {
 				if err == nil {
@@ -141,16 +170,16 @@ func TestExtractVersion(t *testing.T) {
 				return
 			}
 
-			if major != tt.expectedMajor {
-				t.Errorf("major = %d, want %d", major, tt.expectedMajor)
+			if version.Major != tt.expectedMajor {
+				t.Errorf("major = %d, want %d", version.Major, tt.expectedMajor)
 			}
 
-			if minor != tt.expectedMinor {
-				t.Errorf("minor = %d, want %d", minor, tt.expectedMinor)
+			if version.Minor != tt.expectedMinor {
+				t.Errorf("minor = %d, want %d", version.Minor, tt.expectedMinor)
 			}
 
-			if patch != tt.expectedPatch {
-				t.Errorf("patch = %d, want %d", patch, tt.expectedPatch)
+			if version.Patch != tt.expectedPatch {
+				t.Errorf("patch = %d, want %d", version.Patch, tt.expectedPatch)
 			}
 		})
 	}
@@ -192,6 +221,16 @@ func TestNormalizeVersionTypeName(t *testing.T) {
 		{"B", "B", "patch"},
 		{"FIX", "FIX", "patch"},
 
+		// プレリリース関連
+		{"premajor", "premajor", "premajor"},
+		{"preminor", "preminor", "preminor"},
+		{"prepatch", "prepatch", "prepatch"},
+		{"prerelease", "prerelease", "prerelease"},
+		{"pre", "pre", "prerelease"},
+		{"release", "release", "release"},
+		{"RELEASE", "RELEASE", "release"},
+		{"auto", "auto", "auto"},
+
 		// 無効な入力
 		{"invalid", "invalid", ""},
 		{"empty", "", ""},
@@ -216,91 +255,77 @@ func TestNormalizeVersionTypeName(t *testing.T) {
 // TestComputeNewVersion はcomputeNewVersion関数をテストします
 func TestComputeNewVersion(t *testing.T) {
 	tests := []struct {
-		name          string
-		major         int
-		minor         int
-		patch         int
-		versionType   string
-		expectedMajor int
-		expectedMinor int
-		expectedPatch int
+		name        string
+		current     string
+		versionType string
+		preid       string
+		expected    string
 	}{
-		{
-			name:          "major update",
-			major:         1,
-			minor:         2,
-			patch:         3,
-			versionType:   "major",
-			expectedMajor: 2,
-			expectedMinor: 0,
-			expectedPatch: 0,
-		},
-		{
-			name:          "minor update",
-			major:         1,
-			minor:         2,
-			patch:         3,
-			versionType:   "minor",
-			expectedMajor: 1,
-			expectedMinor: 3,
-			expectedPatch: 0,
-		},
-		{
-			name:          "patch update",
-			major:         1,
-			minor:         2,
-			patch:         3,
-			versionType:   "patch",
-			expectedMajor: 1,
-			expectedMinor: 2,
-			expectedPatch: 4,
-		},
-		{
-			name:          "unknown type defaults to patch",
-			major:         1,
-			minor:         2,
-			patch:         3,
-			versionType:   "unknown",
-			expectedMajor: 1,
-			expectedMinor: 2,
-			expectedPatch: 4,
-		},
-		{
-			name:          "major from zero",
-			major:         0,
-			minor:         0,
-			patch:         1,
-			versionType:   "major",
-			expectedMajor: 1,
-			expectedMinor: 0,
-			expectedPatch: 0,
-		},
-		{
-			name:          "minor from zero patch",
-			major:         1,
-			minor:         0,
-			patch:         0,
-			versionType:   "minor",
-			expectedMajor: 1,
-			expectedMinor: 1,
-			expectedPatch: 0,
-		},
+		{name: "major update", current: "v1.2.3", versionType: "major", expected: "2.0.0"},
+		{name: "minor update", current: "v1.2.3", versionType: "minor", expected: "1.3.0"},
+		{name: "patch update", current: "v1.2.3", versionType: "patch", expected: "1.2.4"},
+		{name: "unknown type defaults to patch", current: "v1.2.3", versionType: "unknown", expected: "1.2.4"},
+		{name: "major from zero", current: "v0.0.1", versionType: "major", expected: "1.0.0"},
+		{name: "minor from zero patch", current: "v1.0.0", versionType: "minor", expected: "1.1.0"},
+		{name: "build metadata is dropped", current: "v1.2.3+build.5", versionType: "patch", expected: "1.2.4"},
+		{name: "premajor with preid", current: "v1.2.3", versionType: "premajor", preid: "rc", expected: "2.0.0-rc.0"},
+		{name: "preminor default preid", current: "v1.2.3", versionType: "preminor", expected: "1.3.0-rc.0"},
+		{name: "prepatch with beta", current: "v1.2.3", versionType: "prepatch", preid: "beta", expected: "1.2.4-beta.0"},
+		{name: "prerelease increments", current: "v1.4.0-rc.1", versionType: "prerelease", expected: "1.4.0-rc.2"},
+		{name: "prerelease switches preid", current: "v1.4.0-beta.2", versionType: "prerelease", preid: "rc", expected: "1.4.0-rc.0"},
+		{name: "release promotes rc", current: "v1.4.0-rc.2", versionType: "release", expected: "1.4.0"},
+		{name: "minor on minor rc promotes", current: "v1.4.0-rc.2", versionType: "minor", expected: "1.4.0"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			newMajor, newMinor, newPatch := computeNewVersion(tt.major, tt.minor, tt.patch, tt.versionType)
+			current, err := extractVersion(tt.current, "")
+			if err != nil {
+				t.Fatalf("extractVersion(%q) returned error: %v", tt.current, err)
+			}
 
-			if newMajor != tt.expectedMajor {
-				t.Errorf("newMajor = %d, want %d", newMajor, tt.expectedMajor)
+			newVersion, err := computeNewVersion(current, tt.versionType, tt.preid)
+			if err != nil {
+				t.Fatalf("computeNewVersion returned error: %v", err)
 			}
 
-			if newMinor != tt.expectedMinor {
-				t.Errorf("newMinor = %d, want %d", newMinor, tt.expectedMinor)
+			if newVersion.String() != tt.expected {
+				t.Errorf("computeNewVersion(%s, %s, %q) = %s, want %s", tt.current, tt.versionType, tt.preid, newVersion, tt.expected)
 			}
+		})
+	}
+}
+
+// TestComputeNewVersion_ReleaseWithoutPrerelease は正式版への release がエラーになることを確認します
+func TestComputeNewVersion_ReleaseWithoutPrerelease(t *testing.T) {
+	current, _ := extractVersion("v1.2.3", "")
+	if _, err := computeNewVersion(current, "release", ""); err == nil {
+		t.Error("Expected error for release on non-prerelease version")
+	}
+}
+
+// TestLatestSemverTag は最新タグの選択をテストします
+func TestLatestSemverTag(t *testing.T) {
+	tests := []struct {
+		name     string
+		tags     []string
+		prefix   string
+		expected string
+	}{
+		{name: "正式版がプレリリースより新しい", tags: []string{"v1.4.0-rc.1", "v1.4.0", "v1.3.9"}, expected: "v1.4.0"},
+		{name: "数値の識別子を数値として比較", tags: []string{"v1.4.0-rc.2", "v1.4.0-rc.10"}, expected: "v1.4.0-rc.10"},
+		{name: "SemVer でないタグは無視", tags: []string{"latest", "v1.2.3", "release-2024"}, expected: "v1.2.3"},
+		{name: "SemVer のタグなし", tags: []string{"latest"}, expected: ""},
+		{name: "タグなし", tags: nil, expected: ""},
+		{name: "プレフィックスなしでは名前空間付きタグを無視", tags: []string{"api/v9.0.0", "v1.0.0"}, expected: "v1.0.0"},
+		{name: "名前空間内の最新", tags: []string{"api/v1.2.0", "web/v3.0.1", "api/v1.10.0", "v5.0.0"}, prefix: "api/", expected: "api/v1.10.0"},
+		{name: "名前空間にタグなし", tags: []string{"web/v3.0.1", "v5.0.0"}, prefix: "api/", expected: ""},
+	}
 
-			if newPatch != tt.expectedPatch {
-				t.Errorf("newPatch = %d, want %d", newPatch, tt.expectedPatch)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := latestSemverTag(tt.tags, tt.prefix); got != tt.expected {
+				t.Errorf("latestSemverTag(%v, %q) = %q, want %q", tt.tags, tt.prefix, got, tt.expected)
 			}
 		})
 	}
@@ -562,3 +587,161 @@ func TestResolveReleaseNote(t *testing.T) {
 		t.Error("resolveReleaseNote(empty) should not be empty")
 	}
 }
+
+// chdirTagRepo はテスト用リポジトリに移動し、終了時に元のディレクトリへ戻します
+func chdirTagRepo(t *testing.T, dir string) {
+	t.Helper()
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(oldDir) })
+
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+}
+
+// TestFormatTagName はタグ名の生成をテストします
+func TestFormatTagName(t *testing.T) {
+	version, _ := semver.Parse("1.3.0-rc.0")
+
+	tests := []struct {
+		name       string
+		prefix     string
+		currentTag string
+		expected   string
+	}{
+		{name: "v付き", prefix: "", currentTag: "v1.2.3", expected: "v1.3.0-rc.0"},
+		{name: "vなしを引き継ぐ", prefix: "", currentTag: "1.2.3", expected: "1.3.0-rc.0"},
+		{name: "名前空間付き", prefix: "api/", currentTag: "api/v1.2.0", expected: "api/v1.3.0-rc.0"},
+		{name: "名前空間付きでvなし", prefix: "web-", currentTag: "web-1.2.0", expected: "web-1.3.0-rc.0"},
+		{name: "初期タグ", prefix: "api/", currentTag: "api/" + initialVersionTag, expected: "api/v1.3.0-rc.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatTagName(tt.prefix, tt.currentTag, version); got != tt.expected {
+				t.Errorf("formatTagName(%q, %q) = %q, want %q", tt.prefix, tt.currentTag, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestVersionPreview は選択肢の表示が実際に作成するタグ名と一致することを確認します
+func TestVersionPreview(t *testing.T) {
+	current, _ := semver.Parse("1.2.3")
+
+	tests := []struct {
+		name        string
+		versionType string
+		prefix      string
+		currentTag  string
+		expected    string
+	}{
+		{name: "v付き", versionType: "minor", prefix: "", currentTag: "v1.2.3", expected: "v1.3.0"},
+		{name: "vなしを引き継ぐ", versionType: "patch", prefix: "", currentTag: "1.2.3", expected: "1.2.4"},
+		{name: "名前空間付き", versionType: "major", prefix: "api/", currentTag: "api/v1.2.3", expected: "api/v2.0.0"},
+		{name: "作成できない", versionType: "release", prefix: "", currentTag: "v1.2.3", expected: "-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := versionPreview(current, tt.versionType, tt.prefix, tt.currentTag, ""); got != tt.expected {
+				t.Errorf("versionPreview(%q, %q, %q) = %q, want %q", tt.versionType, tt.prefix, tt.currentTag, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestGetLatestTag_Prefix は名前空間ごとに最新タグを取得できることを確認します
+func TestGetLatestTag_Prefix(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("README.md", "# Test")
+	repo.Commit("Initial commit")
+	repo.CreateLightweightTag("api/v1.2.0")
+	repo.CreateLightweightTag("v3.0.0")
+
+	repo.CreateFile("web/index.html", "<html></html>")
+	repo.Commit("Add web")
+	repo.CreateLightweightTag("web/v3.0.1")
+	chdirTagRepo(t, repo.Dir)
+
+	tests := []struct {
+		prefix   string
+		expected string
+	}{
+		{prefix: "", expected: "v3.0.0"},
+		{prefix: "api/", expected: "api/v1.2.0"},
+		{prefix: "web/", expected: "web/v3.0.1"},
+	}
+	for _, tt := range tests {
+		got, err := getLatestTag(tt.prefix)
+		if err != nil {
+			t.Fatalf("getLatestTag(%q) returned error: %v", tt.prefix, err)
+		}
+		if got != tt.expected {
+			t.Errorf("getLatestTag(%q) = %q, want %q", tt.prefix, got, tt.expected)
+		}
+	}
+
+	if _, err := getLatestTag("cli/"); !errors.Is(err, errNoGitTags) {
+		t.Errorf("getLatestTag(cli/) error = %v, want errNoGitTags", err)
+	}
+}
+
+// TestComponentPaths はコンポーネントのパスの取得をテストします
+func TestComponentPaths(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.MustGit("config", "--add", componentPathConfigKey("api/"), "services/api")
+	repo.MustGit("config", "--add", componentPathConfigKey("api/"), "libs/shared")
+	chdirTagRepo(t, repo.Dir)
+
+	if got := componentPaths("api/"); len(got) != 2 || got[0] != "services/api" || got[1] != "libs/shared" {
+		t.Errorf("componentPaths(api/) = %v", got)
+	}
+	if got := componentPaths("web/"); len(got) != 0 {
+		t.Errorf("componentPaths(web/) = %v, want empty", got)
+	}
+
+	tagPaths = []string{"apps/web"}
+	defer func() { tagPaths = nil }()
+	if got := componentPaths("api/"); len(got) != 1 || got[0] != "apps/web" {
+		t.Errorf("componentPaths with --path = %v", got)
+	}
+}
+
+// TestCheckComponentChanges は前回のタグ以降のパスの変更を確認できることをテストします
+func TestCheckComponentChanges(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("services/api/main.go", "package main")
+	repo.CreateFile("apps/web/index.html", "<html></html>")
+	repo.Commit("Initial commit")
+	repo.CreateLightweightTag("api/v1.0.0")
+
+	repo.CreateFile("apps/web/index.html", "<html>v2</html>")
+	repo.Commit("Update web")
+	chdirTagRepo(t, repo.Path("apps"))
+
+	changed, err := checkComponentChanges("api/v1.0.0", []string{"services/api"})
+	if err != nil {
+		t.Fatalf("checkComponentChanges returned error: %v", err)
+	}
+	if changed {
+		t.Error("services/api should have no changes")
+	}
+
+	changed, err = checkComponentChanges("api/v1.0.0", []string{"services/api", "apps/web"})
+	if err != nil {
+		t.Fatalf("checkComponentChanges returned error: %v", err)
+	}
+	if !changed {
+		t.Error("apps/web should have changes")
+	}
+
+	changed, err = checkComponentChanges("api/v1.0.0", nil)
+	if err != nil || !changed {
+		t.Errorf("checkComponentChanges without paths = %v, %v; want true, nil", changed, err)
+	}
+}
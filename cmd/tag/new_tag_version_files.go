@@ -0,0 +1,181 @@
+// ================================================================================
+// new_tag_version_files.go
+// ================================================================================
+// このファイルは new-tag のバージョンファイルの更新を実装しています。
+//
+// 【概要】
+// VERSION・package.json・Chart.yaml・Go の const Version などに書かれたバージョンを
+// 新しいバージョンに書き換え、リリースコミット（chore(release): <タグ>）を作成してから、
+// そのコミットにタグを付けます。--dry-run では書き換えの差分を表示するのみです。
+//
+// 【ルールの設定】
+// --version-file（複数指定可）、または git config で設定します（複数指定可）。
+//   git config --add gitplus-tag.versionfile VERSION
+//   git config --add gitplus-tag.versionfile package.json:json=version
+//   git config --add gitplus-tag.versionfile 'version.go:regex=Version = "([^"]+)"'
+//   git config --add gitplus-tag.api/.versionfile services/api/Chart.yaml:yaml=appVersion
+// プレフィックス付きタグの場合は gitplus-tag.<prefix>.versionfile を使用します。
+// ルールの形式は internal/versionfile を参照してください。
+//
+// 【書き込むバージョン】
+// プレフィックスと v を除いたバージョン（例: api/v1.3.0 → 1.3.0）を書き込みます。
+// ================================================================================
+
+package tag
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/tonbiattack/git-plus/internal/gitcmd"
+	"github.com/tonbiattack/git-plus/internal/versionfile"
+)
+
+// versionFileRules はバージョンファイルのルールを返します。
+//
+// パラメータ:
+//   - prefix: タグの名前空間を表すプレフィックス
+//
+// 戻り値:
+//   - []versionfile.Rule: --version-file で指定したルール。未指定の場合は
+//     git config gitplus-tag[.<prefix>].versionfile の値（未設定の場合は nil）
+//   - error: ルールの形式が正しくない場合のエラー
+func versionFileRules(prefix string) ([]versionfile.Rule, error) {
+	specs := tagVersionFiles
+	if len(specs) == 0 {
+		output, err := exec.Command("git", "config", "--get-all", versionFileConfigKey(prefix)).Output()
+		if err != nil {
+			return nil, nil
+		}
+		// 正規表現に空白を含められるように、1行を1つのルールとする
+		for _, line := range strings.Split(string(output), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				specs = append(specs, line)
+			}
+		}
+	}
+	return versionfile.ParseRules(specs)
+}
+
+// versionFileConfigKey はバージョンファイルのルールを設定する git config のキーを返します。
+func versionFileConfigKey(prefix string) string {
+	if prefix == "" {
+		return "gitplus-tag.versionfile"
+	}
+	return fmt.Sprintf("gitplus-tag.%s.versionfile", prefix)
+}
+
+// planVersionFiles はバージョンファイルの書き換え内容を計算します。
+//
+// パラメータ:
+//   - rules: バージョンファイルのルール（パスはリポジトリのルートからの相対パス）
+//   - version: 書き込むバージョン（例: 1.3.0）
+//
+// 戻り値:
+//   - string: リポジトリのルートディレクトリ
+//   - []versionfile.Change: 内容が変わるファイルの一覧
+//   - error: ファイルの読み込みに失敗した場合や、ルールに一致しない場合のエラー
+func planVersionFiles(rules []versionfile.Rule, version string) (string, []versionfile.Change, error) {
+	output, err := gitcmd.Run("rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", nil, fmt.Errorf("リポジトリのルートの取得に失敗しました: %w", err)
+	}
+	root := strings.TrimSpace(string(output))
+
+	changes, err := versionfile.Plan(root, rules, version)
+	if err != nil {
+		return "", nil, err
+	}
+	return root, changes, nil
+}
+
+// printVersionFileChanges はバージョンファイルの書き換えの差分を表示します
+func printVersionFileChanges(changes []versionfile.Change) {
+	if len(changes) == 0 {
+		fmt.Println("バージョンファイル: すべて新しいバージョンです（リリースコミットは作成しません）")
+		return
+	}
+	fmt.Printf("バージョンファイル: %d 個を更新します\n", len(changes))
+	for _, change := range changes {
+		fmt.Print(change.Diff())
+	}
+}
+
+// commitVersionFiles はバージョンファイルを書き換えてリリースコミットを作成します。
+//
+// パラメータ:
+//   - root: リポジトリのルートディレクトリ
+//   - changes: planVersionFiles で計算した書き換え内容
+//   - tag: 作成するタグ（コミットメッセージに使用）
+//   - sign: コミットに署名するかどうか（new-tag --sign）
+//
+// 戻り値:
+//   - error: 対象のファイルに未コミットの変更がある場合や、コミットに失敗した場合のエラー
+//
+// 内部処理:
+//
+//	対象のファイル以外の変更をコミットに含めないよう、git commit -- <パス> で
+//	対象のファイルだけをコミットします。コミットに失敗した場合（署名やフックの失敗）は
+//	書き換えたファイルを元の内容に戻すため、再実行（release finish --continue など）で
+//	同じ書き換えとコミットをやり直せます。
+func commitVersionFiles(root string, changes []versionfile.Change, tag string, sign bool) error {
+	paths := make([]string, 0, len(changes))
+	for _, change := range changes {
+		paths = append(paths, change.Path)
+	}
+
+	statusArgs := append([]string{"-C", root, "status", "--porcelain", "--"}, paths...)
+	status, err := gitcmd.Run(statusArgs...)
+	if err != nil {
+		return fmt.Errorf("作業ツリーの確認に失敗しました: %w", err)
+	}
+	if text := strings.TrimSpace(string(status)); text != "" {
+		return fmt.Errorf("バージョンファイルに未コミットの変更があります。コミットまたはスタッシュしてから実行してください:\n%s", text)
+	}
+
+	if err := versionfile.Apply(root, changes); err != nil {
+		return err
+	}
+
+	args := []string{"-C", root, "commit", "-m", releaseCommitMessage(tag)}
+	if sign {
+		args = append(args, "-S")
+	}
+	args = append(args, "--")
+	args = append(args, paths...)
+	if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+		commitErr := fmt.Errorf("%w\n%s", err, strings.TrimSpace(string(output)))
+		if rollbackErr := rollbackVersionFiles(root, changes); rollbackErr != nil {
+			return fmt.Errorf("%w\nバージョンファイルを元に戻せませんでした: %v", commitErr, rollbackErr)
+		}
+		return commitErr
+	}
+	return nil
+}
+
+// rollbackVersionFiles は書き換えたバージョンファイルを書き換え前の内容に戻します
+func rollbackVersionFiles(root string, changes []versionfile.Change) error {
+	reverted := make([]versionfile.Change, 0, len(changes))
+	paths := make([]string, 0, len(changes))
+	for _, change := range changes {
+		reverted = append(reverted, versionfile.Change{Path: change.Path, Before: change.After, After: change.Before})
+		paths = append(paths, change.Path)
+	}
+	if err := versionfile.Apply(root, reverted); err != nil {
+		return err
+	}
+	// コミットの途中でインデックスに追加された場合に備えて、インデックスも HEAD に戻す
+	resetArgs := append([]string{"-C", root, "reset", "-q", "--"}, paths...)
+	return gitcmd.RunQuiet(resetArgs...)
+}
+
+// releaseCommitMessage はリリースコミットのメッセージを返します
+func releaseCommitMessage(tag string) string {
+	return "chore(release): " + tag
+}
+
+// pushReleaseCommit はリリースコミットを作成したブランチをリモートにプッシュします
+func pushReleaseCommit() error {
+	return exec.Command("git", "push", "origin", "HEAD").Run()
+}
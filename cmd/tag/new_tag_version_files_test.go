@@ -0,0 +1,116 @@
+package tag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tonbiattack/git-plus/internal/testutil"
+	"github.com/tonbiattack/git-plus/internal/versionfile"
+)
+
+// setupVersionFileRepo はバージョンファイルを含むテスト用リポジトリを作成します
+func setupVersionFileRepo(t *testing.T) *testutil.GitRepo {
+	t.Helper()
+
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("VERSION", "1.2.3\n")
+	repo.CreateFile("package.json", "{\n  \"name\": \"app\",\n  \"version\": \"1.2.3\"\n}\n")
+	repo.Commit("Initial commit")
+	repo.CreateTag("v1.2.3", "v1.2.3")
+	chdirTagRepo(t, repo.Dir)
+	return repo
+}
+
+// TestVersionFileConfigKey はバージョンファイルの git config のキーをテストします
+func TestVersionFileConfigKey(t *testing.T) {
+	if got := versionFileConfigKey(""); got != "gitplus-tag.versionfile" {
+		t.Errorf("versionFileConfigKey(\"\") = %q", got)
+	}
+	if got := versionFileConfigKey("api/"); got != "gitplus-tag.api/.versionfile" {
+		t.Errorf("versionFileConfigKey(\"api/\") = %q", got)
+	}
+}
+
+// TestVersionFileRules はバージョンファイルのルールの取得をテストします
+func TestVersionFileRules(t *testing.T) {
+	repo := setupVersionFileRepo(t)
+
+	rules, err := versionFileRules("")
+	if err != nil || rules != nil {
+		t.Fatalf("versionFileRules() = %v, %v, want nil", rules, err)
+	}
+
+	repo.MustGit("config", "--add", "gitplus-tag.versionfile", "VERSION")
+	repo.MustGit("config", "--add", "gitplus-tag.versionfile", `version.go:regex=Version = "([^"]+)"`)
+	rules, err = versionFileRules("")
+	if err != nil {
+		t.Fatalf("versionFileRules() error = %v", err)
+	}
+	want := []versionfile.Rule{
+		{Path: "VERSION", Kind: versionfile.KindFile},
+		{Path: "version.go", Kind: versionfile.KindRegex, Expr: `Version = "([^"]+)"`},
+	}
+	if len(rules) != len(want) || rules[0] != want[0] || rules[1] != want[1] {
+		t.Errorf("versionFileRules() = %+v, want %+v", rules, want)
+	}
+
+	// --version-file は git config より優先する
+	tagVersionFiles = []string{"package.json:json=version"}
+	t.Cleanup(func() { tagVersionFiles = nil })
+	rules, err = versionFileRules("")
+	if err != nil {
+		t.Fatalf("versionFileRules() error = %v", err)
+	}
+	if len(rules) != 1 || rules[0].Path != "package.json" {
+		t.Errorf("versionFileRules() = %+v", rules)
+	}
+}
+
+// TestCommitVersionFiles はバージョンファイルの更新とリリースコミットの作成をテストします
+func TestCommitVersionFiles(t *testing.T) {
+	repo := setupVersionFileRepo(t)
+	repo.CreateFile("untracked.txt", "not committed")
+
+	rules, _ := versionfile.ParseRules([]string{"VERSION", "package.json:json=version"})
+	root, changes, err := planVersionFiles(rules, "1.3.0")
+	if err != nil {
+		t.Fatalf("planVersionFiles() error = %v", err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("len(changes) = %d, want 2", len(changes))
+	}
+
+	if err := commitVersionFiles(root, changes, "v1.3.0", false); err != nil {
+		t.Fatalf("commitVersionFiles() error = %v", err)
+	}
+
+	if subject := strings.TrimSpace(repo.MustGit("log", "-1", "--format=%s")); subject != "chore(release): v1.3.0" {
+		t.Errorf("commit subject = %q", subject)
+	}
+	files := strings.Fields(repo.MustGit("show", "--name-only", "--format=", "HEAD"))
+	if strings.Join(files, " ") != "VERSION package.json" {
+		t.Errorf("committed files = %v, want VERSION and package.json only", files)
+	}
+	if got := repo.ReadFile("VERSION"); got != "1.3.0\n" {
+		t.Errorf("VERSION = %q", got)
+	}
+}
+
+// TestCommitVersionFiles_Dirty は未コミットの変更があるバージョンファイルを拒否することをテストします
+func TestCommitVersionFiles_Dirty(t *testing.T) {
+	repo := setupVersionFileRepo(t)
+	repo.CreateFile("VERSION", "1.2.4\n")
+
+	rules, _ := versionfile.ParseRules([]string{"VERSION"})
+	root, changes, err := planVersionFiles(rules, "1.3.0")
+	if err != nil {
+		t.Fatalf("planVersionFiles() error = %v", err)
+	}
+
+	if err := commitVersionFiles(root, changes, "v1.3.0", false); err == nil {
+		t.Fatal("commitVersionFiles() should fail when VERSION has uncommitted changes")
+	}
+	if got := repo.ReadFile("VERSION"); got != "1.2.4\n" {
+		t.Errorf("VERSION should not be rewritten, got %q", got)
+	}
+}
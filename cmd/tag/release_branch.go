@@ -0,0 +1,225 @@
+// ================================================================================
+// release_branch.go
+// ================================================================================
+// このファイルは git の拡張コマンド release コマンド（リリースブランチの運用）を実装しています。
+//
+// 【概要】
+// release start でメインブランチから release/<バージョン> を作成し、
+// release finish で new-tag と同じ方法でタグを作成して、メインブランチと develop に
+// マージしてプッシュします。完了処理の共通部分は release_flow.go にあります。
+//
+// 【使用例】
+//   git release start 1.3.0            # main から release/1.3.0 を作成
+//   git release start minor            # 最新タグから次のマイナーバージョンを計算
+//   git release finish                 # 現在のリリースブランチを完了
+//   git release finish --continue      # コンフリクト解決後に再開
+//   git release finish --abort         # 完了処理を中止
+// ================================================================================
+
+package tag
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/gitcmd"
+	"github.com/tonbiattack/git-plus/internal/semver"
+)
+
+var (
+	flowMessage    string // タグメッセージ
+	flowSign       bool   // 署名付きタグを作成するフラグ
+	flowNoPush     bool   // プッシュしないフラグ
+	flowKeepBranch bool   // リリースブランチを残すフラグ
+	flowContinue   bool   // コンフリクト解決後に再開するフラグ
+	flowAbort      bool   // 完了処理を中止するフラグ
+)
+
+// releaseCmd はリリースブランチを運用するコマンドです。
+var releaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "リリースブランチの作成と完了",
+	Long: `リリースブランチ（release/<バージョン>）を作成・完了します。
+
+  release start <バージョン>  メインブランチから release/<バージョン> を作成
+  release finish [ブランチ]   タグを作成し、メインブランチと develop にマージしてプッシュ`,
+}
+
+// releaseStartCmd はリリースブランチを作成するコマンドです。
+var releaseStartCmd = &cobra.Command{
+	Use:   "start <バージョン>",
+	Short: "メインブランチから release/<バージョン> を作成",
+	Long: `メインブランチから release/<バージョン> ブランチを作成して切り替えます。
+バージョンには 1.3.0 のような値か、major/minor/patch（最新タグから計算）を指定できます。`,
+	Example: `  git release start 1.3.0    # release/1.3.0 を作成
+  git release start minor    # v1.2.3 → release/1.3.0`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(c *cobra.Command, args []string) error {
+		mainBranch, err := flowMainBranch()
+		if err != nil {
+			return err
+		}
+		version, err := resolveFlowVersion(args[0], mainBranch)
+		if err != nil {
+			return err
+		}
+		return startFlowBranch("release/"+version.String(), mainBranch)
+	},
+}
+
+// releaseFinishCmd はリリースブランチを完了するコマンドです。
+var releaseFinishCmd = &cobra.Command{
+	Use:   "finish [ブランチ]",
+	Short: "タグを作成し、メインブランチと develop にマージしてプッシュ",
+	Long: `リリースブランチを完了します。
+
+  1. バージョンファイルを更新してリリースコミットを作成（new-tag と同じルール）
+  2. メインブランチに --no-ff でマージ
+  3. マージコミットにタグを作成
+  4. develop に --no-ff でマージ（develop がない場合は省略）
+  5. メインブランチ・develop・タグをプッシュ
+  6. リリースブランチを削除
+
+コンフリクトが発生した場合は解決後に --continue で再開できます。`,
+	Example: `  git release finish                  # 現在のリリースブランチを完了
+  git release finish release/1.3.0    # ブランチを指定
+  git release finish --sign           # 署名付きタグを作成
+  git release finish --continue       # コンフリクト解決後に再開`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(c *cobra.Command, args []string) error {
+		return finishFlowBranch("release", args)
+	},
+}
+
+// resolveFlowVersion はリリースのバージョンを決定します。
+//
+// パラメータ:
+//   - arg: バージョン（例: 1.3.0、v1.3.0）またはバージョンタイプ（major/minor/patch など）
+//   - mainBranch: メインブランチ（このブランチの最新タグを基準にする）
+//
+// 戻り値:
+//   - semver.Version: リリースのバージョン
+//   - error: バージョンを解析できない場合や、最新タグ以下のバージョンの場合のエラー
+func resolveFlowVersion(arg, mainBranch string) (semver.Version, error) {
+	latest, err := latestFlowTag(mainBranch)
+	if err != nil {
+		if !errors.Is(err, errNoGitTags) {
+			return semver.Version{}, err
+		}
+		latest = initialVersionTag
+	}
+	current, err := extractVersion(latest, "")
+	if err != nil {
+		return semver.Version{}, fmt.Errorf("最新タグ %s を解析できません: %w", latest, err)
+	}
+
+	if versionType := normalizeVersionTypeName(arg); versionType != "" && versionType != autoVersionType {
+		return computeNewVersion(current, versionType, "")
+	}
+
+	version, err := semver.Parse(arg)
+	if err != nil {
+		return semver.Version{}, fmt.Errorf("無効なバージョン: %s（例: 1.3.0 または major/minor/patch）", arg)
+	}
+	if semver.Compare(version, current) <= 0 {
+		return semver.Version{}, fmt.Errorf("バージョン %s は最新タグ %s より新しくありません", version, latest)
+	}
+	return version, nil
+}
+
+// startFlowBranch はリリースブランチまたはホットフィックスブランチを作成して切り替えます
+//
+// パラメータ:
+//   - branch: 作成するブランチ（例: release/1.3.0）
+//   - base: 作成元（ブランチまたはタグ）
+func startFlowBranch(branch, base string) error {
+	if localBranchExists(branch) {
+		return fmt.Errorf("ブランチ %s は既に存在します", branch)
+	}
+	if err := gitcmd.RunQuiet("switch", "-c", branch, base); err != nil {
+		return fmt.Errorf("ブランチ %s の作成に失敗しました: %w", branch, err)
+	}
+
+	kind, _, _ := strings.Cut(branch, "/")
+	fmt.Printf("✓ %s から %s を作成しました\n", base, branch)
+	fmt.Printf("完了したら git %s finish を実行してください\n", kind)
+	return nil
+}
+
+// finishFlowBranch は release finish と hotfix finish の共通処理です。
+//
+// パラメータ:
+//   - kind: release または hotfix
+//   - args: コマンドライン引数（ブランチ名、省略時は現在のブランチ）
+func finishFlowBranch(kind string, args []string) error {
+	if flowContinue {
+		return continueReleaseFlow(kind)
+	}
+	if flowAbort {
+		return abortReleaseFlow(kind)
+	}
+
+	if state, err := loadReleaseFlowState(); err != nil {
+		return err
+	} else if state != nil {
+		return fmt.Errorf("%s の完了処理が中断しています。git %s finish --continue または --abort を実行してください", state.Branch, state.Kind)
+	}
+
+	branch := ""
+	if len(args) > 0 {
+		branch = args[0]
+	} else {
+		output, err := gitcmd.Run("branch", "--show-current")
+		if err != nil {
+			return fmt.Errorf("現在のブランチの取得に失敗しました: %w", err)
+		}
+		branch = strings.TrimSpace(string(output))
+	}
+	if !strings.Contains(branch, "/") {
+		branch = kind + "/" + branch
+	}
+
+	status, err := gitcmd.Run("status", "--porcelain", "--untracked-files=no")
+	if err != nil {
+		return fmt.Errorf("作業ツリーの確認に失敗しました: %w", err)
+	}
+	if strings.TrimSpace(string(status)) != "" {
+		return fmt.Errorf("未コミットの変更があります。コミットまたはスタッシュしてから実行してください")
+	}
+
+	state, err := newReleaseFlowState(kind, branch)
+	if err != nil {
+		return err
+	}
+	state.Message = flowMessage
+	state.Sign = flowSign
+	state.Push = !flowNoPush
+	state.KeepBranch = flowKeepBranch
+
+	targets := state.Main
+	if state.Develop != "" {
+		targets += ", " + state.Develop
+	}
+	fmt.Printf("%s を完了します（タグ: %s、マージ先: %s）\n", state.Branch, state.Tag, targets)
+	return runReleaseFlow(state)
+}
+
+// addFlowFinishFlags は finish コマンドのフラグを設定します（release と hotfix で共通）
+func addFlowFinishFlags(c *cobra.Command) {
+	c.Flags().StringVarP(&flowMessage, "message", "m", "", "タグメッセージを指定（未指定時は Release <タグ>）")
+	c.Flags().BoolVarP(&flowSign, "sign", "s", false, "署名付きタグを作成（gpg.format と user.signingkey に従う）")
+	c.Flags().BoolVar(&flowNoPush, "no-push", false, "リモートにプッシュしない")
+	c.Flags().BoolVar(&flowKeepBranch, "keep-branch", false, "完了後もブランチを削除しない")
+	c.Flags().BoolVar(&flowContinue, "continue", false, "コンフリクト解決後に完了処理を再開")
+	c.Flags().BoolVar(&flowAbort, "abort", false, "中断している完了処理を中止")
+}
+
+// init はコマンドの初期化を行います。
+func init() {
+	addFlowFinishFlags(releaseFinishCmd)
+	releaseCmd.AddCommand(releaseStartCmd, releaseFinishCmd)
+	cmd.RootCmd.AddCommand(releaseCmd)
+}
@@ -0,0 +1,92 @@
+package tag
+
+import (
+	"testing"
+
+	"github.com/tonbiattack/git-plus/cmd"
+)
+
+// TestReleaseCmd_CommandSetup はreleaseコマンドの設定をテストします
+func TestReleaseCmd_CommandSetup(t *testing.T) {
+	names := map[string]bool{}
+	for _, c := range releaseCmd.Commands() {
+		names[c.Name()] = true
+	}
+	if !names["start"] || !names["finish"] {
+		t.Errorf("release should have start and finish subcommands, got %v", names)
+	}
+
+	for _, name := range []string{"message", "sign", "no-push", "keep-branch", "continue", "abort"} {
+		if releaseFinishCmd.Flags().Lookup(name) == nil {
+			t.Errorf("Flag %q not found on release finish", name)
+		}
+	}
+
+	found := false
+	for _, c := range cmd.RootCmd.Commands() {
+		if c.Name() == "release" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("release command should be registered to RootCmd")
+	}
+}
+
+// TestResolveFlowVersion はリリースのバージョンの決定をテストします
+func TestResolveFlowVersion(t *testing.T) {
+	repo := setupReleaseFlowRepo(t)
+	// develop にだけあるタグは基準にしない
+	repo.CheckoutBranch("develop")
+	repo.CreateFile("dev.txt", "dev")
+	repo.Commit("dev")
+	repo.CreateTag("v9.0.0", "v9.0.0")
+
+	tests := []struct {
+		arg     string
+		want    string
+		wantErr bool
+	}{
+		{arg: "minor", want: "1.3.0"},
+		{arg: "patch", want: "1.2.4"},
+		{arg: "major", want: "2.0.0"},
+		{arg: "1.5.0", want: "1.5.0"},
+		{arg: "v1.4.0", want: "1.4.0"},
+		{arg: "1.2.3", wantErr: true},
+		{arg: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			got, err := resolveFlowVersion(tt.arg, "main")
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("resolveFlowVersion(%q) should fail", tt.arg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveFlowVersion(%q) error = %v", tt.arg, err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("resolveFlowVersion(%q) = %s, want %s", tt.arg, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestStartFlowBranch はリリースブランチの作成をテストします
+func TestStartFlowBranch(t *testing.T) {
+	repo := setupReleaseFlowRepo(t)
+
+	if err := startFlowBranch("release/1.3.0", "main"); err != nil {
+		t.Fatalf("startFlowBranch() error = %v", err)
+	}
+	if got := repo.CurrentBranch(); got != "release/1.3.0" {
+		t.Errorf("current branch = %q, want release/1.3.0", got)
+	}
+	if err := startFlowBranch("release/1.3.0", "main"); err == nil {
+		t.Error("startFlowBranch() should fail for an existing branch")
+	}
+}
@@ -0,0 +1,484 @@
+// ================================================================================
+// release_flow.go - リリースブランチとホットフィックスの完了処理
+// ================================================================================
+// release finish と hotfix finish で共通の処理（手順の実行と進行状況の保存）を実装しています。
+//
+// 【完了処理の手順】
+//  1. bump:          バージョンファイルを更新してリリースコミットを作成（new-tag と同じルール）
+//  2. merge-main:    メインブランチに --no-ff でマージ
+//  3. tag:           マージコミットにタグを作成（new-tag と同じメッセージ・署名）
+//  4. merge-develop: develop ブランチに --no-ff でマージ（develop がない場合は省略）
+//  5. push:          メインブランチ・develop・タグをプッシュ（origin がない場合や --no-push の場合は省略）
+//  6. cleanup:       リリースブランチを削除（--keep-branch の場合は省略）
+//
+// 【再開】
+// 各手順の前に進行状況を .git/git-plus/release-flow.json に保存します。
+// マージでコンフリクトが発生した場合は処理を中断し、解決後に --continue で
+// 中断した手順から再開できます。各手順は完了済みであれば何もしないため、
+// 同じ手順を再実行しても二重にマージやタグ付けは行いません。
+// --abort は進行中のマージを中止して進行状況を削除します（完了済みの手順は元に戻しません）。
+//
+// 【ブランチの設定】
+// - メインブランチ: git config gitplus-flow.main、未設定の場合は origin/HEAD、main、master の順に検出
+// - develop ブランチ: git config gitplus-flow.develop、未設定の場合は develop
+// ================================================================================
+
+package tag
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tonbiattack/git-plus/internal/gitcmd"
+	"github.com/tonbiattack/git-plus/internal/semver"
+)
+
+// releaseFlowStateFile は進行状況を保存するファイル（.git からの相対パス）です
+const releaseFlowStateFile = "git-plus/release-flow.json"
+
+// errReleaseFlowConflict はマージでコンフリクトが発生したことを表します
+var errReleaseFlowConflict = errors.New("コンフリクトが発生しました")
+
+// releaseFlowState は完了処理の進行状況を表す構造体です
+type releaseFlowState struct {
+	Kind       string `json:"kind"`              // release または hotfix
+	Branch     string `json:"branch"`            // リリースブランチ（例: release/1.3.0）
+	Tag        string `json:"tag"`               // 作成するタグ（例: v1.3.0）
+	Message    string `json:"message"`           // タグメッセージ
+	Main       string `json:"main"`              // メインブランチ
+	Develop    string `json:"develop,omitempty"` // develop ブランチ（ない場合は空文字列）
+	Sign       bool   `json:"sign"`              // 署名付きタグを作成するかどうか
+	Push       bool   `json:"push"`              // プッシュするかどうか
+	KeepBranch bool   `json:"keep_branch"`       // リリースブランチを残すかどうか
+	Step       int    `json:"step"`              // 次に実行する手順の番号
+}
+
+// releaseFlowStep は完了処理の手順1つを表す構造体です
+type releaseFlowStep struct {
+	Name string                        // 手順の名前（進行状況の表示に使用）
+	Run  func(*releaseFlowState) error // 手順の処理（完了済みの場合は何もしない）
+}
+
+// releaseFlowSteps は完了処理の手順です（順に実行します）
+var releaseFlowSteps = []releaseFlowStep{
+	{Name: "bump", Run: stepBumpVersionFiles},
+	{Name: "merge-main", Run: func(s *releaseFlowState) error { return stepMergeInto(s, s.Main) }},
+	{Name: "tag", Run: stepCreateTag},
+	{Name: "merge-develop", Run: func(s *releaseFlowState) error { return stepMergeInto(s, s.Develop) }},
+	{Name: "push", Run: stepPush},
+	{Name: "cleanup", Run: stepCleanup},
+}
+
+// newReleaseFlowState はリリースブランチから完了処理の進行状況を作成します。
+//
+// パラメータ:
+//   - kind: release または hotfix
+//   - branch: リリースブランチ（例: release/1.3.0）
+//
+// 戻り値:
+//   - *releaseFlowState: 進行状況（Sign などのオプションは呼び出し側で設定）
+//   - error: ブランチ名からバージョンを取得できない場合や、メインブランチがない場合のエラー
+func newReleaseFlowState(kind, branch string) (*releaseFlowState, error) {
+	name := strings.TrimPrefix(branch, kind+"/")
+	version, err := semver.Parse(name)
+	if err != nil || name == branch {
+		return nil, fmt.Errorf("%s は %s/<バージョン> の形式のブランチではありません", branch, kind)
+	}
+	if !localBranchExists(branch) {
+		return nil, fmt.Errorf("ブランチ %s が見つかりません", branch)
+	}
+
+	mainBranch, err := flowMainBranch()
+	if err != nil {
+		return nil, err
+	}
+
+	// タグの v の有無は既存のタグに合わせる
+	latest, err := latestFlowTag(mainBranch)
+	if err != nil {
+		latest = initialVersionTag
+	}
+
+	return &releaseFlowState{
+		Kind:    kind,
+		Branch:  branch,
+		Tag:     formatTagName("", latest, version),
+		Main:    mainBranch,
+		Develop: flowDevelopBranch(),
+	}, nil
+}
+
+// runReleaseFlow は中断した手順から完了処理を実行します。
+//
+// 内部処理:
+//
+//	各手順の前に進行状況を保存し、すべての手順が終わったら削除します。
+//	コンフリクトが発生した場合は進行状況を残したまま再開方法を表示します。
+func runReleaseFlow(state *releaseFlowState) error {
+	for state.Step < len(releaseFlowSteps) {
+		if err := saveReleaseFlowState(state); err != nil {
+			return err
+		}
+
+		step := releaseFlowSteps[state.Step]
+		if err := step.Run(state); err != nil {
+			if errors.Is(err, errReleaseFlowConflict) {
+				fmt.Println("\nコンフリクトが発生しました。")
+				fmt.Println("コンフリクトを解決して git add した後、以下のコマンドを実行してください:")
+				fmt.Printf("  git %s finish --continue    # 完了処理を再開\n", state.Kind)
+				fmt.Printf("  git %s finish --abort       # 完了処理を中止\n", state.Kind)
+			}
+			return fmt.Errorf("%s に失敗しました: %w", step.Name, err)
+		}
+		state.Step++
+	}
+
+	if err := removeReleaseFlowState(); err != nil {
+		return err
+	}
+	fmt.Printf("\n✓ %s を完了しました: %s\n", state.Branch, state.Tag)
+	return nil
+}
+
+// continueReleaseFlow は保存した進行状況から完了処理を再開します。
+//
+// パラメータ:
+//   - kind: release または hotfix（保存した進行状況と一致する必要があります）
+func continueReleaseFlow(kind string) error {
+	state, err := loadReleaseFlowState()
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		return fmt.Errorf("再開できる %s finish がありません", kind)
+	}
+	if state.Kind != kind {
+		return fmt.Errorf("進行中なのは %s finish（%s）です。git %s finish --continue を実行してください", state.Kind, state.Branch, state.Kind)
+	}
+
+	if state.Step < len(releaseFlowSteps) {
+		fmt.Printf("%s の完了処理を再開します（%s から）\n", state.Branch, releaseFlowSteps[state.Step].Name)
+	}
+	return runReleaseFlow(state)
+}
+
+// abortReleaseFlow は完了処理を中止します。
+//
+// 内部処理:
+//
+//	進行中のマージを git merge --abort で中止し、リリースブランチに戻ってから
+//	進行状況を削除します。完了済みの手順（マージ・タグ）は元に戻さないため、表示して知らせます。
+func abortReleaseFlow(kind string) error {
+	state, err := loadReleaseFlowState()
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		return fmt.Errorf("中止できる %s finish がありません", kind)
+	}
+
+	if mergeInProgress() {
+		if err := gitcmd.RunWithIO("merge", "--abort"); err != nil {
+			return fmt.Errorf("マージの中止に失敗しました: %w", err)
+		}
+	}
+	if localBranchExists(state.Branch) {
+		if err := gitcmd.RunQuiet("switch", state.Branch); err != nil {
+			fmt.Printf("警告: %s に戻れませんでした: %v\n", state.Branch, err)
+		}
+	}
+	if err := removeReleaseFlowState(); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s の完了処理を中止しました\n", state.Branch)
+	if state.Step > 0 {
+		var done []string
+		for _, step := range releaseFlowSteps[:state.Step] {
+			done = append(done, step.Name)
+		}
+		fmt.Printf("完了済みの手順は元に戻していません: %s\n", strings.Join(done, ", "))
+	}
+	return nil
+}
+
+// stepBumpVersionFiles はリリースブランチでバージョンファイルを更新します
+func stepBumpVersionFiles(s *releaseFlowState) error {
+	rules, err := versionFileRules("")
+	if err != nil || len(rules) == 0 {
+		return err
+	}
+	if err := gitcmd.RunQuiet("switch", s.Branch); err != nil {
+		return fmt.Errorf("%s への切り替えに失敗しました: %w", s.Branch, err)
+	}
+
+	version, err := semver.Parse(s.Tag)
+	if err != nil {
+		return err
+	}
+	root, changes, err := planVersionFiles(rules, version.String())
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	printVersionFileChanges(changes)
+	if err := commitVersionFiles(root, changes, s.Tag, s.Sign); err != nil {
+		return err
+	}
+	fmt.Printf("✓ リリースコミットを作成しました: %s\n", releaseCommitMessage(s.Tag))
+	return nil
+}
+
+// stepMergeInto はリリースブランチを指定したブランチに --no-ff でマージします。
+//
+// 内部処理:
+//
+//	マージが中断している場合（--continue）は、未解決のファイルがなければ
+//	git commit --no-edit でマージを完了します。既にマージ済みの場合は何もしません。
+func stepMergeInto(s *releaseFlowState, target string) error {
+	if target == "" || (target == s.Develop && s.Develop == s.Main) {
+		return nil
+	}
+
+	if mergeInProgress() {
+		if unmerged := unmergedFiles(); len(unmerged) > 0 {
+			return fmt.Errorf("%w: 未解決のファイルがあります: %s", errReleaseFlowConflict, strings.Join(unmerged, ", "))
+		}
+		if err := gitcmd.RunQuiet("commit", "--no-edit"); err != nil {
+			return fmt.Errorf("マージコミットの作成に失敗しました: %w", err)
+		}
+		fmt.Printf("✓ %s を %s にマージしました\n", s.Branch, target)
+		return nil
+	}
+
+	if err := gitcmd.RunQuiet("switch", target); err != nil {
+		return fmt.Errorf("%s への切り替えに失敗しました: %w", target, err)
+	}
+	if gitcmd.RunQuiet("merge-base", "--is-ancestor", s.Branch, "HEAD") == nil {
+		return nil
+	}
+
+	fmt.Printf("%s を %s にマージしています...\n", s.Branch, target)
+	message := fmt.Sprintf("Merge branch '%s' into %s", s.Branch, target)
+	if err := gitcmd.RunWithIO("merge", "--no-ff", "-m", message, s.Branch); err != nil {
+		if mergeInProgress() {
+			return errReleaseFlowConflict
+		}
+		return err
+	}
+	fmt.Printf("✓ %s を %s にマージしました\n", s.Branch, target)
+	return nil
+}
+
+// stepCreateTag はメインブランチのマージコミットにタグを作成します
+func stepCreateTag(s *releaseFlowState) error {
+	if output, err := gitcmd.Run("rev-parse", "--verify", "--quiet", "refs/tags/"+s.Tag+"^{commit}"); err == nil {
+		mainHead, _ := gitcmd.Run("rev-parse", s.Main)
+		if strings.TrimSpace(string(output)) == strings.TrimSpace(string(mainHead)) {
+			return nil
+		}
+		return fmt.Errorf("タグ %s は既に別のコミットに存在します", s.Tag)
+	}
+
+	if err := gitcmd.RunQuiet("switch", s.Main); err != nil {
+		return fmt.Errorf("%s への切り替えに失敗しました: %w", s.Main, err)
+	}
+	if err := makeTag(s.Tag, resolveTagMessage(s.Tag, s.Message), s.Sign); err != nil {
+		return err
+	}
+	fmt.Printf("✓ タグを作成しました: %s\n", s.Tag)
+	return nil
+}
+
+// stepPush はメインブランチ・develop・タグをプッシュします
+func stepPush(s *releaseFlowState) error {
+	if !s.Push {
+		return nil
+	}
+	if !remoteExists("origin") {
+		fmt.Println("origin が設定されていないため、プッシュを省略します")
+		return nil
+	}
+
+	args := []string{"push", "origin", s.Main}
+	if s.Develop != "" {
+		args = append(args, s.Develop)
+	}
+	args = append(args, "refs/tags/"+s.Tag)
+	if err := gitcmd.RunWithIO(args...); err != nil {
+		return err
+	}
+	fmt.Printf("✓ リモートにプッシュしました: %s\n", strings.Join(args[2:], ", "))
+	return nil
+}
+
+// stepCleanup はリリースブランチを削除します（リモートにある場合はリモートからも削除）
+func stepCleanup(s *releaseFlowState) error {
+	if s.KeepBranch || !localBranchExists(s.Branch) {
+		return nil
+	}
+
+	if current, err := gitcmd.Run("branch", "--show-current"); err == nil && strings.TrimSpace(string(current)) == s.Branch {
+		if err := gitcmd.RunQuiet("switch", s.Main); err != nil {
+			return err
+		}
+	}
+	if err := gitcmd.RunQuiet("branch", "-d", s.Branch); err != nil {
+		return fmt.Errorf("%s の削除に失敗しました: %w", s.Branch, err)
+	}
+	fmt.Printf("✓ ブランチを削除しました: %s\n", s.Branch)
+
+	if s.Push && gitcmd.RunQuiet("show-ref", "--verify", "--quiet", "refs/remotes/origin/"+s.Branch) == nil {
+		if err := gitcmd.RunQuiet("push", "origin", "--delete", s.Branch); err != nil {
+			fmt.Printf("警告: リモートの %s を削除できませんでした: %v\n", s.Branch, err)
+		} else {
+			fmt.Printf("✓ リモートのブランチを削除しました: origin/%s\n", s.Branch)
+		}
+	}
+	return nil
+}
+
+// flowMainBranch はリリースのマージ先となるメインブランチを返します。
+//
+// 内部処理:
+//
+//	git config gitplus-flow.main、origin/HEAD の指す先、main、master の順に確認し、
+//	ローカルに存在する最初のブランチを返します。
+func flowMainBranch() (string, error) {
+	candidates := []string{gitConfigValue("gitplus-flow.main")}
+	if output, err := gitcmd.Run("symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD"); err == nil {
+		candidates = append(candidates, strings.TrimPrefix(strings.TrimSpace(string(output)), "origin/"))
+	}
+	candidates = append(candidates, "main", "master")
+
+	for _, name := range candidates {
+		if name != "" && localBranchExists(name) {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("メインブランチが見つかりません（git config gitplus-flow.main で指定してください）")
+}
+
+// latestFlowTag はメインブランチから到達できるタグのうち SemVer の優先順位が最も高いタグを返します。
+//
+// 内部処理:
+//
+//	new-tag は HEAD から到達できるタグを使用しますが、release と hotfix は
+//	develop やリリースブランチから実行するため、メインブランチを基準にします。
+//	タグがない場合は errNoGitTags を返します。
+func latestFlowTag(mainBranch string) (string, error) {
+	output, err := gitcmd.Run("tag", "--merged", mainBranch)
+	if err != nil {
+		return "", err
+	}
+	if latest := latestSemverTag(strings.Fields(string(output)), ""); latest != "" {
+		return latest, nil
+	}
+	return "", errNoGitTags
+}
+
+// flowDevelopBranch は develop ブランチを返します（存在しない場合は空文字列）
+func flowDevelopBranch() string {
+	name := gitConfigValue("gitplus-flow.develop")
+	if name == "" {
+		name = "develop"
+	}
+	if localBranchExists(name) {
+		return name
+	}
+	return ""
+}
+
+// localBranchExists はローカルブランチが存在するかを確認します
+func localBranchExists(name string) bool {
+	return gitcmd.RunQuiet("show-ref", "--verify", "--quiet", "refs/heads/"+name) == nil
+}
+
+// remoteExists はリモートが設定されているかを確認します
+func remoteExists(name string) bool {
+	return gitcmd.RunQuiet("remote", "get-url", name) == nil
+}
+
+// mergeInProgress はマージが中断しているか（MERGE_HEAD があるか）を確認します
+func mergeInProgress() bool {
+	return gitcmd.RunQuiet("rev-parse", "--verify", "--quiet", "MERGE_HEAD") == nil
+}
+
+// unmergedFiles はコンフリクトが解決されていないファイルを返します
+func unmergedFiles() []string {
+	output, err := gitcmd.Run("diff", "--name-only", "--diff-filter=U")
+	if err != nil {
+		return nil
+	}
+	return strings.Fields(string(output))
+}
+
+// releaseFlowStatePath は進行状況のファイルのパスを返します
+func releaseFlowStatePath() (string, error) {
+	output, err := gitcmd.Run("rev-parse", "--git-path", releaseFlowStateFile)
+	if err != nil {
+		return "", fmt.Errorf("Git リポジトリではありません: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// loadReleaseFlowState は保存した進行状況を読み込みます（ない場合は nil）
+func loadReleaseFlowState() (*releaseFlowState, error) {
+	path, err := releaseFlowStatePath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("進行状況の読み込みに失敗しました: %w", err)
+	}
+
+	var state releaseFlowState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("進行状況の解析に失敗しました: %w", err)
+	}
+	if state.Step < 0 || state.Step > len(releaseFlowSteps) {
+		return nil, fmt.Errorf("進行状況の手順が正しくありません: %d", state.Step)
+	}
+	return &state, nil
+}
+
+// saveReleaseFlowState は進行状況を保存します
+func saveReleaseFlowState(state *releaseFlowState) error {
+	path, err := releaseFlowStatePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("進行状況の保存に失敗しました: %w", err)
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("進行状況の保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// removeReleaseFlowState は進行状況を削除します
+func removeReleaseFlowState() error {
+	path, err := releaseFlowStatePath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("進行状況の削除に失敗しました: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,291 @@
+package tag
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tonbiattack/git-plus/internal/testutil"
+)
+
+// setupReleaseFlowRepo は main と develop、v1.2.3 のタグを持つテスト用リポジトリを作成します
+func setupReleaseFlowRepo(t *testing.T) *testutil.GitRepo {
+	t.Helper()
+
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("app.txt", "a\n")
+	repo.Commit("Initial commit")
+	repo.MustGit("branch", "-M", "main")
+	repo.CreateTag("v1.2.3", "v1.2.3")
+	repo.CreateBranch("develop")
+	chdirTagRepo(t, repo.Dir)
+	return repo
+}
+
+// TestFlowMainBranch はメインブランチの検出をテストします
+func TestFlowMainBranch(t *testing.T) {
+	repo := setupReleaseFlowRepo(t)
+
+	if got, err := flowMainBranch(); err != nil || got != "main" {
+		t.Errorf("flowMainBranch() = %q, %v, want main", got, err)
+	}
+	if got := flowDevelopBranch(); got != "develop" {
+		t.Errorf("flowDevelopBranch() = %q, want develop", got)
+	}
+
+	repo.CreateBranch("trunk")
+	repo.MustGit("config", "gitplus-flow.main", "trunk")
+	repo.MustGit("config", "gitplus-flow.develop", "dev")
+	if got, err := flowMainBranch(); err != nil || got != "trunk" {
+		t.Errorf("flowMainBranch() = %q, %v, want trunk", got, err)
+	}
+	if got := flowDevelopBranch(); got != "" {
+		t.Errorf("flowDevelopBranch() = %q, want empty for a missing branch", got)
+	}
+}
+
+// TestReleaseFlowState_SaveLoad は進行状況の保存と読み込みをテストします
+func TestReleaseFlowState_SaveLoad(t *testing.T) {
+	setupReleaseFlowRepo(t)
+
+	if state, err := loadReleaseFlowState(); err != nil || state != nil {
+		t.Fatalf("loadReleaseFlowState() = %v, %v, want nil", state, err)
+	}
+
+	want := &releaseFlowState{Kind: "release", Branch: "release/1.3.0", Tag: "v1.3.0", Main: "main", Develop: "develop", Push: true, Step: 2}
+	if err := saveReleaseFlowState(want); err != nil {
+		t.Fatalf("saveReleaseFlowState() error = %v", err)
+	}
+	got, err := loadReleaseFlowState()
+	if err != nil {
+		t.Fatalf("loadReleaseFlowState() error = %v", err)
+	}
+	if *got != *want {
+		t.Errorf("loadReleaseFlowState() = %+v, want %+v", got, want)
+	}
+
+	if err := removeReleaseFlowState(); err != nil {
+		t.Fatalf("removeReleaseFlowState() error = %v", err)
+	}
+	if state, _ := loadReleaseFlowState(); state != nil {
+		t.Error("state should be removed")
+	}
+}
+
+// TestNewReleaseFlowState はブランチ名からの進行状況の作成をテストします
+func TestNewReleaseFlowState(t *testing.T) {
+	repo := setupReleaseFlowRepo(t)
+	repo.CreateBranch("release/1.3.0")
+	repo.CreateBranch("feature/x")
+
+	state, err := newReleaseFlowState("release", "release/1.3.0")
+	if err != nil {
+		t.Fatalf("newReleaseFlowState() error = %v", err)
+	}
+	if state.Tag != "v1.3.0" || state.Main != "main" || state.Develop != "develop" {
+		t.Errorf("newReleaseFlowState() = %+v", state)
+	}
+
+	if _, err := newReleaseFlowState("release", "feature/x"); err == nil {
+		t.Error("newReleaseFlowState() should fail for a non-release branch")
+	}
+	if _, err := newReleaseFlowState("release", "release/2.0.0"); err == nil {
+		t.Error("newReleaseFlowState() should fail for a missing branch")
+	}
+}
+
+// TestRunReleaseFlow は完了処理（マージ・タグ・プッシュ・ブランチの削除）をテストします
+func TestRunReleaseFlow(t *testing.T) {
+	repo := setupReleaseFlowRepo(t)
+	remote := t.TempDir()
+	if output, err := exec.Command("git", "init", "--bare", remote).CombinedOutput(); err != nil {
+		t.Fatalf("git init --bare failed: %v\n%s", err, output)
+	}
+	repo.MustGit("remote", "add", "origin", remote)
+	repo.MustGit("push", "origin", "main", "develop")
+
+	repo.CreateFile("VERSION", "1.2.3\n")
+	repo.MustGit("add", "VERSION")
+	repo.Commit("add version file")
+	repo.MustGit("push", "origin", "main")
+	repo.MustGit("config", "--add", "gitplus-tag.versionfile", "VERSION")
+
+	if err := startFlowBranch("release/1.3.0", "main"); err != nil {
+		t.Fatalf("startFlowBranch() error = %v", err)
+	}
+	repo.CreateFile("feature.txt", "feature")
+	repo.Commit("feat: add feature")
+
+	state, err := newReleaseFlowState("release", "release/1.3.0")
+	if err != nil {
+		t.Fatalf("newReleaseFlowState() error = %v", err)
+	}
+	state.Push = true
+	if err := runReleaseFlow(state); err != nil {
+		t.Fatalf("runReleaseFlow() error = %v", err)
+	}
+
+	tagCommit := strings.TrimSpace(repo.MustGit("rev-parse", "v1.3.0^{commit}"))
+	if mainHead := strings.TrimSpace(repo.MustGit("rev-parse", "main")); tagCommit != mainHead {
+		t.Errorf("v1.3.0 should point to main (%s), got %s", mainHead, tagCommit)
+	}
+	if got := strings.TrimSpace(repo.MustGit("show", "main:VERSION")); got != "1.3.0" {
+		t.Errorf("VERSION on main = %q, want 1.3.0", got)
+	}
+	if err := exec.Command("git", "merge-base", "--is-ancestor", "v1.3.0^2", "develop").Run(); err != nil {
+		t.Error("release branch should be merged into develop")
+	}
+	if localBranchExists("release/1.3.0") {
+		t.Error("release branch should be deleted")
+	}
+	remoteTags := repo.MustGit("ls-remote", "--tags", "origin")
+	if !strings.Contains(remoteTags, "refs/tags/v1.3.0") {
+		t.Errorf("v1.3.0 should be pushed, got %q", remoteTags)
+	}
+	if state, _ := loadReleaseFlowState(); state != nil {
+		t.Error("state should be removed after completion")
+	}
+}
+
+// TestRunReleaseFlow_ConflictAndContinue はコンフリクト後の再開をテストします
+func TestRunReleaseFlow_ConflictAndContinue(t *testing.T) {
+	repo := setupReleaseFlowRepo(t)
+
+	if err := startFlowBranch("release/1.3.0", "main"); err != nil {
+		t.Fatalf("startFlowBranch() error = %v", err)
+	}
+	repo.CreateFile("app.txt", "release\n")
+	repo.Commit("fix: release change")
+	repo.CheckoutBranch("develop")
+	repo.CreateFile("app.txt", "develop\n")
+	repo.Commit("develop change")
+
+	state, err := newReleaseFlowState("release", "release/1.3.0")
+	if err != nil {
+		t.Fatalf("newReleaseFlowState() error = %v", err)
+	}
+	err = runReleaseFlow(state)
+	if !errors.Is(err, errReleaseFlowConflict) {
+		t.Fatalf("runReleaseFlow() error = %v, want conflict", err)
+	}
+
+	saved, err := loadReleaseFlowState()
+	if err != nil || saved == nil || releaseFlowSteps[saved.Step].Name != "merge-develop" {
+		t.Fatalf("saved state = %+v, %v, want merge-develop", saved, err)
+	}
+
+	// 未解決のまま再開するとコンフリクトのまま止まる
+	if err := continueReleaseFlow("release"); !errors.Is(err, errReleaseFlowConflict) {
+		t.Fatalf("continueReleaseFlow() error = %v, want conflict", err)
+	}
+
+	if err := os.WriteFile("app.txt", []byte("develop\nrelease\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	repo.MustGit("add", "app.txt")
+	if err := continueReleaseFlow("hotfix"); err == nil {
+		t.Error("continueReleaseFlow(hotfix) should fail for a release in progress")
+	}
+	if err := continueReleaseFlow("release"); err != nil {
+		t.Fatalf("continueReleaseFlow() error = %v", err)
+	}
+
+	if got := strings.TrimSpace(repo.MustGit("show", "develop:app.txt")); got != "develop\nrelease" {
+		t.Errorf("develop:app.txt = %q", got)
+	}
+	if out := repo.MustGit("tag", "-l", "v1.3.0"); strings.TrimSpace(out) != "v1.3.0" {
+		t.Error("v1.3.0 should be created")
+	}
+	if state, _ := loadReleaseFlowState(); state != nil {
+		t.Error("state should be removed after completion")
+	}
+}
+
+// TestRunReleaseFlow_CommitFailureAndContinue はリリースコミットの失敗後の再開をテストします
+func TestRunReleaseFlow_CommitFailureAndContinue(t *testing.T) {
+	repo := setupReleaseFlowRepo(t)
+
+	repo.CreateFile("VERSION", "1.2.3\n")
+	repo.MustGit("add", "VERSION")
+	repo.Commit("add version file")
+	repo.MustGit("config", "--add", "gitplus-tag.versionfile", "VERSION")
+
+	if err := startFlowBranch("release/1.3.0", "main"); err != nil {
+		t.Fatalf("startFlowBranch() error = %v", err)
+	}
+
+	// pre-commit フックでリリースコミットを失敗させる
+	hook := filepath.Join(repo.Dir, ".git", "hooks", "pre-commit")
+	if err := os.WriteFile(hook, []byte("#!/bin/sh\nexit 1\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := newReleaseFlowState("release", "release/1.3.0")
+	if err != nil {
+		t.Fatalf("newReleaseFlowState() error = %v", err)
+	}
+	if err := runReleaseFlow(state); err == nil {
+		t.Fatal("runReleaseFlow() should fail when the release commit fails")
+	}
+	if got := repo.ReadFile("VERSION"); got != "1.2.3\n" {
+		t.Errorf("VERSION should be rolled back, got %q", got)
+	}
+	if status := strings.TrimSpace(repo.MustGit("status", "--porcelain")); status != "" {
+		t.Errorf("work tree should be clean, got %q", status)
+	}
+	saved, err := loadReleaseFlowState()
+	if err != nil || saved == nil || releaseFlowSteps[saved.Step].Name != "bump" {
+		t.Fatalf("saved state = %+v, %v, want bump", saved, err)
+	}
+
+	if err := os.Remove(hook); err != nil {
+		t.Fatal(err)
+	}
+	if err := continueReleaseFlow("release"); err != nil {
+		t.Fatalf("continueReleaseFlow() error = %v", err)
+	}
+
+	if got := strings.TrimSpace(repo.MustGit("show", "main:VERSION")); got != "1.3.0" {
+		t.Errorf("VERSION on main = %q, want 1.3.0", got)
+	}
+	log := repo.MustGit("log", "--format=%s", "v1.3.0")
+	if !strings.Contains(log, "chore(release): v1.3.0") {
+		t.Errorf("release commit should be in the history of v1.3.0, got %q", log)
+	}
+}
+
+// TestAbortReleaseFlow は完了処理の中止をテストします
+func TestAbortReleaseFlow(t *testing.T) {
+	repo := setupReleaseFlowRepo(t)
+
+	repo.CreateBranch("hotfix/1.2.4")
+	repo.CreateFile("app.txt", "main change\n")
+	repo.Commit("main change")
+	repo.CheckoutBranch("hotfix/1.2.4")
+	repo.CreateFile("app.txt", "hotfix\n")
+	repo.Commit("fix: hotfix")
+
+	state, err := newReleaseFlowState("hotfix", "hotfix/1.2.4")
+	if err != nil {
+		t.Fatalf("newReleaseFlowState() error = %v", err)
+	}
+	if err := runReleaseFlow(state); !errors.Is(err, errReleaseFlowConflict) {
+		t.Fatalf("runReleaseFlow() error = %v, want conflict", err)
+	}
+
+	if err := abortReleaseFlow("hotfix"); err != nil {
+		t.Fatalf("abortReleaseFlow() error = %v", err)
+	}
+	if mergeInProgress() {
+		t.Error("merge should be aborted")
+	}
+	if got := repo.CurrentBranch(); got != "hotfix/1.2.4" {
+		t.Errorf("current branch = %q, want hotfix/1.2.4", got)
+	}
+	if state, _ := loadReleaseFlowState(); state != nil {
+		t.Error("state should be removed after abort")
+	}
+}
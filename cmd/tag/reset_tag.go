@@ -2,88 +2,364 @@
 Package tag は git の拡張コマンドのうち、タグ関連コマンドを定義します。
 
 このファイル (reset_tag.go) は、Git タグをリセットして再作成するコマンドを提供します。
-既存のタグをローカルとリモートから削除し、最新のコミットに同名のタグを再作成します。
+既存のタグを最新のコミットに付け替え、ローカルとリモートのタグを更新します。
 
 主な機能:
-  - ローカルタグの削除
-  - リモートタグの削除
-  - 最新コミットへのタグの再作成
-  - リモートへのタグのプッシュ
+  - 現在のタグと新しい付け替え先のコミット、その間のコミットの表示
+  - 付け替え前の確認（--yes で省略）
+  - 削除前のタグのバックアップ（refs/git-plus/backup/reset-tag/）
+  - 注釈付きタグのメッセージの引き継ぎ（署名付きタグは再署名）
+  - タグに紐づく GitHub リリースの確認
+  - リモートへのタグの強制プッシュと、プッシュ後のリモートのタグの確認
 
 使用例:
 
-	git reset-tag v1.2.3  # v1.2.3 タグをリセット
+	git reset-tag v1.2.3        # v1.2.3 タグを HEAD に付け替え
+	git reset-tag v1.2.3 --yes  # 確認せずに付け替え
 */
 package tag
 
 import (
 	"fmt"
+	"os/exec"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/backup"
 	"github.com/tonbiattack/git-plus/internal/gitcmd"
+	"github.com/tonbiattack/git-plus/internal/ui"
 )
 
+// resetTagRemote はタグを更新するリモート名です
+const resetTagRemote = "origin"
+
+// resetTagMaxCommits は付け替えの前に表示するコミットの最大数です
+const resetTagMaxCommits = 20
+
+var resetTagYes bool // 確認せずに付け替えるフラグ
+
+// tagState は付け替え前のタグの状態を表す構造体です
+type tagState struct {
+	Exists    bool   // タグが存在するか
+	Annotated bool   // 注釈付きタグか
+	Signed    bool   // 署名付きタグか
+	Commit    string // タグが指すコミット
+	Message   string // 注釈付きタグのメッセージ（署名を除く）
+}
+
 // resetTagCmd は指定されたタグをリセットして再作成するコマンドです。
-// ローカルとリモートのタグを削除してから、最新のコミットに同じ名前のタグを作成します。
+// 既存のタグをバックアップしてから、最新のコミットに同じ名前のタグを作成し直します。
 var resetTagCmd = &cobra.Command{
 	Use:   "reset-tag <タグ名>",
 	Short: "タグをリセットして再作成",
-	Long: `指定したタグをローカルとリモートから削除し、
-最新コミットに同名のタグを再作成してリモートにプッシュします。`,
-	Example: `  git reset-tag v1.2.3    # v1.2.3 タグをリセット`,
-	Args:    cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
+	Long: `指定したタグを最新コミットに付け替え、リモートのタグも更新します。
+
+付け替えの前に、現在のタグが指すコミットと HEAD、その間のコミットを表示して確認します。
+削除前のタグは refs/git-plus/backup/reset-tag/ に保存されます。
+注釈付きタグはメッセージを引き継ぎ、署名付きタグは再署名します。
+
+リモートのタグは削除せずに強制プッシュで更新するため、タグに GitHub リリースが
+紐づいている場合もリリースはそのまま新しいタグを参照します。
+プッシュ後にリモートのタグが HEAD を指していることを確認します。`,
+	Example: `  git reset-tag v1.2.3          # v1.2.3 タグをリセット
+  git reset-tag v1.2.3 --yes    # 確認せずにリセット`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(c *cobra.Command, args []string) error {
 		tagName := args[0]
+		tagRef := "refs/tags/" + tagName
+
+		state, err := resolveTagState(tagName)
+		if err != nil {
+			return err
+		}
+		newCommit, err := resolveCommit("HEAD")
+		if err != nil {
+			return fmt.Errorf("HEAD の取得に失敗しました: %w", err)
+		}
+
+		// 付け替えの内容を表示
+		if state.Exists {
+			if state.Commit == newCommit {
+				fmt.Printf("タグ %s は既に HEAD (%s) を指しています。\n", tagName, shortHash(newCommit))
+				return nil
+			}
+			fmt.Printf("現在のタグ: %s → %s\n", tagName, describeCommit(state.Commit))
+		} else {
+			fmt.Printf("警告: タグ %s は存在しないため、新しく作成します\n", tagName)
+		}
+		fmt.Printf("新しい付け替え先: %s\n", describeCommit(newCommit))
+		if state.Annotated {
+			kind := "注釈付きタグ"
+			if state.Signed {
+				kind = "署名付きタグ（再署名します）"
+			}
+			fmt.Printf("種類: %s\n", kind)
+		}
+		if state.Exists {
+			printCommitsBetween(state.Commit, newCommit)
+		}
+
+		// GitHub リリースの確認
+		if release, ok := findGitHubRelease(tagName); ok {
+			fmt.Printf("\nGitHub リリースが紐づいています: %s\n", release)
+			fmt.Println("リリースは新しいタグを参照します（リリースノートは更新されません）")
+		}
 
-		// ローカルタグ削除（既に存在しない場合があるためエラーは無視）
-		// エラーが発生しても処理を継続する
-		if err := runGitCommandIgnoreError("tag", "-d", tagName); err != nil {
-			fmt.Printf("ローカルタグの削除で予期せぬエラーが発生しました: %v\n", err)
+		if !resetTagYes && !ui.Confirm(fmt.Sprintf("\nタグ %s を付け替えますか？", tagName), false) {
+			fmt.Println("キャンセルしました")
+			return nil
 		}
 
-		// リモートタグ削除（存在しないこともあるので警告として扱う）
-		// エラーが発生しても処理を継続する
-		if err := runGitCommandIgnoreError("push", "--delete", "origin", tagName); err != nil {
-			fmt.Printf("リモートタグの削除に失敗しました: %v\n", err)
+		// 付け替え前のタグをバックアップ参照に保存する
+		if state.Exists {
+			backupRef, err := backup.Create("reset-tag", tagRef)
+			if err != nil {
+				return fmt.Errorf("バックアップの作成に失敗しました: %w", err)
+			}
+			fmt.Printf("バックアップを作成しました: %s\n", backupRef)
 		}
 
-		// 最新コミットにタグを再付与
-		if err := gitcmd.RunWithIO("tag", tagName); err != nil {
+		// 最新コミットにタグを付け替え（-f で置き換えるため、失敗しても元のタグは残る）
+		if err := recreateTag(tagName, newCommit, state); err != nil {
 			return fmt.Errorf("タグの再作成に失敗しました: %w", err)
 		}
+		fmt.Printf("✓ ローカルのタグ %s を %s に付け替えました\n", tagName, shortHash(newCommit))
+
+		if gitcmd.RunQuiet("remote", "get-url", resetTagRemote) != nil {
+			fmt.Printf("警告: リモート %s がないため、ローカルのタグのみ更新しました\n", resetTagRemote)
+			return nil
+		}
 
-		// リモートにタグをプッシュ
-		if err := gitcmd.RunWithIO("push", "origin", tagName); err != nil {
+		// リモートのタグを強制プッシュで更新（削除しないため GitHub リリースは紐づいたまま）
+		if err := gitcmd.RunWithIO("push", "--force", resetTagRemote, tagRef); err != nil {
 			return fmt.Errorf("タグのプッシュに失敗しました: %w", err)
 		}
 
+		// リモートのタグを確認
+		if err := verifyRemoteTag(resetTagRemote, tagName, newCommit); err != nil {
+			return err
+		}
+		fmt.Printf("✓ リモートのタグ %s が %s を指していることを確認しました\n", tagName, shortHash(newCommit))
+
 		fmt.Printf("タグ %s をリセットして再作成しました。\n", tagName)
 		return nil
 	},
 }
 
-// runGitCommandIgnoreError は Git コマンドを実行しますが、エラーを無視します。
-// タグの削除など、既に存在しない可能性がある操作に使用します。
+// resolveTagState はタグの現在の状態を取得します。
 //
 // パラメータ:
-//
-//	args: Git コマンドの引数
+//   - tagName: タグ名
 //
 // 戻り値:
+//   - *tagState: タグの状態（存在しない場合は Exists が false）
+//   - error: タグの情報の取得に失敗した場合のエラー情報
+func resolveTagState(tagName string) (*tagState, error) {
+	tagRef := "refs/tags/" + tagName
+	state := &tagState{}
+	if gitcmd.RunQuiet("rev-parse", "--verify", "--quiet", tagRef) != nil {
+		return state, nil
+	}
+	state.Exists = true
+
+	commit, err := resolveCommit(tagRef)
+	if err != nil {
+		return nil, fmt.Errorf("タグ %s が指すコミットの取得に失敗しました: %w", tagName, err)
+	}
+	state.Commit = commit
+
+	objectType, err := gitcmd.Run("cat-file", "-t", tagRef)
+	if err != nil {
+		return nil, fmt.Errorf("タグ %s の種類の取得に失敗しました: %w", tagName, err)
+	}
+	if strings.TrimSpace(string(objectType)) != "tag" {
+		return state, nil
+	}
+	state.Annotated = true
+
+	contents, err := gitcmd.Run("for-each-ref", "--format=%(contents)", tagRef)
+	if err != nil {
+		return nil, fmt.Errorf("タグ %s のメッセージの取得に失敗しました: %w", tagName, err)
+	}
+	signature, err := gitcmd.Run("for-each-ref", "--format=%(contents:signature)", tagRef)
+	if err != nil {
+		return nil, fmt.Errorf("タグ %s の署名の取得に失敗しました: %w", tagName, err)
+	}
+	state.Message, state.Signed = splitTagSignature(string(contents), string(signature))
+	return state, nil
+}
+
+// splitTagSignature はタグの内容からメッセージと署名の有無を取り出します。
 //
-//	常に nil を返す（エラーは無視される）
-func runGitCommandIgnoreError(args ...string) error {
-	err := gitcmd.RunWithIO(args...)
+// パラメータ:
+//   - contents: git for-each-ref --format=%(contents) の出力（署名を含む）
+//   - signature: git for-each-ref --format=%(contents:signature) の出力
+//
+// 戻り値:
+//   - string: 署名を除いたメッセージ
+//   - bool: 署名付きの場合は true
+func splitTagSignature(contents, signature string) (string, bool) {
+	contents = strings.TrimRight(contents, "\n")
+	signature = strings.TrimRight(signature, "\n")
+	if signature == "" {
+		return strings.TrimSpace(contents), false
+	}
+	return strings.TrimSpace(strings.TrimSuffix(contents, signature)), true
+}
+
+// resolveCommit はリビジョンが指すコミットのハッシュを返します
+func resolveCommit(rev string) (string, error) {
+	output, err := gitcmd.Run("rev-parse", "--verify", "--quiet", rev+"^{commit}")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// describeCommit はコミットを "短縮ハッシュ 件名 (日付)" の形式で返します
+func describeCommit(commit string) string {
+	output, err := gitcmd.Run("log", "-1", "--format=%h %s (%ad)", "--date=short", commit)
+	if err != nil {
+		return shortHash(commit)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// printCommitsBetween は現在のタグと新しい付け替え先の間のコミットを表示します。
+// 新しい付け替え先で追加されるコミットと、タグから外れるコミットを分けて表示します。
+func printCommitsBetween(oldCommit, newCommit string) {
+	added := listCommits(oldCommit + ".." + newCommit)
+	removed := listCommits(newCommit + ".." + oldCommit)
+
+	fmt.Printf("\n追加されるコミット (%d 個):\n", len(added))
+	printCommitList(added)
+	if len(removed) > 0 {
+		fmt.Printf("\n警告: タグから外れるコミット (%d 個):\n", len(removed))
+		printCommitList(removed)
+	}
+}
+
+// listCommits は範囲内のコミットを "短縮ハッシュ 件名" の形式で返します
+func listCommits(revRange string) []string {
+	output, err := gitcmd.Run("log", "--format=%h %s", revRange)
 	if err != nil {
-		// エラーを無視
 		return nil
 	}
+	text := strings.TrimSpace(string(output))
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// printCommitList はコミットを最大 resetTagMaxCommits 個まで表示します
+func printCommitList(commits []string) {
+	for i, commit := range commits {
+		if i >= resetTagMaxCommits {
+			fmt.Printf("  ... 他 %d 個\n", len(commits)-resetTagMaxCommits)
+			break
+		}
+		fmt.Printf("  %s\n", commit)
+	}
+}
+
+// findGitHubRelease はタグに紐づく GitHub リリースを探します。
+//
+// 戻り値:
+//   - string: リリースの名前と URL
+//   - bool: リリースが見つかった場合は true（gh がない場合やリリースがない場合は false）
+func findGitHubRelease(tagName string) (string, bool) {
+	if !checkGitHubCLIInstalled() {
+		return "", false
+	}
+	output, err := exec.Command("gh", "release", "view", tagName, "--json", "name,url", "--jq", `.name + " " + .url`).Output()
+	if err != nil {
+		return "", false
+	}
+	release := strings.TrimSpace(string(output))
+	return release, release != ""
+}
+
+// recreateTag はタグを指定したコミットに付け替えます。
+//
+// パラメータ:
+//   - tagName: タグ名
+//   - commit: 新しい付け替え先のコミット
+//   - state: 付け替え前のタグの状態
+//
+// 内部処理:
+//
+//	注釈付きタグは元のメッセージを標準入力から渡して git tag -a -f -F - で作成し、
+//	署名付きタグは -s で再署名します。軽量タグは git tag -f で作成します。
+func recreateTag(tagName, commit string, state *tagState) error {
+	args := buildRecreateTagArgs(tagName, commit, state)
+	if !state.Annotated {
+		return gitcmd.RunWithIO(args...)
+	}
+
+	gitCmd := exec.Command("git", args...)
+	gitCmd.Stdin = strings.NewReader(state.Message + "\n")
+	output, err := gitCmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w\n%s", err, strings.TrimSpace(string(output)))
+	}
 	return nil
 }
 
+// buildRecreateTagArgs はタグを付け替える git tag の引数を生成します
+func buildRecreateTagArgs(tagName, commit string, state *tagState) []string {
+	if !state.Annotated {
+		return []string{"tag", "-f", tagName, commit}
+	}
+	args := []string{"tag", "-a", "-f"}
+	if state.Signed {
+		args = append(args, "-s")
+	}
+	return append(args, "-F", "-", tagName, commit)
+}
+
+// verifyRemoteTag はリモートのタグが指定したコミットを指していることを確認します
+func verifyRemoteTag(remote, tagName, commit string) error {
+	tagRef := "refs/tags/" + tagName
+	output, err := gitcmd.Run("ls-remote", remote, tagRef, tagRef+"^{}")
+	if err != nil {
+		return fmt.Errorf("リモートのタグの確認に失敗しました: %w", err)
+	}
+	remoteCommit := parseLsRemoteTag(string(output), tagName)
+	if remoteCommit == "" {
+		return fmt.Errorf("リモート %s にタグ %s が見つかりません", remote, tagName)
+	}
+	if remoteCommit != commit {
+		return fmt.Errorf("リモートのタグ %s が %s を指しています（期待値: %s）", tagName, shortHash(remoteCommit), shortHash(commit))
+	}
+	return nil
+}
+
+// parseLsRemoteTag は git ls-remote の出力からタグが指すコミットを取り出します。
+// 注釈付きタグの場合は ^{} の行（タグが指すコミット）を優先します。
+func parseLsRemoteTag(output, tagName string) string {
+	tagRef := "refs/tags/" + tagName
+	object := ""
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		switch fields[1] {
+		case tagRef + "^{}":
+			return fields[0]
+		case tagRef:
+			object = fields[0]
+		}
+	}
+	return object
+}
+
 // init はコマンドの初期化を行います。
 // resetTagCmd を RootCmd に登録することで、CLI から実行可能にします。
 func init() {
 	cmd.RootCmd.AddCommand(resetTagCmd)
+
+	resetTagCmd.Flags().BoolVarP(&resetTagYes, "yes", "y", false, "確認せずに付け替える")
 }
@@ -1,9 +1,12 @@
 package tag
 
 import (
+	"os/exec"
+	"strings"
 	"testing"
 
 	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/testutil"
 )
 
 // TestResetTagCmd_CommandSetup はreset-tagコマンドの設定をテストします
@@ -55,11 +58,151 @@ func TestResetTagCmd_HasRunE(t *testing.T) {
 	}
 }
 
-// TestRunGitCommandIgnoreError はエラーを無視する関数をテストします
-func TestRunGitCommandIgnoreError(t *testing.T) {
-	// この関数は常にnilを返すはず
-	err := runGitCommandIgnoreError("invalid-command-that-does-not-exist")
+// TestResetTagCmd_Flags はフラグが正しく設定されていることを確認します
+func TestResetTagCmd_Flags(t *testing.T) {
+	flag := resetTagCmd.Flags().Lookup("yes")
+	if flag == nil {
+		t.Fatal("Flag yes not found")
+	}
+	if flag.Shorthand != "y" {
+		t.Errorf("Flag yes shorthand = %q, want %q", flag.Shorthand, "y")
+	}
+}
+
+// TestSplitTagSignature はタグのメッセージと署名の分離をテストします
+func TestSplitTagSignature(t *testing.T) {
+	signature := "-----BEGIN PGP SIGNATURE-----\nabc\n-----END PGP SIGNATURE-----\n"
+
+	message, signed := splitTagSignature("Release v1.0.0\n\nDetails\n"+signature, signature)
+	if !signed || message != "Release v1.0.0\n\nDetails" {
+		t.Errorf("splitTagSignature() = %q, %v", message, signed)
+	}
+
+	message, signed = splitTagSignature("Release v1.0.0\n", "")
+	if signed || message != "Release v1.0.0" {
+		t.Errorf("splitTagSignature() = %q, %v", message, signed)
+	}
+}
+
+// TestBuildRecreateTagArgs はタグを付け替える引数の生成をテストします
+func TestBuildRecreateTagArgs(t *testing.T) {
+	tests := []struct {
+		name  string
+		state tagState
+		want  string
+	}{
+		{"lightweight", tagState{}, "tag -f v1.0.0 abc"},
+		{"annotated", tagState{Annotated: true}, "tag -a -f -F - v1.0.0 abc"},
+		{"signed", tagState{Annotated: true, Signed: true}, "tag -a -f -s -F - v1.0.0 abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Join(buildRecreateTagArgs("v1.0.0", "abc", &tt.state), " ")
+			if got != tt.want {
+				t.Errorf("buildRecreateTagArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestParseLsRemoteTag は git ls-remote の出力の解析をテストします
+func TestParseLsRemoteTag(t *testing.T) {
+	annotated := "1111111\trefs/tags/v1.0.0\n2222222\trefs/tags/v1.0.0^{}\n"
+	if got := parseLsRemoteTag(annotated, "v1.0.0"); got != "2222222" {
+		t.Errorf("parseLsRemoteTag(annotated) = %q, want %q", got, "2222222")
+	}
+
+	lightweight := "3333333\trefs/tags/v1.0.0\n"
+	if got := parseLsRemoteTag(lightweight, "v1.0.0"); got != "3333333" {
+		t.Errorf("parseLsRemoteTag(lightweight) = %q, want %q", got, "3333333")
+	}
+
+	if got := parseLsRemoteTag("", "v1.0.0"); got != "" {
+		t.Errorf("parseLsRemoteTag(empty) = %q, want empty", got)
+	}
+}
+
+// TestResolveTagState はタグの状態の取得をテストします
+func TestResolveTagState(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("README.md", "# Test")
+	repo.Commit("Initial commit")
+	repo.CreateTag("v1.0.0", "Release v1.0.0\n\nFirst release")
+	repo.CreateLightweightTag("light")
+	chdirTagRepo(t, repo.Dir)
+
+	state, err := resolveTagState("v1.0.0")
+	if err != nil {
+		t.Fatalf("resolveTagState returned error: %v", err)
+	}
+	if !state.Exists || !state.Annotated || state.Signed {
+		t.Errorf("unexpected state: %+v", state)
+	}
+	if state.Message != "Release v1.0.0\n\nFirst release" {
+		t.Errorf("Message = %q", state.Message)
+	}
+	if state.Commit != strings.TrimSpace(repo.MustGit("rev-parse", "HEAD")) {
+		t.Errorf("Commit = %q", state.Commit)
+	}
+
+	state, err = resolveTagState("light")
+	if err != nil {
+		t.Fatalf("resolveTagState returned error: %v", err)
+	}
+	if !state.Exists || state.Annotated {
+		t.Errorf("unexpected state for lightweight tag: %+v", state)
+	}
+
+	state, err = resolveTagState("missing")
+	if err != nil || state.Exists {
+		t.Errorf("resolveTagState(missing) = %+v, %v", state, err)
+	}
+}
+
+// TestResetTagCmd_RunE はタグの付け替え、バックアップ、リモートの更新をテストします
+func TestResetTagCmd_RunE(t *testing.T) {
+	remote := t.TempDir()
+	if output, err := exec.Command("git", "init", "--bare", "-q", remote).CombinedOutput(); err != nil {
+		t.Fatalf("git init --bare failed: %v\n%s", err, output)
+	}
+
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("README.md", "# Test")
+	repo.Commit("Initial commit")
+	repo.CreateTag("v1.0.0", "Release v1.0.0")
+	repo.MustGit("remote", "add", "origin", remote)
+	repo.MustGit("push", "-q", "origin", "v1.0.0")
+	repo.CreateFile("fix.txt", "fix")
+	repo.Commit("fix: late fix")
+	head := strings.TrimSpace(repo.MustGit("rev-parse", "HEAD"))
+	chdirTagRepo(t, repo.Dir)
+
+	resetTagYes = true
+	defer func() { resetTagYes = false }()
+
+	if err := resetTagCmd.RunE(resetTagCmd, []string{"v1.0.0"}); err != nil {
+		t.Fatalf("RunE returned error: %v", err)
+	}
+
+	if got := strings.TrimSpace(repo.MustGit("rev-parse", "v1.0.0^{commit}")); got != head {
+		t.Errorf("local tag points to %s, want %s", got, head)
+	}
+	if got := strings.TrimSpace(repo.MustGit("cat-file", "-t", "v1.0.0")); got != "tag" {
+		t.Errorf("tag should stay annotated, got %s", got)
+	}
+	if got := strings.TrimSpace(repo.MustGit("for-each-ref", "--format=%(contents)", "refs/tags/v1.0.0")); got != "Release v1.0.0" {
+		t.Errorf("tag message = %q, want %q", got, "Release v1.0.0")
+	}
+	if backups := repo.MustGit("for-each-ref", "refs/git-plus/backup/reset-tag/"); strings.TrimSpace(backups) == "" {
+		t.Error("backup ref should be created")
+	}
+
+	remoteOutput, err := exec.Command("git", "--git-dir", remote, "rev-parse", "v1.0.0^{commit}").Output()
 	if err != nil {
-		t.Errorf("runGitCommandIgnoreError should return nil, got %v", err)
+		t.Fatalf("failed to read remote tag: %v", err)
+	}
+	if got := strings.TrimSpace(string(remoteOutput)); got != head {
+		t.Errorf("remote tag points to %s, want %s", got, head)
 	}
 }
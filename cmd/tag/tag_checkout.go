@@ -9,20 +9,27 @@
 // チェックアウトします。
 //
 // 【主な機能】
-// - セマンティックバージョン順で最新のタグを取得（--sort=-v:refname）
+// - セマンティックバージョン（SemVer 2.0）順で最新のタグを取得
 // - 最新N個のタグを表示（デフォルト: 10個）
 // - 対話的にタグを選択してチェックアウト
 // - 最新タグに自動チェックアウト（-y オプション）
+// - タグを worktree として別ディレクトリに開く（--worktree オプション、tag_checkout_worktree.go）
 //
 // 【使用例】
 //   git tag-checkout                 # 最新10個のタグから選択
 //   git tag-checkout -n 5            # 最新5個のタグから選択
 //   git tag-checkout -y              # 最新タグに自動チェックアウト
 //   git tag-checkout --limit 20      # 最新20個のタグから選択
+//   git tag-checkout v1.2.3          # 指定したタグにチェックアウト
+//   git tag-checkout -w v1.2.3       # ../<リポジトリ名>-v1.2.3 に worktree として開く
+//   git tag-checkout --hotfix v1.2.3 # worktree で hotfix/v1.2.3 を開始
+//   git tag-checkout --list-worktrees   # タグの worktree を一覧表示
+//   git tag-checkout --clean-worktrees  # タグの worktree を削除
 //
 // 【ソート方法】
-// git tag --sort=-v:refname を使用してセマンティックバージョン順で
-// 新しいもの → 古いものの順に並べます。
+// new-tag と同じ SemVer 2.0 の優先順位で新しいもの → 古いものの順に並べます。
+// v1.4.0 は v1.4.0-rc.10 より新しく、v1.4.0-rc.10 は v1.4.0-rc.2 より新しくなります。
+// SemVer として解析できないタグは最後にタグ名の逆順で並べます。
 // ================================================================================
 
 package tag
@@ -30,33 +37,68 @@ package tag
 import (
 	"fmt"
 	"os/exec"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/gitcmd"
+	"github.com/tonbiattack/git-plus/internal/semver"
 	"github.com/tonbiattack/git-plus/internal/ui"
 )
 
 var (
-	tagLimit   int  // 表示するタグの数
-	autoYes    bool // 確認なしで最新タグにチェックアウト
-	showLatest bool // 最新タグのみを表示して終了
+	tagLimit          int  // 表示するタグの数
+	autoYes           bool // 確認なしで最新タグにチェックアウト
+	showLatest        bool // 最新タグのみを表示して終了
+	tagWorktreeMode   bool // タグを worktree として開く
+	tagHotfixBranch   bool // worktree で hotfix/<タグ> ブランチを開始
+	tagListWorktrees  bool // タグの worktree を一覧表示
+	tagCleanWorktrees bool // タグの worktree を削除
 )
 
 // tagCheckoutCmd は tag-checkout コマンドの定義です。
 // セマンティックバージョン順で最新のタグを取得してチェックアウトします。
 var tagCheckoutCmd = &cobra.Command{
-	Use:   "tag-checkout",
+	Use:   "tag-checkout [タグ]",
 	Short: "最新のタグを取得してチェックアウト",
 	Long: `セマンティックバージョン順で最新のタグを取得してチェックアウトします。
-デフォルトでは最新10個のタグを表示し、選択してチェックアウトできます。`,
+デフォルトでは最新10個のタグを表示し、選択してチェックアウトできます。
+タグを指定すると、選択せずにそのタグにチェックアウトします。
+
+--worktree を指定すると、現在の作業ツリーは変更せず、worktree-new と同じ規則の
+../<リポジトリ名>-<タグ> にタグを detached HEAD の worktree として作成します
+（既にある場合は再利用します）。--hotfix を指定すると、その worktree で
+hotfix/<タグ> ブランチを開始します。`,
 	Example: `  git tag-checkout                 # 最新10個のタグから選択
   git tag-checkout -n 5            # 最新5個のタグから選択
   git tag-checkout -y              # 最新タグに自動チェックアウト
   git tag-checkout --limit 20      # 最新20個のタグから選択
-  git tag-checkout --latest        # 最新タグを表示するのみ`,
+  git tag-checkout --latest        # 最新タグを表示するのみ
+  git tag-checkout v1.2.3          # 指定したタグにチェックアウト
+  git tag-checkout -w              # 選択したタグを worktree として開く
+  git tag-checkout -w -y           # 最新タグを worktree として開く
+  git tag-checkout --hotfix v1.2.3 # worktree で hotfix/v1.2.3 を開始
+  git tag-checkout --list-worktrees
+  git tag-checkout --clean-worktrees`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(c *cobra.Command, args []string) error {
+		if tagListWorktrees {
+			return printTagWorktrees()
+		}
+		if tagCleanWorktrees {
+			return cleanTagWorktrees()
+		}
+
+		// タグを指定した場合は選択せずにチェックアウト
+		if len(args) > 0 {
+			if err := gitcmd.RunQuiet("rev-parse", "--verify", "--quiet", "refs/tags/"+args[0]); err != nil {
+				return fmt.Errorf("タグ '%s' が存在しません", args[0])
+			}
+			return openTag(args[0])
+		}
+
 		// 全てのタグを最新順に取得
 		tags, err := getTagsSortedByVersion()
 		if err != nil {
@@ -84,7 +126,7 @@ var tagCheckoutCmd = &cobra.Command{
 		if autoYes {
 			latestTag := displayTags[0]
 			fmt.Printf("最新のタグ: %s\n", latestTag)
-			return checkoutTag(latestTag)
+			return openTag(latestTag)
 		}
 
 		// タグ一覧を表示
@@ -125,10 +167,23 @@ var tagCheckoutCmd = &cobra.Command{
 		}
 
 		// チェックアウト実行
-		return checkoutTag(selectedTag)
+		return openTag(selectedTag)
 	},
 }
 
+// openTag はタグにチェックアウトするか、--worktree（--hotfix）の場合は worktree として開きます
+func openTag(tag string) error {
+	if !tagWorktreeMode && !tagHotfixBranch {
+		return checkoutTag(tag)
+	}
+	path, err := checkoutTagWorktree(tag, tagHotfixBranch)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("移動するには: cd %s\n", path)
+	return nil
+}
+
 // getTagsSortedByVersion は全てのタグをセマンティックバージョン順（最新→古い）で取得します。
 //
 // 戻り値:
@@ -137,10 +192,10 @@ var tagCheckoutCmd = &cobra.Command{
 //
 // 内部処理:
 //
-//	git tag --sort=-v:refname コマンドでセマンティックバージョン順に
-//	ソートされたタグ一覧を取得します。
+//	git tag でタグ一覧を取得し、semver.CompareTags で新しい順に並べ替えます。
+//	git の v:refname はプレリリースを正式版より新しいとみなすため使用しません。
 func getTagsSortedByVersion() ([]string, error) {
-	cmd := exec.Command("git", "tag", "--sort=-v:refname")
+	cmd := exec.Command("git", "tag")
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, err
@@ -152,6 +207,9 @@ func getTagsSortedByVersion() ([]string, error) {
 	}
 
 	tags := strings.Split(tagsStr, "\n")
+	sort.SliceStable(tags, func(i, j int) bool {
+		return semver.CompareTags(tags[i], tags[j]) > 0
+	})
 	return tags, nil
 }
 
@@ -192,9 +250,17 @@ func checkoutTag(tag string) error {
 //	-n, --limit: 表示するタグの数（デフォルト: 10）
 //	-y, --yes: 確認なしで最新タグにチェックアウト
 //	--latest: 最新タグのみを表示して終了
+//	-w, --worktree: タグを worktree として開く
+//	--hotfix: worktree で hotfix/<タグ> ブランチを開始（--worktree を含む）
+//	--list-worktrees: タグの worktree を一覧表示
+//	--clean-worktrees: タグの worktree を削除
 func init() {
 	tagCheckoutCmd.Flags().IntVarP(&tagLimit, "limit", "n", 10, "表示するタグの数")
 	tagCheckoutCmd.Flags().BoolVarP(&autoYes, "yes", "y", false, "確認なしで最新タグにチェックアウト")
 	tagCheckoutCmd.Flags().BoolVarP(&showLatest, "latest", "l", false, "最新タグのみを表示")
+	tagCheckoutCmd.Flags().BoolVarP(&tagWorktreeMode, "worktree", "w", false, "タグを ../<リポジトリ名>-<タグ> に worktree として開く（既にある場合は再利用）")
+	tagCheckoutCmd.Flags().BoolVar(&tagHotfixBranch, "hotfix", false, "worktree で hotfix/<タグ> ブランチを開始（--worktree を含む）")
+	tagCheckoutCmd.Flags().BoolVar(&tagListWorktrees, "list-worktrees", false, "タグの worktree を一覧表示")
+	tagCheckoutCmd.Flags().BoolVar(&tagCleanWorktrees, "clean-worktrees", false, "タグの worktree を削除（未コミットの変更がある worktree は残す）")
 	cmd.RootCmd.AddCommand(tagCheckoutCmd)
 }
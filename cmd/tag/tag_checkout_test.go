@@ -2,6 +2,7 @@ package tag
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/tonbiattack/git-plus/cmd"
@@ -10,8 +11,8 @@ import (
 
 // TestTagCheckoutCmd_CommandSetup はtag-checkoutコマンドの設定をテストします
 func TestTagCheckoutCmd_CommandSetup(t *testing.T) {
-	if tagCheckoutCmd.Use != "tag-checkout" {
-		t.Errorf("tagCheckoutCmd.Use = %q, want %q", tagCheckoutCmd.Use, "tag-checkout")
+	if tagCheckoutCmd.Use != "tag-checkout [タグ]" {
+		t.Errorf("tagCheckoutCmd.Use = %q, want %q", tagCheckoutCmd.Use, "tag-checkout [タグ]")
 	}
 
 	if tagCheckoutCmd.Short == "" {
@@ -36,6 +37,10 @@ func TestTagCheckoutCmd_Flags(t *testing.T) {
 		{"limit", "n"},
 		{"yes", "y"},
 		{"latest", "l"},
+		{"worktree", "w"},
+		{"hotfix", ""},
+		{"list-worktrees", ""},
+		{"clean-worktrees", ""},
 	}
 
 	for _, tt := range tests {
@@ -96,6 +101,37 @@ func TestGetTagsSortedByVersion(t *testing.T) {
 	}
 }
 
+// TestGetTagsSortedByVersion_Prerelease はプレリリースを含むタグを SemVer 2.0 順で並べることを確認します
+func TestGetTagsSortedByVersion_Prerelease(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+
+	repo.CreateFile("README.md", "# Test")
+	repo.Commit("Initial commit")
+	for _, tag := range []string{"v1.4.0-rc.2", "v1.4.0-rc.10", "v1.4.0", "v1.3.0+build.5", "nightly"} {
+		repo.CreateLightweightTag(tag)
+	}
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer func() { _ = os.Chdir(oldDir) }()
+
+	if err := os.Chdir(repo.Dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	tags, err := getTagsSortedByVersion()
+	if err != nil {
+		t.Fatalf("getTagsSortedByVersion returned error: %v", err)
+	}
+
+	expected := []string{"v1.4.0", "v1.4.0-rc.10", "v1.4.0-rc.2", "v1.3.0+build.5", "nightly"}
+	if strings.Join(tags, ",") != strings.Join(expected, ",") {
+		t.Errorf("tags = %v, want %v", tags, expected)
+	}
+}
+
 // TestGetTagsSortedByVersion_NoTags はタグがない場合をテストします
 func TestGetTagsSortedByVersion_NoTags(t *testing.T) {
 	repo := testutil.NewGitRepo(t)
@@ -187,7 +223,7 @@ func TestCheckoutTag_NonexistentTag(t *testing.T) {
 func TestTagCheckoutCmd_InRootCmd(t *testing.T) {
 	found := false
 	for _, c := range cmd.RootCmd.Commands() {
-		if c.Use == "tag-checkout" {
+		if c.Use == "tag-checkout [タグ]" {
 			found = true
 			break
 		}
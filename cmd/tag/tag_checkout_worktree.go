@@ -0,0 +1,247 @@
+// ================================================================================
+// tag_checkout_worktree.go
+// ================================================================================
+// このファイルは tag-checkout の --worktree オプション（タグを worktree として開く）を実装しています。
+//
+// 【概要】
+// 通常の tag-checkout はメインの作業ツリーを detached HEAD にするため、作業中の変更の
+// 邪魔になります。--worktree を指定すると、worktree-new と同じ規則のパス
+// （../<リポジトリ名>-<タグ>）にタグを detached HEAD の worktree として作成します。
+// 既に同じタグの worktree がある場合はそれを再利用します。
+//
+// 【主な機能】
+// - タグの worktree の作成・再利用（--worktree）
+// - worktree で hotfix/<タグ> ブランチを開始（--hotfix）
+// - タグの worktree の一覧表示（--list-worktrees）
+// - タグの worktree の削除（--clean-worktrees、未コミットの変更がある worktree は残す）
+//
+// 【タグの worktree の判定】
+// メインの作業ツリーから見て ../<リポジトリ名>-<タグ>（/ は - に置き換え）にある
+// worktree をタグの worktree とみなします。
+// ================================================================================
+
+package tag
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/tonbiattack/git-plus/internal/gitcmd"
+	"github.com/tonbiattack/git-plus/internal/gitworktree"
+	"github.com/tonbiattack/git-plus/internal/ui"
+)
+
+// tagWorktree はタグの worktree の情報です
+type tagWorktree struct {
+	Tag    string // タグ名
+	Path   string // worktree のパス
+	Branch string // ブランチ名（detached HEAD の場合は空）
+	Moved  bool   // HEAD がタグのコミットから移動している場合 true
+	Dirty  bool   // 未コミットの変更がある場合 true
+}
+
+// hotfixBranchForTag は --hotfix で作成するブランチ名を返します（例: hotfix/v1.2.3）
+func hotfixBranchForTag(tag string) string {
+	return "hotfix/" + tag
+}
+
+// checkoutTagWorktree はタグの worktree を作成または再利用します。
+//
+// パラメータ:
+//   - tag: worktree として開くタグ
+//   - hotfix: worktree で hotfix/<タグ> ブランチを開始するかどうか
+//
+// 戻り値:
+//   - string: worktree のパス
+//   - error: worktree の作成に失敗した場合や、パスに worktree 以外のディレクトリがある場合のエラー
+func checkoutTagWorktree(tag string, hotfix bool) (string, error) {
+	mainRoot, err := gitworktree.MainRoot()
+	if err != nil {
+		return "", fmt.Errorf("リポジトリのルートディレクトリを取得できませんでした: %w", err)
+	}
+	path := gitworktree.Path(mainRoot, tag)
+	branch := ""
+	if hotfix {
+		branch = hotfixBranchForTag(tag)
+	}
+
+	worktrees, err := gitworktree.List()
+	if err != nil {
+		return "", fmt.Errorf("worktree の一覧の取得に失敗しました: %w", err)
+	}
+	for _, wt := range worktrees {
+		if wt.Path != path {
+			continue
+		}
+		fmt.Printf("既存の worktree を使用します: %s\n", path)
+		if branch == "" || wt.Branch == branch {
+			return path, nil
+		}
+		if wt.Branch != "" {
+			return "", fmt.Errorf("worktree %s は既にブランチ %s を使用しています", path, wt.Branch)
+		}
+		if localBranchExists(branch) {
+			return "", fmt.Errorf("ブランチ %s は既に存在します", branch)
+		}
+		if output, err := exec.Command("git", "-C", path, "switch", "--quiet", "-c", branch).CombinedOutput(); err != nil {
+			return "", fmt.Errorf("ブランチ %s の作成に失敗しました: %s", branch, strings.TrimSpace(string(output)))
+		}
+		fmt.Printf("✓ ブランチ %s を作成しました\n", branch)
+		return path, nil
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		return "", fmt.Errorf("ディレクトリが既に存在します（worktree ではありません）: %s", path)
+	}
+
+	args := []string{"worktree", "add", "--quiet"}
+	if branch != "" {
+		if localBranchExists(branch) {
+			return "", fmt.Errorf("ブランチ %s は既に存在します", branch)
+		}
+		args = append(args, "-b", branch, path, tag)
+	} else {
+		args = append(args, "--detach", path, tag)
+	}
+	if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+		return "", fmt.Errorf("worktree の作成に失敗しました: %s", strings.TrimSpace(string(output)))
+	}
+
+	if branch != "" {
+		fmt.Printf("✓ タグ '%s' から %s を作成しました: %s\n", tag, branch, path)
+	} else {
+		fmt.Printf("✓ タグ '%s' の worktree を作成しました（detached HEAD）: %s\n", tag, path)
+	}
+	return path, nil
+}
+
+// listTagWorktrees はタグの worktree の一覧を返します。
+//
+// 戻り値:
+//   - []tagWorktree: タグの worktree（worktree の一覧の順）
+//   - error: worktree やタグの一覧の取得に失敗した場合のエラー
+func listTagWorktrees() ([]tagWorktree, error) {
+	worktrees, err := gitworktree.List()
+	if err != nil {
+		return nil, fmt.Errorf("worktree の一覧の取得に失敗しました: %w", err)
+	}
+	if len(worktrees) < 2 {
+		return nil, nil
+	}
+	tags, err := getTagsSortedByVersion()
+	if err != nil {
+		return nil, fmt.Errorf("タグの取得に失敗しました: %w", err)
+	}
+
+	mainRoot := worktrees[0].Path
+	byPath := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		byPath[gitworktree.Path(mainRoot, tag)] = tag
+	}
+
+	var result []tagWorktree
+	for _, wt := range worktrees[1:] {
+		tag, ok := byPath[wt.Path]
+		if !ok {
+			continue
+		}
+		item := tagWorktree{Tag: tag, Path: wt.Path, Branch: wt.Branch}
+		if commit, err := gitcmd.Run("rev-parse", tag+"^{commit}"); err == nil {
+			item.Moved = strings.TrimSpace(string(commit)) != wt.Head
+		}
+		if status, err := gitcmd.Run("-C", wt.Path, "status", "--porcelain"); err == nil {
+			item.Dirty = strings.TrimSpace(string(status)) != ""
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+// describeTagWorktree は worktree の状態を表示用の文字列にします
+func describeTagWorktree(wt tagWorktree) string {
+	state := "detached HEAD"
+	if wt.Branch != "" {
+		state = wt.Branch
+	} else if wt.Moved {
+		state = "detached HEAD、タグから移動"
+	}
+	if wt.Dirty {
+		state += "、未コミットの変更あり"
+	}
+	return state
+}
+
+// printTagWorktrees はタグの worktree の一覧を表示します
+func printTagWorktrees() error {
+	worktrees, err := listTagWorktrees()
+	if err != nil {
+		return err
+	}
+	if len(worktrees) == 0 {
+		fmt.Println("タグの worktree はありません")
+		return nil
+	}
+
+	fmt.Printf("タグの worktree (%d 個):\n\n", len(worktrees))
+	printTagWorktreeLines(worktrees)
+	return nil
+}
+
+// printTagWorktreeLines はタグの worktree を1行ずつ表示します
+func printTagWorktreeLines(worktrees []tagWorktree) {
+	for _, wt := range worktrees {
+		fmt.Printf("  %-16s %s（%s）\n", wt.Tag, wt.Path, describeTagWorktree(wt))
+	}
+}
+
+// cleanTagWorktrees はタグの worktree を確認してから削除します
+func cleanTagWorktrees() error {
+	worktrees, err := listTagWorktrees()
+	if err != nil {
+		return err
+	}
+	if len(worktrees) == 0 {
+		fmt.Println("タグの worktree はありません")
+		return nil
+	}
+
+	fmt.Printf("削除するタグの worktree (%d 個):\n\n", len(worktrees))
+	printTagWorktreeLines(worktrees)
+	fmt.Println()
+	if !ui.Confirm("これらの worktree を削除しますか？", false) {
+		fmt.Println("キャンセルしました")
+		return nil
+	}
+
+	removeTagWorktrees(worktrees)
+	return nil
+}
+
+// removeTagWorktrees はタグの worktree を削除します。
+// 未コミットの変更がある worktree は削除せずに残します。
+// hotfix ブランチなどのブランチは削除しません。
+//
+// 戻り値:
+//   - int: 削除した worktree の数
+func removeTagWorktrees(worktrees []tagWorktree) int {
+	removed := 0
+	for _, wt := range worktrees {
+		if wt.Dirty {
+			fmt.Printf("警告: %s には未コミットの変更があるため削除しません\n", wt.Path)
+			continue
+		}
+		if output, err := exec.Command("git", "worktree", "remove", wt.Path).CombinedOutput(); err != nil {
+			fmt.Printf("警告: %s を削除できませんでした: %s\n", wt.Path, strings.TrimSpace(string(output)))
+			continue
+		}
+		removed++
+		fmt.Printf("✓ %s を削除しました\n", wt.Path)
+		if wt.Branch != "" {
+			fmt.Printf("  ブランチ %s は残しています（不要な場合: git branch -d %s）\n", wt.Branch, wt.Branch)
+		}
+	}
+	_ = gitcmd.RunQuiet("worktree", "prune")
+	return removed
+}
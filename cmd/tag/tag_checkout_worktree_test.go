@@ -0,0 +1,135 @@
+package tag
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tonbiattack/git-plus/internal/testutil"
+)
+
+// setupTagWorktreeRepo は v1.0.0 と v1.1.0 のタグを持つテスト用リポジトリを作成します
+func setupTagWorktreeRepo(t *testing.T) *testutil.GitRepo {
+	t.Helper()
+
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("app.txt", "1.0.0\n")
+	repo.Commit("Initial commit")
+	repo.CreateTag("v1.0.0", "v1.0.0")
+	repo.CreateFile("app.txt", "1.1.0\n")
+	repo.Commit("Update app")
+	repo.CreateTag("v1.1.0", "v1.1.0")
+	chdirTagRepo(t, repo.Dir)
+	return repo
+}
+
+// expectedTagWorktreePath は worktree-new と同じ規則のタグの worktree のパスを返します
+func expectedTagWorktreePath(t *testing.T, repo *testutil.GitRepo, tag string) string {
+	t.Helper()
+
+	root, err := filepath.EvalSymlinks(repo.Dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(filepath.Dir(root), filepath.Base(root)+"-"+strings.ReplaceAll(tag, "/", "-"))
+}
+
+// TestCheckoutTagWorktree はタグの worktree の作成と再利用をテストします
+func TestCheckoutTagWorktree(t *testing.T) {
+	repo := setupTagWorktreeRepo(t)
+	want := expectedTagWorktreePath(t, repo, "v1.0.0")
+	branch := repo.CurrentBranch()
+
+	path, err := checkoutTagWorktree("v1.0.0", false)
+	if err != nil {
+		t.Fatalf("checkoutTagWorktree() error = %v", err)
+	}
+	if path != want {
+		t.Errorf("path = %q, want %q", path, want)
+	}
+	if got := strings.TrimSpace(repo.MustGit("-C", path, "describe", "--tags", "--exact-match")); got != "v1.0.0" {
+		t.Errorf("worktree HEAD = %q, want v1.0.0", got)
+	}
+	if got := repo.CurrentBranch(); got != branch {
+		t.Errorf("main worktree should stay on %s, got %q", branch, got)
+	}
+
+	// 2回目は既存の worktree を再利用する
+	again, err := checkoutTagWorktree("v1.0.0", false)
+	if err != nil || again != path {
+		t.Errorf("checkoutTagWorktree() = %q, %v, want reuse of %q", again, err, path)
+	}
+
+	// 既存の worktree で hotfix ブランチを開始する
+	if _, err := checkoutTagWorktree("v1.0.0", true); err != nil {
+		t.Fatalf("checkoutTagWorktree(hotfix) error = %v", err)
+	}
+	if got := strings.TrimSpace(repo.MustGit("-C", path, "branch", "--show-current")); got != "hotfix/v1.0.0" {
+		t.Errorf("worktree branch = %q, want hotfix/v1.0.0", got)
+	}
+}
+
+// TestCheckoutTagWorktree_Hotfix は hotfix ブランチ付きの worktree の作成をテストします
+func TestCheckoutTagWorktree_Hotfix(t *testing.T) {
+	repo := setupTagWorktreeRepo(t)
+
+	path, err := checkoutTagWorktree("v1.0.0", true)
+	if err != nil {
+		t.Fatalf("checkoutTagWorktree() error = %v", err)
+	}
+	if got := strings.TrimSpace(repo.MustGit("-C", path, "branch", "--show-current")); got != "hotfix/v1.0.0" {
+		t.Errorf("worktree branch = %q, want hotfix/v1.0.0", got)
+	}
+	if got := repo.ReadFile("app.txt"); got != "1.1.0\n" {
+		t.Errorf("main worktree should not change, app.txt = %q", got)
+	}
+}
+
+// TestListAndRemoveTagWorktrees はタグの worktree の一覧と削除をテストします
+func TestListAndRemoveTagWorktrees(t *testing.T) {
+	repo := setupTagWorktreeRepo(t)
+
+	clean, err := checkoutTagWorktree("v1.0.0", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dirty, err := checkoutTagWorktree("v1.1.0", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dirty, "app.txt"), []byte("changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// タグではない worktree は対象外
+	repo.MustGit("worktree", "add", "--quiet", expectedTagWorktreePath(t, repo, "feature/x"), "-b", "feature/x")
+
+	worktrees, err := listTagWorktrees()
+	if err != nil {
+		t.Fatalf("listTagWorktrees() error = %v", err)
+	}
+	if len(worktrees) != 2 {
+		t.Fatalf("listTagWorktrees() = %+v, want 2 tag worktrees", worktrees)
+	}
+	byTag := map[string]tagWorktree{}
+	for _, wt := range worktrees {
+		byTag[wt.Tag] = wt
+	}
+	if wt := byTag["v1.0.0"]; wt.Path != clean || wt.Dirty || wt.Moved {
+		t.Errorf("v1.0.0 = %+v", wt)
+	}
+	if wt := byTag["v1.1.0"]; wt.Path != dirty || !wt.Dirty {
+		t.Errorf("v1.1.0 = %+v", wt)
+	}
+	if got := describeTagWorktree(byTag["v1.1.0"]); got != "detached HEAD、未コミットの変更あり" {
+		t.Errorf("describeTagWorktree() = %q", got)
+	}
+
+	if removed := removeTagWorktrees(worktrees); removed != 1 {
+		t.Errorf("removeTagWorktrees() = %d, want 1", removed)
+	}
+	worktrees, _ = listTagWorktrees()
+	if len(worktrees) != 1 || worktrees[0].Tag != "v1.1.0" {
+		t.Errorf("dirty worktree should remain, got %+v", worktrees)
+	}
+}
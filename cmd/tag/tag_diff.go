@@ -2,24 +2,36 @@
 Package tag は git の拡張コマンドのうち、タグ関連コマンドを定義します。
 
 このファイル (tag_diff.go) は、2つのタグ間の差分を取得するコマンドを提供します。
-指定された2つのタグ間のコミット差分をファイルに出力します。
+指定された2つのタグ間のコミット差分と、リリースのレビューに必要な統計をファイルに出力します。
 
 主な機能:
   - 2つのタグ間のコミット差分の取得
   - マージコミットの自動除外
-  - ファイルへの差分出力
+  - ファイルへの差分出力（text/markdown/json、tag_diff_format.go）
   - コミット数の集計
+  - 作成者ごとのコミット数
+  - トップレベルのディレクトリごとの変更行数（diffstat）
+  - 追加・削除・名前変更・変更されたファイルの内訳
+  - マージコミットとスカッシュコミット（(#123)）からの PR 番号の抽出と、
+    GitHub CLI によるタイトルの取得
 
 使用例:
 
-	git tag-diff V4.2.00.00 V4.3.00.00  # 2つのタグ間の差分を取得
+	git tag-diff V4.2.00.00 V4.3.00.00               # 2つのタグ間の差分を取得
+	git tag-diff v1.2.0 v1.3.0 --format=markdown     # Markdown で出力
+	git tag-diff v1.2.0 v1.3.0 --format=json -o -    # JSON を標準出力に出力
 */
 package tag
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -27,6 +39,59 @@ import (
 	"github.com/tonbiattack/git-plus/internal/gitcmd"
 )
 
+// rootDirName はトップレベルのファイルをまとめるディレクトリ名です
+const rootDirName = "(ルート)"
+
+var (
+	tagDiffDetailFormat string // 出力形式（text/markdown/json）
+	tagDiffDetailOutput string // 出力ファイル名（- の場合は標準出力）
+	tagDiffNoFetch      bool   // PR のタイトルを GitHub から取得しない
+
+	squashPRRefPattern = regexp.MustCompile(`^(.*?)\s*\(#(\d+)\)\s*$`)
+	mergePRRefPattern  = regexp.MustCompile(`^Merge pull request #(\d+) `)
+)
+
+// dirStat はトップレベルのディレクトリごとの変更行数を表す構造体です
+type dirStat struct {
+	Name       string `json:"name"` // ディレクトリ名（例: cmd/、トップレベルのファイルは (ルート)）
+	Files      int    `json:"files"`
+	Insertions int    `json:"insertions"`
+	Deletions  int    `json:"deletions"`
+}
+
+// renamedFile は名前が変更されたファイルを表す構造体です
+type renamedFile struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// fileChanges は変更の種類ごとのファイルの内訳を表す構造体です
+type fileChanges struct {
+	Added    []string      `json:"added"`
+	Deleted  []string      `json:"deleted"`
+	Renamed  []renamedFile `json:"renamed"`
+	Modified []string      `json:"modified"`
+}
+
+// pullRequestRef はコミットから抽出した PR を表す構造体です
+type pullRequestRef struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+}
+
+// tagDiffSummary は2つのタグ間の差分の詳細を表す構造体です
+type tagDiffSummary struct {
+	From         string           `json:"from"`
+	To           string           `json:"to"`
+	Commits      []diffCommit     `json:"commits"`       // マージコミットを除くコミット（新しい順）
+	Authors      []authorCount    `json:"authors"`       // コミット数の多い順
+	Insertions   int              `json:"insertions"`    // 追加行数の合計
+	Deletions    int              `json:"deletions"`     // 削除行数の合計
+	Directories  []dirStat        `json:"directories"`   // 変更行数の多い順
+	Changes      fileChanges      `json:"changes"`       // 変更の種類ごとのファイル
+	PullRequests []pullRequestRef `json:"pull_requests"` // PR 番号の昇順
+}
+
 // tagDiffCmd は2つのタグ間のコミット差分を取得するコマンドです。
 // git log を使用して、指定されたタグ間の差分をファイルに出力します。
 var tagDiffCmd = &cobra.Command{
@@ -34,16 +99,45 @@ var tagDiffCmd = &cobra.Command{
 	Short: "2つのタグ間の差分を取得",
 	Long: `2つのタグ間のコミット差分を取得し、ファイルに出力します。
 Mergeコミットは自動的に除外されます。
-出力形式: - コミットメッセージ (作成者名, 日付)`,
-	Example: `  git tag-diff V4.2.00.00 V4.3.00.00`,
-	Args:    cobra.ExactArgs(2),
+出力形式: - コミットメッセージ (作成者名, 日付)
+
+コミット一覧に加えて、リリースのレビュー用に次の情報を出力します。
+  - 作成者ごとのコミット数
+  - トップレベルのディレクトリごとの変更ファイル数と行数
+  - 追加・削除・名前変更・変更されたファイルの内訳
+  - マージコミット（Merge pull request #123）とスカッシュコミット（... (#123)）の PR 番号とタイトル
+
+PR のタイトルは GitHub CLI (gh) で取得します。gh が使用できない場合や --no-fetch を
+指定した場合は、コミットメッセージに含まれるタイトルを使用します。
+
+--format で text（デフォルト）、markdown、json を選択できます。
+出力ファイル名は tag_diff_<古いタグ>_to_<新しいタグ>.<拡張子> です（--output - で標準出力）。`,
+	Example: `  git tag-diff V4.2.00.00 V4.3.00.00
+  git tag-diff v1.2.0 v1.3.0 --format=markdown
+  git tag-diff v1.2.0 v1.3.0 --format=json --output=-   # 標準出力に JSON を出力
+  git tag-diff v1.2.0 v1.3.0 --no-fetch                 # GitHub に問い合わせない`,
+	Args: cobra.ExactArgs(2),
 	RunE: func(c *cobra.Command, args []string) error {
 		oldTag := args[0]
 		newTag := args[1]
 
+		format, err := normalizeTagDiffFormat(tagDiffDetailFormat)
+		if err != nil {
+			return err
+		}
+		if format != "text" && format != "markdown" && format != "json" {
+			return fmt.Errorf("tag-diff の出力形式は text/markdown/json のいずれかです: %s", format)
+		}
+
 		// 出力ファイル名の自動生成
 		// 例: tag_diff_v1.0.0_to_v2.0.0.txt
-		outputFile := fmt.Sprintf("tag_diff_%s_to_%s.txt", oldTag, newTag)
+		outputFile := tagDiffDetailOutput
+		if outputFile == "" {
+			outputFile = fmt.Sprintf("tag_diff_%s_to_%s.%s",
+				strings.ReplaceAll(oldTag, "/", "_"),
+				strings.ReplaceAll(newTag, "/", "_"),
+				tagDiffFormatExtensions[format])
+		}
 
 		// タグの存在確認
 		// 両方のタグが存在することを確認してから差分を取得
@@ -54,35 +148,52 @@ Mergeコミットは自動的に除外されます。
 			return fmt.Errorf("タグ '%s' が存在しません", newTag)
 		}
 
-		// git logコマンドの実行
-		// --no-merges: マージコミットを除外
-		// --pretty=format: 出力フォーマットを指定
-		tagRange := fmt.Sprintf("%s..%s", oldTag, newTag)
-		output, err := gitcmd.Run("log", tagRange, "--no-merges", "--pretty=format:- %s (%an, %ad)", "--date=iso")
+		summary, err := collectTagDiffSummary(oldTag, newTag)
 		if err != nil {
-			return fmt.Errorf("git logの実行に失敗しました: %w", err)
+			return err
 		}
 
-		// 出力が空の場合は差分なし
-		if len(output) == 0 {
+		// コミットもファイルの変更もない場合は差分なし
+		if len(summary.Commits) == 0 && len(summary.Directories) == 0 {
 			fmt.Printf("タグ %s と %s の間に差分はありません。\n", oldTag, newTag)
 			return nil
 		}
 
+		// PR のタイトルを GitHub から取得（失敗した場合はコミットメッセージのタイトルのまま）
+		if !tagDiffNoFetch && len(summary.PullRequests) > 0 && checkGitHubCLIInstalled() {
+			titles, err := fetchPullRequestTitles(summary.PullRequests)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "警告: PR のタイトルを取得できませんでした: %v\n", err)
+			}
+			applyPullRequestTitles(summary.PullRequests, titles)
+		}
+
+		content, err := renderTagDiffSummary(format, summary)
+		if err != nil {
+			return err
+		}
+
+		if outputFile == "-" {
+			fmt.Print(content)
+			return nil
+		}
+
 		// ファイルに書き込み
 		absPath, err := filepath.Abs(outputFile)
 		if err != nil {
 			return fmt.Errorf("ファイルパスの取得に失敗しました: %w", err)
 		}
 
-		if err := os.WriteFile(outputFile, output, 0644); err != nil {
+		if err := os.WriteFile(outputFile, []byte(content), 0644); err != nil {
 			return fmt.Errorf("ファイルへの書き込みに失敗しました: %w", err)
 		}
 
 		// サマリー表示
-		commits := strings.Split(string(output), "\n")
 		fmt.Printf("✓ タグ %s と %s の差分を %s に出力しました。\n", oldTag, newTag, absPath)
-		fmt.Printf("  コミット数: %d\n", len(commits))
+		fmt.Printf("  コミット数: %d\n", len(summary.Commits))
+		fmt.Printf("  作成者: %d 人\n", len(summary.Authors))
+		fmt.Printf("  変更: %d ファイル (+%d -%d)\n", summary.fileCount(), summary.Insertions, summary.Deletions)
+		fmt.Printf("  PR: %d 件\n", len(summary.PullRequests))
 		return nil
 	},
 }
@@ -100,8 +211,254 @@ func verifyTagExists(tag string) error {
 	return gitcmd.RunQuiet("rev-parse", "--verify", tag)
 }
 
+// collectTagDiffSummary は2つのタグ間のコミット・作成者・ファイルの変更・PR を取得します。
+//
+// パラメータ:
+//   - oldTag: 古いタグ
+//   - newTag: 新しいタグ
+//
+// 戻り値:
+//   - *tagDiffSummary: 差分の詳細（PR のタイトルはコミットメッセージから取得したもの）
+//   - error: git コマンドの実行に失敗した場合のエラー情報
+func collectTagDiffSummary(oldTag, newTag string) (*tagDiffSummary, error) {
+	commits, err := getTagDiffCommits(oldTag, newTag)
+	if err != nil {
+		return nil, fmt.Errorf("git logの実行に失敗しました: %w", err)
+	}
+	files, err := getTagDiffFileStats(oldTag, newTag)
+	if err != nil {
+		return nil, fmt.Errorf("git diffの実行に失敗しました: %w", err)
+	}
+	statusOutput, err := gitcmd.Run("diff", "--name-status", "-z", "-M", oldTag, newTag)
+	if err != nil {
+		return nil, fmt.Errorf("git diffの実行に失敗しました: %w", err)
+	}
+	messages, err := gitcmd.Run("log", fmt.Sprintf("%s..%s", oldTag, newTag), "--format=%s%x00%b%x1e")
+	if err != nil {
+		return nil, fmt.Errorf("git logの実行に失敗しました: %w", err)
+	}
+
+	summary := &tagDiffSummary{
+		From:         oldTag,
+		To:           newTag,
+		Commits:      commits,
+		Authors:      countAuthors(commits),
+		Directories:  groupByTopLevelDir(files),
+		Changes:      parseNameStatus(string(statusOutput)),
+		PullRequests: parsePullRequestRefs(string(messages)),
+	}
+	for _, f := range files {
+		summary.Insertions += f.Insertions
+		summary.Deletions += f.Deletions
+	}
+	return summary, nil
+}
+
+// fileCount は変更されたファイルの数を返します
+func (s *tagDiffSummary) fileCount() int {
+	return len(s.Changes.Added) + len(s.Changes.Deleted) + len(s.Changes.Renamed) + len(s.Changes.Modified)
+}
+
+// topLevelDir はパスのトップレベルのディレクトリ名（例: cmd/）を返します
+// トップレベルのファイルは rootDirName にまとめます。
+func topLevelDir(path string) string {
+	if i := strings.Index(path, "/"); i >= 0 {
+		return path[:i+1]
+	}
+	return rootDirName
+}
+
+// groupByTopLevelDir はファイルごとの変更行数をトップレベルのディレクトリごとに集計します
+// 変更行数（追加＋削除）の多い順、同数の場合は名前順に並べます。
+func groupByTopLevelDir(files []fileStat) []dirStat {
+	index := make(map[string]int)
+	var dirs []dirStat
+	for _, f := range files {
+		name := topLevelDir(f.Path)
+		i, ok := index[name]
+		if !ok {
+			i = len(dirs)
+			index[name] = i
+			dirs = append(dirs, dirStat{Name: name})
+		}
+		dirs[i].Files++
+		dirs[i].Insertions += f.Insertions
+		dirs[i].Deletions += f.Deletions
+	}
+
+	sort.Slice(dirs, func(i, j int) bool {
+		ci := dirs[i].Insertions + dirs[i].Deletions
+		cj := dirs[j].Insertions + dirs[j].Deletions
+		if ci != cj {
+			return ci > cj
+		}
+		return dirs[i].Name < dirs[j].Name
+	})
+	return dirs
+}
+
+// parseNameStatus は git diff --name-status -z -M の出力を変更の種類ごとに分類します
+//
+// 内部処理:
+//
+//	ステータスの後にパスが続きます。名前変更（R）とコピー（C）は変更前と変更後の2つのパスが続きます。
+//	コピーは追加として、型の変更（T）は変更として扱います。
+func parseNameStatus(output string) fileChanges {
+	changes := fileChanges{
+		Added:    []string{},
+		Deleted:  []string{},
+		Renamed:  []renamedFile{},
+		Modified: []string{},
+	}
+
+	fields := strings.Split(output, "\x00")
+	for i := 0; i+1 < len(fields); i++ {
+		status := fields[i]
+		if status == "" {
+			continue
+		}
+		switch status[0] {
+		case 'R', 'C':
+			if i+2 >= len(fields) {
+				return changes
+			}
+			from, to := fields[i+1], fields[i+2]
+			i += 2
+			if status[0] == 'R' {
+				changes.Renamed = append(changes.Renamed, renamedFile{From: from, To: to})
+			} else {
+				changes.Added = append(changes.Added, to)
+			}
+		case 'A':
+			i++
+			changes.Added = append(changes.Added, fields[i])
+		case 'D':
+			i++
+			changes.Deleted = append(changes.Deleted, fields[i])
+		default:
+			i++
+			changes.Modified = append(changes.Modified, fields[i])
+		}
+	}
+	return changes
+}
+
+// parsePullRequestRefs はコミットメッセージから PR 番号とタイトルを抽出します
+//
+// パラメータ:
+//   - output: git log --format=%s%x00%b%x1e の出力（マージコミットを含む）
+//
+// 戻り値:
+//   - []pullRequestRef: PR 番号の昇順（同じ PR は1件にまとめる）
+//
+// 内部処理:
+//
+//	"Merge pull request #123 from ..." の場合は本文の1行目を、
+//	"タイトル (#123)" の場合は (#123) を除いた件名をタイトルとします。
+func parsePullRequestRefs(output string) []pullRequestRef {
+	titles := make(map[int]string)
+	for _, record := range strings.Split(output, "\x1e") {
+		parts := strings.SplitN(strings.TrimLeft(record, "\n"), "\x00", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		subject := strings.TrimSpace(parts[0])
+
+		if m := mergePRRefPattern.FindStringSubmatch(subject); m != nil {
+			number, _ := strconv.Atoi(m[1])
+			title := strings.TrimSpace(strings.SplitN(strings.TrimSpace(parts[1]), "\n", 2)[0])
+			if titles[number] == "" {
+				titles[number] = title
+			}
+			continue
+		}
+		if m := squashPRRefPattern.FindStringSubmatch(subject); m != nil {
+			number, _ := strconv.Atoi(m[2])
+			if titles[number] == "" {
+				titles[number] = m[1]
+			}
+		}
+	}
+
+	refs := make([]pullRequestRef, 0, len(titles))
+	for number, title := range titles {
+		refs = append(refs, pullRequestRef{Number: number, Title: title})
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].Number < refs[j].Number
+	})
+	return refs
+}
+
+// fetchPullRequestTitles は GitHub から PR のタイトルを取得します
+//
+// パラメータ:
+//   - refs: タイトルを取得する PR
+//
+// 戻り値:
+//   - map[int]string: PR 番号とタイトル（取得できた PR のみ）
+//   - error: gh の実行や応答の解析に失敗した場合のエラー情報
+//
+// 内部処理:
+//
+//	gh api graphql で全ての PR を1回のクエリで取得します（pr123: pullRequest(number: 123) の別名を使用）。
+//	存在しない番号（Issue など）があると gh はエラーを返しますが、取得できた PR の結果は使用します。
+func fetchPullRequestTitles(refs []pullRequestRef) (map[int]string, error) {
+	var query strings.Builder
+	query.WriteString("query($owner: String!, $name: String!) { repository(owner: $owner, name: $name) {")
+	for _, ref := range refs {
+		fmt.Fprintf(&query, " pr%d: pullRequest(number: %d) { title }", ref.Number, ref.Number)
+	}
+	query.WriteString(" } }")
+
+	output, runErr := exec.Command("gh", "api", "graphql",
+		"-F", "owner={owner}",
+		"-F", "name={repo}",
+		"-f", "query="+query.String()).Output()
+	if len(output) == 0 {
+		if runErr != nil {
+			return nil, runErr
+		}
+		return nil, fmt.Errorf("gh api graphql の応答が空です")
+	}
+
+	var response struct {
+		Data struct {
+			Repository map[string]*struct {
+				Title string `json:"title"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(output, &response); err != nil {
+		return nil, fmt.Errorf("gh api graphql の応答の解析に失敗しました: %w", err)
+	}
+
+	titles := make(map[int]string)
+	for alias, pr := range response.Data.Repository {
+		number, err := strconv.Atoi(strings.TrimPrefix(alias, "pr"))
+		if err != nil || pr == nil {
+			continue
+		}
+		titles[number] = pr.Title
+	}
+	return titles, nil
+}
+
+// applyPullRequestTitles は GitHub から取得したタイトルで PR のタイトルを置き換えます
+func applyPullRequestTitles(refs []pullRequestRef, titles map[int]string) {
+	for i := range refs {
+		if title, ok := titles[refs[i].Number]; ok && title != "" {
+			refs[i].Title = title
+		}
+	}
+}
+
 // init はコマンドの初期化を行います。
 // tagDiffCmd を RootCmd に登録することで、CLI から実行可能にします。
 func init() {
 	cmd.RootCmd.AddCommand(tagDiffCmd)
+
+	tagDiffCmd.Flags().StringVarP(&tagDiffDetailFormat, "format", "f", "text", "出力形式（text/markdown/json）")
+	tagDiffCmd.Flags().StringVarP(&tagDiffDetailOutput, "output", "o", "", "出力ファイル名（- で標準出力。未指定時は tag_diff_<古いタグ>_to_<新しいタグ>.<拡張子>）")
+	tagDiffCmd.Flags().BoolVar(&tagDiffNoFetch, "no-fetch", false, "PR のタイトルを GitHub から取得しない")
 }
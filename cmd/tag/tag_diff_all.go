@@ -9,15 +9,22 @@ Package tag は git の拡張コマンドのうち、タグ関連コマンドを
   - 連続するタグ間の差分を一括出力
   - マージコミットの自動除外
   - 単一ファイルまたは複数ファイルへの出力
-  - タグプレフィックスによるフィルタリング
+  - 出力形式の選択（text/markdown/json/html/csv、tag_diff_all_format.go）
+  - タグペアごとのコミット一覧・作成者・ファイルの変更統計
+  - 複数のタグペアの並列処理（--jobs、出力の順序は常に同じ）
+  - タグプレフィックスによるフィルタリング（api/v1.2.0 のようなモノレポのタグは
+    new-tag --prefix と同じ SemVer 2.0 の優先順位で並べ替え）
   - 詳細なサマリー情報
 
 使用例:
 
 	git tag-diff-all                    # 全タグ間の差分を取得
 	git tag-diff-all --prefix=V4        # V4で始まるタグのみ
+	git tag-diff-all --prefix=api/      # api/v1.0.0, api/v1.1.0 ... をバージョン順に
 	git tag-diff-all --split            # タグペアごとにファイル分割
 	git tag-diff-all --output=diff.txt  # 出力ファイル名を指定
+	git tag-diff-all --format=json      # JSON で出力（tag_diff_all.json）
+	git tag-diff-all --jobs=8           # 8並列で差分を取得
 */
 package tag
 
@@ -25,13 +32,17 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/tonbiattack/git-plus/cmd"
 	"github.com/tonbiattack/git-plus/internal/gitcmd"
+	"github.com/tonbiattack/git-plus/internal/semver"
 )
 
 // タグ情報を保持する構造体
@@ -40,6 +51,41 @@ type tagInfo struct {
 	Date time.Time
 }
 
+// diffCommit はタグペアに含まれるコミット1件を表す構造体です
+type diffCommit struct {
+	Hash    string `json:"hash"`
+	Subject string `json:"subject"`
+	Author  string `json:"author"`
+	Date    string `json:"date"` // コミット日（YYYY-MM-DD）
+}
+
+// authorCount は作成者ごとのコミット数を表す構造体です
+type authorCount struct {
+	Name    string `json:"name"`
+	Commits int    `json:"commits"`
+}
+
+// fileStat はファイルごとの変更行数を表す構造体です
+type fileStat struct {
+	Path       string `json:"path"`
+	OldPath    string `json:"old_path,omitempty"` // 名前が変更された場合の変更前のパス
+	Insertions int    `json:"insertions"`
+	Deletions  int    `json:"deletions"`
+	Binary     bool   `json:"binary,omitempty"`
+}
+
+// tagPairDiff は1つのタグペアの差分を表す構造体です
+type tagPairDiff struct {
+	From       tagInfo
+	To         tagInfo
+	Commits    []diffCommit  // マージコミットを除くコミット（新しい順）
+	Authors    []authorCount // コミット数の多い順
+	Files      []fileStat    // 2つのタグ間で変更されたファイル
+	Insertions int           // 追加行数の合計
+	Deletions  int           // 削除行数の合計
+	Err        error         // 差分の取得に失敗した場合のエラー
+}
+
 // tagDiffAllCmd は全てのタグ間の差分を一括取得するコマンドです。
 var tagDiffAllCmd = &cobra.Command{
 	Use:   "tag-diff-all",
@@ -50,12 +96,28 @@ var tagDiffAllCmd = &cobra.Command{
 Mergeコミットは自動的に除外されます。
 出力形式: - コミットメッセージ (作成者名, 日付)
 
-各セクションにはタグ名、コミット数、統計情報が含まれます。`,
+各セクションにはタグ名、コミット数、統計情報が含まれます。
+
+--format で text（デフォルト）、markdown、json、html、csv を選択できます。
+markdown/json/html にはタグペアごとのコミット一覧、作成者ごとのコミット数、
+ファイルごとの変更行数が含まれ、csv はタグペアごとに1行のサマリーを出力します。
+--output を指定しない場合のファイル名は tag_diff_all.<拡張子> です。
+
+タグペアの差分は --jobs で指定した数だけ並列に取得します（デフォルト: CPU 数）。
+並列で処理しても出力の順序は変わりません。
+
+--prefix で絞り込んだタグがすべてプレフィックスを除いて SemVer として
+解析できる場合（api/v1.2.0 など）は、new-tag --prefix と同じ優先順位で並べます。`,
 	Example: `  git tag-diff-all                    # 全タグ間の差分を取得
   git tag-diff-all --prefix=V4        # V4で始まるタグのみ
+  git tag-diff-all --prefix=api/      # api/ のタグをバージョン順に
   git tag-diff-all --split            # タグペアごとにファイル分割
   git tag-diff-all --output=diff.txt  # 出力ファイル名を指定
-  git tag-diff-all --limit=10         # 最新10タグ間の差分のみ`,
+  git tag-diff-all --limit=10         # 最新10タグ間の差分のみ
+  git tag-diff-all --format=markdown  # Markdown で出力
+  git tag-diff-all --format=csv --output=tags.csv
+  git tag-diff-all --format=json --split  # タグペアごとに JSON ファイルを出力
+  git tag-diff-all --jobs=1           # 並列処理しない`,
 	RunE: runTagDiffAll,
 }
 
@@ -65,6 +127,8 @@ var (
 	tagDiffSplit   bool
 	tagDiffLimit   int
 	tagDiffReverse bool
+	tagDiffFormat  string
+	tagDiffJobs    int
 )
 
 func init() {
@@ -75,9 +139,20 @@ func init() {
 	tagDiffAllCmd.Flags().BoolVarP(&tagDiffSplit, "split", "s", false, "タグペアごとにファイルを分割")
 	tagDiffAllCmd.Flags().IntVarP(&tagDiffLimit, "limit", "l", 0, "処理するタグ数の上限（0=無制限）")
 	tagDiffAllCmd.Flags().BoolVarP(&tagDiffReverse, "reverse", "r", false, "新しいタグから古いタグの順で出力")
+	tagDiffAllCmd.Flags().StringVarP(&tagDiffFormat, "format", "f", "text", "出力形式（text/markdown/json/html/csv）")
+	tagDiffAllCmd.Flags().IntVarP(&tagDiffJobs, "jobs", "j", runtime.NumCPU(), "並列に処理するタグペアの数")
 }
 
 func runTagDiffAll(c *cobra.Command, args []string) error {
+	format, err := normalizeTagDiffFormat(tagDiffFormat)
+	if err != nil {
+		return err
+	}
+	output := tagDiffOutput
+	if !c.Flags().Changed("output") {
+		output = "tag_diff_all." + tagDiffFormatExtensions[format]
+	}
+
 	// 全タグを取得
 	tags, err := getAllTags()
 	if err != nil {
@@ -94,6 +169,7 @@ func runTagDiffAll(c *cobra.Command, args []string) error {
 		if len(tags) < 2 {
 			return fmt.Errorf("プレフィックス '%s' に一致するタグが2つ未満です", tagDiffPrefix)
 		}
+		sortTagsByVersion(tags, tagDiffPrefix)
 	}
 
 	// タグ数の制限
@@ -105,10 +181,11 @@ func runTagDiffAll(c *cobra.Command, args []string) error {
 	fmt.Printf("📊 %d個のタグペアの差分を取得します\n\n", len(tags)-1)
 
 	// 差分を取得
+	diffs := collectTagPairDiffs(generateTagPairs(tags), tagDiffJobs)
 	if tagDiffSplit {
-		return generateSplitFiles(tags)
+		return generateSplitFiles(diffs, format)
 	}
-	return generateSingleFile(tags)
+	return generateSingleFile(len(tags), diffs, format, output)
 }
 
 // getAllTags はリポジトリ内の全タグを時系列順で取得します
@@ -204,84 +281,49 @@ func filterTagsByPrefix(tags []tagInfo, prefix string) []tagInfo {
 	return filtered
 }
 
-// generateSingleFile は全差分を1つのファイルに出力します
-func generateSingleFile(tags []tagInfo) error {
-	var builder strings.Builder
-	totalCommits := 0
-	processedPairs := 0
-
-	// ヘッダー
-	builder.WriteString("================================================================================\n")
-	builder.WriteString("タグ間差分レポート\n")
-	builder.WriteString(fmt.Sprintf("生成日時: %s\n", time.Now().Format("2006-01-02 15:04:05")))
-	builder.WriteString(fmt.Sprintf("タグ数: %d\n", len(tags)))
-	builder.WriteString(fmt.Sprintf("タグペア数: %d\n", len(tags)-1))
-	builder.WriteString("================================================================================\n\n")
-
-	// 各タグペアの差分を取得
-	pairs := generateTagPairs(tags)
-	for i, pair := range pairs {
-		oldTag := pair[0]
-		newTag := pair[1]
-
-		fmt.Printf("  [%d/%d] %s → %s ... ", i+1, len(pairs), oldTag.Name, newTag.Name)
-
-		diff, commitCount, err := getTagDiff(oldTag.Name, newTag.Name)
-		if err != nil {
-			fmt.Printf("エラー\n")
-			builder.WriteString(fmt.Sprintf("## %s → %s\n", oldTag.Name, newTag.Name))
-			builder.WriteString(fmt.Sprintf("エラー: %s\n\n", err.Error()))
-			continue
-		}
-
-		fmt.Printf("%d コミット\n", commitCount)
-
-		// セクションヘッダー
-		builder.WriteString("--------------------------------------------------------------------------------\n")
-		builder.WriteString(fmt.Sprintf("## %s → %s\n", oldTag.Name, newTag.Name))
-		builder.WriteString(fmt.Sprintf("   期間: %s → %s\n", oldTag.Date.Format("2006-01-02"), newTag.Date.Format("2006-01-02")))
-		builder.WriteString(fmt.Sprintf("   コミット数: %d\n", commitCount))
-		builder.WriteString("--------------------------------------------------------------------------------\n")
-
-		if diff == "" {
-			builder.WriteString("(差分なし)\n")
-		} else {
-			builder.WriteString(diff)
-			builder.WriteString("\n")
+// sortTagsByVersion は全てのタグがプレフィックスを除いて SemVer として解析できる場合に、
+// new-tag と同じ優先順位で古い順に並べ替えます。
+// 解析できないタグが含まれる場合は日付順のまま変更しません。
+//
+// 戻り値:
+//   - bool: 並べ替えた場合は true
+func sortTagsByVersion(tags []tagInfo, prefix string) bool {
+	for _, tag := range tags {
+		if _, err := semver.ParseTag(tag.Name, prefix); err != nil {
+			return false
 		}
-		builder.WriteString("\n")
-
-		totalCommits += commitCount
-		processedPairs++
 	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		return semver.CompareTagsWithPrefix(tags[i].Name, tags[j].Name, prefix) < 0
+	})
+	return true
+}
 
-	// サマリー
-	builder.WriteString("================================================================================\n")
-	builder.WriteString("サマリー\n")
-	builder.WriteString("================================================================================\n")
-	builder.WriteString(fmt.Sprintf("処理済みタグペア: %d\n", processedPairs))
-	builder.WriteString(fmt.Sprintf("総コミット数: %d\n", totalCommits))
-	builder.WriteString(fmt.Sprintf("平均コミット数/ペア: %.1f\n", float64(totalCommits)/float64(processedPairs)))
+// generateSingleFile は全差分を1つのファイルに出力します
+func generateSingleFile(tagCount int, diffs []tagPairDiff, format, output string) error {
+	content, err := renderTagDiffReport(format, tagCount, diffs, time.Now())
+	if err != nil {
+		return err
+	}
 
 	// ファイルに書き込み
-	absPath, err := filepath.Abs(tagDiffOutput)
+	absPath, err := filepath.Abs(output)
 	if err != nil {
 		return fmt.Errorf("ファイルパスの取得に失敗しました: %w", err)
 	}
 
-	if err := os.WriteFile(tagDiffOutput, []byte(builder.String()), 0644); err != nil {
+	if err := os.WriteFile(output, []byte(content), 0644); err != nil {
 		return fmt.Errorf("ファイルへの書き込みに失敗しました: %w", err)
 	}
 
 	fmt.Printf("\n✓ 全差分を %s に出力しました。\n", absPath)
-	fmt.Printf("  総コミット数: %d\n", totalCommits)
+	fmt.Printf("  総コミット数: %d\n", totalDiffCommits(diffs))
 
 	return nil
 }
 
 // generateSplitFiles はタグペアごとに別ファイルに出力します
-func generateSplitFiles(tags []tagInfo) error {
-	totalCommits := 0
+func generateSplitFiles(diffs []tagPairDiff, format string) error {
 	outputDir := "tag_diffs"
 
 	// 出力ディレクトリを作成
@@ -289,54 +331,118 @@ func generateSplitFiles(tags []tagInfo) error {
 		return fmt.Errorf("ディレクトリの作成に失敗しました: %w", err)
 	}
 
-	pairs := generateTagPairs(tags)
-	for i, pair := range pairs {
-		oldTag := pair[0]
-		newTag := pair[1]
-
-		fmt.Printf("  [%d/%d] %s → %s ... ", i+1, len(pairs), oldTag.Name, newTag.Name)
-
-		diff, commitCount, err := getTagDiff(oldTag.Name, newTag.Name)
-		if err != nil {
-			fmt.Printf("エラー\n")
+	fileCount := 0
+	for _, d := range diffs {
+		if d.Err != nil {
 			continue
 		}
 
-		fmt.Printf("%d コミット\n", commitCount)
-
 		// ファイル名の生成（タグ名の/を_に置換）
-		safeName := fmt.Sprintf("diff_%s_to_%s.txt",
-			strings.ReplaceAll(oldTag.Name, "/", "_"),
-			strings.ReplaceAll(newTag.Name, "/", "_"))
+		safeName := fmt.Sprintf("diff_%s_to_%s.%s",
+			strings.ReplaceAll(d.From.Name, "/", "_"),
+			strings.ReplaceAll(d.To.Name, "/", "_"),
+			tagDiffFormatExtensions[format])
 		filePath := filepath.Join(outputDir, safeName)
 
-		// ファイル内容
-		var builder strings.Builder
-		builder.WriteString(fmt.Sprintf("タグ差分: %s → %s\n", oldTag.Name, newTag.Name))
-		builder.WriteString(fmt.Sprintf("期間: %s → %s\n", oldTag.Date.Format("2006-01-02"), newTag.Date.Format("2006-01-02")))
-		builder.WriteString(fmt.Sprintf("コミット数: %d\n", commitCount))
-		builder.WriteString("--------------------------------------------------------------------------------\n")
-		if diff == "" {
-			builder.WriteString("(差分なし)\n")
-		} else {
-			builder.WriteString(diff)
+		content, err := renderTagDiffPair(format, d, time.Now())
+		if err != nil {
+			return err
 		}
-
-		if err := os.WriteFile(filePath, []byte(builder.String()), 0644); err != nil {
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
 			return fmt.Errorf("ファイル %s への書き込みに失敗しました: %w", filePath, err)
 		}
-
-		totalCommits += commitCount
+		fileCount++
 	}
 
 	absPath, _ := filepath.Abs(outputDir)
 	fmt.Printf("\n✓ 全差分を %s/ に出力しました。\n", absPath)
-	fmt.Printf("  ファイル数: %d\n", len(pairs))
-	fmt.Printf("  総コミット数: %d\n", totalCommits)
+	fmt.Printf("  ファイル数: %d\n", fileCount)
+	fmt.Printf("  総コミット数: %d\n", totalDiffCommits(diffs))
 
 	return nil
 }
 
+// collectTagPairDiffs は全タグペアの差分を並列に取得します
+//
+// パラメータ:
+//   - pairs: タグペア（出力する順）
+//   - jobs: 並列に処理するタグペアの数（1未満の場合は1）
+//
+// 戻り値:
+//   - []tagPairDiff: pairs と同じ順序の差分（処理の完了順に関係なく順序は固定）
+//
+// 内部処理:
+//
+//	jobs 個のワーカーがタグペアのインデックスを受け取り、結果を同じインデックスに格納します。
+//	進捗はメインの goroutine で完了順に表示します。
+func collectTagPairDiffs(pairs [][2]tagInfo, jobs int) []tagPairDiff {
+	results := make([]tagPairDiff, len(pairs))
+	if jobs < 1 {
+		jobs = 1
+	}
+
+	indexes := make(chan int)
+	done := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < jobs && w < len(pairs); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = collectTagPairDiff(pairs[i][0], pairs[i][1])
+				done <- i
+			}
+		}()
+	}
+	go func() {
+		for i := range pairs {
+			indexes <- i
+		}
+		close(indexes)
+		wg.Wait()
+		close(done)
+	}()
+
+	completed := 0
+	for i := range done {
+		completed++
+		d := results[i]
+		fmt.Printf("  [%d/%d] %s → %s ... ", completed, len(pairs), d.From.Name, d.To.Name)
+		if d.Err != nil {
+			fmt.Printf("エラー\n")
+			continue
+		}
+		fmt.Printf("%d コミット\n", len(d.Commits))
+	}
+
+	return results
+}
+
+// collectTagPairDiff は1つのタグペアのコミット・作成者・ファイルの変更統計を取得します
+func collectTagPairDiff(from, to tagInfo) tagPairDiff {
+	d := tagPairDiff{From: from, To: to}
+
+	commits, err := getTagDiffCommits(from.Name, to.Name)
+	if err != nil {
+		d.Err = err
+		return d
+	}
+	files, err := getTagDiffFileStats(from.Name, to.Name)
+	if err != nil {
+		d.Err = err
+		return d
+	}
+
+	d.Commits = commits
+	d.Authors = countAuthors(commits)
+	d.Files = files
+	for _, f := range files {
+		d.Insertions += f.Insertions
+		d.Deletions += f.Deletions
+	}
+	return d
+}
+
 // generateTagPairs はタグのペアを生成します
 func generateTagPairs(tags []tagInfo) [][2]tagInfo {
 	pairs := make([][2]tagInfo, 0, len(tags)-1)
@@ -356,20 +462,96 @@ func generateTagPairs(tags []tagInfo) [][2]tagInfo {
 	return pairs
 }
 
-// getTagDiff は2つのタグ間の差分を取得します
-func getTagDiff(oldTag, newTag string) (string, int, error) {
+// getTagDiffCommits は2つのタグ間のコミット（マージコミットを除く）を取得します
+func getTagDiffCommits(oldTag, newTag string) ([]diffCommit, error) {
 	tagRange := fmt.Sprintf("%s..%s", oldTag, newTag)
-	output, err := gitcmd.Run("log", tagRange, "--no-merges", "--pretty=format:- %s (%an, %ad)", "--date=short")
+	output, err := gitcmd.Run("log", tagRange, "--no-merges", "--pretty=format:%H%x00%s%x00%an%x00%ad", "--date=short")
 	if err != nil {
-		return "", 0, err
+		return nil, err
 	}
+	return parseDiffCommits(string(output)), nil
+}
 
-	if len(output) == 0 {
-		return "", 0, nil
+// parseDiffCommits は git log --pretty=format:%H%x00%s%x00%an%x00%ad の出力を解析します
+func parseDiffCommits(output string) []diffCommit {
+	var commits []diffCommit
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 4 {
+			continue
+		}
+		commits = append(commits, diffCommit{
+			Hash:    fields[0],
+			Subject: fields[1],
+			Author:  fields[2],
+			Date:    fields[3],
+		})
+	}
+	return commits
+}
+
+// countAuthors は作成者ごとのコミット数を数えます（コミット数の多い順、同数は名前順）
+func countAuthors(commits []diffCommit) []authorCount {
+	counts := make(map[string]int)
+	for _, c := range commits {
+		counts[c.Author]++
+	}
+
+	authors := make([]authorCount, 0, len(counts))
+	for name, n := range counts {
+		authors = append(authors, authorCount{Name: name, Commits: n})
+	}
+	sort.Slice(authors, func(i, j int) bool {
+		if authors[i].Commits != authors[j].Commits {
+			return authors[i].Commits > authors[j].Commits
+		}
+		return authors[i].Name < authors[j].Name
+	})
+	return authors
+}
+
+// getTagDiffFileStats は2つのタグ間で変更されたファイルと行数を取得します
+func getTagDiffFileStats(oldTag, newTag string) ([]fileStat, error) {
+	output, err := gitcmd.Run("diff", "--numstat", "-z", "-M", oldTag, newTag)
+	if err != nil {
+		return nil, err
 	}
+	return parseNumstat(string(output)), nil
+}
 
-	diff := strings.TrimSpace(string(output))
-	commitCount := len(strings.Split(diff, "\n"))
+// parseNumstat は git diff --numstat -z の出力を解析します
+// バイナリファイル（行数が "-"）は Binary を true にし、行数は0とします。
+// 名前が変更されたファイルはパスが空のレコードの後に変更前と変更後のパスが続きます。
+func parseNumstat(output string) []fileStat {
+	var files []fileStat
+	fields := strings.Split(output, "\x00")
+	for i := 0; i < len(fields); i++ {
+		parts := strings.SplitN(fields[i], "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		stat := fileStat{Path: parts[2]}
+		if stat.Path == "" && i+2 < len(fields) {
+			stat.OldPath = fields[i+1]
+			stat.Path = fields[i+2]
+			i += 2
+		}
+		if parts[0] == "-" && parts[1] == "-" {
+			stat.Binary = true
+		} else {
+			stat.Insertions, _ = strconv.Atoi(parts[0])
+			stat.Deletions, _ = strconv.Atoi(parts[1])
+		}
+		files = append(files, stat)
+	}
+	return files
+}
 
-	return diff, commitCount, nil
+// totalDiffCommits は全タグペアのコミット数の合計を返します
+func totalDiffCommits(diffs []tagPairDiff) int {
+	total := 0
+	for _, d := range diffs {
+		total += len(d.Commits)
+	}
+	return total
 }
@@ -0,0 +1,393 @@
+// ================================================================================
+// tag_diff_all_format.go - tag-diff-all の出力形式
+// ================================================================================
+// タグペアごとの差分（tagPairDiff）を text/markdown/json/html/csv の各形式に変換します。
+// - text: 従来のプレーンテキスト（コミット一覧とコミット数）
+// - markdown/html: コミット一覧・作成者ごとのコミット数・ファイルごとの変更行数
+// - json: markdown/html と同じ内容を機械処理向けに出力
+// - csv: タグペアごとに1行のサマリー
+// ================================================================================
+
+package tag
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tagDiffFormatExtensions は出力形式ごとのファイルの拡張子です
+var tagDiffFormatExtensions = map[string]string{
+	"text":     "txt",
+	"markdown": "md",
+	"json":     "json",
+	"html":     "html",
+	"csv":      "csv",
+}
+
+// normalizeTagDiffFormat は --format の値を正規化します（md → markdown、txt → text）
+func normalizeTagDiffFormat(format string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	switch format {
+	case "md":
+		format = "markdown"
+	case "txt":
+		format = "text"
+	}
+	if _, ok := tagDiffFormatExtensions[format]; !ok {
+		return "", fmt.Errorf("不明な出力形式です: %s（text/markdown/json/html/csv のいずれかを指定してください）", format)
+	}
+	return format, nil
+}
+
+// renderTagDiffReport は全タグペアの差分を指定した形式のレポートに変換します
+//
+// パラメータ:
+//   - format: 出力形式（normalizeTagDiffFormat で正規化済み）
+//   - tagCount: 対象のタグ数
+//   - diffs: タグペアごとの差分（出力する順）
+//   - generatedAt: レポートの生成日時
+func renderTagDiffReport(format string, tagCount int, diffs []tagPairDiff, generatedAt time.Time) (string, error) {
+	switch format {
+	case "markdown":
+		return renderTagDiffMarkdown(tagCount, diffs, generatedAt), nil
+	case "json":
+		return renderTagDiffJSON(tagCount, diffs, generatedAt)
+	case "html":
+		return renderTagDiffHTML(tagCount, diffs, generatedAt)
+	case "csv":
+		return renderTagDiffCSV(diffs)
+	default:
+		return renderTagDiffText(tagCount, diffs, generatedAt), nil
+	}
+}
+
+// renderTagDiffPair は1つのタグペアの差分を --split 用のファイルの内容に変換します
+func renderTagDiffPair(format string, d tagPairDiff, generatedAt time.Time) (string, error) {
+	if format != "text" {
+		return renderTagDiffReport(format, 2, []tagPairDiff{d}, generatedAt)
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("タグ差分: %s → %s\n", d.From.Name, d.To.Name))
+	builder.WriteString(fmt.Sprintf("期間: %s → %s\n", d.From.Date.Format("2006-01-02"), d.To.Date.Format("2006-01-02")))
+	builder.WriteString(fmt.Sprintf("コミット数: %d\n", len(d.Commits)))
+	builder.WriteString(fmt.Sprintf("変更: %d ファイル (+%d -%d)\n", len(d.Files), d.Insertions, d.Deletions))
+	builder.WriteString("--------------------------------------------------------------------------------\n")
+	if len(d.Commits) == 0 {
+		builder.WriteString("(差分なし)\n")
+	} else {
+		builder.WriteString(formatTextCommits(d.Commits))
+	}
+	return builder.String(), nil
+}
+
+// formatTextCommits はコミット一覧を "- コミットメッセージ (作成者名, 日付)" の形式に変換します
+func formatTextCommits(commits []diffCommit) string {
+	lines := make([]string, 0, len(commits))
+	for _, c := range commits {
+		lines = append(lines, fmt.Sprintf("- %s (%s, %s)", c.Subject, c.Author, c.Date))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderTagDiffText はプレーンテキストのレポートを生成します
+func renderTagDiffText(tagCount int, diffs []tagPairDiff, generatedAt time.Time) string {
+	var builder strings.Builder
+	totalCommits := 0
+	processedPairs := 0
+
+	// ヘッダー
+	builder.WriteString("================================================================================\n")
+	builder.WriteString("タグ間差分レポート\n")
+	builder.WriteString(fmt.Sprintf("生成日時: %s\n", generatedAt.Format("2006-01-02 15:04:05")))
+	builder.WriteString(fmt.Sprintf("タグ数: %d\n", tagCount))
+	builder.WriteString(fmt.Sprintf("タグペア数: %d\n", len(diffs)))
+	builder.WriteString("================================================================================\n\n")
+
+	for _, d := range diffs {
+		if d.Err != nil {
+			builder.WriteString(fmt.Sprintf("## %s → %s\n", d.From.Name, d.To.Name))
+			builder.WriteString(fmt.Sprintf("エラー: %s\n\n", d.Err.Error()))
+			continue
+		}
+
+		// セクションヘッダー
+		builder.WriteString("--------------------------------------------------------------------------------\n")
+		builder.WriteString(fmt.Sprintf("## %s → %s\n", d.From.Name, d.To.Name))
+		builder.WriteString(fmt.Sprintf("   期間: %s → %s\n", d.From.Date.Format("2006-01-02"), d.To.Date.Format("2006-01-02")))
+		builder.WriteString(fmt.Sprintf("   コミット数: %d\n", len(d.Commits)))
+		builder.WriteString(fmt.Sprintf("   変更: %d ファイル (+%d -%d)\n", len(d.Files), d.Insertions, d.Deletions))
+		builder.WriteString("--------------------------------------------------------------------------------\n")
+
+		if len(d.Commits) == 0 {
+			builder.WriteString("(差分なし)\n")
+		} else {
+			builder.WriteString(formatTextCommits(d.Commits))
+			builder.WriteString("\n")
+		}
+		builder.WriteString("\n")
+
+		totalCommits += len(d.Commits)
+		processedPairs++
+	}
+
+	// サマリー
+	builder.WriteString("================================================================================\n")
+	builder.WriteString("サマリー\n")
+	builder.WriteString("================================================================================\n")
+	builder.WriteString(fmt.Sprintf("処理済みタグペア: %d\n", processedPairs))
+	builder.WriteString(fmt.Sprintf("総コミット数: %d\n", totalCommits))
+	if processedPairs > 0 {
+		builder.WriteString(fmt.Sprintf("平均コミット数/ペア: %.1f\n", float64(totalCommits)/float64(processedPairs)))
+	}
+
+	return builder.String()
+}
+
+// renderTagDiffMarkdown は Markdown のレポートを生成します
+func renderTagDiffMarkdown(tagCount int, diffs []tagPairDiff, generatedAt time.Time) string {
+	var builder strings.Builder
+
+	builder.WriteString("# タグ間差分レポート\n\n")
+	builder.WriteString(fmt.Sprintf("- 生成日時: %s\n", generatedAt.Format("2006-01-02 15:04:05")))
+	builder.WriteString(fmt.Sprintf("- タグ数: %d\n", tagCount))
+	builder.WriteString(fmt.Sprintf("- タグペア数: %d\n", len(diffs)))
+	builder.WriteString(fmt.Sprintf("- 総コミット数: %d\n", totalDiffCommits(diffs)))
+
+	for _, d := range diffs {
+		builder.WriteString(fmt.Sprintf("\n## %s → %s\n\n", d.From.Name, d.To.Name))
+		if d.Err != nil {
+			builder.WriteString(fmt.Sprintf("エラー: %s\n", d.Err.Error()))
+			continue
+		}
+
+		builder.WriteString(fmt.Sprintf("- 期間: %s → %s\n", d.From.Date.Format("2006-01-02"), d.To.Date.Format("2006-01-02")))
+		builder.WriteString(fmt.Sprintf("- コミット数: %d\n", len(d.Commits)))
+		builder.WriteString(fmt.Sprintf("- 変更: %d ファイル (+%d -%d)\n", len(d.Files), d.Insertions, d.Deletions))
+
+		if len(d.Commits) == 0 {
+			builder.WriteString("\n(差分なし)\n")
+		} else {
+			builder.WriteString("\n### コミット\n\n")
+			for _, c := range d.Commits {
+				builder.WriteString(fmt.Sprintf("- %s (%s, %s) `%s`\n", c.Subject, c.Author, c.Date, shortHash(c.Hash)))
+			}
+
+			builder.WriteString("\n### 作成者\n\n")
+			for _, a := range d.Authors {
+				builder.WriteString(fmt.Sprintf("- %s: %d\n", a.Name, a.Commits))
+			}
+		}
+
+		if len(d.Files) > 0 {
+			builder.WriteString("\n### ファイル\n\n")
+			builder.WriteString("| ファイル | 追加 | 削除 |\n")
+			builder.WriteString("| --- | ---: | ---: |\n")
+			for _, f := range d.Files {
+				path := escapeMarkdownCell(f.Path)
+				if f.Binary {
+					builder.WriteString(fmt.Sprintf("| %s | (バイナリ) | |\n", path))
+					continue
+				}
+				builder.WriteString(fmt.Sprintf("| %s | %d | %d |\n", path, f.Insertions, f.Deletions))
+			}
+		}
+	}
+
+	return builder.String()
+}
+
+// tagDiffReportJSON は JSON 形式のレポート全体を表す構造体です
+type tagDiffReportJSON struct {
+	GeneratedAt  time.Time         `json:"generated_at"`
+	Tags         int               `json:"tags"`
+	TotalCommits int               `json:"total_commits"`
+	Pairs        []tagPairDiffJSON `json:"pairs"`
+}
+
+// tagPairDiffJSON は JSON 形式のタグペア1件を表す構造体です
+type tagPairDiffJSON struct {
+	From         string        `json:"from"`
+	To           string        `json:"to"`
+	FromDate     string        `json:"from_date"`
+	ToDate       string        `json:"to_date"`
+	CommitCount  int           `json:"commit_count"`
+	Commits      []diffCommit  `json:"commits"`
+	Authors      []authorCount `json:"authors"`
+	FilesChanged int           `json:"files_changed"`
+	Insertions   int           `json:"insertions"`
+	Deletions    int           `json:"deletions"`
+	Files        []fileStat    `json:"files"`
+	Error        string        `json:"error,omitempty"`
+}
+
+// renderTagDiffJSON は JSON のレポートを生成します
+func renderTagDiffJSON(tagCount int, diffs []tagPairDiff, generatedAt time.Time) (string, error) {
+	report := tagDiffReportJSON{
+		GeneratedAt:  generatedAt,
+		Tags:         tagCount,
+		TotalCommits: totalDiffCommits(diffs),
+		Pairs:        make([]tagPairDiffJSON, 0, len(diffs)),
+	}
+	for _, d := range diffs {
+		pair := tagPairDiffJSON{
+			From:         d.From.Name,
+			To:           d.To.Name,
+			FromDate:     d.From.Date.Format("2006-01-02"),
+			ToDate:       d.To.Date.Format("2006-01-02"),
+			CommitCount:  len(d.Commits),
+			Commits:      d.Commits,
+			Authors:      d.Authors,
+			FilesChanged: len(d.Files),
+			Insertions:   d.Insertions,
+			Deletions:    d.Deletions,
+			Files:        d.Files,
+		}
+		// 空の一覧は null ではなく [] として出力する
+		if pair.Commits == nil {
+			pair.Commits = []diffCommit{}
+		}
+		if pair.Authors == nil {
+			pair.Authors = []authorCount{}
+		}
+		if pair.Files == nil {
+			pair.Files = []fileStat{}
+		}
+		if d.Err != nil {
+			pair.Error = d.Err.Error()
+		}
+		report.Pairs = append(report.Pairs, pair)
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("JSON の生成に失敗しました: %w", err)
+	}
+	return string(data) + "\n", nil
+}
+
+// tagDiffCSVHeader は CSV 形式の列名です
+var tagDiffCSVHeader = []string{
+	"from", "to", "from_date", "to_date", "commits", "authors", "files_changed", "insertions", "deletions", "error",
+}
+
+// renderTagDiffCSV はタグペアごとに1行のサマリーを CSV で生成します
+// authors 列には "名前 (コミット数)" をセミコロン区切りで出力します。
+func renderTagDiffCSV(diffs []tagPairDiff) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(tagDiffCSVHeader); err != nil {
+		return "", fmt.Errorf("CSV の生成に失敗しました: %w", err)
+	}
+
+	for _, d := range diffs {
+		authors := make([]string, 0, len(d.Authors))
+		for _, a := range d.Authors {
+			authors = append(authors, fmt.Sprintf("%s (%d)", a.Name, a.Commits))
+		}
+		errMsg := ""
+		if d.Err != nil {
+			errMsg = d.Err.Error()
+		}
+		record := []string{
+			d.From.Name,
+			d.To.Name,
+			d.From.Date.Format("2006-01-02"),
+			d.To.Date.Format("2006-01-02"),
+			strconv.Itoa(len(d.Commits)),
+			strings.Join(authors, "; "),
+			strconv.Itoa(len(d.Files)),
+			strconv.Itoa(d.Insertions),
+			strconv.Itoa(d.Deletions),
+			errMsg,
+		}
+		if err := w.Write(record); err != nil {
+			return "", fmt.Errorf("CSV の生成に失敗しました: %w", err)
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", fmt.Errorf("CSV の生成に失敗しました: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// tagDiffHTMLTemplate は HTML 形式のレポートのテンプレートです
+var tagDiffHTMLTemplate = template.Must(template.New("tag-diff-all").Funcs(template.FuncMap{
+	"date":      func(t time.Time) string { return t.Format("2006-01-02") },
+	"shortHash": shortHash,
+}).Parse(`<!DOCTYPE html>
+<html lang="ja">
+<head>
+<meta charset="utf-8">
+<title>タグ間差分レポート</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin: 0.5em 0 1em; }
+th, td { border: 1px solid #ccc; padding: 0.25em 0.5em; text-align: left; }
+td.num { text-align: right; }
+code { color: #666; }
+.error { color: #c00; }
+</style>
+</head>
+<body>
+<h1>タグ間差分レポート</h1>
+<ul>
+<li>生成日時: {{.GeneratedAt.Format "2006-01-02 15:04:05"}}</li>
+<li>タグ数: {{.Tags}}</li>
+<li>タグペア数: {{len .Pairs}}</li>
+<li>総コミット数: {{.TotalCommits}}</li>
+</ul>
+{{range .Pairs}}
+<section>
+<h2>{{.From.Name}} → {{.To.Name}}</h2>
+{{if .Err}}<p class="error">エラー: {{.Err}}</p>
+{{else}}<p>期間: {{date .From.Date}} → {{date .To.Date}} / コミット数: {{len .Commits}} / 変更: {{len .Files}} ファイル (+{{.Insertions}} -{{.Deletions}})</p>
+{{if .Commits}}<h3>コミット</h3>
+<ul>
+{{range .Commits}}<li>{{.Subject}} ({{.Author}}, {{.Date}}) <code>{{shortHash .Hash}}</code></li>
+{{end}}</ul>
+<h3>作成者</h3>
+<table>
+<tr><th>作成者</th><th>コミット数</th></tr>
+{{range .Authors}}<tr><td>{{.Name}}</td><td class="num">{{.Commits}}</td></tr>
+{{end}}</table>
+{{else}}<p>(差分なし)</p>
+{{end}}{{if .Files}}<h3>ファイル</h3>
+<table>
+<tr><th>ファイル</th><th>追加</th><th>削除</th></tr>
+{{range .Files}}<tr><td>{{.Path}}</td>{{if .Binary}}<td colspan="2">(バイナリ)</td>{{else}}<td class="num">{{.Insertions}}</td><td class="num">{{.Deletions}}</td>{{end}}</tr>
+{{end}}</table>
+{{end}}{{end}}</section>
+{{end}}
+</body>
+</html>
+`))
+
+// renderTagDiffHTML は HTML のレポートを生成します
+func renderTagDiffHTML(tagCount int, diffs []tagPairDiff, generatedAt time.Time) (string, error) {
+	data := struct {
+		GeneratedAt  time.Time
+		Tags         int
+		TotalCommits int
+		Pairs        []tagPairDiff
+	}{
+		GeneratedAt:  generatedAt,
+		Tags:         tagCount,
+		TotalCommits: totalDiffCommits(diffs),
+		Pairs:        diffs,
+	}
+
+	var buf bytes.Buffer
+	if err := tagDiffHTMLTemplate.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("HTML の生成に失敗しました: %w", err)
+	}
+	return buf.String(), nil
+}
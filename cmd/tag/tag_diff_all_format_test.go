@@ -0,0 +1,183 @@
+package tag
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sampleTagPairDiffs はテスト用のタグペアの差分を返します
+func sampleTagPairDiffs() []tagPairDiff {
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	return []tagPairDiff{
+		{
+			From: tagInfo{Name: "v1.0.0", Date: date},
+			To:   tagInfo{Name: "v1.1.0", Date: date.AddDate(0, 0, 7)},
+			Commits: []diffCommit{
+				{Hash: "abcdef1234567", Subject: "feat: add <api>", Author: "Alice", Date: "2024-01-20"},
+				{Hash: "1234567abcdef", Subject: "fix: typo", Author: "Bob, Jr.", Date: "2024-01-18"},
+			},
+			Authors:    []authorCount{{"Alice", 1}, {"Bob, Jr.", 1}},
+			Files:      []fileStat{{Path: "api.go", Insertions: 10, Deletions: 2}, {Path: "logo.png", Binary: true}},
+			Insertions: 10,
+			Deletions:  2,
+		},
+		{
+			From: tagInfo{Name: "v1.1.0", Date: date.AddDate(0, 0, 7)},
+			To:   tagInfo{Name: "v1.2.0", Date: date.AddDate(0, 0, 14)},
+			Err:  errors.New("unknown revision"),
+		},
+	}
+}
+
+// TestNormalizeTagDiffFormat は出力形式の正規化をテストします
+func TestNormalizeTagDiffFormat(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"text", "text", false},
+		{"txt", "text", false},
+		{"Markdown", "markdown", false},
+		{"md", "markdown", false},
+		{"json", "json", false},
+		{"html", "html", false},
+		{"csv", "csv", false},
+		{"xml", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := normalizeTagDiffFormat(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("normalizeTagDiffFormat(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeTagDiffFormat(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRenderTagDiffText はテキスト形式のレポートをテストします
+func TestRenderTagDiffText(t *testing.T) {
+	got := renderTagDiffText(3, sampleTagPairDiffs(), time.Now())
+
+	for _, want := range []string{
+		"## v1.0.0 → v1.1.0",
+		"   コミット数: 2",
+		"   変更: 2 ファイル (+10 -2)",
+		"- feat: add <api> (Alice, 2024-01-20)",
+		"エラー: unknown revision",
+		"処理済みタグペア: 1",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("text report does not contain %q:\n%s", want, got)
+		}
+	}
+}
+
+// TestRenderTagDiffMarkdown は Markdown 形式のレポートをテストします
+func TestRenderTagDiffMarkdown(t *testing.T) {
+	got := renderTagDiffMarkdown(3, sampleTagPairDiffs(), time.Now())
+
+	for _, want := range []string{
+		"# タグ間差分レポート",
+		"## v1.0.0 → v1.1.0",
+		"- feat: add <api> (Alice, 2024-01-20) `abcdef1`",
+		"- Bob, Jr.: 1",
+		"| api.go | 10 | 2 |",
+		"| logo.png | (バイナリ) | |",
+		"エラー: unknown revision",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("markdown report does not contain %q:\n%s", want, got)
+		}
+	}
+}
+
+// TestRenderTagDiffJSON は JSON 形式のレポートをテストします
+func TestRenderTagDiffJSON(t *testing.T) {
+	got, err := renderTagDiffJSON(3, sampleTagPairDiffs(), time.Now())
+	if err != nil {
+		t.Fatalf("renderTagDiffJSON returned error: %v", err)
+	}
+
+	var report tagDiffReportJSON
+	if err := json.Unmarshal([]byte(got), &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, got)
+	}
+	if report.Tags != 3 || report.TotalCommits != 2 || len(report.Pairs) != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	first := report.Pairs[0]
+	if first.From != "v1.0.0" || first.CommitCount != 2 || first.FilesChanged != 2 || first.Insertions != 10 {
+		t.Errorf("unexpected first pair: %+v", first)
+	}
+	if report.Pairs[1].Error != "unknown revision" {
+		t.Errorf("second pair error = %q", report.Pairs[1].Error)
+	}
+	if !strings.Contains(got, `"commits": []`) {
+		t.Errorf("empty commits should be [] instead of null:\n%s", got)
+	}
+}
+
+// TestRenderTagDiffCSV は CSV 形式のレポートをテストします
+func TestRenderTagDiffCSV(t *testing.T) {
+	got, err := renderTagDiffCSV(sampleTagPairDiffs())
+	if err != nil {
+		t.Fatalf("renderTagDiffCSV returned error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(got), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected header and 2 rows, got %d lines:\n%s", len(lines), got)
+	}
+	if lines[0] != strings.Join(tagDiffCSVHeader, ",") {
+		t.Errorf("header = %q", lines[0])
+	}
+	want := `v1.0.0,v1.1.0,2024-01-15,2024-01-22,2,"Alice (1); Bob, Jr. (1)",2,10,2,`
+	if lines[1] != want {
+		t.Errorf("row = %q, want %q", lines[1], want)
+	}
+	if !strings.HasSuffix(lines[2], ",unknown revision") {
+		t.Errorf("error row = %q", lines[2])
+	}
+}
+
+// TestRenderTagDiffHTML は HTML 形式のレポートをテストします
+func TestRenderTagDiffHTML(t *testing.T) {
+	got, err := renderTagDiffHTML(3, sampleTagPairDiffs(), time.Now())
+	if err != nil {
+		t.Fatalf("renderTagDiffHTML returned error: %v", err)
+	}
+
+	for _, want := range []string{
+		"<h2>v1.0.0 → v1.1.0</h2>",
+		"feat: add &lt;api&gt; (Alice, 2024-01-20) <code>abcdef1</code>",
+		`<td>api.go</td><td class="num">10</td><td class="num">2</td>`,
+		`<td colspan="2">(バイナリ)</td>`,
+		`<p class="error">エラー: unknown revision</p>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("html report does not contain %q:\n%s", want, got)
+		}
+	}
+}
+
+// TestRenderTagDiffPair_Text は --split のテキスト形式をテストします
+func TestRenderTagDiffPair_Text(t *testing.T) {
+	got, err := renderTagDiffPair("text", sampleTagPairDiffs()[0], time.Now())
+	if err != nil {
+		t.Fatalf("renderTagDiffPair returned error: %v", err)
+	}
+	if !strings.HasPrefix(got, "タグ差分: v1.0.0 → v1.1.0\n") {
+		t.Errorf("unexpected header:\n%s", got)
+	}
+	if !strings.Contains(got, "- fix: typo (Bob, Jr., 2024-01-18)") {
+		t.Errorf("commit line not found:\n%s", got)
+	}
+}
@@ -1,10 +1,12 @@
 package tag
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/testutil"
 )
 
 // TestTagDiffAllCmd_CommandSetup はtag-diff-allコマンドの設定をテストします
@@ -37,6 +39,8 @@ func TestTagDiffAllCmd_Flags(t *testing.T) {
 		{"split", "s"},
 		{"limit", "l"},
 		{"reverse", "r"},
+		{"format", "f"},
+		{"jobs", "j"},
 	}
 
 	for _, tt := range tests {
@@ -117,6 +121,41 @@ func TestFilterTagsByPrefix(t *testing.T) {
 	}
 }
 
+// TestSortTagsByVersion はプレフィックス付きタグのバージョン順の並べ替えをテストします
+func TestSortTagsByVersion(t *testing.T) {
+	now := time.Now()
+
+	t.Run("SemVer のタグはバージョン順", func(t *testing.T) {
+		tags := []tagInfo{
+			{Name: "api/v1.10.0", Date: now},
+			{Name: "api/v1.2.0", Date: now.Add(time.Hour)},
+			{Name: "api/v1.10.0-rc.1", Date: now.Add(2 * time.Hour)},
+		}
+		if !sortTagsByVersion(tags, "api/") {
+			t.Fatal("sortTagsByVersion should sort semver tags")
+		}
+		expected := []string{"api/v1.2.0", "api/v1.10.0-rc.1", "api/v1.10.0"}
+		for i, tag := range tags {
+			if tag.Name != expected[i] {
+				t.Errorf("tags[%d] = %s, want %s", i, tag.Name, expected[i])
+			}
+		}
+	})
+
+	t.Run("解析できないタグがあれば日付順のまま", func(t *testing.T) {
+		tags := []tagInfo{
+			{Name: "V4.1", Date: now},
+			{Name: "V4.0", Date: now.Add(time.Hour)},
+		}
+		if sortTagsByVersion(tags, "V4") {
+			t.Error("sortTagsByVersion should not sort non-semver tags")
+		}
+		if tags[0].Name != "V4.1" {
+			t.Errorf("order should be unchanged, got %v", tags)
+		}
+	})
+}
+
 // TestGenerateTagPairs はタグペアの生成をテストします
 func TestGenerateTagPairs(t *testing.T) {
 	now := time.Now()
@@ -198,3 +237,105 @@ func TestParseGitDate(t *testing.T) {
 		})
 	}
 }
+
+// TestParseDiffCommits は git log の出力の解析をテストします
+func TestParseDiffCommits(t *testing.T) {
+	output := "abc123\x00feat: add api\x00Alice\x002024-01-15\n" +
+		"def456\x00fix: typo\x00Bob\x002024-01-14"
+
+	commits := parseDiffCommits(output)
+	if len(commits) != 2 {
+		t.Fatalf("Expected 2 commits, got %d", len(commits))
+	}
+	want := diffCommit{Hash: "abc123", Subject: "feat: add api", Author: "Alice", Date: "2024-01-15"}
+	if commits[0] != want {
+		t.Errorf("commits[0] = %+v, want %+v", commits[0], want)
+	}
+	if len(parseDiffCommits("")) != 0 {
+		t.Error("empty output should return no commits")
+	}
+}
+
+// TestCountAuthors は作成者ごとのコミット数をテストします
+func TestCountAuthors(t *testing.T) {
+	commits := []diffCommit{
+		{Author: "Bob"},
+		{Author: "Alice"},
+		{Author: "Carol"},
+		{Author: "Carol"},
+	}
+
+	authors := countAuthors(commits)
+	want := []authorCount{{"Carol", 2}, {"Alice", 1}, {"Bob", 1}}
+	if len(authors) != len(want) {
+		t.Fatalf("countAuthors() = %v, want %v", authors, want)
+	}
+	for i := range want {
+		if authors[i] != want[i] {
+			t.Errorf("authors[%d] = %+v, want %+v", i, authors[i], want[i])
+		}
+	}
+}
+
+// TestParseNumstat は git diff --numstat の出力の解析をテストします
+func TestParseNumstat(t *testing.T) {
+	output := "10\t2\tcmd/main.go\x00-\t-\timage.png\x000\t5\t\x00docs/old.md\x00docs/new.md\x00"
+
+	files := parseNumstat(output)
+	if len(files) != 3 {
+		t.Fatalf("Expected 3 files, got %d", len(files))
+	}
+	if files[0] != (fileStat{Path: "cmd/main.go", Insertions: 10, Deletions: 2}) {
+		t.Errorf("files[0] = %+v", files[0])
+	}
+	if !files[1].Binary || files[1].Insertions != 0 {
+		t.Errorf("files[1] should be binary, got %+v", files[1])
+	}
+	if files[2].Path != "docs/new.md" || files[2].OldPath != "docs/old.md" || files[2].Deletions != 5 {
+		t.Errorf("files[2] = %+v", files[2])
+	}
+}
+
+// TestCollectTagPairDiffs は並列に取得しても順序が変わらないことをテストします
+func TestCollectTagPairDiffs(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("README.md", "# Test")
+	repo.Commit("Initial commit")
+	repo.CreateLightweightTag("v1.0.0")
+
+	var tags []tagInfo
+	tags = append(tags, tagInfo{Name: "v1.0.0"})
+	for i := 1; i <= 6; i++ {
+		for j := 0; j < i; j++ {
+			repo.CreateFile(fmt.Sprintf("file%d.txt", i), fmt.Sprintf("line %d\n", j))
+			repo.Commit(fmt.Sprintf("Commit %d-%d", i, j))
+		}
+		name := fmt.Sprintf("v1.%d.0", i)
+		repo.CreateLightweightTag(name)
+		tags = append(tags, tagInfo{Name: name})
+	}
+	chdirTagRepo(t, repo.Dir)
+
+	tagDiffReverse = false
+	diffs := collectTagPairDiffs(generateTagPairs(tags), 4)
+	if len(diffs) != 6 {
+		t.Fatalf("Expected 6 diffs, got %d", len(diffs))
+	}
+	for i, d := range diffs {
+		if d.Err != nil {
+			t.Fatalf("diffs[%d] returned error: %v", i, d.Err)
+		}
+		if d.To.Name != tags[i+1].Name {
+			t.Errorf("diffs[%d].To = %s, want %s", i, d.To.Name, tags[i+1].Name)
+		}
+		if len(d.Commits) != i+1 {
+			t.Errorf("diffs[%d] commits = %d, want %d", i, len(d.Commits), i+1)
+		}
+		if len(d.Files) != 1 || d.Insertions != 1 {
+			t.Errorf("diffs[%d] files = %+v, insertions = %d", i, d.Files, d.Insertions)
+		}
+		if len(d.Authors) != 1 || d.Authors[0].Commits != i+1 {
+			t.Errorf("diffs[%d] authors = %+v", i, d.Authors)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+// ================================================================================
+// tag_diff_format.go - tag-diff の出力形式
+// ================================================================================
+// 2つのタグ間の差分の詳細（tagDiffSummary）を text/markdown/json に変換します。
+// text と markdown は同じ構成で、コミット・作成者・ディレクトリごとの変更・
+// ファイルの変更の内訳・PR の順に出力します。
+// ================================================================================
+
+package tag
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// renderTagDiffSummary は差分の詳細を指定した形式に変換します
+//
+// パラメータ:
+//   - format: 出力形式（text/markdown/json）
+//   - s: 差分の詳細
+func renderTagDiffSummary(format string, s *tagDiffSummary) (string, error) {
+	switch format {
+	case "markdown":
+		return renderTagDiffSummaryMarkdown(s), nil
+	case "json":
+		return renderTagDiffSummaryJSON(s)
+	default:
+		return renderTagDiffSummaryText(s), nil
+	}
+}
+
+// renderTagDiffSummaryText はプレーンテキストの差分を生成します
+func renderTagDiffSummaryText(s *tagDiffSummary) string {
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("タグ差分: %s → %s\n", s.From, s.To))
+	builder.WriteString(fmt.Sprintf("コミット数: %d\n", len(s.Commits)))
+	builder.WriteString(fmt.Sprintf("作成者: %d 人\n", len(s.Authors)))
+	builder.WriteString(fmt.Sprintf("変更: %d ファイル (+%d -%d)\n", s.fileCount(), s.Insertions, s.Deletions))
+
+	builder.WriteString("\n## コミット\n")
+	if len(s.Commits) == 0 {
+		builder.WriteString("(なし)\n")
+	} else {
+		builder.WriteString(formatTextCommits(s.Commits))
+		builder.WriteString("\n")
+	}
+
+	builder.WriteString("\n## 作成者\n")
+	for _, a := range s.Authors {
+		builder.WriteString(fmt.Sprintf("%5d  %s\n", a.Commits, a.Name))
+	}
+
+	builder.WriteString("\n## ディレクトリごとの変更\n")
+	for _, d := range s.Directories {
+		builder.WriteString(fmt.Sprintf("%5d ファイル  %8s %8s  %s\n", d.Files, fmt.Sprintf("+%d", d.Insertions), fmt.Sprintf("-%d", d.Deletions), d.Name))
+	}
+
+	c := s.Changes
+	builder.WriteString("\n## ファイルの変更\n")
+	builder.WriteString(fmt.Sprintf("追加: %d / 削除: %d / 名前変更: %d / 変更: %d\n",
+		len(c.Added), len(c.Deleted), len(c.Renamed), len(c.Modified)))
+	for _, path := range c.Added {
+		builder.WriteString(fmt.Sprintf("  A  %s\n", path))
+	}
+	for _, path := range c.Deleted {
+		builder.WriteString(fmt.Sprintf("  D  %s\n", path))
+	}
+	for _, r := range c.Renamed {
+		builder.WriteString(fmt.Sprintf("  R  %s → %s\n", r.From, r.To))
+	}
+
+	builder.WriteString("\n## PR\n")
+	if len(s.PullRequests) == 0 {
+		builder.WriteString("(なし)\n")
+	}
+	for _, pr := range s.PullRequests {
+		builder.WriteString(fmt.Sprintf("#%d %s\n", pr.Number, pr.Title))
+	}
+
+	return builder.String()
+}
+
+// renderTagDiffSummaryMarkdown は Markdown の差分を生成します
+func renderTagDiffSummaryMarkdown(s *tagDiffSummary) string {
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("# %s → %s\n\n", s.From, s.To))
+	builder.WriteString(fmt.Sprintf("- コミット数: %d\n", len(s.Commits)))
+	builder.WriteString(fmt.Sprintf("- 作成者: %d 人\n", len(s.Authors)))
+	builder.WriteString(fmt.Sprintf("- 変更: %d ファイル (+%d -%d)\n", s.fileCount(), s.Insertions, s.Deletions))
+
+	if len(s.PullRequests) > 0 {
+		builder.WriteString("\n## PR\n\n")
+		for _, pr := range s.PullRequests {
+			builder.WriteString(fmt.Sprintf("- #%d %s\n", pr.Number, pr.Title))
+		}
+	}
+
+	builder.WriteString("\n## コミット\n\n")
+	if len(s.Commits) == 0 {
+		builder.WriteString("(なし)\n")
+	}
+	for _, c := range s.Commits {
+		builder.WriteString(fmt.Sprintf("- %s (%s, %s) `%s`\n", c.Subject, c.Author, c.Date, shortHash(c.Hash)))
+	}
+
+	if len(s.Authors) > 0 {
+		builder.WriteString("\n## 作成者\n\n")
+		builder.WriteString("| 作成者 | コミット数 |\n")
+		builder.WriteString("| --- | ---: |\n")
+		for _, a := range s.Authors {
+			builder.WriteString(fmt.Sprintf("| %s | %d |\n", escapeMarkdownCell(a.Name), a.Commits))
+		}
+	}
+
+	if len(s.Directories) > 0 {
+		builder.WriteString("\n## ディレクトリごとの変更\n\n")
+		builder.WriteString("| ディレクトリ | ファイル | 追加 | 削除 |\n")
+		builder.WriteString("| --- | ---: | ---: | ---: |\n")
+		for _, d := range s.Directories {
+			builder.WriteString(fmt.Sprintf("| %s | %d | %d | %d |\n", escapeMarkdownCell(d.Name), d.Files, d.Insertions, d.Deletions))
+		}
+	}
+
+	c := s.Changes
+	builder.WriteString("\n## ファイルの変更\n\n")
+	builder.WriteString(fmt.Sprintf("追加: %d / 削除: %d / 名前変更: %d / 変更: %d\n",
+		len(c.Added), len(c.Deleted), len(c.Renamed), len(c.Modified)))
+	if len(c.Added) > 0 {
+		builder.WriteString("\n### 追加\n\n")
+		for _, path := range c.Added {
+			builder.WriteString(fmt.Sprintf("- `%s`\n", path))
+		}
+	}
+	if len(c.Deleted) > 0 {
+		builder.WriteString("\n### 削除\n\n")
+		for _, path := range c.Deleted {
+			builder.WriteString(fmt.Sprintf("- `%s`\n", path))
+		}
+	}
+	if len(c.Renamed) > 0 {
+		builder.WriteString("\n### 名前変更\n\n")
+		for _, r := range c.Renamed {
+			builder.WriteString(fmt.Sprintf("- `%s` → `%s`\n", r.From, r.To))
+		}
+	}
+
+	return builder.String()
+}
+
+// escapeMarkdownCell は Markdown の表のセルで使用できない | をエスケープします
+func escapeMarkdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// renderTagDiffSummaryJSON は JSON の差分を生成します
+func renderTagDiffSummaryJSON(s *tagDiffSummary) (string, error) {
+	out := *s
+	// 空の一覧は null ではなく [] として出力する
+	if out.Commits == nil {
+		out.Commits = []diffCommit{}
+	}
+	if out.Authors == nil {
+		out.Authors = []authorCount{}
+	}
+	if out.Directories == nil {
+		out.Directories = []dirStat{}
+	}
+	if out.PullRequests == nil {
+		out.PullRequests = []pullRequestRef{}
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("JSON の生成に失敗しました: %w", err)
+	}
+	return string(data) + "\n", nil
+}
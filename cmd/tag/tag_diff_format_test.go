@@ -0,0 +1,98 @@
+package tag
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// sampleTagDiffSummary はテスト用の差分の詳細を返します
+func sampleTagDiffSummary() *tagDiffSummary {
+	return &tagDiffSummary{
+		From: "v1.0.0",
+		To:   "v1.1.0",
+		Commits: []diffCommit{
+			{Hash: "abcdef1234567", Subject: "feat: add api (#12)", Author: "Alice", Date: "2024-01-20"},
+		},
+		Authors:     []authorCount{{Name: "Alice", Commits: 1}},
+		Insertions:  12,
+		Deletions:   3,
+		Directories: []dirStat{{Name: "cmd/", Files: 2, Insertions: 12, Deletions: 3}},
+		Changes: fileChanges{
+			Added:    []string{"cmd/api.go"},
+			Deleted:  []string{},
+			Renamed:  []renamedFile{{From: "cmd/old.go", To: "cmd/new.go"}},
+			Modified: []string{},
+		},
+		PullRequests: []pullRequestRef{{Number: 12, Title: "Add API"}},
+	}
+}
+
+// TestRenderTagDiffSummaryText はテキスト形式をテストします
+func TestRenderTagDiffSummaryText(t *testing.T) {
+	got := renderTagDiffSummaryText(sampleTagDiffSummary())
+
+	for _, want := range []string{
+		"タグ差分: v1.0.0 → v1.1.0",
+		"変更: 2 ファイル (+12 -3)",
+		"- feat: add api (#12) (Alice, 2024-01-20)",
+		"    1  Alice",
+		"+12",
+		"cmd/",
+		"追加: 1 / 削除: 0 / 名前変更: 1 / 変更: 0",
+		"  R  cmd/old.go → cmd/new.go",
+		"#12 Add API",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("text output does not contain %q:\n%s", want, got)
+		}
+	}
+}
+
+// TestRenderTagDiffSummaryMarkdown は Markdown 形式をテストします
+func TestRenderTagDiffSummaryMarkdown(t *testing.T) {
+	got := renderTagDiffSummaryMarkdown(sampleTagDiffSummary())
+
+	for _, want := range []string{
+		"# v1.0.0 → v1.1.0",
+		"- #12 Add API",
+		"| Alice | 1 |",
+		"| cmd/ | 2 | 12 | 3 |",
+		"### 追加\n\n- `cmd/api.go`",
+		"- `cmd/old.go` → `cmd/new.go`",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("markdown output does not contain %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "### 削除") {
+		t.Errorf("empty sections should be omitted:\n%s", got)
+	}
+}
+
+// TestRenderTagDiffSummaryJSON は JSON 形式をテストします
+func TestRenderTagDiffSummaryJSON(t *testing.T) {
+	got, err := renderTagDiffSummaryJSON(sampleTagDiffSummary())
+	if err != nil {
+		t.Fatalf("renderTagDiffSummaryJSON returned error: %v", err)
+	}
+
+	var decoded tagDiffSummary
+	if err := json.Unmarshal([]byte(got), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, got)
+	}
+	if decoded.From != "v1.0.0" || decoded.Insertions != 12 || len(decoded.PullRequests) != 1 {
+		t.Errorf("unexpected decoded summary: %+v", decoded)
+	}
+	if !strings.Contains(got, `"pull_requests"`) || !strings.Contains(got, `"deleted": []`) {
+		t.Errorf("unexpected JSON:\n%s", got)
+	}
+
+	empty, err := renderTagDiffSummaryJSON(&tagDiffSummary{From: "a", To: "b", Changes: parseNameStatus("")})
+	if err != nil {
+		t.Fatalf("renderTagDiffSummaryJSON returned error: %v", err)
+	}
+	if strings.Contains(empty, "null") {
+		t.Errorf("empty lists should be [] instead of null:\n%s", empty)
+	}
+}
@@ -2,6 +2,7 @@ package tag
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/tonbiattack/git-plus/cmd"
@@ -81,3 +82,172 @@ func TestTagDiffCmd_InRootCmd(t *testing.T) {
 		t.Error("tagDiffCmd should be registered in RootCmd")
 	}
 }
+
+// TestTagDiffCmd_Flags はフラグが正しく設定されていることを確認します
+func TestTagDiffCmd_Flags(t *testing.T) {
+	tests := []struct {
+		name      string
+		shorthand string
+	}{
+		{"format", "f"},
+		{"output", "o"},
+		{"no-fetch", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flag := tagDiffCmd.Flags().Lookup(tt.name)
+			if flag == nil {
+				t.Fatalf("Flag %s not found", tt.name)
+			}
+			if flag.Shorthand != tt.shorthand {
+				t.Errorf("Flag %s shorthand = %q, want %q", tt.name, flag.Shorthand, tt.shorthand)
+			}
+		})
+	}
+}
+
+// TestGroupByTopLevelDir はディレクトリごとの集計をテストします
+func TestGroupByTopLevelDir(t *testing.T) {
+	files := []fileStat{
+		{Path: "README.md", Insertions: 1},
+		{Path: "cmd/tag/tag_diff.go", Insertions: 30, Deletions: 10},
+		{Path: "cmd/root.go", Insertions: 2},
+		{Path: "internal/ui/ui.go", Insertions: 5, Deletions: 5},
+		{Path: "logo.png", Binary: true},
+	}
+
+	dirs := groupByTopLevelDir(files)
+	want := []dirStat{
+		{Name: "cmd/", Files: 2, Insertions: 32, Deletions: 10},
+		{Name: "internal/", Files: 1, Insertions: 5, Deletions: 5},
+		{Name: rootDirName, Files: 2, Insertions: 1},
+	}
+	if len(dirs) != len(want) {
+		t.Fatalf("groupByTopLevelDir() = %+v, want %+v", dirs, want)
+	}
+	for i := range want {
+		if dirs[i] != want[i] {
+			t.Errorf("dirs[%d] = %+v, want %+v", i, dirs[i], want[i])
+		}
+	}
+}
+
+// TestParseNameStatus は変更の種類ごとの分類をテストします
+func TestParseNameStatus(t *testing.T) {
+	output := "A\x00new.go\x00D\x00old.go\x00R087\x00a/x.go\x00b/x.go\x00M\x00main.go\x00T\x00link\x00C100\x00src.go\x00copy.go\x00"
+
+	changes := parseNameStatus(output)
+	if strings.Join(changes.Added, ",") != "new.go,copy.go" {
+		t.Errorf("Added = %v", changes.Added)
+	}
+	if strings.Join(changes.Deleted, ",") != "old.go" {
+		t.Errorf("Deleted = %v", changes.Deleted)
+	}
+	if len(changes.Renamed) != 1 || changes.Renamed[0] != (renamedFile{From: "a/x.go", To: "b/x.go"}) {
+		t.Errorf("Renamed = %v", changes.Renamed)
+	}
+	if strings.Join(changes.Modified, ",") != "main.go,link" {
+		t.Errorf("Modified = %v", changes.Modified)
+	}
+}
+
+// TestParsePullRequestRefs は PR 番号とタイトルの抽出をテストします
+func TestParsePullRequestRefs(t *testing.T) {
+	output := "Merge pull request #42 from owner/feature\x00Add login page\n\nDetails\x1e\n" +
+		"feat(api): add endpoint (#15)\x00\x1e\n" +
+		"fix: typo\x00\x1e\n" +
+		"refactor: inner commit of #42\x00\x1e\n" +
+		"docs: update readme (#15)\x00\x1e"
+
+	refs := parsePullRequestRefs(output)
+	want := []pullRequestRef{
+		{Number: 15, Title: "feat(api): add endpoint"},
+		{Number: 42, Title: "Add login page"},
+	}
+	if len(refs) != len(want) {
+		t.Fatalf("parsePullRequestRefs() = %+v, want %+v", refs, want)
+	}
+	for i := range want {
+		if refs[i] != want[i] {
+			t.Errorf("refs[%d] = %+v, want %+v", i, refs[i], want[i])
+		}
+	}
+}
+
+// TestApplyPullRequestTitles は GitHub から取得したタイトルの反映をテストします
+func TestApplyPullRequestTitles(t *testing.T) {
+	refs := []pullRequestRef{{Number: 1, Title: "local"}, {Number: 2, Title: "local 2"}}
+	applyPullRequestTitles(refs, map[int]string{1: "Remote title", 2: ""})
+
+	if refs[0].Title != "Remote title" {
+		t.Errorf("refs[0].Title = %q, want %q", refs[0].Title, "Remote title")
+	}
+	if refs[1].Title != "local 2" {
+		t.Errorf("refs[1].Title = %q, want unchanged", refs[1].Title)
+	}
+}
+
+// TestCollectTagDiffSummary はタグ間の差分の詳細の取得をテストします
+func TestCollectTagDiffSummary(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("README.md", "# Test")
+	repo.CreateFile("docs/guide.md", "line 1\nline 2\nline 3\nline 4\n")
+	repo.CreateFile("obsolete.txt", "old")
+	repo.Commit("Initial commit")
+	repo.CreateLightweightTag("v1.0.0")
+	baseBranch := repo.CurrentBranch()
+
+	repo.CreateAndCheckoutBranch("feature")
+	repo.CreateFile("cmd/new.go", "package cmd\n")
+	repo.Commit("feat: add new command")
+	repo.CheckoutBranch(baseBranch)
+	repo.MustGit("merge", "--no-ff", "feature", "-m", "Merge pull request #8 from owner/feature", "-m", "Add new command")
+
+	repo.MustGit("mv", "docs/guide.md", "docs/manual.md")
+	repo.MustGit("rm", "-q", "obsolete.txt")
+	repo.CreateFile("README.md", "# Test\nmore\n")
+	repo.Commit("docs: reorganize (#9)")
+	repo.CreateLightweightTag("v1.1.0")
+	chdirTagRepo(t, repo.Dir)
+
+	summary, err := collectTagDiffSummary("v1.0.0", "v1.1.0")
+	if err != nil {
+		t.Fatalf("collectTagDiffSummary returned error: %v", err)
+	}
+
+	if len(summary.Commits) != 2 {
+		t.Errorf("Commits = %d, want 2 (merge commits excluded)", len(summary.Commits))
+	}
+	if len(summary.Authors) != 1 || summary.Authors[0].Commits != 2 {
+		t.Errorf("Authors = %+v", summary.Authors)
+	}
+	if strings.Join(summary.Changes.Added, ",") != "cmd/new.go" {
+		t.Errorf("Added = %v", summary.Changes.Added)
+	}
+	if strings.Join(summary.Changes.Deleted, ",") != "obsolete.txt" {
+		t.Errorf("Deleted = %v", summary.Changes.Deleted)
+	}
+	if len(summary.Changes.Renamed) != 1 || summary.Changes.Renamed[0].To != "docs/manual.md" {
+		t.Errorf("Renamed = %v", summary.Changes.Renamed)
+	}
+	if strings.Join(summary.Changes.Modified, ",") != "README.md" {
+		t.Errorf("Modified = %v", summary.Changes.Modified)
+	}
+	if summary.fileCount() != 4 {
+		t.Errorf("fileCount() = %d, want 4", summary.fileCount())
+	}
+
+	dirs := make(map[string]int)
+	for _, d := range summary.Directories {
+		dirs[d.Name] = d.Files
+	}
+	if dirs["cmd/"] != 1 || dirs["docs/"] != 1 || dirs[rootDirName] != 2 {
+		t.Errorf("Directories = %+v", summary.Directories)
+	}
+
+	want := []pullRequestRef{{Number: 8, Title: "Add new command"}, {Number: 9, Title: "docs: reorganize"}}
+	if len(summary.PullRequests) != 2 || summary.PullRequests[0] != want[0] || summary.PullRequests[1] != want[1] {
+		t.Errorf("PullRequests = %+v, want %+v", summary.PullRequests, want)
+	}
+}
@@ -0,0 +1,199 @@
+// ================================================================================
+// tag_sign.go - タグとコミットの署名
+// ================================================================================
+// new-tag --sign と verify-tags で使用する署名の設定と検証結果の判定を実装しています。
+//
+// 【署名の設定】
+// git の設定に従います（git-plus 独自の設定はありません）。
+// - gpg.format: openpgp（デフォルト）、ssh、x509
+// - user.signingkey: 署名に使用する鍵（ssh の場合は必須）
+//
+// 【検証結果】
+// git log の %G? と同じ1文字で表します。
+//   G: 有効な署名、U: 信頼されていない署名、B: 不正な署名、
+//   X: 期限切れの署名、Y: 期限切れの鍵による署名、R: 失効した鍵による署名、
+//   E: 検証できない（公開鍵や許可された署名者のファイルがない）、N: 署名なし
+// ================================================================================
+
+package tag
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/tonbiattack/git-plus/internal/gitcmd"
+)
+
+// 署名の検証結果（git log の %G? と同じ）
+const (
+	sigGood         = "G"
+	sigUntrusted    = "U"
+	sigBad          = "B"
+	sigExpired      = "X"
+	sigExpiredKey   = "Y"
+	sigRevoked      = "R"
+	sigUnverifiable = "E"
+	sigNone         = "N"
+)
+
+var (
+	gpgGoodSigPattern = regexp.MustCompile(`\[GNUPG:\] GOODSIG \S+ (.+)`)
+	sshSignerPattern  = regexp.MustCompile(`Good "git" signature (?:for (\S+) )?with (\S+ key \S+)`)
+)
+
+// signingConfig は署名の設定を表す構造体です
+type signingConfig struct {
+	Format string // gpg.format（openpgp/ssh/x509）
+	Key    string // user.signingkey（未設定の場合は空文字列）
+}
+
+// loadSigningConfig は git の設定から署名の形式と鍵を読み込みます。
+//
+// 戻り値:
+//   - signingConfig: 署名の設定
+//   - error: ssh 形式で鍵が設定されていない場合のエラー
+//
+// 内部処理:
+//
+//	openpgp と x509 は鍵が未設定の場合、gpg がコミッターのメールアドレスから鍵を選びます。
+//	ssh は user.signingkey か gpg.ssh.defaultKeyCommand のどちらかが必要です。
+func loadSigningConfig() (signingConfig, error) {
+	config := signingConfig{
+		Format: gitConfigValue("gpg.format"),
+		Key:    gitConfigValue("user.signingkey"),
+	}
+	if config.Format == "" {
+		config.Format = "openpgp"
+	}
+
+	switch config.Format {
+	case "openpgp", "x509":
+	case "ssh":
+		if config.Key == "" && gitConfigValue("gpg.ssh.defaultKeyCommand") == "" {
+			return config, fmt.Errorf("ssh 形式で署名するには user.signingkey を設定してください（例: git config user.signingkey ~/.ssh/id_ed25519.pub）")
+		}
+	default:
+		return config, fmt.Errorf("gpg.format の値 %q には対応していません（openpgp/ssh/x509）", config.Format)
+	}
+	return config, nil
+}
+
+// Describe は署名の設定を表示用の文字列に変換します
+func (c signingConfig) Describe() string {
+	if c.Key == "" {
+		return c.Format + "（デフォルトの鍵）"
+	}
+	return fmt.Sprintf("%s（%s）", c.Format, c.Key)
+}
+
+// gitConfigValue は git config の値を返します（未設定の場合は空文字列）
+func gitConfigValue(key string) string {
+	output, err := gitcmd.Run("config", "--get", key)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// gradeVerifyOutput は git verify-tag --raw の出力から検証結果を判定します。
+//
+// パラメータ:
+//   - output: git verify-tag --raw の出力（標準出力と標準エラー出力）
+//   - ok: git verify-tag が成功したかどうか
+//
+// 戻り値:
+//   - string: 検証結果（sigGood などの %G? と同じ1文字）
+//   - string: 署名者（判定できない場合は空文字列）
+//
+// 内部処理:
+//
+//	openpgp と x509 は gpg のステータス行（[GNUPG:] GOODSIG など）と信頼度（TRUST_*）から、
+//	ssh は ssh-keygen の出力（Good "git" signature、No principal matched など）から判定します。
+func gradeVerifyOutput(output string, ok bool) (string, string) {
+	switch {
+	case strings.Contains(output, "no signature found"),
+		strings.Contains(output, "cannot verify a non-tag object"):
+		return sigNone, ""
+	case strings.Contains(output, "[GNUPG:] BADSIG"):
+		return sigBad, ""
+	case strings.Contains(output, "[GNUPG:] ERRSIG"), strings.Contains(output, "[GNUPG:] NO_PUBKEY"):
+		return sigUnverifiable, ""
+	}
+
+	if m := gpgGoodSigPattern.FindStringSubmatch(output); m != nil || strings.Contains(output, "[GNUPG:] ") {
+		signer := ""
+		if m != nil {
+			signer = strings.TrimSpace(m[1])
+		}
+		switch {
+		case strings.Contains(output, "[GNUPG:] EXPKEYSIG"):
+			return sigExpiredKey, signer
+		case strings.Contains(output, "[GNUPG:] EXPSIG"):
+			return sigExpired, signer
+		case strings.Contains(output, "[GNUPG:] REVKEYSIG"):
+			return sigRevoked, signer
+		case m == nil:
+			return sigUnverifiable, ""
+		case strings.Contains(output, "[GNUPG:] TRUST_ULTIMATE"),
+			strings.Contains(output, "[GNUPG:] TRUST_FULLY"),
+			strings.Contains(output, "[GNUPG:] TRUST_MARGINAL"):
+			return sigGood, signer
+		default:
+			return sigUntrusted, signer
+		}
+	}
+
+	if m := sshSignerPattern.FindStringSubmatch(output); m != nil {
+		signer := m[2]
+		if m[1] != "" {
+			signer = m[1] + " " + m[2]
+		}
+		if !ok || strings.Contains(output, "No principal matched") {
+			return sigUntrusted, signer
+		}
+		return sigGood, signer
+	}
+	if strings.Contains(output, "allowedSignersFile needs to be configured") {
+		return sigUnverifiable, ""
+	}
+
+	if ok {
+		return sigGood, ""
+	}
+	if strings.Contains(output, "Could not verify signature") || strings.Contains(output, "verification failed") {
+		return sigBad, ""
+	}
+	return sigUnverifiable, ""
+}
+
+// signatureStatusLabel は検証結果の説明を返します
+func signatureStatusLabel(status string) string {
+	switch status {
+	case sigGood:
+		return "有効な署名"
+	case sigUntrusted:
+		return "信頼されていない署名"
+	case sigBad:
+		return "不正な署名"
+	case sigExpired:
+		return "期限切れの署名"
+	case sigExpiredKey:
+		return "期限切れの鍵による署名"
+	case sigRevoked:
+		return "失効した鍵による署名"
+	case sigUnverifiable:
+		return "検証できない署名（公開鍵または許可された署名者のファイルがありません）"
+	default:
+		return "署名なし"
+	}
+}
+
+// isSignatureAccepted は検証結果を合格とするかを判定します
+//
+// パラメータ:
+//   - status: 検証結果
+//   - allowUntrusted: 信頼されていない署名（U）を合格とするかどうか
+func isSignatureAccepted(status string, allowUntrusted bool) bool {
+	return status == sigGood || (allowUntrusted && status == sigUntrusted)
+}
@@ -0,0 +1,202 @@
+package tag
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tonbiattack/git-plus/internal/testutil"
+)
+
+// setupSSHSigning はテスト用の ssh 鍵を作成し、リポジトリに ssh 署名を設定します。
+// 許可された署名者のファイルには作成した鍵を登録します。
+func setupSSHSigning(t *testing.T, repo *testutil.GitRepo) {
+	t.Helper()
+
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen が見つからないためスキップします")
+	}
+
+	keyPath := filepath.Join(t.TempDir(), "key")
+	if output, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", "test", "-f", keyPath).CombinedOutput(); err != nil {
+		t.Fatalf("ssh-keygen failed: %v\n%s", err, output)
+	}
+	publicKey, err := os.ReadFile(keyPath + ".pub")
+	if err != nil {
+		t.Fatalf("Failed to read public key: %v", err)
+	}
+	repo.CreateFile("allowed_signers", "test@example.com "+string(publicKey))
+
+	repo.MustGit("config", "gpg.format", "ssh")
+	repo.MustGit("config", "user.signingkey", keyPath+".pub")
+	repo.MustGit("config", "user.email", "test@example.com")
+	repo.MustGit("config", "gpg.ssh.allowedSignersFile", filepath.Join(repo.Dir, "allowed_signers"))
+}
+
+// TestLoadSigningConfig は署名の設定の読み込みをテストします
+func TestLoadSigningConfig(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("README.md", "# Test")
+	repo.Commit("Initial commit")
+	chdirTagRepo(t, repo.Dir)
+
+	config, err := loadSigningConfig()
+	if err != nil {
+		t.Fatalf("loadSigningConfig() error = %v", err)
+	}
+	if config.Format != "openpgp" {
+		t.Errorf("Format = %q, want openpgp", config.Format)
+	}
+
+	repo.MustGit("config", "gpg.format", "ssh")
+	if _, err := loadSigningConfig(); err == nil {
+		t.Error("loadSigningConfig() should fail for ssh without user.signingkey")
+	}
+
+	repo.MustGit("config", "user.signingkey", "/tmp/key.pub")
+	config, err = loadSigningConfig()
+	if err != nil {
+		t.Fatalf("loadSigningConfig() error = %v", err)
+	}
+	if got := config.Describe(); got != "ssh（/tmp/key.pub）" {
+		t.Errorf("Describe() = %q", got)
+	}
+
+	repo.MustGit("config", "gpg.format", "pgp")
+	if _, err := loadSigningConfig(); err == nil {
+		t.Error("loadSigningConfig() should fail for unknown gpg.format")
+	}
+}
+
+// TestGradeVerifyOutput は git verify-tag --raw の出力の判定をテストします
+func TestGradeVerifyOutput(t *testing.T) {
+	tests := []struct {
+		name       string
+		output     string
+		ok         bool
+		wantStatus string
+		wantSigner string
+	}{
+		{
+			name:       "gpg good ultimate",
+			output:     "[GNUPG:] NEWSIG\n[GNUPG:] GOODSIG ABCDEF Alice <alice@example.com>\n[GNUPG:] TRUST_ULTIMATE 0 pgp\n",
+			ok:         true,
+			wantStatus: sigGood,
+			wantSigner: "Alice <alice@example.com>",
+		},
+		{
+			name:       "gpg good unknown trust",
+			output:     "[GNUPG:] GOODSIG ABCDEF Bob\n[GNUPG:] TRUST_UNDEFINED 0 pgp\n",
+			ok:         true,
+			wantStatus: sigUntrusted,
+			wantSigner: "Bob",
+		},
+		{
+			name:       "gpg bad",
+			output:     "[GNUPG:] BADSIG ABCDEF Alice\n",
+			wantStatus: sigBad,
+		},
+		{
+			name:       "gpg missing key",
+			output:     "[GNUPG:] ERRSIG ABCDEF 1 10 00 0 9\n[GNUPG:] NO_PUBKEY ABCDEF\n",
+			wantStatus: sigUnverifiable,
+		},
+		{
+			name:       "gpg expired key",
+			output:     "[GNUPG:] EXPKEYSIG ABCDEF Alice\n",
+			wantStatus: sigExpiredKey,
+		},
+		{
+			name:       "gpg revoked key",
+			output:     "[GNUPG:] REVKEYSIG ABCDEF Alice\n",
+			wantStatus: sigRevoked,
+		},
+		{
+			name:       "ssh good",
+			output:     `Good "git" signature for alice@example.com with ED25519 key SHA256:abc` + "\n",
+			ok:         true,
+			wantStatus: sigGood,
+			wantSigner: "alice@example.com ED25519 key SHA256:abc",
+		},
+		{
+			name:       "ssh no principal",
+			output:     `Good "git" signature with ED25519 key SHA256:abc` + "\nNo principal matched.\n",
+			wantStatus: sigUntrusted,
+			wantSigner: "ED25519 key SHA256:abc",
+		},
+		{
+			name:       "ssh allowed signers not configured",
+			output:     "error: gpg.ssh.allowedSignersFile needs to be configured and exist for ssh signature verification\n",
+			wantStatus: sigUnverifiable,
+		},
+		{
+			name:       "unsigned",
+			output:     "error: no signature found\n",
+			wantStatus: sigNone,
+		},
+		{
+			name:       "lightweight",
+			output:     "error: v1: cannot verify a non-tag object of type commit.\n",
+			wantStatus: sigNone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, signer := gradeVerifyOutput(tt.output, tt.ok)
+			if status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", status, tt.wantStatus)
+			}
+			if signer != tt.wantSigner {
+				t.Errorf("signer = %q, want %q", signer, tt.wantSigner)
+			}
+		})
+	}
+}
+
+// TestIsSignatureAccepted は検証結果の合否をテストします
+func TestIsSignatureAccepted(t *testing.T) {
+	if !isSignatureAccepted(sigGood, false) {
+		t.Error("G should be accepted")
+	}
+	if isSignatureAccepted(sigUntrusted, false) {
+		t.Error("U should not be accepted without allowUntrusted")
+	}
+	if !isSignatureAccepted(sigUntrusted, true) {
+		t.Error("U should be accepted with allowUntrusted")
+	}
+	for _, status := range []string{sigBad, sigExpired, sigExpiredKey, sigRevoked, sigUnverifiable, sigNone} {
+		if isSignatureAccepted(status, true) {
+			t.Errorf("%s should not be accepted", status)
+		}
+	}
+}
+
+// TestMakeTag_Sign は署名付きタグの作成をテストします
+func TestMakeTag_Sign(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("README.md", "# Test")
+	repo.Commit("Initial commit")
+	setupSSHSigning(t, repo)
+	chdirTagRepo(t, repo.Dir)
+
+	if err := makeTag("v1.0.0", "Release v1.0.0", true); err != nil {
+		t.Fatalf("makeTag() error = %v", err)
+	}
+	if err := makeTag("v1.0.1", "Release v1.0.1", false); err != nil {
+		t.Fatalf("makeTag() error = %v", err)
+	}
+
+	contents := repo.MustGit("tag", "-l", "--format=%(contents:signature)", "v1.0.0")
+	if !strings.Contains(contents, "BEGIN SSH SIGNATURE") {
+		t.Errorf("v1.0.0 should be signed, got %q", contents)
+	}
+	if result := verifyTagSignature("v1.0.0"); result.Status != sigGood {
+		t.Errorf("verifyTagSignature(v1.0.0) = %q, want G", result.Status)
+	}
+	if result := verifyTagSignature("v1.0.1"); result.Status != sigNone {
+		t.Errorf("verifyTagSignature(v1.0.1) = %q, want N", result.Status)
+	}
+}
@@ -0,0 +1,249 @@
+/*
+Package tag は git の拡張コマンドのうち、タグ関連コマンドを定義します。
+
+このファイル (verify_tags.go) は、タグとコミットの署名を一括で検証するコマンドを提供します。
+
+主な機能:
+  - 範囲内のすべてのタグの署名の検証（git verify-tag）
+  - 範囲内のすべてのコミットの署名の検証（--commits オプション、git log の %G?）
+  - 署名がない、信頼されていない、不正な署名の報告
+  - 検証に失敗したものがある場合は終了コード1（CI 向け）
+
+使用例:
+
+	git verify-tags                         # すべてのタグを検証
+	git verify-tags v1.0.0..v2.0.0          # v1.0.0 より後、v2.0.0 までのタグを検証
+	git verify-tags v1.0.0..HEAD --commits  # タグとコミットを検証
+*/
+package tag
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/gitcmd"
+)
+
+var (
+	verifyCommits        bool // コミットの署名も検証するフラグ
+	verifyAllowUntrusted bool // 信頼されていない署名を合格とするフラグ
+)
+
+// signatureResult は署名の検証結果1件を表す構造体です
+type signatureResult struct {
+	Name    string // タグ名またはコミットハッシュ
+	Subject string // コミットの件名（タグの場合は空文字列）
+	Status  string // 検証結果（sigGood などの %G? と同じ1文字）
+	Signer  string // 署名者（判定できない場合は空文字列）
+}
+
+// verifyTagsCmd はタグとコミットの署名を検証するコマンドです。
+var verifyTagsCmd = &cobra.Command{
+	Use:   "verify-tags [範囲]",
+	Short: "タグとコミットの署名を一括で検証",
+	Long: `範囲内のすべてのタグの署名を検証し、署名がないもの、信頼されていないもの、
+不正なものを報告します。--commits を指定するとコミットの署名も検証します。
+
+範囲の指定方法:
+  (省略)       すべてのタグ（--commits の場合は HEAD までのすべてのコミット）
+  v2.0.0       v2.0.0 から到達できるタグ
+  v1.0.0..v2.0.0  v1.0.0 から到達できず、v2.0.0 から到達できるタグ
+
+署名の検証には git の設定を使用します（openpgp は gpg の鍵束、
+ssh は gpg.ssh.allowedSignersFile）。
+検証に失敗したものが1つでもある場合は終了コード1で終了するため、CI でも利用できます。`,
+	Example: `  git verify-tags                            # すべてのタグを検証
+  git verify-tags v1.0.0..v2.0.0             # 範囲内のタグを検証
+  git verify-tags v1.0.0..HEAD --commits     # タグとコミットを検証
+  git verify-tags --allow-untrusted          # 信頼されていない署名も合格とする`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(c *cobra.Command, args []string) error {
+		revRange := ""
+		if len(args) > 0 {
+			revRange = strings.TrimSpace(args[0])
+		}
+
+		tags, err := listTagsInRange(revRange)
+		if err != nil {
+			return fmt.Errorf("タグの取得に失敗しました: %w", err)
+		}
+
+		failed := 0
+		total := len(tags)
+		fmt.Printf("タグの署名を検証しています（%d 個）\n", len(tags))
+		for _, tag := range tags {
+			result := verifyTagSignature(tag)
+			if !printSignatureResult(result, true) {
+				failed++
+			}
+		}
+
+		if verifyCommits {
+			commitRange := revRange
+			if commitRange == "" {
+				commitRange = "HEAD"
+			}
+			commits, err := verifyCommitSignatures(commitRange)
+			if err != nil {
+				return fmt.Errorf("コミットの署名の検証に失敗しました: %w", err)
+			}
+			total += len(commits)
+			fmt.Printf("\nコミットの署名を検証しています（%d 個、失敗したもののみ表示）\n", len(commits))
+			commitFailed := 0
+			for _, result := range commits {
+				if !printSignatureResult(result, false) {
+					commitFailed++
+				}
+			}
+			if commitFailed == 0 && len(commits) > 0 {
+				fmt.Println("  ✓ すべてのコミットに有効な署名があります")
+			}
+			failed += commitFailed
+		}
+
+		if total == 0 {
+			fmt.Println("検証するタグがありません")
+			return nil
+		}
+		if failed > 0 {
+			fmt.Printf("\n%d 個中 %d 個の署名の検証に失敗しました\n", total, failed)
+			os.Exit(1)
+		}
+
+		fmt.Printf("\n✓ %d 個の署名を検証しました\n", total)
+		return nil
+	},
+}
+
+// listTagsInRange は範囲内のタグを取得します。
+//
+// パラメータ:
+//   - revRange: 範囲（空の場合はすべてのタグ、"A..B" の場合は A から到達できず B から到達できるタグ、
+//     それ以外の場合はそのリビジョンから到達できるタグ）
+//
+// 戻り値:
+//   - []string: タグ名（バージョン順）
+//   - error: git tag の実行に失敗した場合のエラー情報
+func listTagsInRange(revRange string) ([]string, error) {
+	args := []string{"tag", "-l", "--sort=v:refname"}
+	if from, to, ok := strings.Cut(revRange, ".."); ok {
+		if to == "" {
+			to = "HEAD"
+		}
+		args = append(args, "--merged", to)
+		if from != "" {
+			args = append(args, "--no-merged", from)
+		}
+	} else if revRange != "" {
+		args = append(args, "--merged", revRange)
+	}
+
+	output, err := gitcmd.Run(args...)
+	if err != nil {
+		return nil, err
+	}
+	text := strings.TrimSpace(string(output))
+	if text == "" {
+		return nil, nil
+	}
+	return strings.Split(text, "\n"), nil
+}
+
+// verifyTagSignature はタグの署名を検証します。
+//
+// 内部処理:
+//
+//	軽量タグは署名できないため、git verify-tag を実行せずに署名なしとします。
+//	注釈付きタグは git verify-tag --raw の出力から検証結果を判定します（gradeVerifyOutput）。
+func verifyTagSignature(tag string) signatureResult {
+	result := signatureResult{Name: tag, Status: sigNone}
+
+	objectType, err := gitcmd.Run("cat-file", "-t", "refs/tags/"+tag)
+	if err != nil || strings.TrimSpace(string(objectType)) != "tag" {
+		return result
+	}
+
+	output, err := exec.Command("git", "verify-tag", "--raw", tag).CombinedOutput()
+	result.Status, result.Signer = gradeVerifyOutput(string(output), err == nil)
+	return result
+}
+
+// verifyCommitSignatures は範囲内のコミットの署名を検証します。
+//
+// パラメータ:
+//   - revRange: git log に渡す範囲
+//
+// 戻り値:
+//   - []signatureResult: コミットごとの検証結果（新しい順）
+//   - error: git log の実行に失敗した場合のエラー情報
+func verifyCommitSignatures(revRange string) ([]signatureResult, error) {
+	output, err := gitcmd.Run("log", "--format=%H%x00%G?%x00%GS%x00%s", revRange)
+	if err != nil {
+		return nil, err
+	}
+	return parseCommitSignatures(string(output)), nil
+}
+
+// parseCommitSignatures は git log --format=%H%x00%G?%x00%GS%x00%s の出力を解析します
+func parseCommitSignatures(output string) []signatureResult {
+	var results []signatureResult
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(line, "\x00", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		results = append(results, signatureResult{
+			Name:    fields[0],
+			Status:  fields[1],
+			Signer:  fields[2],
+			Subject: fields[3],
+		})
+	}
+	return results
+}
+
+// printSignatureResult は検証結果を表示します。
+//
+// パラメータ:
+//   - result: 検証結果
+//   - showAccepted: 合格したものも表示するかどうか
+//
+// 戻り値:
+//   - bool: 合格した場合は true
+func printSignatureResult(result signatureResult, showAccepted bool) bool {
+	accepted := isSignatureAccepted(result.Status, verifyAllowUntrusted)
+	if accepted && !showAccepted {
+		return true
+	}
+
+	mark := "✗"
+	switch {
+	case result.Status == sigGood:
+		mark = "✓"
+	case accepted:
+		mark = "!"
+	}
+
+	name := result.Name
+	if result.Subject != "" {
+		name = shortHash(result.Name) + " " + result.Subject
+	}
+	label := signatureStatusLabel(result.Status)
+	if result.Signer != "" {
+		label += "（" + result.Signer + "）"
+	}
+	fmt.Printf("  %s %s  %s\n", mark, name, label)
+	return accepted
+}
+
+// init はコマンドの初期化を行います。
+func init() {
+	cmd.RootCmd.AddCommand(verifyTagsCmd)
+
+	verifyTagsCmd.Flags().BoolVar(&verifyCommits, "commits", false, "範囲内のコミットの署名も検証")
+	verifyTagsCmd.Flags().BoolVar(&verifyAllowUntrusted, "allow-untrusted", false, "信頼されていない署名も合格とする")
+}
@@ -0,0 +1,110 @@
+package tag
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/testutil"
+)
+
+// TestVerifyTagsCmd_CommandSetup はverify-tagsコマンドの設定をテストします
+func TestVerifyTagsCmd_CommandSetup(t *testing.T) {
+	if verifyTagsCmd.Use != "verify-tags [範囲]" {
+		t.Errorf("verifyTagsCmd.Use = %q", verifyTagsCmd.Use)
+	}
+	for _, name := range []string{"commits", "allow-untrusted"} {
+		if verifyTagsCmd.Flags().Lookup(name) == nil {
+			t.Errorf("Flag %q not found", name)
+		}
+	}
+
+	found := false
+	for _, c := range cmd.RootCmd.Commands() {
+		if c.Name() == "verify-tags" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("verify-tags command should be registered to RootCmd")
+	}
+}
+
+// TestListTagsInRange は範囲内のタグの取得をテストします
+func TestListTagsInRange(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("a.txt", "a")
+	repo.Commit("first")
+	repo.CreateTag("v1.0.0", "v1.0.0")
+	repo.CreateFile("b.txt", "b")
+	repo.Commit("second")
+	repo.CreateLightweightTag("v1.1.0")
+	repo.CreateFile("c.txt", "c")
+	repo.Commit("third")
+	repo.CreateTag("v2.0.0", "v2.0.0")
+	chdirTagRepo(t, repo.Dir)
+
+	tests := []struct {
+		revRange string
+		want     []string
+	}{
+		{"", []string{"v1.0.0", "v1.1.0", "v2.0.0"}},
+		{"v1.1.0", []string{"v1.0.0", "v1.1.0"}},
+		{"v1.0.0..v2.0.0", []string{"v1.1.0", "v2.0.0"}},
+		{"v1.1.0..", []string{"v2.0.0"}},
+		{"v2.0.0..HEAD", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.revRange, func(t *testing.T) {
+			got, err := listTagsInRange(tt.revRange)
+			if err != nil {
+				t.Fatalf("listTagsInRange() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("listTagsInRange(%q) = %v, want %v", tt.revRange, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestParseCommitSignatures は git log の署名情報の解析をテストします
+func TestParseCommitSignatures(t *testing.T) {
+	output := "aaa\x00G\x00alice@example.com\x00feat: add\nbbb\x00N\x00\x00fix: bug\n"
+	want := []signatureResult{
+		{Name: "aaa", Status: sigGood, Signer: "alice@example.com", Subject: "feat: add"},
+		{Name: "bbb", Status: sigNone, Subject: "fix: bug"},
+	}
+
+	got := parseCommitSignatures(output)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseCommitSignatures() = %+v, want %+v", got, want)
+	}
+}
+
+// TestVerifyCommitSignatures はコミットの署名の検証をテストします
+func TestVerifyCommitSignatures(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("a.txt", "a")
+	repo.Commit("unsigned")
+	setupSSHSigning(t, repo)
+	repo.CreateFile("b.txt", "b")
+	repo.MustGit("add", "b.txt")
+	repo.MustGit("commit", "-S", "-m", "signed")
+	chdirTagRepo(t, repo.Dir)
+
+	results, err := verifyCommitSignatures("HEAD")
+	if err != nil {
+		t.Fatalf("verifyCommitSignatures() error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("len(results) = %d, want 2", len(results))
+	}
+	if results[0].Subject != "signed" || results[0].Status != sigGood {
+		t.Errorf("results[0] = %+v, want signed commit with G", results[0])
+	}
+	if results[1].Subject != "unsigned" || results[1].Status != sigNone {
+		t.Errorf("results[1] = %+v, want unsigned commit with N", results[1])
+	}
+}
@@ -10,6 +10,7 @@
 // 【主な機能】
 // - 新しいブランチを worktree として別ディレクトリに作成
 // - ディレクトリ名は自動的に生成（feature/xxx → ../repo-feature-xxx）
+//   追加の worktree の中で実行した場合も、メインの作業ツリーの隣に作成します
 // - --no-code フラグでVSCodeを開かないことも可能
 // - --base フラグでベースブランチを指定可能
 //
@@ -25,12 +26,11 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"path/filepath"
-	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/tonbiattack/git-plus/cmd"
 	"github.com/tonbiattack/git-plus/internal/gitcmd"
+	"github.com/tonbiattack/git-plus/internal/gitworktree"
 )
 
 var (
@@ -47,6 +47,7 @@ var worktreeNewCmd = &cobra.Command{
   feature/xxx → ../repo-feature-xxx
   bugfix/abc  → ../repo-bugfix-abc
 
+追加の worktree の中で実行した場合も、メインの作業ツリーの隣に作成します。
 これにより、複数のタスクを並行して作業することが容易になります。`,
 	Example: `  git worktree-new feature/new-login
   git worktree-new bugfix/issue-123 --no-code
@@ -55,20 +56,16 @@ var worktreeNewCmd = &cobra.Command{
 	RunE: func(c *cobra.Command, args []string) error {
 		branchName := args[0]
 
-		// リポジトリのルートディレクトリを取得
-		repoRoot, err := getRepoRoot()
+		// メインの作業ツリーのルートディレクトリを取得
+		// （追加の worktree の中で実行しても、tag-checkout --worktree と同じ場所に作成するため）
+		repoRoot, err := gitworktree.MainRoot()
 		if err != nil {
 			return fmt.Errorf("リポジトリのルートディレクトリを取得できませんでした: %w", err)
 		}
 
-		// リポジトリ名を取得
-		repoName := filepath.Base(repoRoot)
-
 		// worktree用のディレクトリ名を生成
-		// feature/xxx → repo-feature-xxx
-		sanitizedBranch := strings.ReplaceAll(branchName, "/", "-")
-		worktreeDirName := fmt.Sprintf("%s-%s", repoName, sanitizedBranch)
-		worktreePath := filepath.Join(filepath.Dir(repoRoot), worktreeDirName)
+		// feature/xxx → ../repo-feature-xxx
+		worktreePath := gitworktree.Path(repoRoot, branchName)
 
 		// ディレクトリが既に存在するかチェック
 		if _, err := os.Stat(worktreePath); err == nil {
@@ -123,15 +120,6 @@ var worktreeNewCmd = &cobra.Command{
 	},
 }
 
-// getRepoRoot は現在のリポジトリのルートディレクトリを取得します
-func getRepoRoot() (string, error) {
-	output, err := gitcmd.Run("rev-parse", "--show-toplevel")
-	if err != nil {
-		return "", err
-	}
-	return strings.TrimSpace(string(output)), nil
-}
-
 // checkBranchExists は指定されたブランチが存在するかチェックします
 func checkBranchExists(branchName string) (bool, error) {
 	err := gitcmd.RunQuiet("show-ref", "--verify", "--quiet", "refs/heads/"+branchName)
@@ -1,9 +1,12 @@
 package worktree
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/tonbiattack/git-plus/cmd"
+	"github.com/tonbiattack/git-plus/internal/testutil"
 )
 
 // TestWorktreeNewCmd_CommandSetup はworktree-newコマンドの設定をテストします
@@ -95,3 +98,38 @@ func TestCheckBranchExists_InvalidBranch(t *testing.T) {
 	// エラーが発生する可能性があるが、panicしないことが重要
 	_ = err
 }
+
+// TestWorktreeNewCmd_FromLinkedWorktree は追加の worktree の中で実行してもメインの作業ツリーの隣に作成することを確認します
+func TestWorktreeNewCmd_FromLinkedWorktree(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("README.md", "# Test")
+	repo.Commit("Initial commit")
+
+	mainRoot, err := filepath.EvalSymlinks(repo.Dir)
+	if err != nil {
+		t.Fatalf("EvalSymlinks failed: %v", err)
+	}
+	linked := filepath.Join(t.TempDir(), "linked")
+	repo.MustGit("worktree", "add", "-b", "feature/first", linked)
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer func() { _ = os.Chdir(oldDir) }()
+	if err := os.Chdir(linked); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	noCode = true
+	defer func() { noCode = false }()
+	if err := worktreeNewCmd.RunE(worktreeNewCmd, []string{"feature/second"}); err != nil {
+		t.Fatalf("worktree-new returned error: %v", err)
+	}
+
+	want := filepath.Join(filepath.Dir(mainRoot), filepath.Base(mainRoot)+"-feature-second")
+	defer func() { _ = os.RemoveAll(want) }()
+	if _, err := os.Stat(want); err != nil {
+		t.Errorf("worktree should be created next to the main worktree: %s", want)
+	}
+}
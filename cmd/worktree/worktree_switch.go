@@ -30,6 +30,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/tonbiattack/git-plus/cmd"
 	"github.com/tonbiattack/git-plus/internal/gitcmd"
+	"github.com/tonbiattack/git-plus/internal/gitworktree"
 	"github.com/tonbiattack/git-plus/internal/ui"
 )
 
@@ -140,51 +141,24 @@ var worktreeSwitchCmd = &cobra.Command{
 
 // getWorktreeList は全ての worktree 情報を取得します
 func getWorktreeList() ([]WorktreeInfo, error) {
-	output, err := gitcmd.Run("worktree", "list", "--porcelain")
+	entries, err := gitworktree.List()
 	if err != nil {
 		return nil, err
 	}
 
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	worktrees := make([]WorktreeInfo, 0)
-
-	var currentWorktree WorktreeInfo
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			if currentWorktree.Path != "" {
-				worktrees = append(worktrees, currentWorktree)
-				currentWorktree = WorktreeInfo{}
-			}
-			continue
+	worktrees := make([]WorktreeInfo, 0, len(entries))
+	for _, entry := range entries {
+		info := WorktreeInfo{Path: entry.Path, Branch: entry.Branch, Commit: entry.Head}
+		if len(info.Commit) > 7 {
+			info.Commit = info.Commit[:7]
 		}
-
-		if strings.HasPrefix(line, "worktree ") {
-			currentWorktree.Path = strings.TrimPrefix(line, "worktree ")
-		} else if strings.HasPrefix(line, "HEAD ") {
-			commit := strings.TrimPrefix(line, "HEAD ")
-			if len(commit) > 7 {
-				currentWorktree.Commit = commit[:7]
-			} else {
-				currentWorktree.Commit = commit
-			}
-		} else if strings.HasPrefix(line, "branch ") {
-			branch := strings.TrimPrefix(line, "branch ")
-			// refs/heads/ を除去
-			branch = strings.TrimPrefix(branch, "refs/heads/")
-			currentWorktree.Branch = branch
-		} else if line == "detached" {
-			currentWorktree.Branch = "(detached HEAD)"
-		} else if line == "bare" {
-			currentWorktree.Branch = "(bare)"
+		if entry.Detached {
+			info.Branch = "(detached HEAD)"
+		} else if entry.Bare {
+			info.Branch = "(bare)"
 		}
+		worktrees = append(worktrees, info)
 	}
-
-	// 最後の worktree を追加
-	if currentWorktree.Path != "" {
-		worktrees = append(worktrees, currentWorktree)
-	}
-
 	return worktrees, nil
 }
 
@@ -0,0 +1,331 @@
+// ================================================================================
+// Package backup - 履歴書き換え前のバックアップ参照管理
+// ================================================================================
+// このパッケージは、参照（ブランチ・タグ・HEAD）を動かすコマンドが
+// 実行前の値を保存しておくためのバックアップ参照を管理します。
+//
+// 提供する機能:
+// - Create() / CreateForHEAD(): 参照の現在の値をバックアップ参照として保存
+// - List(): バックアップ参照の一覧を取得（新しい順）
+// - Delete() / Prune(): バックアップ参照の削除と保持期間を過ぎたものの整理
+// - Retention(): git config gitplus.backup.retention から保持期間を取得
+// - ParseAge(): "30d" や "12h" のような期間表記の解析
+//
+// 参照の形式:
+//
+//	refs/git-plus/backup/<command>/<timestamp>
+//	例: refs/git-plus/backup/squash/20260102-030405
+//
+// バックアップ元の参照名（例: refs/heads/main）は、バックアップ参照の
+// reflog メッセージに記録されます（git update-ref --create-reflog）。
+// refs/ 配下の参照として保存されるため、git gc でオブジェクトが消えることはありません。
+// ================================================================================
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tonbiattack/git-plus/internal/gitcmd"
+)
+
+const (
+	// RefPrefix はバックアップ参照の名前空間です。
+	RefPrefix = "refs/git-plus/backup/"
+
+	// RetentionConfigKey は保持期間を設定する git config のキーです。
+	RetentionConfigKey = "gitplus.backup.retention"
+
+	// DefaultRetention は保持期間が設定されていない場合の既定値（30日）です。
+	DefaultRetention = 30 * 24 * time.Hour
+
+	// timestampLayout は参照名に埋め込むタイムスタンプの形式です。
+	timestampLayout = "20060102-150405"
+
+	// reflogPrefix はバックアップ元を記録する reflog メッセージの接頭辞です。
+	reflogPrefix = "git-plus backup: "
+)
+
+// Entry はバックアップ参照1件の情報を表す構造体です。
+type Entry struct {
+	Ref     string    // バックアップ参照名（例: refs/git-plus/backup/squash/20260102-030405）
+	Command string    // バックアップを作成したコマンド名（例: squash）
+	Time    time.Time // バックアップ作成日時
+	Object  string    // 保存されたオブジェクトのハッシュ（コミットまたはタグオブジェクト）
+	Type    string    // オブジェクトの種類（commit または tag）
+	Source  string    // バックアップ元の参照名（例: refs/heads/main、HEAD）
+	Subject string    // コミットまたはタグメッセージの件名
+}
+
+// BuildRefName はコマンド名と日時からバックアップ参照名を生成します。
+func BuildRefName(command string, t time.Time) string {
+	return RefPrefix + command + "/" + t.Format(timestampLayout)
+}
+
+// Create は指定した参照の現在の値をバックアップ参照として保存します。
+//
+// パラメータ:
+//   - command: バックアップを作成するコマンド名（例: squash, reset-tag）
+//   - source: バックアップする参照名（例: refs/heads/main, refs/tags/v1.0.0, HEAD）
+//
+// 戻り値:
+//   - string: 作成したバックアップ参照名
+//   - error: 参照の解決やバックアップ参照の作成に失敗した場合のエラー
+//
+// 内部処理:
+//
+//	タグの場合はタグオブジェクト自体を保存するため、注釈付きタグのメッセージや署名も保持されます。
+//	同じ秒に複数のバックアップが作成された場合（参照が既に存在する場合）のみ、参照名の末尾に
+//	連番を付けて作り直します。それ以外の失敗（不正な参照名やロック中の参照など）はそのまま返します。
+//	保持期間を過ぎたバックアップの整理は行いません（git backups prune で明示的に実行します）。
+func Create(command, source string) (string, error) {
+	output, err := gitcmd.Run("rev-parse", "--verify", "--quiet", source)
+	if err != nil {
+		return "", fmt.Errorf("%s を解決できません: %w", source, err)
+	}
+	object := strings.TrimSpace(string(output))
+
+	base := BuildRefName(command, time.Now())
+	ref := base
+	for i := 2; ; i++ {
+		// 古い値に空文字列を指定すると、参照が存在しない場合のみ作成される
+		_, err := gitcmd.Run("update-ref", "--create-reflog", "-m", reflogPrefix+source, ref, object, "")
+		if err == nil {
+			return ref, nil
+		}
+		if !refExists(ref) || i > 100 {
+			return "", fmt.Errorf("バックアップ参照 %s の作成に失敗しました: %w%s", ref, err, stderrSuffix(err))
+		}
+		ref = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// refExists は参照が存在するかどうかを返します
+func refExists(ref string) bool {
+	return gitcmd.RunQuiet("rev-parse", "--verify", "--quiet", ref) == nil
+}
+
+// stderrSuffix は git コマンドのエラー出力をエラーメッセージに付け加える形式で返します
+func stderrSuffix(err error) string {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if msg := strings.TrimSpace(string(exitErr.Stderr)); msg != "" {
+			return "\n" + msg
+		}
+	}
+	return ""
+}
+
+// CreateForHEAD は現在の HEAD が指す参照をバックアップします。
+//
+// ブランチ上にいる場合はそのブランチ（refs/heads/<name>）を、
+// detached HEAD の場合は HEAD をバックアップ元として記録します。
+func CreateForHEAD(command string) (string, error) {
+	source := "HEAD"
+	if output, err := gitcmd.Run("symbolic-ref", "-q", "HEAD"); err == nil {
+		if ref := strings.TrimSpace(string(output)); ref != "" {
+			source = ref
+		}
+	}
+	return Create(command, source)
+}
+
+// List はバックアップ参照の一覧を新しい順に返します。
+func List() ([]Entry, error) {
+	output, err := gitcmd.Run("for-each-ref", "--format=%(refname)%00%(objectname)%00%(objecttype)%00%(subject)", RefPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("バックアップ参照の取得に失敗しました: %w", err)
+	}
+
+	entries := parseForEachRef(string(output))
+	for i := range entries {
+		entries[i].Source = readSource(entries[i].Ref)
+	}
+	return entries, nil
+}
+
+// Delete はバックアップ参照を削除します。
+func Delete(ref string) error {
+	if !strings.HasPrefix(ref, RefPrefix) {
+		return fmt.Errorf("バックアップ参照ではありません: %s", ref)
+	}
+	return gitcmd.RunQuiet("update-ref", "-d", ref)
+}
+
+// Prune は保持期間を過ぎたバックアップ参照を削除します。
+//
+// パラメータ:
+//   - retention: 保持期間（0 以下の場合は何も削除しない）
+//   - now: 基準日時
+//
+// 戻り値:
+//   - []Entry: 削除したバックアップ
+//   - error: 一覧の取得や削除に失敗した場合のエラー
+func Prune(retention time.Duration, now time.Time) ([]Entry, error) {
+	if retention <= 0 {
+		return nil, nil
+	}
+	entries, err := List()
+	if err != nil {
+		return nil, err
+	}
+
+	var pruned []Entry
+	for _, e := range Expired(entries, retention, now) {
+		if err := Delete(e.Ref); err != nil {
+			return pruned, fmt.Errorf("%s の削除に失敗しました: %w", e.Ref, err)
+		}
+		pruned = append(pruned, e)
+	}
+	return pruned, nil
+}
+
+// Expired は保持期間を過ぎたバックアップを返します。
+// 作成日時を解析できないバックアップは対象外です。
+func Expired(entries []Entry, retention time.Duration, now time.Time) []Entry {
+	var expired []Entry
+	for _, e := range entries {
+		if !e.Time.IsZero() && now.Sub(e.Time) > retention {
+			expired = append(expired, e)
+		}
+	}
+	return expired
+}
+
+// Retention は git config から保持期間を取得します。
+//
+// gitplus.backup.retention が未設定の場合は DefaultRetention を返します。
+// "never"、"off"、"0" を設定した場合は 0（削除しない）を返します。
+func Retention() (time.Duration, error) {
+	output, err := gitcmd.Run("config", "--get", RetentionConfigKey)
+	if err != nil {
+		if gitcmd.IsExitError(err, 1) {
+			return DefaultRetention, nil
+		}
+		return 0, err
+	}
+	value := strings.TrimSpace(string(output))
+	switch strings.ToLower(value) {
+	case "never", "off", "0":
+		return 0, nil
+	}
+	d, err := ParseAge(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s の値が不正です: %w", RetentionConfigKey, err)
+	}
+	return d, nil
+}
+
+// ParseAge は期間の表記を time.Duration に変換します。
+//
+// サポートする形式:
+//   - 日数: "30d"
+//   - 週数: "2w"
+//   - Go の time.ParseDuration が解釈できる形式: "12h", "90m"
+func ParseAge(value string) (time.Duration, error) {
+	value = strings.TrimSpace(strings.ToLower(value))
+	if value == "" {
+		return 0, fmt.Errorf("期間が空です")
+	}
+
+	unit := value[len(value)-1]
+	if unit == 'd' || unit == 'w' {
+		n, err := strconv.Atoi(value[:len(value)-1])
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("無効な期間です: %s", value)
+		}
+		days := n
+		if unit == 'w' {
+			days = n * 7
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("無効な期間です: %s（例: 30d, 2w, 12h）", value)
+	}
+	return d, nil
+}
+
+// parseForEachRef は git for-each-ref の出力を解析し、新しい順に並べます。
+func parseForEachRef(output string) []Entry {
+	var entries []Entry
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.SplitN(line, "\x00", 4)
+		if len(fields) < 3 {
+			continue
+		}
+		e := Entry{Ref: fields[0], Object: fields[1], Type: fields[2]}
+		if len(fields) == 4 {
+			e.Subject = fields[3]
+		}
+		e.Command, e.Time = parseRefName(e.Ref)
+		entries = append(entries, e)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].Time.Equal(entries[j].Time) {
+			return entries[i].Time.After(entries[j].Time)
+		}
+		return entries[i].Ref > entries[j].Ref
+	})
+	return entries
+}
+
+// parseRefName はバックアップ参照名からコマンド名と作成日時を取り出します。
+// 日時を解析できない場合はゼロ値を返します。
+func parseRefName(ref string) (string, time.Time) {
+	rest := strings.TrimPrefix(ref, RefPrefix)
+	idx := strings.LastIndex(rest, "/")
+	if idx < 0 {
+		return rest, time.Time{}
+	}
+	command, stamp := rest[:idx], rest[idx+1:]
+	// 同じ秒に作成された場合の連番（-2, -3 ...）を除去する
+	if len(stamp) > len(timestampLayout) {
+		stamp = stamp[:len(timestampLayout)]
+	}
+	t, err := time.ParseInLocation(timestampLayout, stamp, time.Local)
+	if err != nil {
+		return command, time.Time{}
+	}
+	return command, t
+}
+
+// readSource はバックアップ参照の reflog からバックアップ元の参照名を取得します。
+//
+// git log -g はタグオブジェクトを指す参照の reflog を表示しないため、
+// git rev-parse --git-path logs/<ref> で reflog ファイルを直接読み込みます。
+// reflog の各行は "<old> <new> <ident> <time> <tz>\t<message>" 形式です。
+func readSource(ref string) string {
+	output, err := gitcmd.Run("rev-parse", "--git-path", "logs/"+ref)
+	if err != nil {
+		return ""
+	}
+	data, err := os.ReadFile(strings.TrimSpace(string(output)))
+	if err != nil {
+		return ""
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	last := lines[len(lines)-1]
+	idx := strings.Index(last, "\t")
+	if idx < 0 {
+		return ""
+	}
+	msg := last[idx+1:]
+	if !strings.HasPrefix(msg, reflogPrefix) {
+		return ""
+	}
+	return strings.TrimPrefix(msg, reflogPrefix)
+}
@@ -0,0 +1,276 @@
+package backup
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tonbiattack/git-plus/internal/testutil"
+)
+
+// chdirRepo はテスト用リポジトリに移動し、終了時に元のディレクトリへ戻します
+func chdirRepo(t *testing.T, repo *testutil.GitRepo) {
+	t.Helper()
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(oldDir) })
+
+	if err := os.Chdir(repo.Dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+}
+
+// TestBuildRefName はバックアップ参照名の生成をテストします
+func TestBuildRefName(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.Local)
+	expected := "refs/git-plus/backup/squash/20260102-030405"
+	if got := BuildRefName("squash", now); got != expected {
+		t.Errorf("BuildRefName() = %q, want %q", got, expected)
+	}
+}
+
+// TestParseRefName は参照名からのコマンド名・日時の取り出しをテストします
+func TestParseRefName(t *testing.T) {
+	tests := []struct {
+		ref         string
+		command     string
+		expectTime  bool
+		expectedDay int
+	}{
+		{"refs/git-plus/backup/squash/20260102-030405", "squash", true, 2},
+		{"refs/git-plus/backup/reset-tag/20260315-120000-2", "reset-tag", true, 15},
+		{"refs/git-plus/backup/amend/invalid", "amend", false, 0},
+	}
+
+	for _, tt := range tests {
+		command, ts := parseRefName(tt.ref)
+		if command != tt.command {
+			t.Errorf("parseRefName(%q) command = %q, want %q", tt.ref, command, tt.command)
+		}
+		if tt.expectTime {
+			if ts.IsZero() || ts.Day() != tt.expectedDay {
+				t.Errorf("parseRefName(%q) time = %v", tt.ref, ts)
+			}
+		} else if !ts.IsZero() {
+			t.Errorf("parseRefName(%q) time should be zero, got %v", tt.ref, ts)
+		}
+	}
+}
+
+// TestParseAge は期間表記の解析をテストします
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		input       string
+		expected    time.Duration
+		expectError bool
+	}{
+		{"30d", 30 * 24 * time.Hour, false},
+		{"2w", 14 * 24 * time.Hour, false},
+		{"12h", 12 * time.Hour, false},
+		{"90m", 90 * time.Minute, false},
+		{" 1D ", 24 * time.Hour, false},
+		{"", 0, true},
+		{"abc", 0, true},
+		{"-1d", 0, true},
+		{"xd", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseAge(tt.input)
+		if tt.expectError {
+			if err == nil {
+				t.Errorf("ParseAge(%q) should return error", tt.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseAge(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("ParseAge(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+// TestExpired は保持期間を過ぎたバックアップの抽出をテストします
+func TestExpired(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	entries := []Entry{
+		{Ref: "new", Time: now.Add(-24 * time.Hour)},
+		{Ref: "old", Time: now.Add(-40 * 24 * time.Hour)},
+		{Ref: "unknown"},
+	}
+
+	expired := Expired(entries, DefaultRetention, now)
+	if len(expired) != 1 || expired[0].Ref != "old" {
+		t.Errorf("Expired() = %+v, want only 'old'", expired)
+	}
+}
+
+// TestCreateAndList はバックアップの作成と一覧取得をテストします
+func TestCreateAndList(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("a.txt", "a")
+	repo.Commit("First commit")
+	repo.CreateTag("v1.0.0", "Release v1.0.0")
+	chdirRepo(t, repo)
+
+	branchRef, err := CreateForHEAD("squash")
+	if err != nil {
+		t.Fatalf("CreateForHEAD returned error: %v", err)
+	}
+	if !strings.HasPrefix(branchRef, RefPrefix+"squash/") {
+		t.Errorf("Unexpected ref name: %s", branchRef)
+	}
+
+	// 同じ秒に作成しても衝突しない
+	second, err := CreateForHEAD("squash")
+	if err != nil {
+		t.Fatalf("Second CreateForHEAD returned error: %v", err)
+	}
+	if second == branchRef {
+		t.Error("Second backup should have a different ref name")
+	}
+
+	tagRef, err := Create("reset-tag", "refs/tags/v1.0.0")
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	entries, err := List()
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+
+	found := map[string]Entry{}
+	for _, e := range entries {
+		found[e.Ref] = e
+	}
+
+	branchEntry := found[branchRef]
+	expectedSource := "refs/heads/" + repo.CurrentBranch()
+	if branchEntry.Source != expectedSource {
+		t.Errorf("Branch backup source = %q, want %q", branchEntry.Source, expectedSource)
+	}
+	if branchEntry.Command != "squash" || branchEntry.Type != "commit" || branchEntry.Subject != "First commit" {
+		t.Errorf("Unexpected branch entry: %+v", branchEntry)
+	}
+
+	// タグはタグオブジェクトのまま保存される
+	tagEntry := found[tagRef]
+	if tagEntry.Type != "tag" {
+		t.Errorf("Tag backup type = %q, want tag", tagEntry.Type)
+	}
+	if tagEntry.Source != "refs/tags/v1.0.0" {
+		t.Errorf("Tag backup source = %q, want refs/tags/v1.0.0", tagEntry.Source)
+	}
+
+	if err := Delete(tagRef); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if err := Delete("refs/heads/main"); err == nil {
+		t.Error("Delete should refuse non-backup refs")
+	}
+
+	entries, err = List()
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("Expected 2 entries after delete, got %d", len(entries))
+	}
+}
+
+// TestCreate_Errors は参照名の衝突以外の失敗を再試行せずに返すことをテストします
+func TestCreate_Errors(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("README.md", "# Test")
+	repo.Commit("First commit")
+	chdirRepo(t, repo)
+
+	// 不正な参照名は連番を付けても作成できないため、そのままエラーになる
+	if _, err := Create("bad..name", "HEAD"); err == nil || strings.Contains(err.Error(), "-2") {
+		t.Errorf("Create with invalid ref name error = %v, want error for the first ref name", err)
+	}
+	if refs := strings.TrimSpace(repo.MustGit("for-each-ref", RefPrefix)); refs != "" {
+		t.Errorf("No backup should be created: %s", refs)
+	}
+}
+
+// TestPrune は保持期間を過ぎたバックアップの削除をテストします
+func TestPrune(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("a.txt", "a")
+	repo.Commit("First commit")
+	chdirRepo(t, repo)
+
+	oldRef := BuildRefName("amend", time.Now().Add(-60*24*time.Hour))
+	repo.MustGit("update-ref", oldRef, "HEAD")
+
+	newRef, err := CreateForHEAD("amend")
+	if err != nil {
+		t.Fatalf("CreateForHEAD returned error: %v", err)
+	}
+
+	// バックアップの作成時には古いバックアップを削除しない
+	entries, err := List()
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expired backup should be kept until Prune, got %+v", entries)
+	}
+
+	pruned, err := Prune(DefaultRetention, time.Now())
+	if err != nil || len(pruned) != 1 || pruned[0].Ref != oldRef {
+		t.Errorf("Prune = %+v, %v; want only %s", pruned, err, oldRef)
+	}
+	entries, err = List()
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Ref != newRef {
+		t.Errorf("Only new backup should remain, got %+v", entries)
+	}
+
+	pruned, err = Prune(0, time.Now())
+	if err != nil || len(pruned) != 0 {
+		t.Errorf("Prune with zero retention should do nothing: %v, %v", pruned, err)
+	}
+}
+
+// TestRetention は保持期間の設定の読み込みをテストします
+func TestRetention(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	chdirRepo(t, repo)
+
+	got, err := Retention()
+	if err != nil || got != DefaultRetention {
+		t.Errorf("Retention() default = %v, %v; want %v", got, err, DefaultRetention)
+	}
+
+	repo.MustGit("config", RetentionConfigKey, "7d")
+	got, err = Retention()
+	if err != nil || got != 7*24*time.Hour {
+		t.Errorf("Retention() = %v, %v; want 7d", got, err)
+	}
+
+	repo.MustGit("config", RetentionConfigKey, "never")
+	got, err = Retention()
+	if err != nil || got != 0 {
+		t.Errorf("Retention() never = %v, %v; want 0", got, err)
+	}
+
+	repo.MustGit("config", RetentionConfigKey, "soon")
+	if _, err := Retention(); err == nil {
+		t.Error("Retention() should fail for invalid value")
+	}
+}
@@ -0,0 +1,317 @@
+// ================================================================================
+// Package conventional - Conventional Commits パーサー
+// ================================================================================
+// このパッケージは、Conventional Commits 形式のコミットメッセージを
+// 解析・検証・生成するための共通ユーティリティを提供します。
+//
+// 提供する機能:
+// - Parse(): コミットメッセージを解析して Commit 構造体に変換
+// - Validate(): コミットメッセージが規約に沿っているかを検証
+// - Format(): Commit 構造体からコミットメッセージを生成
+// - IsExempt(): マージコミットや fixup! など検証対象外のメッセージを判定
+//
+// 使用目的:
+// conventional-commit（対話的なコミット作成）、lint-commits（範囲の検証）、
+// commit-msg フックで同じパーサーを共有し、判定結果を一致させます。
+//
+// メッセージ形式:
+//
+//	<type>[(scope)][!]: <description>
+//
+//	[body]
+//
+//	[footer(s)]
+//
+// ================================================================================
+package conventional
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultTypes は標準で許可するコミットタイプの一覧です。
+// 表示順は conventional-commit の選択肢の順序にも使用されます。
+var DefaultTypes = []TypeInfo{
+	{Name: "feat", Description: "新機能の追加"},
+	{Name: "fix", Description: "バグ修正"},
+	{Name: "docs", Description: "ドキュメントのみの変更"},
+	{Name: "style", Description: "コードの意味に影響しない変更（空白、フォーマットなど）"},
+	{Name: "refactor", Description: "バグ修正や機能追加を伴わないコード変更"},
+	{Name: "perf", Description: "パフォーマンス改善"},
+	{Name: "test", Description: "テストの追加・修正"},
+	{Name: "build", Description: "ビルドシステムや外部依存関係の変更"},
+	{Name: "ci", Description: "CI 設定の変更"},
+	{Name: "chore", Description: "その他の変更（ソースやテストを変更しないもの）"},
+	{Name: "revert", Description: "以前のコミットの取り消し"},
+}
+
+// BreakingChangeToken は破壊的変更を示すフッターのトークンです。
+const BreakingChangeToken = "BREAKING CHANGE"
+
+// TypeInfo はコミットタイプの名前と説明を表す構造体です。
+type TypeInfo struct {
+	Name        string // タイプ名（例: feat）
+	Description string // タイプの説明
+}
+
+// Footer はコミットメッセージのフッター1件を表す構造体です。
+type Footer struct {
+	Token string // フッターのトークン（例: Refs, BREAKING CHANGE）
+	Value string // フッターの値（例: #123）
+}
+
+// Commit は解析済みの Conventional Commit を表す構造体です。
+type Commit struct {
+	Type        string   // コミットタイプ（例: feat）
+	Scope       string   // スコープ（省略可）
+	Breaking    bool     // 破壊的変更かどうか（! またはフッターで指定）
+	Description string   // 概要（ヘッダーのコロン以降）
+	Body        string   // 本文（省略可）
+	Footers     []Footer // フッター一覧
+}
+
+var (
+	headerPattern = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^()\r\n]*)\))?(!)?: (.*)$`)
+	footerPattern = regexp.MustCompile(`^(BREAKING CHANGE|BREAKING-CHANGE|[A-Za-z][\w-]*)(: | #)(.*)$`)
+)
+
+// MaxHeaderLength はヘッダー行の最大文字数です。
+const MaxHeaderLength = 100
+
+// Header はコミットのヘッダー行（1行目）を生成します。
+func (c *Commit) Header() string {
+	var b strings.Builder
+	b.WriteString(c.Type)
+	if c.Scope != "" {
+		b.WriteString("(" + c.Scope + ")")
+	}
+	if c.Breaking {
+		b.WriteString("!")
+	}
+	b.WriteString(": ")
+	b.WriteString(c.Description)
+	return b.String()
+}
+
+// BreakingDescription は BREAKING CHANGE フッターの内容を返します。
+// フッターがない場合は空文字列を返します。
+func (c *Commit) BreakingDescription() string {
+	for _, f := range c.Footers {
+		if f.Token == BreakingChangeToken || f.Token == "BREAKING-CHANGE" {
+			return f.Value
+		}
+	}
+	return ""
+}
+
+// Parse はコミットメッセージを解析して Commit を返します。
+//
+// パラメータ:
+//   - message: コミットメッセージ全体
+//
+// 戻り値:
+//   - *Commit: 解析結果
+//   - error: ヘッダーが規約に沿っていない場合のエラー
+//
+// 内部処理:
+//  1. '#' で始まるコメント行（git のテンプレート）を除去
+//  2. 1行目をヘッダーとして type/scope/!/description に分解
+//  3. 最後の段落がすべてフッター形式であればフッターとして扱い、それ以外は本文とする
+func Parse(message string) (*Commit, error) {
+	lines := cleanLines(message)
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) == "" {
+		return nil, fmt.Errorf("コミットメッセージが空です")
+	}
+
+	header := strings.TrimRight(lines[0], " \t")
+	matches := headerPattern.FindStringSubmatch(header)
+	if matches == nil {
+		return nil, fmt.Errorf("ヘッダーが '<type>(<scope>): <description>' 形式ではありません: %q", header)
+	}
+
+	c := &Commit{
+		Type:        strings.ToLower(matches[1]),
+		Scope:       strings.TrimSpace(matches[2]),
+		Breaking:    matches[3] == "!",
+		Description: strings.TrimSpace(matches[4]),
+	}
+
+	if len(lines) > 1 && strings.TrimSpace(lines[1]) != "" {
+		return nil, fmt.Errorf("ヘッダーと本文の間には空行が必要です")
+	}
+
+	paragraphs := splitParagraphs(lines[1:])
+	if len(paragraphs) > 0 {
+		if footers, ok := parseFooters(paragraphs[len(paragraphs)-1]); ok {
+			c.Footers = footers
+			paragraphs = paragraphs[:len(paragraphs)-1]
+		}
+	}
+
+	bodyParts := make([]string, 0, len(paragraphs))
+	for _, p := range paragraphs {
+		bodyParts = append(bodyParts, strings.Join(p, "\n"))
+	}
+	c.Body = strings.Join(bodyParts, "\n\n")
+
+	if c.BreakingDescription() != "" {
+		c.Breaking = true
+	}
+
+	return c, nil
+}
+
+// Validate はコミットメッセージを解析し、規約違反を一覧で返します。
+//
+// パラメータ:
+//   - message: コミットメッセージ全体
+//   - allowedTypes: 許可するタイプ名（nil の場合は DefaultTypes を使用）
+//
+// 戻り値:
+//   - *Commit: 解析結果（ヘッダーの解析に失敗した場合は nil）
+//   - []string: 規約違反の説明（問題がない場合は空）
+func Validate(message string, allowedTypes []string) (*Commit, []string) {
+	c, err := Parse(message)
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+
+	if allowedTypes == nil {
+		allowedTypes = TypeNames()
+	}
+
+	var problems []string
+	if !containsType(allowedTypes, c.Type) {
+		problems = append(problems, fmt.Sprintf("不明なタイプです: %q（使用可能: %s）", c.Type, strings.Join(allowedTypes, ", ")))
+	}
+	if c.Description == "" {
+		problems = append(problems, "概要（コロンの後）が空です")
+	}
+	if header := c.Header(); len([]rune(header)) > MaxHeaderLength {
+		problems = append(problems, fmt.Sprintf("ヘッダーが長すぎます（%d文字、最大%d文字）", len([]rune(header)), MaxHeaderLength))
+	}
+
+	return c, problems
+}
+
+// Format は Commit からコミットメッセージ全体を生成します。
+//
+// ヘッダー、本文、フッターを空行で区切って連結します。
+// Parse(Format(c)) は元の Commit と同じ内容を返します。
+func Format(c *Commit) string {
+	sections := []string{c.Header()}
+	if body := strings.TrimSpace(c.Body); body != "" {
+		sections = append(sections, body)
+	}
+	if len(c.Footers) > 0 {
+		footerLines := make([]string, 0, len(c.Footers))
+		for _, f := range c.Footers {
+			footerLines = append(footerLines, fmt.Sprintf("%s: %s", f.Token, f.Value))
+		}
+		sections = append(sections, strings.Join(footerLines, "\n"))
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+// IsExempt は検証対象外のコミットメッセージかどうかを判定します。
+//
+// git が自動生成するマージ・リバートのメッセージや、
+// rebase --autosquash 用の fixup!/squash!/amend! コミットは検証しません。
+func IsExempt(message string) bool {
+	lines := cleanLines(message)
+	if len(lines) == 0 {
+		return false
+	}
+	header := lines[0]
+	prefixes := []string{"Merge ", "Revert \"", "fixup! ", "squash! ", "amend! "}
+	for _, p := range prefixes {
+		if strings.HasPrefix(header, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// TypeNames は DefaultTypes のタイプ名一覧を返します。
+func TypeNames() []string {
+	names := make([]string, 0, len(DefaultTypes))
+	for _, t := range DefaultTypes {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// cleanLines はメッセージを行に分割し、コメント行と末尾の空行を除去します。
+func cleanLines(message string) []string {
+	message = strings.ReplaceAll(message, "\r\n", "\n")
+	var lines []string
+	for _, line := range strings.Split(message, "\n") {
+		// git commit -v のスシザーライン以降は差分なので無視する
+		if strings.HasPrefix(line, "# ------------------------ >8 ------------------------") {
+			break
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// splitParagraphs は空行で区切られた段落に分割します。
+func splitParagraphs(lines []string) [][]string {
+	var paragraphs [][]string
+	var current []string
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			if len(current) > 0 {
+				paragraphs = append(paragraphs, current)
+				current = nil
+			}
+			continue
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		paragraphs = append(paragraphs, current)
+	}
+	return paragraphs
+}
+
+// parseFooters は段落がフッターのみで構成されている場合にフッター一覧を返します。
+// フッターの値が複数行にわたる場合は、継続行を直前のフッターに連結します。
+func parseFooters(paragraph []string) ([]Footer, bool) {
+	var footers []Footer
+	for _, line := range paragraph {
+		if m := footerPattern.FindStringSubmatch(line); m != nil {
+			value := m[3]
+			if m[2] == " #" {
+				// "Refs #123" 形式は値に # を含める
+				value = "#" + value
+			}
+			footers = append(footers, Footer{Token: m[1], Value: strings.TrimSpace(value)})
+			continue
+		}
+		if len(footers) == 0 {
+			return nil, false
+		}
+		last := &footers[len(footers)-1]
+		last.Value = last.Value + "\n" + strings.TrimSpace(line)
+	}
+	return footers, len(footers) > 0
+}
+
+// containsType はタイプ一覧に指定したタイプが含まれるかを判定します。
+func containsType(types []string, t string) bool {
+	for _, name := range types {
+		if strings.EqualFold(name, t) {
+			return true
+		}
+	}
+	return false
+}
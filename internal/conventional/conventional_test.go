@@ -0,0 +1,197 @@
+package conventional
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestParse はコミットメッセージの解析をテストします
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected Commit
+	}{
+		{
+			name:     "タイプと概要のみ",
+			message:  "feat: ユーザー検索を追加",
+			expected: Commit{Type: "feat", Description: "ユーザー検索を追加"},
+		},
+		{
+			name:     "スコープ付き",
+			message:  "fix(api): タイムアウトを修正",
+			expected: Commit{Type: "fix", Scope: "api", Description: "タイムアウトを修正"},
+		},
+		{
+			name:     "破壊的変更の!",
+			message:  "refactor(core)!: 設定形式を変更",
+			expected: Commit{Type: "refactor", Scope: "core", Breaking: true, Description: "設定形式を変更"},
+		},
+		{
+			name:    "本文とフッター",
+			message: "feat: 新機能\n\n本文1行目\n本文2行目\n\n2段落目\n\nRefs: #123\nReviewed-by: Alice",
+			expected: Commit{
+				Type:        "feat",
+				Description: "新機能",
+				Body:        "本文1行目\n本文2行目\n\n2段落目",
+				Footers: []Footer{
+					{Token: "Refs", Value: "#123"},
+					{Token: "Reviewed-by", Value: "Alice"},
+				},
+			},
+		},
+		{
+			name:    "BREAKING CHANGE フッター",
+			message: "feat: API を刷新\n\nBREAKING CHANGE: v1 エンドポイントを削除",
+			expected: Commit{
+				Type:        "feat",
+				Breaking:    true,
+				Description: "API を刷新",
+				Footers:     []Footer{{Token: "BREAKING CHANGE", Value: "v1 エンドポイントを削除"}},
+			},
+		},
+		{
+			name:    "# 区切りのフッター",
+			message: "fix: 修正\n\nCloses #42",
+			expected: Commit{
+				Type:        "fix",
+				Description: "修正",
+				Footers:     []Footer{{Token: "Closes", Value: "#42"}},
+			},
+		},
+		{
+			name:     "コメント行を無視",
+			message:  "docs: README を更新\n# Please enter the commit message\n#\n",
+			expected: Commit{Type: "docs", Description: "README を更新"},
+		},
+		{
+			name:     "大文字のタイプは小文字に正規化",
+			message:  "Feat: 機能追加",
+			expected: Commit{Type: "feat", Description: "機能追加"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.message)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if !reflect.DeepEqual(*got, tt.expected) {
+				t.Errorf("Parse() = %+v, want %+v", *got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestParse_Invalid は不正なメッセージの解析エラーをテストします
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+	}{
+		{"空メッセージ", ""},
+		{"コメントのみ", "# comment\n"},
+		{"タイプなし", "ユーザー検索を追加"},
+		{"コロンの後に空白なし", "feat:追加"},
+		{"ヘッダー直後に本文", "feat: 追加\n本文"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.message); err == nil {
+				t.Errorf("Parse(%q) should return error", tt.message)
+			}
+		})
+	}
+}
+
+// TestValidate は規約違反の検出をテストします
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name         string
+		message      string
+		allowedTypes []string
+		wantProblems int
+	}{
+		{"正常", "feat(ui): ボタンを追加", nil, 0},
+		{"不明なタイプ", "feature: ボタンを追加", nil, 1},
+		{"概要が空", "fix: ", nil, 1},
+		{"許可タイプを指定", "feature: ボタンを追加", []string{"feature"}, 0},
+		{"ヘッダーが長すぎる", "feat: " + strings.Repeat("a", MaxHeaderLength), nil, 1},
+		{"形式不正", "just a message", nil, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, problems := Validate(tt.message, tt.allowedTypes)
+			if len(problems) != tt.wantProblems {
+				t.Errorf("Validate(%q) problems = %v, want %d problems", tt.message, problems, tt.wantProblems)
+			}
+		})
+	}
+}
+
+// TestFormat_RoundTrip は Format と Parse の往復で内容が保持されることをテストします
+func TestFormat_RoundTrip(t *testing.T) {
+	original := &Commit{
+		Type:        "feat",
+		Scope:       "tag",
+		Breaking:    true,
+		Description: "プレフィックス付きタグに対応",
+		Body:        "モノレポ向けの変更です。",
+		Footers: []Footer{
+			{Token: BreakingChangeToken, Value: "--prefix が必須になりました"},
+			{Token: "Refs", Value: "#10"},
+		},
+	}
+
+	message := Format(original)
+	expected := "feat(tag)!: プレフィックス付きタグに対応\n\nモノレポ向けの変更です。\n\nBREAKING CHANGE: --prefix が必須になりました\nRefs: #10"
+	if message != expected {
+		t.Errorf("Format() = %q, want %q", message, expected)
+	}
+
+	parsed, err := Parse(message)
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(parsed, original) {
+		t.Errorf("Parse(Format()) = %+v, want %+v", parsed, original)
+	}
+}
+
+// TestIsExempt は検証対象外メッセージの判定をテストします
+func TestIsExempt(t *testing.T) {
+	tests := []struct {
+		message  string
+		expected bool
+	}{
+		{"Merge branch 'main' into feature", true},
+		{"Merge pull request #1 from user/branch", true},
+		{"Revert \"feat: something\"", true},
+		{"fixup! feat: something", true},
+		{"squash! fix: something", true},
+		{"feat: something", false},
+		{"revert: feat を取り消し", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsExempt(tt.message); got != tt.expected {
+			t.Errorf("IsExempt(%q) = %v, want %v", tt.message, got, tt.expected)
+		}
+	}
+}
+
+// TestTypeNames はタイプ名一覧の取得をテストします
+func TestTypeNames(t *testing.T) {
+	names := TypeNames()
+	if len(names) != len(DefaultTypes) {
+		t.Fatalf("TypeNames() length = %d, want %d", len(names), len(DefaultTypes))
+	}
+	if names[0] != "feat" || names[1] != "fix" {
+		t.Errorf("TypeNames() should start with feat, fix: got %v", names[:2])
+	}
+}
@@ -0,0 +1,138 @@
+// ================================================================================
+// Package editor - ユーザーのエディタの起動
+// ================================================================================
+// このパッケージは、issue-edit・issue-create・pr-issue-link などで共通の
+// エディタの取得と起動を提供します。
+//
+// 提供する機能:
+// - Get(): git config core.editor / VISUAL / EDITOR からエディタを取得
+// - Open(): ターミナルの状態を保護しながらエディタでファイルを開く
+// - ParseCommand(): 引用符を考慮してエディタコマンドを引数に分割
+// ================================================================================
+package editor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/tonbiattack/git-plus/internal/gitcmd"
+	"github.com/tonbiattack/git-plus/internal/terminal"
+)
+
+// Get はユーザーが設定しているエディタを取得します。
+// 優先順位: git config core.editor > VISUAL > EDITOR > デフォルト(vi)
+func Get() (string, error) {
+	// git config core.editor を確認
+	output, err := gitcmd.Run("config", "--get", "core.editor")
+	if err == nil && len(output) > 0 {
+		editor := strings.TrimSpace(string(output))
+		if editor != "" {
+			return editor, nil
+		}
+	}
+
+	// 環境変数 VISUAL を確認
+	if visual := os.Getenv("VISUAL"); visual != "" {
+		return visual, nil
+	}
+
+	// 環境変数 EDITOR を確認
+	if editor := os.Getenv("EDITOR"); editor != "" {
+		return editor, nil
+	}
+
+	// デフォルトは vi
+	return "vi", nil
+}
+
+// Open は指定されたエディタでファイルを開きます。
+// エディタが中断された場合や、ユーザーがキャンセルした場合はエラーを返します。
+// ターミナルの状態は常に保護され、不正な状態になることはありません。
+func Open(editor, filepath string) error {
+	// VSCodeなどのエディタには --wait フラグを追加
+	editor = terminal.AddWaitFlagIfNeeded(editor)
+
+	// エディタコマンドをパースして引数を分割
+	// 引用符を考慮したパースを行う
+	parts, err := ParseCommand(editor)
+	if err != nil {
+		return fmt.Errorf("エディタコマンドのパースに失敗: %w", err)
+	}
+	if len(parts) == 0 {
+		return fmt.Errorf("エディタコマンドが空です")
+	}
+
+	args := append(parts[1:], filepath)
+	cmd := exec.Command(parts[0], args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	// ターミナル状態を保護しながらエディタを実行
+	result := terminal.RunEditorWithProtection(cmd)
+
+	if result.Cancelled {
+		return fmt.Errorf("操作がキャンセルされました")
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+// ParseCommand はエディタコマンド文字列をパースして、引用符を考慮した引数リストに分割します。
+func ParseCommand(command string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inQuote := false
+	quoteChar := rune(0)
+
+	runes := []rune(command)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case !inQuote && (r == '"' || r == '\''):
+			// 引用符の開始
+			inQuote = true
+			quoteChar = r
+		case inQuote && r == quoteChar:
+			// 引用符の終了
+			inQuote = false
+			quoteChar = 0
+		case !inQuote && r == ' ':
+			// スペース: 引数の区切り
+			if current.Len() > 0 {
+				args = append(args, current.String())
+				current.Reset()
+			}
+		case r == '\\' && i+1 < len(runes):
+			// バックスラッシュエスケープ
+			next := runes[i+1]
+			if inQuote && next == quoteChar {
+				// 引用符内のエスケープされた引用符
+				current.WriteRune(next)
+				i++ // 次の文字をスキップ
+			} else {
+				// 通常のバックスラッシュ（Windowsのパス区切りなど）
+				current.WriteRune(r)
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	// 最後の引数を追加
+	if current.Len() > 0 {
+		args = append(args, current.String())
+	}
+
+	if inQuote {
+		return nil, fmt.Errorf("引用符が閉じられていません")
+	}
+
+	return args, nil
+}
@@ -0,0 +1,95 @@
+// ================================================================================
+// Package gitworktree - git worktree のパスと一覧
+// ================================================================================
+// このパッケージは、worktree-new・worktree-switch・tag-checkout --worktree で共通の
+// worktree の操作を提供します。
+//
+// 提供する機能:
+// - Path(): worktree のディレクトリのパスを決定（feature/xxx → ../repo-feature-xxx）
+// - MainRoot(): メインの作業ツリーのルートディレクトリを取得
+// - List(): git worktree list --porcelain の結果を取得
+// ================================================================================
+package gitworktree
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/tonbiattack/git-plus/internal/gitcmd"
+)
+
+// Worktree は git worktree list --porcelain の1件です。
+type Worktree struct {
+	Path     string // worktree のパス
+	Head     string // HEAD のコミットハッシュ
+	Branch   string // ブランチ名（refs/heads/ を除いたもの。detached HEAD の場合は空）
+	Detached bool   // detached HEAD の場合 true
+	Bare     bool   // bare リポジトリの場合 true
+}
+
+// Path は worktree のディレクトリのパスを返します。
+//
+// パラメータ:
+//   - repoRoot: リポジトリのルートディレクトリ
+//   - name: ブランチ名やタグ名（/ は - に置き換えます）
+//
+// 戻り値:
+//   - string: リポジトリと同じ階層の <リポジトリ名>-<名前>（例: ../repo-feature-xxx）
+func Path(repoRoot, name string) string {
+	dirName := fmt.Sprintf("%s-%s", filepath.Base(repoRoot), strings.ReplaceAll(name, "/", "-"))
+	return filepath.Join(filepath.Dir(repoRoot), dirName)
+}
+
+// MainRoot はメインの作業ツリーのルートディレクトリを返します。
+// 追加の worktree の中で実行した場合も、メインの作業ツリーを返します。
+func MainRoot() (string, error) {
+	worktrees, err := List()
+	if err != nil {
+		return "", err
+	}
+	if len(worktrees) == 0 {
+		return "", fmt.Errorf("worktree が見つかりません")
+	}
+	return worktrees[0].Path, nil
+}
+
+// List は全ての worktree を返します（最初の要素はメインの作業ツリー）。
+func List() ([]Worktree, error) {
+	output, err := gitcmd.Run("worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	return parseList(string(output)), nil
+}
+
+// parseList は git worktree list --porcelain の出力を解析します
+func parseList(output string) []Worktree {
+	worktrees := make([]Worktree, 0)
+
+	var current Worktree
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			if current.Path != "" {
+				worktrees = append(worktrees, current)
+				current = Worktree{}
+			}
+		case strings.HasPrefix(line, "worktree "):
+			current.Path = strings.TrimPrefix(line, "worktree ")
+		case strings.HasPrefix(line, "HEAD "):
+			current.Head = strings.TrimPrefix(line, "HEAD ")
+		case strings.HasPrefix(line, "branch "):
+			current.Branch = strings.TrimPrefix(strings.TrimPrefix(line, "branch "), "refs/heads/")
+		case line == "detached":
+			current.Detached = true
+		case line == "bare":
+			current.Bare = true
+		}
+	}
+	if current.Path != "" {
+		worktrees = append(worktrees, current)
+	}
+	return worktrees
+}
@@ -0,0 +1,80 @@
+package gitworktree
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tonbiattack/git-plus/internal/testutil"
+)
+
+// TestPath は worktree のパスの決定をテストします
+func TestPath(t *testing.T) {
+	root := filepath.Join("/work", "repo")
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"feature/xxx", filepath.Join("/work", "repo-feature-xxx")},
+		{"v1.2.3", filepath.Join("/work", "repo-v1.2.3")},
+		{"api/v1.2.0", filepath.Join("/work", "repo-api-v1.2.0")},
+	}
+	for _, tt := range tests {
+		if got := Path(root, tt.name); got != tt.want {
+			t.Errorf("Path(%q, %q) = %q, want %q", root, tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestParseList は git worktree list --porcelain の解析をテストします
+func TestParseList(t *testing.T) {
+	output := `worktree /work/repo
+HEAD 1111111111111111111111111111111111111111
+branch refs/heads/feature/x
+
+worktree /work/repo-v1.2.3
+HEAD 2222222222222222222222222222222222222222
+detached
+
+`
+	got := parseList(output)
+	if len(got) != 2 {
+		t.Fatalf("parseList() = %+v", got)
+	}
+	if got[0].Path != "/work/repo" || got[0].Branch != "feature/x" || got[0].Detached {
+		t.Errorf("main worktree = %+v", got[0])
+	}
+	if got[1].Path != "/work/repo-v1.2.3" || got[1].Branch != "" || !got[1].Detached || !strings.HasPrefix(got[1].Head, "2222") {
+		t.Errorf("tag worktree = %+v", got[1])
+	}
+
+	if got := parseList(""); got == nil || len(got) != 0 {
+		t.Errorf("parseList(\"\") = %#v, want empty slice", got)
+	}
+}
+
+// TestMainRoot は追加の worktree の中からメインの作業ツリーを取得できることをテストします
+func TestMainRoot(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("README.md", "# Test")
+	repo.Commit("Initial commit")
+
+	linked := filepath.Join(t.TempDir(), "linked")
+	repo.MustGit("worktree", "add", "--detach", linked)
+
+	oldDir, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(oldDir) })
+	if err := os.Chdir(linked); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	root, err := MainRoot()
+	if err != nil {
+		t.Fatalf("MainRoot() error = %v", err)
+	}
+	want, _ := filepath.EvalSymlinks(repo.Dir)
+	if got, _ := filepath.EvalSymlinks(root); got != want {
+		t.Errorf("MainRoot() = %q, want %q", root, repo.Dir)
+	}
+}
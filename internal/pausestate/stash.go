@@ -0,0 +1,211 @@
+// ================================================================================
+// stash.go - pause で作成したスタッシュの保持と復元
+// ================================================================================
+// stash@{N} は他のスタッシュ操作（git stash / git stash drop）で指す先が変わるため、
+// pause で作成したスタッシュはコミットハッシュで識別します。
+//
+// さらに、スタッシュのコミットを refs/git-plus/pause/<hash> にも保存します。
+// これにより、スタッシュ一覧から drop されてもコミットが git gc で消えず、
+// resume で復元できます。
+// ================================================================================
+package pausestate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tonbiattack/git-plus/internal/gitcmd"
+)
+
+// StashRefPrefix は、pause で作成したスタッシュを保持する参照の名前空間です。
+const StashRefPrefix = "refs/git-plus/pause/"
+
+// ErrStashNotFound は、pause で保存したスタッシュが見つからない場合のエラーです。
+var ErrStashNotFound = errors.New("pause で保存したスタッシュが見つかりません")
+
+// ErrNothingToStash は、スタッシュに保存する変更がない場合のエラーです。
+// 未追跡のファイルはスタッシュに保存しないため、未追跡のファイルしかない場合もこのエラーになります。
+var ErrNothingToStash = errors.New("スタッシュに保存する変更がありません")
+
+// CreateStash は、追跡中のファイルの変更をスタッシュに保存し、そのコミットハッシュを返します。
+//
+// git stash push の後に stash@{0} を読むと、保存する変更がなかった場合や、直後に別の
+// スタッシュが作成された場合に無関係なスタッシュを指してしまいます。そのため、
+// git stash create で作成したコミットのハッシュをそのまま使用します。
+//
+// パラメータ:
+// - message: スタッシュのメッセージ（一覧には "On <ブランチ>: <メッセージ>" と表示されます）
+//
+// 戻り値:
+// - string: 作成したスタッシュのコミットハッシュ
+// - error: 保存する変更がない場合は ErrNothingToStash
+//
+// 内部処理:
+//  1. git stash create でスタッシュのコミットを作成（作業ツリーは変更しない）
+//  2. git stash store でスタッシュ一覧に追加
+//  3. git reset --hard で作業ツリーとインデックスを HEAD に戻す（git stash push と同じ）
+//
+// 未追跡のファイルは git stash push と同様に保存せず、作業ツリーに残します。
+func CreateStash(message string) (string, error) {
+	output, err := gitcmd.Run("stash", "create", message)
+	if err != nil {
+		return "", fmt.Errorf("スタッシュの作成に失敗: %w", err)
+	}
+	commit := strings.TrimSpace(string(output))
+	if commit == "" {
+		return "", ErrNothingToStash
+	}
+
+	// git stash create はコミットの件名を "On <ブランチ>: <メッセージ>" にするため、一覧にも同じ件名で登録する
+	subject, err := gitcmd.Run("log", "-1", "--format=%s", commit)
+	if err != nil {
+		return "", fmt.Errorf("スタッシュのメッセージの取得に失敗: %w", err)
+	}
+	if err := gitcmd.RunQuiet("stash", "store", "-m", strings.TrimSpace(string(subject)), commit); err != nil {
+		return "", fmt.Errorf("スタッシュの保存に失敗: %w", err)
+	}
+
+	if err := gitcmd.RunQuiet("reset", "--hard", "--quiet", "--no-recurse-submodules"); err != nil {
+		return "", fmt.Errorf("作業ツリーを戻せませんでした（変更はスタッシュ %s に保存されています）: %w", commit, err)
+	}
+	return commit, nil
+}
+
+// KeepStash は、スタッシュのコミットを refs/git-plus/pause/<hash> に保存します。
+//
+// パラメータ:
+// - commit: スタッシュのコミットハッシュ
+//
+// 戻り値:
+// - string: 作成した参照名
+// - error: 参照の作成に失敗した場合
+func KeepStash(commit string) (string, error) {
+	ref := StashRefPrefix + commit
+	if err := gitcmd.RunQuiet("update-ref", "-m", "git-plus pause", ref, commit); err != nil {
+		return "", fmt.Errorf("スタッシュの参照の作成に失敗: %w", err)
+	}
+	return ref, nil
+}
+
+// ReleaseStash は、KeepStash で作成した参照を削除します。
+// 参照が存在しない場合もエラーを返しません。
+func ReleaseStash(commit string) error {
+	if commit == "" {
+		return nil
+	}
+	ref := StashRefPrefix + commit
+	if gitcmd.RunQuiet("rev-parse", "--verify", "--quiet", ref) != nil {
+		return nil
+	}
+	return gitcmd.RunQuiet("update-ref", "-d", ref)
+}
+
+// FindStash は、pause 状態に記録されたスタッシュの場所を探します。
+//
+// 戻り値:
+//   - entry: スタッシュ一覧に残っている場合はその参照（例: stash@{2}）、
+//     一覧から削除されているがコミットが残っている場合は空文字列
+//   - commit: スタッシュのコミットハッシュ
+//   - error: どちらにも見つからない場合は ErrStashNotFound
+//
+// 内部処理:
+//  1. StashCommit と一致するコミットをスタッシュ一覧から探す
+//  2. 一覧にない場合は refs/git-plus/pause/<hash> またはオブジェクト自体が残っているか確認
+//  3. 以前のバージョンの状態（StashCommit なし）の場合は、StashRef がコミットハッシュであれば
+//     それを使用し、そうでなければスタッシュメッセージが一致するものを探す
+func FindStash(state *PauseState) (entry string, commit string, err error) {
+	entries, err := listStashes()
+	if err != nil {
+		return "", "", err
+	}
+
+	commit = state.StashCommit
+	if commit == "" && isCommitHash(state.StashRef) {
+		commit = state.StashRef
+	}
+
+	if commit == "" {
+		// 以前のバージョンの状態: stash@{N} は信頼できないため、メッセージで探す
+		for _, e := range entries {
+			if state.StashMessage != "" && strings.HasSuffix(e.subject, ": "+state.StashMessage) {
+				return e.ref, e.commit, nil
+			}
+		}
+		return "", "", ErrStashNotFound
+	}
+
+	for _, e := range entries {
+		if e.commit == commit {
+			return e.ref, commit, nil
+		}
+	}
+
+	// スタッシュ一覧から削除されていても、コミットが残っていれば復元できる
+	if gitcmd.RunQuiet("cat-file", "-e", commit+"^{commit}") == nil {
+		return "", commit, nil
+	}
+	return "", commit, ErrStashNotFound
+}
+
+// RestoreStash は、pause 状態に記録されたスタッシュを作業ツリーに適用します。
+//
+// スタッシュ一覧に残っている場合は git stash pop stash@{N} で適用して一覧から削除し、
+// 一覧にない場合は git stash apply <hash> でコミットから適用します。
+// 適用に成功したら refs/git-plus/pause/<hash> を削除します。
+// 競合などで適用に失敗した場合、スタッシュと参照は残ります。
+func RestoreStash(state *PauseState) error {
+	entry, commit, err := FindStash(state)
+	if err != nil {
+		return err
+	}
+
+	if entry != "" {
+		err = gitcmd.RunQuiet("stash", "pop", entry)
+	} else {
+		err = gitcmd.RunQuiet("stash", "apply", commit)
+	}
+	if err != nil {
+		return fmt.Errorf("スタッシュの適用に失敗: %w", err)
+	}
+
+	return ReleaseStash(commit)
+}
+
+// stashEntry は、スタッシュ一覧の1件を表します。
+type stashEntry struct {
+	ref     string // stash@{N}
+	commit  string // コミットハッシュ
+	subject string // スタッシュのメッセージ（例: On main: git-pause: from main）
+}
+
+// listStashes は、スタッシュ一覧を取得します。
+func listStashes() ([]stashEntry, error) {
+	output, err := gitcmd.Run("stash", "list", "--format=%gd%x00%H%x00%gs")
+	if err != nil {
+		return nil, fmt.Errorf("スタッシュ一覧の取得に失敗: %w", err)
+	}
+
+	var entries []stashEntry
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.SplitN(line, "\x00", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		entries = append(entries, stashEntry{ref: fields[0], commit: fields[1], subject: fields[2]})
+	}
+	return entries, nil
+}
+
+// isCommitHash は、文字列が完全なコミットハッシュ（SHA-1 または SHA-256）かどうかを判定します。
+func isCommitHash(s string) bool {
+	if len(s) != 40 && len(s) != 64 {
+		return false
+	}
+	for _, c := range s {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,188 @@
+package pausestate
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/tonbiattack/git-plus/internal/testutil"
+)
+
+// setupStashRepo はテスト用リポジトリを作成して移動し、pause 用のスタッシュを作成する
+func setupStashRepo(t *testing.T) (*testutil.GitRepo, *PauseState) {
+	t.Helper()
+
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("a.txt", "original")
+	repo.Commit("Initial commit")
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(oldDir) })
+	if err := os.Chdir(repo.Dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	repo.CreateFile("a.txt", "paused change")
+	repo.StashPush("git-pause: from master")
+	commit := strings.TrimSpace(repo.MustGit("rev-parse", "stash@{0}"))
+	if _, err := KeepStash(commit); err != nil {
+		t.Fatalf("KeepStash() failed: %v", err)
+	}
+
+	return repo, &PauseState{StashCommit: commit, StashMessage: "git-pause: from master"}
+}
+
+func TestRestoreStash_FindsBySHA(t *testing.T) {
+	repo, state := setupStashRepo(t)
+
+	// pause の後に別のスタッシュを作成すると stash@{0} は別物になる
+	repo.CreateFile("b.txt", "other change")
+	repo.MustGit("stash", "push", "-u", "-m", "other work")
+
+	entry, _, err := FindStash(state)
+	if err != nil {
+		t.Fatalf("FindStash() failed: %v", err)
+	}
+	if entry != "stash@{1}" {
+		t.Errorf("FindStash() entry = %q, want stash@{1}", entry)
+	}
+
+	if err := RestoreStash(state); err != nil {
+		t.Fatalf("RestoreStash() failed: %v", err)
+	}
+	if got := repo.ReadFile("a.txt"); got != "paused change" {
+		t.Errorf("a.txt = %q, want paused change", got)
+	}
+
+	// 別のスタッシュは残り、pause 用の参照は削除される
+	list := repo.MustGit("stash", "list")
+	if !strings.Contains(list, "other work") || strings.Contains(list, "git-pause") {
+		t.Errorf("Unexpected stash list: %q", list)
+	}
+	if _, err := repo.Git("rev-parse", "--verify", StashRefPrefix+state.StashCommit); err == nil {
+		t.Error("Pause ref should be removed after restore")
+	}
+}
+
+func TestRestoreStash_DroppedFromList(t *testing.T) {
+	repo, state := setupStashRepo(t)
+
+	// スタッシュ一覧から削除されても参照から復元できる
+	repo.MustGit("stash", "drop")
+
+	entry, _, err := FindStash(state)
+	if err != nil {
+		t.Fatalf("FindStash() failed: %v", err)
+	}
+	if entry != "" {
+		t.Errorf("FindStash() entry = %q, want empty", entry)
+	}
+
+	if err := RestoreStash(state); err != nil {
+		t.Fatalf("RestoreStash() failed: %v", err)
+	}
+	if got := repo.ReadFile("a.txt"); got != "paused change" {
+		t.Errorf("a.txt = %q, want paused change", got)
+	}
+}
+
+func TestFindStash_NotFound(t *testing.T) {
+	_, _ = setupStashRepo(t)
+
+	state := &PauseState{StashCommit: strings.Repeat("0", 40), StashMessage: "git-pause: from gone"}
+	if _, _, err := FindStash(state); !errors.Is(err, ErrStashNotFound) {
+		t.Errorf("FindStash() error = %v, want ErrStashNotFound", err)
+	}
+	if err := RestoreStash(state); !errors.Is(err, ErrStashNotFound) {
+		t.Errorf("RestoreStash() error = %v, want ErrStashNotFound", err)
+	}
+}
+
+func TestFindStash_LegacyState(t *testing.T) {
+	repo, state := setupStashRepo(t)
+	repo.CreateFile("b.txt", "other change")
+	repo.MustGit("stash", "push", "-u", "-m", "other work")
+
+	// 以前のバージョンの状態（stash@{0} を記録）はメッセージで探す
+	legacy := &PauseState{StashRef: "stash@{0}", StashMessage: state.StashMessage}
+	entry, commit, err := FindStash(legacy)
+	if err != nil {
+		t.Fatalf("FindStash() failed: %v", err)
+	}
+	if entry != "stash@{1}" || commit != state.StashCommit {
+		t.Errorf("FindStash() = %q, %q; want stash@{1}, %s", entry, commit, state.StashCommit)
+	}
+}
+
+func TestCreateStash_WithExistingStash(t *testing.T) {
+	repo, existing := setupStashRepo(t)
+
+	repo.CreateFile("a.txt", "new change")
+	repo.CreateFile("untracked.txt", "untracked")
+	commit, err := CreateStash("git-pause: from master")
+	if err != nil {
+		t.Fatalf("CreateStash() failed: %v", err)
+	}
+	if commit == existing.StashCommit {
+		t.Fatal("CreateStash() returned the existing stash")
+	}
+
+	// 作成したスタッシュが stash@{0} に登録され、件名は git stash push と同じ形式
+	top := strings.Fields(repo.MustGit("stash", "list", "-1", "--format=%H %gs"))
+	if len(top) == 0 || top[0] != commit {
+		t.Errorf("stash@{0} = %v, want %s", top, commit)
+	}
+	if subject := strings.TrimSpace(repo.MustGit("stash", "list", "-1", "--format=%gs")); subject != "On master: git-pause: from master" {
+		t.Errorf("stash subject = %q", subject)
+	}
+
+	// 追跡中のファイルは戻り、未追跡のファイルは残る
+	if got := repo.ReadFile("a.txt"); got != "original" {
+		t.Errorf("a.txt = %q, want original", got)
+	}
+	if !repo.FileExists("untracked.txt") {
+		t.Error("untracked file should be kept in the working tree")
+	}
+}
+
+func TestCreateStash_UntrackedOnly(t *testing.T) {
+	repo, existing := setupStashRepo(t)
+
+	// 未追跡のファイルしかない場合は、既存のスタッシュを返さずにエラーにする
+	repo.CreateFile("untracked.txt", "untracked")
+	commit, err := CreateStash("git-pause: from master")
+	if !errors.Is(err, ErrNothingToStash) {
+		t.Fatalf("CreateStash() = %q, %v; want ErrNothingToStash", commit, err)
+	}
+	if top := strings.TrimSpace(repo.MustGit("rev-parse", "stash@{0}")); top != existing.StashCommit {
+		t.Errorf("stash@{0} = %s, want the existing stash %s", top, existing.StashCommit)
+	}
+	if count := len(strings.Split(strings.TrimSpace(repo.MustGit("stash", "list")), "\n")); count != 1 {
+		t.Errorf("stash count = %d, want 1", count)
+	}
+	if !repo.FileExists("untracked.txt") {
+		t.Error("untracked file should be kept in the working tree")
+	}
+}
+
+func TestIsCommitHash(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{strings.Repeat("a", 40), true},
+		{strings.Repeat("1", 64), true},
+		{"stash@{0}", false},
+		{strings.Repeat("g", 40), false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := isCommitHash(tt.input); got != tt.expected {
+			t.Errorf("isCommitHash(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+	}
+}
@@ -7,19 +7,27 @@
 // git pauseコマンドで作業を一時保存した際の状態を、
 // ~/.git-plus/pause-state.json に保存・読み込み・削除します。
 //
-// 保存される情報:
+// 状態はリポジトリ（共通の .git ディレクトリ）とワークツリーの組み合わせごとに
+// スタックとして保持されます。これにより、
+// - リポジトリAで pause した後にリポジトリBで pause しても、Aの状態は上書きされません
+// - 同じリポジトリで pause を重ねた場合、resume は後から保存したものから順に復元します（LIFO）
+//
+// 保存される情報（1回の pause ごと）:
 // - FromBranch: pause前のブランチ名
 // - ToBranch: pauseで切り替えた先のブランチ名
-// - StashRef: スタッシュの参照名
+// - StashCommit: スタッシュのコミットハッシュ（refs/git-plus/pause/<hash> でも保持）
 // - StashMessage: スタッシュメッセージ
+// - Command: 状態を保存したコマンド（pause, pr-checkout）
 // - Timestamp: pause実行日時
 //
 // ユースケース:
-// 1. git pause: 現在の作業をスタッシュして別ブランチに切り替え → 状態を保存
-// 2. git resume: 保存された状態を読み込んで元のブランチに戻り、スタッシュを適用
+// 1. git pause: 現在の作業をスタッシュして別ブランチに切り替え → 状態をスタックに積む
+// 2. git resume: スタックの先頭の状態で元のブランチに戻り、スタッシュを適用 → スタックから取り除く
+// 3. git pause --list: すべてのリポジトリの pause 状態を一覧表示
 //
 // ファイル構造:
 // ~/.git-plus/pause-state.json (JSON形式で状態を保存)
+// 以前のバージョンが保存した単一の状態（リポジトリ情報なし）は Legacy として読み込まれます。
 // ================================================================================
 package pausestate
 
@@ -28,23 +36,57 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
+
+	"github.com/tonbiattack/git-plus/internal/gitcmd"
 )
 
 // PauseState は、git pauseコマンドで保存される状態を保持する構造体です。
 //
 // フィールド:
-// - FromBranch: pause実行前のブランチ名（復元時にこのブランチに戻る）
-// - ToBranch: pause実行後に切り替えたブランチ名
-// - StashRef: 作成されたスタッシュの参照名（例: stash@{0}）
-// - StashMessage: スタッシュに付けられたメッセージ
-// - Timestamp: pause実行日時（いつpauseしたかを記録）
+//   - FromBranch: pause実行前のブランチ名（復元時にこのブランチに戻る）
+//   - ToBranch: pause実行後に切り替えたブランチ名
+//   - StashCommit: 作成されたスタッシュのコミットハッシュ
+//     stash@{N} は他のスタッシュ操作で指す先が変わるため、コミットハッシュで識別します
+//   - StashRef: 以前のバージョンが保存したスタッシュの参照（読み込み専用、互換性のため）
+//   - StashMessage: スタッシュに付けられたメッセージ
+//   - Command: 状態を保存したコマンド名（例: pause, pr-checkout）
+//   - Timestamp: pause実行日時（いつpauseしたかを記録）
 type PauseState struct {
-	FromBranch   string    `json:"from_branch"`    // pause前のブランチ名
-	ToBranch     string    `json:"to_branch"`      // pause後のブランチ名
-	StashRef     string    `json:"stash_ref"`      // スタッシュ参照名
-	StashMessage string    `json:"stash_message"`  // スタッシュメッセージ
-	Timestamp    time.Time `json:"timestamp"`      // pause実行日時
+	FromBranch   string    `json:"from_branch"`            // pause前のブランチ名
+	ToBranch     string    `json:"to_branch"`              // pause後のブランチ名
+	StashCommit  string    `json:"stash_commit,omitempty"` // スタッシュのコミットハッシュ
+	StashRef     string    `json:"stash_ref,omitempty"`    // 以前のバージョンのスタッシュ参照
+	StashMessage string    `json:"stash_message"`          // スタッシュメッセージ
+	Command      string    `json:"command,omitempty"`      // 状態を保存したコマンド名
+	Timestamp    time.Time `json:"timestamp"`              // pause実行日時
+}
+
+// Context は、pause状態を保持する単位（リポジトリとワークツリーの組み合わせ）です。
+//
+// フィールド:
+// - Repository: 共通の .git ディレクトリの絶対パス（git rev-parse --git-common-dir）
+// - Worktree: ワークツリーのルートの絶対パス（git rev-parse --show-toplevel）
+//
+// 同じリポジトリでも、ワークツリーが異なれば別の Context として扱われます。
+type Context struct {
+	Repository string `json:"repository"` // 共通の .git ディレクトリ
+	Worktree   string `json:"worktree"`   // ワークツリーのルート
+}
+
+// ContextStack は、1つの Context に積まれた pause 状態のスタックです。
+// Stack の末尾が最後に保存された状態（resume で最初に復元される状態）です。
+type ContextStack struct {
+	Context
+	Stack []PauseState `json:"stack"`
+}
+
+// store は、状態ファイル全体の構造を表します。
+type store struct {
+	Contexts []ContextStack `json:"contexts"`
+	Legacy   *PauseState    `json:"legacy,omitempty"` // 以前のバージョンが保存したリポジトリ情報のない状態
 }
 
 // getStateFilePath は、pause状態を保存するJSONファイルのパスを取得します。
@@ -80,76 +122,214 @@ func getStateFilePath() (string, error) {
 	return filepath.Join(gitPlusDir, "pause-state.json"), nil
 }
 
-// Save は、pause状態をJSONファイルに保存します。
+// CurrentContext は、カレントディレクトリのリポジトリとワークツリーを取得します。
+//
+// 戻り値:
+// - Context: 現在のリポジトリとワークツリー
+// - error: Gitリポジトリ外で実行された場合など
+//
+// 内部処理:
+// git rev-parse --path-format=absolute --git-common-dir --show-toplevel を実行します。
+// シンボリックリンク経由でも同じキーになるよう、パスは実体のパスに解決します。
+func CurrentContext() (Context, error) {
+	output, err := gitcmd.Run("rev-parse", "--path-format=absolute", "--git-common-dir", "--show-toplevel")
+	if err != nil {
+		return Context{}, fmt.Errorf("リポジトリ情報の取得に失敗: %w", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	if len(lines) < 2 {
+		return Context{}, fmt.Errorf("ワークツリーの外では使用できません")
+	}
+
+	return Context{
+		Repository: resolvePath(lines[0]),
+		Worktree:   resolvePath(lines[1]),
+	}, nil
+}
+
+// resolvePath は、パスをシンボリックリンクを解決した絶対パスに正規化します。
+// 解決できない場合は filepath.Clean したパスを返します。
+func resolvePath(path string) string {
+	path = filepath.Clean(strings.TrimSpace(path))
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return path
+}
+
+// Push は、pause状態を指定した Context のスタックに積みます。
 //
-// git pauseコマンド実行時に呼び出され、現在の作業状態を保存します。
-// JSONファイルはインデント付きで保存されるため、人間が読みやすい形式になります。
+// git pause / git pr-checkout 実行時に呼び出されます。
+// 既に同じ Context に状態がある場合も上書きせず、スタックの先頭に追加します。
 //
 // パラメータ:
+// - ctx: 状態を保存する Context
 // - state: 保存するPauseState構造体のポインタ
 //
 // 戻り値:
-// - error: ファイルパス取得失敗、JSONエンコード失敗、ファイル書き込み失敗
-//
-// ファイルパーミッション:
-// 保存されるJSONファイルは 0644 (rw-r--r--) で作成されます。
+// - error: ファイルの読み込み・書き込みに失敗した場合
 //
 // 使用例:
 //
-//	state := &pausestate.PauseState{
-//	    FromBranch: "feature-xxx",
-//	    ToBranch: "main",
-//	    StashRef: "stash@{0}",
-//	    StashMessage: "WIP on feature-xxx",
-//	    Timestamp: time.Now(),
+//	ctx, err := pausestate.CurrentContext()
+//	if err != nil {
+//	    return err
 //	}
-//	err := pausestate.Save(state)
-func Save(state *PauseState) error {
-	// 状態ファイルのパスを取得
-	filePath, err := getStateFilePath()
+//	err = pausestate.Push(ctx, &pausestate.PauseState{
+//	    FromBranch: "feature-xxx",
+//	    ToBranch:   "main",
+//	    Command:    "pause",
+//	    Timestamp:  time.Now(),
+//	})
+func Push(ctx Context, state *PauseState) error {
+	s, err := load()
 	if err != nil {
 		return err
 	}
 
-	// PauseState構造体をインデント付きJSONに変換
-	// インデントは2スペース
-	data, err := json.MarshalIndent(state, "", "  ")
-	if err != nil {
-		return fmt.Errorf("JSON のエンコードに失敗: %w", err)
+	idx := s.find(ctx)
+	if idx < 0 {
+		s.Contexts = append(s.Contexts, ContextStack{Context: ctx})
+		idx = len(s.Contexts) - 1
 	}
+	s.Contexts[idx].Stack = append(s.Contexts[idx].Stack, *state)
 
-	// JSONデータをファイルに書き込み
-	// パーミッション: 0644 (所有者: 読み書き, グループ: 読み取り, その他: 読み取り)
-	if err := os.WriteFile(filePath, data, 0644); err != nil {
-		return fmt.Errorf("ファイルの書き込みに失敗 %s: %w", filePath, err)
-	}
+	return save(s)
+}
 
-	return nil
+// Peek は、指定した Context のスタックの先頭（最後に保存された状態）を返します。
+//
+// 戻り値:
+// - *PauseState: スタックの先頭の状態（状態がない場合はnil）
+// - error: ファイルの読み込みに失敗した場合
+//
+// 注意:
+// 状態がない場合、エラーではなく (nil, nil) を返します。
+func Peek(ctx Context) (*PauseState, error) {
+	stack, err := Stack(ctx)
+	if err != nil || len(stack) == 0 {
+		return nil, err
+	}
+	state := stack[len(stack)-1]
+	return &state, nil
 }
 
-// Load は、保存されたpause状態をJSONファイルから読み込みます。
+// Pop は、指定した Context のスタックの先頭を取り除いて返します。
 //
-// git resumeコマンド実行時に呼び出され、保存された作業状態を復元します。
+// git resume が正常に完了した後に呼び出されます。
+// スタックが空になった Context は状態ファイルから削除されます。
 //
 // 戻り値:
-// - *PauseState: 読み込まれた状態（ファイルが存在しない場合はnil）
-// - error: ファイルパス取得失敗、ファイル読み込み失敗、JSONデコード失敗
+// - *PauseState: 取り除いた状態（状態がない場合はnil）
+// - error: ファイルの読み込み・書き込みに失敗した場合
+func Pop(ctx Context) (*PauseState, error) {
+	s, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	idx := s.find(ctx)
+	if idx < 0 || len(s.Contexts[idx].Stack) == 0 {
+		return nil, nil
+	}
+
+	stack := s.Contexts[idx].Stack
+	state := stack[len(stack)-1]
+	s.Contexts[idx].Stack = stack[:len(stack)-1]
+	if len(s.Contexts[idx].Stack) == 0 {
+		s.Contexts = append(s.Contexts[:idx], s.Contexts[idx+1:]...)
+	}
+
+	if err := save(s); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// Stack は、指定した Context に積まれている状態を古い順に返します。
+// 末尾の要素が次の resume で復元される状態です。
+func Stack(ctx Context) ([]PauseState, error) {
+	s, err := load()
+	if err != nil {
+		return nil, err
+	}
+	idx := s.find(ctx)
+	if idx < 0 {
+		return nil, nil
+	}
+	return s.Contexts[idx].Stack, nil
+}
+
+// List は、状態が保存されているすべての Context をリポジトリ・ワークツリーのパス順に返します。
+// git pause --list で使用されます。
+func List() ([]ContextStack, error) {
+	s, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	var contexts []ContextStack
+	for _, c := range s.Contexts {
+		if len(c.Stack) > 0 {
+			contexts = append(contexts, c)
+		}
+	}
+	sort.SliceStable(contexts, func(i, j int) bool {
+		if contexts[i].Repository != contexts[j].Repository {
+			return contexts[i].Repository < contexts[j].Repository
+		}
+		return contexts[i].Worktree < contexts[j].Worktree
+	})
+	return contexts, nil
+}
+
+// LoadLegacy は、以前のバージョンが保存したリポジトリ情報のない状態を返します。
 //
-// 注意:
-// ファイルが存在しない場合、エラーではなく (nil, nil) を返します。
-// これにより、呼び出し側でpauseされていない状態を判別できます。
+// 以前のバージョンは ~/.git-plus/pause-state.json に単一の状態のみを保存していたため、
+// どのリポジトリの状態かを判別できません。resume はこの状態を自動的には使用せず、
+// 呼び出し側で確認を取ってから使用します。
 //
-// 使用例:
+// 戻り値:
+// - *PauseState: 以前のバージョンの状態（存在しない場合はnil）
+// - error: ファイルの読み込みに失敗した場合
+func LoadLegacy() (*PauseState, error) {
+	s, err := load()
+	if err != nil {
+		return nil, err
+	}
+	return s.Legacy, nil
+}
+
+// DeleteLegacy は、以前のバージョンが保存した状態を削除します。
+// 状態が存在しない場合もエラーを返しません。
+func DeleteLegacy() error {
+	s, err := load()
+	if err != nil {
+		return err
+	}
+	if s.Legacy == nil {
+		return nil
+	}
+	s.Legacy = nil
+	return save(s)
+}
+
+// find は、指定した Context のインデックスを返します（存在しない場合は -1）。
+func (s *store) find(ctx Context) int {
+	for i, c := range s.Contexts {
+		if c.Context == ctx {
+			return i
+		}
+	}
+	return -1
+}
+
+// load は、状態ファイルを読み込みます。
 //
-//	state, err := pausestate.Load()
-//	if err != nil {
-//	    return err
-//	}
-//	if state == nil {
-//	    fmt.Println("保存されたpause状態がありません")
-//	    return nil
-//	}
-func Load() (*PauseState, error) {
+// ファイルが存在しない場合は空の store を返します。
+// 以前のバージョンの形式（PauseState を直接保存した形式）の場合は Legacy に格納します。
+func load() (*store, error) {
 	// 状態ファイルのパスを取得
 	filePath, err := getStateFilePath()
 	if err != nil {
@@ -159,94 +339,67 @@ func Load() (*PauseState, error) {
 	// JSONファイルを読み込み
 	data, err := os.ReadFile(filePath)
 	if err != nil {
-		// ファイルが存在しない場合は nil を返す（エラーではない）
+		// ファイルが存在しない場合は空の状態を返す（エラーではない）
 		if os.IsNotExist(err) {
-			return nil, nil
+			return &store{}, nil
 		}
 		return nil, fmt.Errorf("ファイルの読み込みに失敗 %s: %w", filePath, err)
 	}
 
-	// JSONデータをPauseState構造体にデコード
-	var state PauseState
-	if err := json.Unmarshal(data, &state); err != nil {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
 		return nil, fmt.Errorf("JSON のデコードに失敗: %w", err)
 	}
 
-	return &state, nil
+	// 以前のバージョンの形式: {"from_branch": ..., "to_branch": ..., ...}
+	if _, ok := fields["from_branch"]; ok {
+		var legacy PauseState
+		if err := json.Unmarshal(data, &legacy); err != nil {
+			return nil, fmt.Errorf("JSON のデコードに失敗: %w", err)
+		}
+		return &store{Legacy: &legacy}, nil
+	}
+
+	var s store
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("JSON のデコードに失敗: %w", err)
+	}
+	return &s, nil
 }
 
-// Delete は、保存されたpause状態ファイルを削除します。
-//
-// git resumeコマンドが正常に完了した後に呼び出され、
-// 使用済みの状態ファイルをクリーンアップします。
+// save は、状態ファイルを書き込みます。
+// 状態が1つもなくなった場合はファイル自体を削除します。
 //
-// 戻り値:
-// - error: ファイルパス取得失敗、ファイル削除失敗
-//
-// 注意:
-// ファイルが存在しない場合はエラーを返しません。
-// これにより、冪等性（何度実行しても同じ結果）が保証されます。
-//
-// 使用例:
-//
-//	if err := pausestate.Delete(); err != nil {
-//	    fmt.Println("警告: 状態ファイルの削除に失敗しました:", err)
-//	}
-func Delete() error {
+// ファイルパーミッション:
+// 保存されるJSONファイルは 0644 (rw-r--r--) で作成されます。
+func save(s *store) error {
 	// 状態ファイルのパスを取得
 	filePath, err := getStateFilePath()
 	if err != nil {
 		return err
 	}
 
-	// ファイルを削除
-	if err := os.Remove(filePath); err != nil {
-		// ファイルが存在しない場合はエラーを返さない
-		if !os.IsNotExist(err) {
+	if len(s.Contexts) == 0 && s.Legacy == nil {
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
 			return fmt.Errorf("ファイルの削除に失敗 %s: %w", filePath, err)
 		}
+		return nil
 	}
 
-	return nil
-}
-
-// Exists は、pause状態ファイルが存在するかどうかを確認します。
-//
-// git resumeコマンド実行前に呼び出され、
-// 復元可能な状態が保存されているかをチェックします。
-//
-// 戻り値:
-// - bool: true = ファイルが存在する, false = ファイルが存在しない
-// - error: ファイルパス取得失敗、Stat失敗（存在確認以外のエラー）
-//
-// 使用例:
-//
-//	exists, err := pausestate.Exists()
-//	if err != nil {
-//	    return err
-//	}
-//	if !exists {
-//	    fmt.Println("保存されたpause状態がありません")
-//	    return nil
-//	}
-func Exists() (bool, error) {
-	// 状態ファイルのパスを取得
-	filePath, err := getStateFilePath()
+	// インデント付きJSONに変換（インデントは2スペース）
+	data, err := json.MarshalIndent(s, "", "  ")
 	if err != nil {
-		return false, err
+		return fmt.Errorf("JSON のエンコードに失敗: %w", err)
 	}
 
-	// ファイルの存在を確認
-	_, err = os.Stat(filePath)
-	if err != nil {
-		// ファイルが存在しない場合は false を返す（エラーではない）
-		if os.IsNotExist(err) {
-			return false, nil
-		}
-		// その他のエラーの場合はエラーを返す
-		return false, err
+	// 一時ファイルに書き込んでからリネームし、書き込み途中の状態が残らないようにする
+	tmpPath := filePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("ファイルの書き込みに失敗 %s: %w", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		return fmt.Errorf("ファイルの書き込みに失敗 %s: %w", filePath, err)
 	}
 
-	// ファイルが存在する
-	return true, nil
+	return nil
 }
@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/tonbiattack/git-plus/internal/testutil"
 )
 
 // setupTestHome はテスト用のホームディレクトリを設定する
@@ -28,52 +30,69 @@ func setupTestHome(t *testing.T) (cleanup func()) {
 	}
 }
 
-func TestSaveAndLoad(t *testing.T) {
+// testContext はテスト用の Context を生成する
+func testContext(name string) Context {
+	return Context{
+		Repository: "/repos/" + name + "/.git",
+		Worktree:   "/repos/" + name,
+	}
+}
+
+// newState はテスト用の PauseState を生成する
+func newState(from, to string) *PauseState {
+	return &PauseState{
+		FromBranch:   from,
+		ToBranch:     to,
+		StashRef:     "stash@{0}",
+		StashMessage: "git-pause: from " + from,
+		Command:      "pause",
+		Timestamp:    time.Now(),
+	}
+}
+
+func TestPushAndPeek(t *testing.T) {
 	cleanup := setupTestHome(t)
 	defer cleanup()
 
-	// テスト用の状態を作成
+	ctx := testContext("a")
 	now := time.Now()
 	state := &PauseState{
 		FromBranch:   "feature/test-branch",
 		ToBranch:     "main",
 		StashRef:     "stash@{0}",
 		StashMessage: "WIP on feature/test-branch",
+		Command:      "pause",
 		Timestamp:    now,
 	}
 
-	// 状態を保存
-	err := Save(state)
-	if err != nil {
-		t.Fatalf("Save() failed: %v", err)
+	if err := Push(ctx, state); err != nil {
+		t.Fatalf("Push() failed: %v", err)
 	}
 
-	// 状態を読み込み
-	loaded, err := Load()
+	loaded, err := Peek(ctx)
 	if err != nil {
-		t.Fatalf("Load() failed: %v", err)
+		t.Fatalf("Peek() failed: %v", err)
 	}
-
 	if loaded == nil {
-		t.Fatal("Load() returned nil state")
+		t.Fatal("Peek() returned nil state")
 	}
 
 	// フィールドを検証
 	if loaded.FromBranch != state.FromBranch {
 		t.Errorf("FromBranch mismatch: got %q, want %q", loaded.FromBranch, state.FromBranch)
 	}
-
 	if loaded.ToBranch != state.ToBranch {
 		t.Errorf("ToBranch mismatch: got %q, want %q", loaded.ToBranch, state.ToBranch)
 	}
-
 	if loaded.StashRef != state.StashRef {
 		t.Errorf("StashRef mismatch: got %q, want %q", loaded.StashRef, state.StashRef)
 	}
-
 	if loaded.StashMessage != state.StashMessage {
 		t.Errorf("StashMessage mismatch: got %q, want %q", loaded.StashMessage, state.StashMessage)
 	}
+	if loaded.Command != state.Command {
+		t.Errorf("Command mismatch: got %q, want %q", loaded.Command, state.Command)
+	}
 
 	// タイムスタンプはJSON経由で精度が落ちる可能性があるため、秒単位で比較
 	if loaded.Timestamp.Unix() != state.Timestamp.Unix() {
@@ -81,136 +100,150 @@ func TestSaveAndLoad(t *testing.T) {
 	}
 }
 
-func TestLoad_NonexistentFile(t *testing.T) {
+func TestPeek_NonexistentFile(t *testing.T) {
 	cleanup := setupTestHome(t)
 	defer cleanup()
 
 	// ファイルが存在しない場合はnilを返す
-	state, err := Load()
+	state, err := Peek(testContext("a"))
 	if err != nil {
-		t.Errorf("Load() returned error for nonexistent file: %v", err)
+		t.Errorf("Peek() returned error for nonexistent file: %v", err)
 	}
-
 	if state != nil {
-		t.Errorf("Load() returned non-nil state for nonexistent file: %v", state)
+		t.Errorf("Peek() returned non-nil state for nonexistent file: %v", state)
 	}
 }
 
-func TestExists_FileExists(t *testing.T) {
+func TestPush_StacksInLIFOOrder(t *testing.T) {
 	cleanup := setupTestHome(t)
 	defer cleanup()
 
-	// 状態を保存
-	state := &PauseState{
-		FromBranch:   "test-branch",
-		ToBranch:     "main",
-		StashRef:     "stash@{0}",
-		StashMessage: "test",
-		Timestamp:    time.Now(),
+	ctx := testContext("a")
+	if err := Push(ctx, newState("first-branch", "main")); err != nil {
+		t.Fatalf("First Push() failed: %v", err)
 	}
-
-	if err := Save(state); err != nil {
-		t.Fatalf("Save() failed: %v", err)
+	if err := Push(ctx, newState("main", "develop")); err != nil {
+		t.Fatalf("Second Push() failed: %v", err)
 	}
 
-	// ファイルが存在することを確認
-	exists, err := Exists()
+	stack, err := Stack(ctx)
 	if err != nil {
-		t.Errorf("Exists() returned error: %v", err)
+		t.Fatalf("Stack() failed: %v", err)
 	}
-
-	if !exists {
-		t.Error("Exists() returned false, want true")
+	if len(stack) != 2 {
+		t.Fatalf("Stack length = %d, want 2", len(stack))
 	}
-}
 
-func TestExists_FileNotExists(t *testing.T) {
-	cleanup := setupTestHome(t)
-	defer cleanup()
+	// 後から積んだものが先に取り出される
+	popped, err := Pop(ctx)
+	if err != nil {
+		t.Fatalf("Pop() failed: %v", err)
+	}
+	if popped == nil || popped.FromBranch != "main" {
+		t.Errorf("First Pop() = %+v, want FromBranch main", popped)
+	}
 
-	// ファイルが存在しないことを確認
-	exists, err := Exists()
+	popped, err = Pop(ctx)
 	if err != nil {
-		t.Errorf("Exists() returned error: %v", err)
+		t.Fatalf("Pop() failed: %v", err)
+	}
+	if popped == nil || popped.FromBranch != "first-branch" {
+		t.Errorf("Second Pop() = %+v, want FromBranch first-branch", popped)
 	}
 
-	if exists {
-		t.Error("Exists() returned true, want false")
+	// 空のスタックからの Pop は nil を返す
+	popped, err = Pop(ctx)
+	if err != nil || popped != nil {
+		t.Errorf("Pop() on empty stack = %+v, %v; want nil, nil", popped, err)
 	}
 }
 
-func TestDelete_FileExists(t *testing.T) {
+func TestPush_IsolatedByContext(t *testing.T) {
 	cleanup := setupTestHome(t)
 	defer cleanup()
 
-	// 状態を保存
-	state := &PauseState{
-		FromBranch:   "test-branch",
-		ToBranch:     "main",
-		StashRef:     "stash@{0}",
-		StashMessage: "test",
-		Timestamp:    time.Now(),
-	}
+	repoA := testContext("a")
+	repoB := testContext("b")
+	worktreeA := Context{Repository: repoA.Repository, Worktree: "/repos/a-wt"}
 
-	if err := Save(state); err != nil {
-		t.Fatalf("Save() failed: %v", err)
+	if err := Push(repoA, newState("feature-a", "main")); err != nil {
+		t.Fatalf("Push() failed: %v", err)
+	}
+	if err := Push(repoB, newState("feature-b", "main")); err != nil {
+		t.Fatalf("Push() failed: %v", err)
 	}
 
-	// ファイルが存在することを確認
-	exists, _ := Exists()
-	if !exists {
-		t.Fatal("File should exist before deletion")
+	// リポジトリBで pause してもリポジトリAの状態は上書きされない
+	stateA, err := Peek(repoA)
+	if err != nil || stateA == nil || stateA.FromBranch != "feature-a" {
+		t.Errorf("Peek(repoA) = %+v, %v; want feature-a", stateA, err)
 	}
 
-	// ファイルを削除
-	if err := Delete(); err != nil {
-		t.Errorf("Delete() returned error: %v", err)
+	// 同じリポジトリでもワークツリーが異なれば別の状態として扱う
+	stateWT, err := Peek(worktreeA)
+	if err != nil || stateWT != nil {
+		t.Errorf("Peek(worktreeA) = %+v, %v; want nil", stateWT, err)
 	}
 
-	// ファイルが削除されたことを確認
-	exists, _ = Exists()
-	if exists {
-		t.Error("File should not exist after deletion")
+	if _, err := Pop(repoB); err != nil {
+		t.Fatalf("Pop() failed: %v", err)
+	}
+	stateA, err = Peek(repoA)
+	if err != nil || stateA == nil {
+		t.Errorf("Pop(repoB) should not affect repoA: %+v, %v", stateA, err)
 	}
 }
 
-func TestDelete_FileNotExists(t *testing.T) {
+func TestList(t *testing.T) {
 	cleanup := setupTestHome(t)
 	defer cleanup()
 
-	// 存在しないファイルを削除してもエラーにならない
-	err := Delete()
+	if err := Push(testContext("b"), newState("feature-b", "main")); err != nil {
+		t.Fatalf("Push() failed: %v", err)
+	}
+	if err := Push(testContext("a"), newState("feature-a", "main")); err != nil {
+		t.Fatalf("Push() failed: %v", err)
+	}
+	if err := Push(testContext("a"), newState("main", "develop")); err != nil {
+		t.Fatalf("Push() failed: %v", err)
+	}
+
+	contexts, err := List()
 	if err != nil {
-		t.Errorf("Delete() returned error for nonexistent file: %v", err)
+		t.Fatalf("List() failed: %v", err)
+	}
+	if len(contexts) != 2 {
+		t.Fatalf("List() returned %d contexts, want 2", len(contexts))
+	}
+
+	// リポジトリのパス順に並ぶ
+	if contexts[0].Worktree != "/repos/a" || len(contexts[0].Stack) != 2 {
+		t.Errorf("contexts[0] = %+v", contexts[0])
+	}
+	if contexts[1].Worktree != "/repos/b" || len(contexts[1].Stack) != 1 {
+		t.Errorf("contexts[1] = %+v", contexts[1])
 	}
 }
 
-func TestDelete_Idempotent(t *testing.T) {
+func TestPop_RemovesFileWhenEmpty(t *testing.T) {
 	cleanup := setupTestHome(t)
 	defer cleanup()
 
-	// 状態を保存
-	state := &PauseState{
-		FromBranch:   "test-branch",
-		ToBranch:     "main",
-		StashRef:     "stash@{0}",
-		StashMessage: "test",
-		Timestamp:    time.Now(),
+	ctx := testContext("a")
+	if err := Push(ctx, newState("feature", "main")); err != nil {
+		t.Fatalf("Push() failed: %v", err)
 	}
-
-	if err := Save(state); err != nil {
-		t.Fatalf("Save() failed: %v", err)
+	if _, err := Pop(ctx); err != nil {
+		t.Fatalf("Pop() failed: %v", err)
 	}
 
-	// 複数回削除してもエラーにならない（冪等性）
-	for i := 0; i < 3; i++ {
-		if err := Delete(); err != nil {
-			t.Errorf("Delete() iteration %d returned error: %v", i, err)
-		}
+	filePath := filepath.Join(os.Getenv("HOME"), ".git-plus", "pause-state.json")
+	if _, err := os.Stat(filePath); !os.IsNotExist(err) {
+		t.Error("State file should be removed when no state remains")
 	}
 }
 
-func TestSave_CreatesDirectory(t *testing.T) {
+func TestPush_CreatesDirectory(t *testing.T) {
 	cleanup := setupTestHome(t)
 	defer cleanup()
 
@@ -223,16 +256,8 @@ func TestSave_CreatesDirectory(t *testing.T) {
 	}
 
 	// 状態を保存（ディレクトリが自動作成される）
-	state := &PauseState{
-		FromBranch:   "test-branch",
-		ToBranch:     "main",
-		StashRef:     "stash@{0}",
-		StashMessage: "test",
-		Timestamp:    time.Now(),
-	}
-
-	if err := Save(state); err != nil {
-		t.Fatalf("Save() failed: %v", err)
+	if err := Push(testContext("a"), newState("test-branch", "main")); err != nil {
+		t.Fatalf("Push() failed: %v", err)
 	}
 
 	// ディレクトリが作成されたことを確認
@@ -241,44 +266,49 @@ func TestSave_CreatesDirectory(t *testing.T) {
 	}
 }
 
-func TestSave_OverwritesExisting(t *testing.T) {
+func TestLegacyFormat(t *testing.T) {
 	cleanup := setupTestHome(t)
 	defer cleanup()
 
-	// 最初の状態を保存
-	state1 := &PauseState{
-		FromBranch:   "first-branch",
-		ToBranch:     "main",
-		StashRef:     "stash@{0}",
-		StashMessage: "first",
-		Timestamp:    time.Now(),
+	// 以前のバージョンの形式で状態ファイルを作成
+	gitPlusDir := filepath.Join(os.Getenv("HOME"), ".git-plus")
+	if err := os.MkdirAll(gitPlusDir, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
 	}
-
-	if err := Save(state1); err != nil {
-		t.Fatalf("First Save() failed: %v", err)
+	legacyJSON := `{"from_branch":"old-branch","to_branch":"main","stash_ref":"","stash_message":"git-pause: from old-branch","timestamp":"2024-01-15T10:30:00Z"}`
+	if err := os.WriteFile(filepath.Join(gitPlusDir, "pause-state.json"), []byte(legacyJSON), 0644); err != nil {
+		t.Fatalf("Failed to write legacy file: %v", err)
 	}
 
-	// 2番目の状態を保存（上書き）
-	state2 := &PauseState{
-		FromBranch:   "second-branch",
-		ToBranch:     "develop",
-		StashRef:     "stash@{1}",
-		StashMessage: "second",
-		Timestamp:    time.Now(),
+	legacy, err := LoadLegacy()
+	if err != nil {
+		t.Fatalf("LoadLegacy() failed: %v", err)
+	}
+	if legacy == nil || legacy.FromBranch != "old-branch" {
+		t.Fatalf("LoadLegacy() = %+v, want old-branch", legacy)
 	}
 
-	if err := Save(state2); err != nil {
-		t.Fatalf("Second Save() failed: %v", err)
+	// 以前の状態はどのリポジトリのスタックにも含まれない
+	state, err := Peek(testContext("a"))
+	if err != nil || state != nil {
+		t.Errorf("Peek() = %+v, %v; legacy state should not be used", state, err)
 	}
 
-	// 読み込んで2番目の状態が保存されていることを確認
-	loaded, err := Load()
-	if err != nil {
-		t.Fatalf("Load() failed: %v", err)
+	// 新しい状態を積んでも以前の状態は保持される
+	if err := Push(testContext("a"), newState("feature", "main")); err != nil {
+		t.Fatalf("Push() failed: %v", err)
+	}
+	legacy, err = LoadLegacy()
+	if err != nil || legacy == nil {
+		t.Errorf("Legacy state should be preserved after Push(): %+v, %v", legacy, err)
 	}
 
-	if loaded.FromBranch != "second-branch" {
-		t.Errorf("FromBranch should be overwritten: got %q, want %q", loaded.FromBranch, "second-branch")
+	if err := DeleteLegacy(); err != nil {
+		t.Fatalf("DeleteLegacy() failed: %v", err)
+	}
+	legacy, err = LoadLegacy()
+	if err != nil || legacy != nil {
+		t.Errorf("LoadLegacy() after delete = %+v, %v", legacy, err)
 	}
 }
 
@@ -294,8 +324,8 @@ func TestPauseState_JSONFormat(t *testing.T) {
 		Timestamp:    time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC),
 	}
 
-	if err := Save(state); err != nil {
-		t.Fatalf("Save() failed: %v", err)
+	if err := Push(testContext("a"), state); err != nil {
+		t.Fatalf("Push() failed: %v", err)
 	}
 
 	// JSONファイルの内容を直接確認
@@ -311,6 +341,10 @@ func TestPauseState_JSONFormat(t *testing.T) {
 
 	// JSONに必要なキーが含まれていることを確認
 	requiredKeys := []string{
+		"contexts",
+		"repository",
+		"worktree",
+		"stack",
 		"from_branch",
 		"to_branch",
 		"stash_ref",
@@ -330,6 +364,56 @@ func TestPauseState_JSONFormat(t *testing.T) {
 	}
 }
 
+func TestCurrentContext(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("README.md", "# Test")
+	repo.Commit("Initial commit")
+	repo.CreateFile("sub/file.txt", "content")
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer func() { _ = os.Chdir(oldDir) }()
+
+	if err := os.Chdir(repo.Dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+	root, err := CurrentContext()
+	if err != nil {
+		t.Fatalf("CurrentContext() failed: %v", err)
+	}
+
+	// サブディレクトリから実行しても同じ Context になる
+	if err := os.Chdir(filepath.Join(repo.Dir, "sub")); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+	sub, err := CurrentContext()
+	if err != nil {
+		t.Fatalf("CurrentContext() failed: %v", err)
+	}
+	if root != sub {
+		t.Errorf("CurrentContext() from subdirectory = %+v, want %+v", sub, root)
+	}
+
+	// 別のワークツリーはリポジトリが同じでワークツリーが異なる
+	wtDir := filepath.Join(t.TempDir(), "wt")
+	repo.MustGit("worktree", "add", "-b", "wt-branch", wtDir)
+	if err := os.Chdir(wtDir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+	wt, err := CurrentContext()
+	if err != nil {
+		t.Fatalf("CurrentContext() failed: %v", err)
+	}
+	if wt.Repository != root.Repository {
+		t.Errorf("Worktree repository = %q, want %q", wt.Repository, root.Repository)
+	}
+	if wt.Worktree == root.Worktree {
+		t.Error("Worktree path should differ from main worktree")
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && (s[:len(substr)] == substr || s[len(s)-len(substr):] == substr || containsMiddle(s, substr)))
 }
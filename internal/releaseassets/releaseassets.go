@@ -0,0 +1,356 @@
+// ================================================================================
+// Package releaseassets - GitHub リリースへのビルド成果物のアップロード
+// ================================================================================
+// このパッケージは、release-notes と new-tag --release で作成したリリースに
+// ビルド成果物（アセット）を添付するための共通ユーティリティを提供します。
+//
+// 提供する機能:
+// - Collect(): glob パターンに一致するファイルを集めて SHA256 を計算
+// - Checksums(): sha256sum と同じ形式の checksums.txt の内容を生成
+// - Upload(): アセットと checksums.txt を gh release upload でアップロード
+// - Describe(): アセットの一覧とチェックサムを表示用に整形
+//
+// 既存のアセットの扱い:
+// リリースに同じ名前のアセットが既にある場合、replace が true なら置き換え
+// （gh release upload --clobber）、false ならスキップします。
+// checksums.txt は、アセットを1つでもアップロードした場合は常に作り直して置き換えます。
+// 内容はアップロード後にリリースに含まれるすべてのアセットのチェックサムで、
+// スキップしたアセットや以前から添付されているアセットはリリース側のファイルの
+// チェックサム（GitHub の digest、なければダウンロードして計算）を使用します。
+// ================================================================================
+package releaseassets
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ChecksumsFileName はチェックサムファイルのアセット名です。
+const ChecksumsFileName = "checksums.txt"
+
+// アップロードの結果
+const (
+	StatusUploaded = "アップロード"
+	StatusReplaced = "置き換え"
+	StatusSkipped  = "スキップ（既に存在します）"
+)
+
+// Asset はリリースに添付するファイルです。
+type Asset struct {
+	Path   string // ファイルのパス
+	Name   string // アセット名（ファイル名）
+	Size   int64  // ファイルサイズ（バイト）
+	SHA256 string // SHA256 チェックサム（16進数）
+}
+
+// Result はアセットごとのアップロードの結果です。
+type Result struct {
+	Name   string // アセット名
+	Status string // アップロード・置き換え・スキップ
+}
+
+// Collect は glob パターンに一致するファイルを集め、SHA256 を計算します。
+//
+// パラメータ:
+//   - patterns: glob パターン（例: dist/*.tar.gz）。ディレクトリは除外されます
+//
+// 戻り値:
+//   - []Asset: アセット名の順に並べたアセット（同じファイルは1つにまとめる）
+//   - error: パターンに一致するファイルがない場合や、アセット名が重複する場合のエラー
+func Collect(patterns []string) ([]Asset, error) {
+	var assets []Asset
+	seen := make(map[string]string) // アセット名 → パス
+
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("無効なパターンです: %s: %w", pattern, err)
+		}
+
+		found := false
+		for _, path := range matches {
+			info, err := os.Stat(path)
+			if err != nil {
+				return nil, fmt.Errorf("%s の確認に失敗しました: %w", path, err)
+			}
+			if info.IsDir() {
+				continue
+			}
+			found = true
+
+			name := filepath.Base(path)
+			if prev, ok := seen[name]; ok {
+				if filepath.Clean(prev) == filepath.Clean(path) {
+					continue
+				}
+				return nil, fmt.Errorf("アセット名 %s が重複しています: %s, %s", name, prev, path)
+			}
+			if name == ChecksumsFileName {
+				return nil, fmt.Errorf("%s は自動生成されるため、アセットに指定できません: %s", ChecksumsFileName, path)
+			}
+
+			sum, err := fileSHA256(path)
+			if err != nil {
+				return nil, err
+			}
+			seen[name] = path
+			assets = append(assets, Asset{Path: path, Name: name, Size: info.Size(), SHA256: sum})
+		}
+		if !found {
+			return nil, fmt.Errorf("パターン %s に一致するファイルがありません", pattern)
+		}
+	}
+
+	sort.Slice(assets, func(i, j int) bool { return assets[i].Name < assets[j].Name })
+	return assets, nil
+}
+
+// fileSHA256 はファイルの SHA256 チェックサムを計算します
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("%s の読み込みに失敗しました: %w", path, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("%s の読み込みに失敗しました: %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Checksums は checksums.txt の内容を生成します。
+// sha256sum と同じ形式（"<チェックサム>  <アセット名>"）のため、
+// ダウンロードしたディレクトリで sha256sum -c checksums.txt で検証できます。
+func Checksums(assets []Asset) string {
+	var b strings.Builder
+	for _, asset := range assets {
+		fmt.Fprintf(&b, "%s  %s\n", asset.SHA256, asset.Name)
+	}
+	return b.String()
+}
+
+// Upload はアセットと checksums.txt をリリースにアップロードします。
+//
+// パラメータ:
+//   - tag: リリースのタグ
+//   - assets: Collect で集めたアセット
+//   - replace: 既存のアセットを置き換えるかどうか（false の場合はスキップ）
+//
+// 戻り値:
+//   - []Result: checksums.txt を含むアセットごとの結果
+//   - error: リリースの取得やアップロードに失敗した場合のエラー
+//
+// 内部処理:
+//   1. リリースに既にあるアセットと、その SHA256 を取得
+//   2. アップロードするアセットと、アップロード後にリリースに含まれるアセットを決定
+//   3. リリース側の SHA256 が分からないアセットはダウンロードして計算
+//   4. リリースに含まれるすべてのアセットから checksums.txt を作成し、アセットと一緒にアップロード
+func Upload(tag string, assets []Asset, replace bool) ([]Result, error) {
+	existing, err := releaseAssets(tag)
+	if err != nil {
+		return nil, err
+	}
+	plan := planUpload(assets, existing, replace)
+	if !plan.Checksums {
+		return append(plan.Results, Result{Name: ChecksumsFileName, Status: StatusSkipped}), nil
+	}
+
+	tmpDir, err := os.MkdirTemp("", "git-plus-assets-")
+	if err != nil {
+		return nil, fmt.Errorf("一時ディレクトリの作成に失敗しました: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	for i, asset := range plan.Final {
+		if asset.SHA256 != "" {
+			continue
+		}
+		sum, err := downloadSHA256(tag, asset.Name, tmpDir)
+		if err != nil {
+			return nil, err
+		}
+		plan.Final[i].SHA256 = sum
+	}
+
+	checksumsPath := filepath.Join(tmpDir, ChecksumsFileName)
+	if err := os.WriteFile(checksumsPath, []byte(Checksums(plan.Final)), 0644); err != nil {
+		return nil, fmt.Errorf("%s の作成に失敗しました: %w", ChecksumsFileName, err)
+	}
+	status := StatusUploaded
+	if _, ok := existing[ChecksumsFileName]; ok {
+		status = StatusReplaced
+	}
+	paths := append(plan.Paths, checksumsPath)
+	results := append(plan.Results, Result{Name: ChecksumsFileName, Status: status})
+
+	// スキップするアセットは paths に含まれないため、--clobber で置き換わるのは
+	// replace が true の場合のアセットと checksums.txt だけ
+	args := append([]string{"release", "upload", tag, "--clobber"}, paths...)
+	if output, err := exec.Command("gh", args...).CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("アセットのアップロードに失敗しました: %w\n%s", err, strings.TrimSpace(string(output)))
+	}
+	return results, nil
+}
+
+// uploadPlan はアップロードの計画です
+type uploadPlan struct {
+	Paths     []string // gh release upload に渡すファイルのパス（checksums.txt を除く）
+	Results   []Result // アセットごとの結果（checksums.txt を除く）
+	Final     []Asset  // アップロード後にリリースに含まれるアセット（checksums.txt を除く、名前順。SHA256 が空のものはリリースから取得が必要）
+	Checksums bool     // checksums.txt を作り直してアップロードするかどうか
+}
+
+// planUpload はアップロードするファイルと、アセットごとの結果を決定します。
+//
+// パラメータ:
+//   - assets: アップロードするアセット（checksums.txt を除く）
+//   - existing: リリースに既にあるアセット名と、その SHA256（分からない場合は空）
+//   - replace: 既存のアセットを置き換えるかどうか
+//
+// 戻り値:
+//   - uploadPlan: アップロードの計画
+//
+// 内部処理:
+//   checksums.txt はアセットを1つでもアップロードする場合、またはリリースに
+//   checksums.txt がない場合に作り直します。内容はアップロードするアセットの
+//   ローカルの SHA256 と、スキップしたアセット・以前から添付されているアセットの
+//   リリース側の SHA256 から作成します。
+func planUpload(assets []Asset, existing map[string]string, replace bool) uploadPlan {
+	var plan uploadPlan
+	uploaded := make(map[string]bool)
+	for _, asset := range assets {
+		status := StatusUploaded
+		if _, ok := existing[asset.Name]; ok {
+			if !replace {
+				plan.Results = append(plan.Results, Result{Name: asset.Name, Status: StatusSkipped})
+				continue
+			}
+			status = StatusReplaced
+		}
+		uploaded[asset.Name] = true
+		plan.Paths = append(plan.Paths, asset.Path)
+		plan.Results = append(plan.Results, Result{Name: asset.Name, Status: status})
+		plan.Final = append(plan.Final, asset)
+	}
+
+	for name, sum := range existing {
+		if name == ChecksumsFileName || uploaded[name] {
+			continue
+		}
+		plan.Final = append(plan.Final, Asset{Name: name, SHA256: sum})
+	}
+	sort.Slice(plan.Final, func(i, j int) bool { return plan.Final[i].Name < plan.Final[j].Name })
+
+	_, hasChecksums := existing[ChecksumsFileName]
+	plan.Checksums = len(plan.Paths) > 0 || !hasChecksums
+	return plan
+}
+
+// releaseAssets はリリースに既にあるアセット名と、その SHA256 を取得します。
+// GitHub がアセットの digest（sha256:<チェックサム>）を返さない場合、SHA256 は空になります。
+func releaseAssets(tag string) (map[string]string, error) {
+	output, err := exec.Command("gh", "release", "view", tag, "--json", "assets").Output()
+	if err != nil {
+		return nil, fmt.Errorf("リリース %s の取得に失敗しました: %w", tag, err)
+	}
+	return parseReleaseAssets(output)
+}
+
+// parseReleaseAssets は gh release view --json assets の出力を解析します
+func parseReleaseAssets(output []byte) (map[string]string, error) {
+	var release struct {
+		Assets []struct {
+			Name   string `json:"name"`
+			Digest string `json:"digest"`
+		} `json:"assets"`
+	}
+	if err := json.Unmarshal(output, &release); err != nil {
+		return nil, fmt.Errorf("JSONのパースに失敗: %w", err)
+	}
+
+	assets := make(map[string]string, len(release.Assets))
+	for _, asset := range release.Assets {
+		sum, _ := strings.CutPrefix(asset.Digest, "sha256:")
+		if sum == asset.Digest {
+			// sha256 以外の digest は使用できない
+			sum = ""
+		}
+		assets[asset.Name] = sum
+	}
+	return assets, nil
+}
+
+// downloadSHA256 はリリースのアセットをダウンロードして SHA256 を計算します
+func downloadSHA256(tag, name, tmpDir string) (string, error) {
+	dir, err := os.MkdirTemp(tmpDir, "download-")
+	if err != nil {
+		return "", fmt.Errorf("一時ディレクトリの作成に失敗しました: %w", err)
+	}
+	output, err := exec.Command("gh", "release", "download", tag, "--pattern", globEscape(name), "--dir", dir).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("アセット %s のダウンロードに失敗しました: %w\n%s", name, err, strings.TrimSpace(string(output)))
+	}
+	return fileSHA256(filepath.Join(dir, name))
+}
+
+// globEscape はアセット名を gh release download --pattern でその名前だけに一致するパターンにします
+func globEscape(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if strings.ContainsRune(`*?[\`, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// ReleaseExists はタグのリリースが既に存在するかどうかを返します
+func ReleaseExists(tag string) bool {
+	return exec.Command("gh", "release", "view", tag, "--json", "tagName").Run() == nil
+}
+
+// Describe はアセットの一覧をチェックサムとともに表示用の文字列にします
+func Describe(assets []Asset) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "アセット: %d 個（%s を追加）\n", len(assets), ChecksumsFileName)
+	for _, asset := range assets {
+		fmt.Fprintf(&b, "  %s  %s (%s)\n", asset.SHA256, asset.Name, formatSize(asset.Size))
+	}
+	return b.String()
+}
+
+// String はアップロードの結果を1行で表示します（例: ✓ app.tar.gz: アップロード）
+func (r Result) String() string {
+	mark := "✓"
+	if r.Status == StatusSkipped {
+		mark = "-"
+	}
+	return fmt.Sprintf("%s %s: %s", mark, r.Name, r.Status)
+}
+
+// formatSize はファイルサイズを読みやすい形式にします（例: 1.5 MB）
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value := float64(size) / unit
+	for _, suffix := range []string{"KB", "MB", "GB"} {
+		if value < unit || suffix == "GB" {
+			return fmt.Sprintf("%.1f %s", value, suffix)
+		}
+		value /= unit
+	}
+	return fmt.Sprintf("%d B", size)
+}
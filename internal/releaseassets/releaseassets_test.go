@@ -0,0 +1,234 @@
+package releaseassets
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeAssetFiles はテスト用のファイルを作成し、ディレクトリを返します
+func writeAssetFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// TestCollect はパターンに一致するファイルの収集と SHA256 の計算をテストします
+func TestCollect(t *testing.T) {
+	dir := writeAssetFiles(t, map[string]string{
+		"dist/app-linux.tar.gz": "linux",
+		"dist/app-darwin.zip":   "darwin",
+		"dist/notes.md":         "notes",
+	})
+	if err := os.Mkdir(filepath.Join(dir, "dist", "sub.tar.gz"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// 同じファイルに一致するパターンを重ねても1つにまとめる
+	assets, err := Collect([]string{
+		filepath.Join(dir, "dist", "*.tar.gz"),
+		filepath.Join(dir, "dist", "app-*"),
+	})
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if len(assets) != 2 || assets[0].Name != "app-darwin.zip" || assets[1].Name != "app-linux.tar.gz" {
+		t.Fatalf("Collect() = %+v", assets)
+	}
+	// printf linux | sha256sum
+	if assets[1].SHA256 != "caf90169eefa5f807d577486b9f795ab86ae2983c5c20806cff959117e90af18" {
+		t.Errorf("SHA256 = %q", assets[1].SHA256)
+	}
+	if assets[1].Size != int64(len("linux")) {
+		t.Errorf("Size = %d", assets[1].Size)
+	}
+}
+
+// TestCollect_Errors はパターンの誤りを検出することをテストします
+func TestCollect_Errors(t *testing.T) {
+	dir := writeAssetFiles(t, map[string]string{
+		"a/app.zip":     "a",
+		"b/app.zip":     "b",
+		"checksums.txt": "old",
+	})
+
+	tests := []struct {
+		name     string
+		patterns []string
+	}{
+		{"一致しない", []string{filepath.Join(dir, "*.exe")}},
+		{"名前の重複", []string{filepath.Join(dir, "*", "app.zip")}},
+		{"checksums.txt", []string{filepath.Join(dir, "checksums.txt")}},
+		{"無効なパターン", []string{filepath.Join(dir, "[")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Collect(tt.patterns); err == nil {
+				t.Errorf("Collect(%v) should fail", tt.patterns)
+			}
+		})
+	}
+}
+
+// TestChecksums は checksums.txt の形式をテストします
+func TestChecksums(t *testing.T) {
+	dir := writeAssetFiles(t, map[string]string{"hello.txt": "hello"})
+	assets, err := Collect([]string{filepath.Join(dir, "hello.txt")})
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	want := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824  hello.txt\n"
+	if got := Checksums(assets); got != want {
+		t.Errorf("Checksums() = %q, want %q", got, want)
+	}
+}
+
+// TestPlanUpload は既存のアセットの置き換えとスキップをテストします
+func TestPlanUpload(t *testing.T) {
+	assets := []Asset{
+		{Path: "/dist/new.zip", Name: "new.zip", SHA256: "local-new"},
+		{Path: "/dist/old.zip", Name: "old.zip", SHA256: "local-old"},
+	}
+	existing := map[string]string{"old.zip": "remote-old", ChecksumsFileName: ""}
+
+	plan := planUpload(assets, existing, false)
+	if strings.Join(plan.Paths, " ") != "/dist/new.zip" {
+		t.Errorf("skip: paths = %v", plan.Paths)
+	}
+	if plan.Results[0].Status != StatusUploaded || plan.Results[1].Status != StatusSkipped {
+		t.Errorf("skip: results = %+v", plan.Results)
+	}
+
+	plan = planUpload(assets, existing, true)
+	if len(plan.Paths) != 2 {
+		t.Errorf("replace: paths = %v", plan.Paths)
+	}
+	if plan.Results[0].Status != StatusUploaded || plan.Results[1].Status != StatusReplaced {
+		t.Errorf("replace: results = %+v", plan.Results)
+	}
+}
+
+// TestPlanUpload_Checksums は checksums.txt がリリースに含まれるアセットから作り直されることをテストします
+func TestPlanUpload_Checksums(t *testing.T) {
+	assets := []Asset{
+		{Path: "/dist/new.zip", Name: "new.zip", SHA256: "local-new"},
+		{Path: "/dist/old.zip", Name: "old.zip", SHA256: "local-old"},
+	}
+
+	tests := []struct {
+		name      string
+		existing  map[string]string
+		replace   bool
+		checksums bool
+		want      string
+	}{
+		{
+			name:      "新しいリリース",
+			existing:  map[string]string{},
+			checksums: true,
+			want:      "local-new  new.zip\nlocal-old  old.zip\n",
+		},
+		{
+			// 既存の checksums.txt はスキップせずに作り直し、スキップしたアセットはリリース側の値を使う
+			name:      "スキップしたアセットと既存の checksums.txt",
+			existing:  map[string]string{"old.zip": "remote-old", ChecksumsFileName: "remote-checksums"},
+			checksums: true,
+			want:      "local-new  new.zip\nremote-old  old.zip\n",
+		},
+		{
+			name:      "置き換えたアセット",
+			existing:  map[string]string{"old.zip": "remote-old", ChecksumsFileName: ""},
+			replace:   true,
+			checksums: true,
+			want:      "local-new  new.zip\nlocal-old  old.zip\n",
+		},
+		{
+			// アップロードするアセットがなく checksums.txt もある場合は作り直さない
+			name:      "すべてスキップ",
+			existing:  map[string]string{"other.zip": "", "old.zip": "remote-old", "new.zip": "remote-new", ChecksumsFileName: ""},
+			checksums: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := planUpload(assets, tt.existing, tt.replace)
+			if plan.Checksums != tt.checksums {
+				t.Fatalf("Checksums = %v, want %v", plan.Checksums, tt.checksums)
+			}
+			if tt.checksums {
+				if got := Checksums(plan.Final); got != tt.want {
+					t.Errorf("checksums.txt = %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+
+	// アップロードするアセットがあれば、以前から添付されているアセットも checksums.txt に含める
+	plan := planUpload(assets, map[string]string{"other.zip": "", ChecksumsFileName: ""}, false)
+	var names []string
+	for _, asset := range plan.Final {
+		names = append(names, asset.Name+"="+asset.SHA256)
+	}
+	if got := strings.Join(names, " "); got != "new.zip=local-new old.zip=local-old other.zip=" {
+		t.Errorf("Final = %s", got)
+	}
+}
+
+// TestParseReleaseAssets は gh release view の出力から SHA256 を取得することをテストします
+func TestParseReleaseAssets(t *testing.T) {
+	output := `{"assets":[{"name":"a.zip","digest":"sha256:abc"},{"name":"b.zip","digest":null},{"name":"c.zip"}]}`
+	assets, err := parseReleaseAssets([]byte(output))
+	if err != nil {
+		t.Fatalf("parseReleaseAssets() error: %v", err)
+	}
+	if len(assets) != 3 || assets["a.zip"] != "abc" || assets["b.zip"] != "" || assets["c.zip"] != "" {
+		t.Errorf("parseReleaseAssets() = %v", assets)
+	}
+}
+
+// TestGlobEscape はアセット名のパターンのエスケープをテストします
+func TestGlobEscape(t *testing.T) {
+	if got := globEscape("app[1]*.zip"); got != `app\[1]\*.zip` {
+		t.Errorf("globEscape() = %q", got)
+	}
+}
+
+// TestDescribe はアセットの一覧の表示をテストします
+func TestDescribe(t *testing.T) {
+	got := Describe([]Asset{{Name: "app.zip", Size: 1536, SHA256: "abc"}})
+	want := "アセット: 1 個（checksums.txt を追加）\n  abc  app.zip (1.5 KB)\n"
+	if got != want {
+		t.Errorf("Describe() = %q, want %q", got, want)
+	}
+	if got := (Result{Name: "app.zip", Status: StatusSkipped}).String(); !strings.HasPrefix(got, "- app.zip") {
+		t.Errorf("Result.String() = %q", got)
+	}
+}
+
+// TestFormatSize はファイルサイズの表示をテストします
+func TestFormatSize(t *testing.T) {
+	tests := map[int64]string{
+		512:             "512 B",
+		2048:            "2.0 KB",
+		5 * 1024 * 1024: "5.0 MB",
+		3 << 40:         "3072.0 GB",
+	}
+	for size, want := range tests {
+		if got := formatSize(size); got != want {
+			t.Errorf("formatSize(%d) = %q, want %q", size, got, want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// ================================================================================
+// changelog.go - CHANGELOG.md の更新
+// ================================================================================
+// 生成したリリースノートを CHANGELOG.md の先頭（最新のセクションの前）に挿入します。
+// 同じバージョンのセクションが既にある場合は二重に追加しません。
+// ================================================================================
+package releasenotes
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DefaultChangelogPath は CHANGELOG ファイルの既定のパスです。
+const DefaultChangelogPath = "CHANGELOG.md"
+
+// changelogTitle は CHANGELOG ファイルを新規作成するときの見出しです。
+const changelogTitle = "# Changelog\n"
+
+// PrependChangelog は CHANGELOG ファイルの先頭に新しいセクションを挿入します。
+//
+// パラメータ:
+//   - path: CHANGELOG ファイルのパス（存在しない場合は作成）
+//   - version: セクションのバージョン（重複の確認に使用）
+//   - section: 挿入するセクション（Render の結果）
+//
+// 戻り値:
+//   - error: 既に同じバージョンのセクションがある場合や、読み書きに失敗した場合のエラー
+//
+// 内部処理:
+//
+//	ファイルの見出しや説明文はそのまま残し、最初の "## " で始まる見出しの前に挿入します。
+//	"## " の見出しがない場合は末尾に追加します。
+func PrependChangelog(path, version, section string) error {
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("%s の読み込みに失敗しました: %w", path, err)
+	}
+
+	updated, err := insertChangelogSection(string(content), version, section)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(updated), 0644); err != nil {
+		return fmt.Errorf("%s の書き込みに失敗しました: %w", path, err)
+	}
+	return nil
+}
+
+// insertChangelogSection は CHANGELOG の内容にセクションを挿入した結果を返します。
+func insertChangelogSection(content, version, section string) (string, error) {
+	section = strings.TrimSpace(section) + "\n"
+	if strings.TrimSpace(content) == "" {
+		return changelogTitle + "\n" + section, nil
+	}
+
+	lines := strings.SplitAfter(content, "\n")
+	insertAt := -1
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "## ") {
+			continue
+		}
+		if hasVersionHeading(line, version) {
+			return "", fmt.Errorf("CHANGELOG には既に %s のセクションがあります", version)
+		}
+		if insertAt < 0 {
+			insertAt = i
+		}
+	}
+
+	if insertAt < 0 {
+		return strings.TrimRight(content, "\n") + "\n\n" + section, nil
+	}
+	head := strings.Join(lines[:insertAt], "")
+	tail := strings.Join(lines[insertAt:], "")
+	return head + section + "\n" + tail, nil
+}
+
+// hasVersionHeading は見出し行が指定したバージョンのものかを判定します。
+// "## v1.2.3"、"## v1.2.3 (2026-01-01)"、"## [v1.2.3]" などに一致します。
+func hasVersionHeading(line, version string) bool {
+	heading := strings.TrimSpace(strings.TrimPrefix(line, "## "))
+	heading = strings.TrimPrefix(heading, "[")
+	if !strings.HasPrefix(heading, version) {
+		return false
+	}
+	rest := heading[len(version):]
+	return rest == "" || strings.ContainsAny(rest[:1], " ]")
+}
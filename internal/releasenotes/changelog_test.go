@@ -0,0 +1,89 @@
+package releasenotes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestInsertChangelogSection は CHANGELOG へのセクションの挿入位置をテストします
+func TestInsertChangelogSection(t *testing.T) {
+	section := "## v1.1.0 (2026-01-02)\n\n### 新機能\n\n- A\n"
+
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "新規作成",
+			content:  "",
+			expected: "# Changelog\n\n" + section,
+		},
+		{
+			name:     "既存のセクションの前に挿入",
+			content:  "# Changelog\n\nすべての変更を記録します。\n\n## v1.0.0 (2026-01-01)\n\n- 最初のリリース\n",
+			expected: "# Changelog\n\nすべての変更を記録します。\n\n" + section + "\n## v1.0.0 (2026-01-01)\n\n- 最初のリリース\n",
+		},
+		{
+			name:     "見出しのみ",
+			content:  "# Changelog\n",
+			expected: "# Changelog\n\n" + section,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := insertChangelogSection(tt.content, "v1.1.0", section)
+			if err != nil {
+				t.Fatalf("insertChangelogSection returned error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("got:\n%q\nwant:\n%q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestInsertChangelogSection_Duplicate は同じバージョンのセクションがある場合にエラーになることを確認します
+func TestInsertChangelogSection_Duplicate(t *testing.T) {
+	for _, content := range []string{
+		"# Changelog\n\n## v1.1.0 (2026-01-02)\n",
+		"# Changelog\n\n## [v1.1.0] - 2026-01-02\n",
+		"# Changelog\n\n## v1.1.0\n",
+	} {
+		if _, err := insertChangelogSection(content, "v1.1.0", "## v1.1.0\n"); err == nil {
+			t.Errorf("should return error for %q", content)
+		}
+	}
+
+	// 前方一致するだけの別バージョンは重複ではない
+	if _, err := insertChangelogSection("# Changelog\n\n## v1.1.0-rc.1\n", "v1.1.0", "## v1.1.0\n"); err != nil {
+		t.Errorf("v1.1.0-rc.1 should not be treated as duplicate: %v", err)
+	}
+}
+
+// TestPrependChangelog はファイルの作成と更新をテストします
+func TestPrependChangelog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultChangelogPath)
+
+	if err := PrependChangelog(path, "v1.0.0", "## v1.0.0\n\n- A\n"); err != nil {
+		t.Fatalf("PrependChangelog returned error: %v", err)
+	}
+	if err := PrependChangelog(path, "v1.1.0", "## v1.1.0\n\n- B\n"); err != nil {
+		t.Fatalf("PrependChangelog returned error: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# Changelog\n\n## v1.1.0\n\n- B\n\n## v1.0.0\n\n- A\n"
+	if string(content) != want {
+		t.Errorf("content = %q, want %q", content, want)
+	}
+
+	if err := PrependChangelog(path, "v1.1.0", "## v1.1.0\n"); err == nil {
+		t.Error("PrependChangelog should fail for duplicate version")
+	}
+}
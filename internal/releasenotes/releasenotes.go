@@ -0,0 +1,410 @@
+// ================================================================================
+// Package releasenotes - ローカルでのリリースノート生成
+// ================================================================================
+// このパッケージは、2つのタグ間のコミットから GitHub に依存せずに
+// リリースノート（Markdown）を生成するための共通ユーティリティを提供します。
+//
+// 提供する機能:
+// - Collect(): タグ間のコミットを Conventional Commits として解析し、種類ごとに分類
+// - PreviousTag(): 指定したタグの1つ前のリリースタグを SemVer の順序で検索
+// - LoadTemplate() / Render(): テンプレート（text/template）から Markdown を生成
+// - PrependChangelog(): CHANGELOG.md の先頭に新しいセクションを挿入
+//
+// 使用目的:
+// release-notes（リリースノートの作成・CHANGELOG.md の更新）と
+// new-tag --release（GitHub リリースの本文）で同じ本文を生成します。
+//
+// 分類:
+//   - 破壊的変更: BREAKING CHANGE フッターまたは type! のコミット
+//   - 新機能: feat
+//   - バグ修正: fix
+//   - パフォーマンス改善: perf
+//
+// 上記以外のタイプ（docs, chore など）や規約に沿わないコミットは含めません。
+// PR 番号は、スカッシュマージの件名末尾の (#123) と、
+// "Merge pull request #123" のマージコミットで取り込まれたコミットから取得します。
+// ================================================================================
+package releasenotes
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/tonbiattack/git-plus/internal/conventional"
+	"github.com/tonbiattack/git-plus/internal/gitcmd"
+	"github.com/tonbiattack/git-plus/internal/semver"
+)
+
+// TemplateConfigKey はテンプレートファイルのパスを設定する git config のキーです。
+const TemplateConfigKey = "gitplus.releasenotes.template"
+
+// DefaultTemplate は標準のリリースノートのテンプレートです。
+// テンプレートでは Notes のフィールドと、Entry のフィールド・ShortHash メソッドを使用できます。
+const DefaultTemplate = `{{define "entry"}}{{if .Scope}}**{{.Scope}}:** {{end}}{{.Description}}{{range .PRs}} (#{{.}}){{end}} ({{.ShortHash}}){{end -}}
+## {{.Version}} ({{.Date}})
+{{if .Breaking}}
+### ⚠ 破壊的変更
+
+{{range .Breaking}}- {{if .Scope}}**{{.Scope}}:** {{end}}{{if .BreakingNote}}{{.BreakingNote}}{{else}}{{.Description}}{{end}}{{range .PRs}} (#{{.}}){{end}} ({{.ShortHash}})
+{{end}}{{end}}{{range .Sections}}
+### {{.Title}}
+
+{{range .Entries}}- {{template "entry" .}}
+{{end}}{{end}}{{if not (or .Breaking .Sections)}}
+特筆すべき変更はありません。
+{{end}}`
+
+// sectionTypes はセクションに含めるコミットタイプと見出しです（表示順）。
+var sectionTypes = []struct {
+	Type  string
+	Title string
+}{
+	{Type: "feat", Title: "新機能"},
+	{Type: "fix", Title: "バグ修正"},
+	{Type: "perf", Title: "パフォーマンス改善"},
+}
+
+var (
+	squashPRPattern = regexp.MustCompile(`\(#(\d+)\)\s*$`)
+	mergePRPattern  = regexp.MustCompile(`^Merge pull request #(\d+) `)
+)
+
+// Notes はリリースノート1件分のデータを表す構造体です。
+type Notes struct {
+	Version     string    // リリースするタグ（例: v1.3.0）
+	PreviousTag string    // 比較元のタグ（最初のリリースの場合は空文字列）
+	Date        string    // リリースするタグのコミット日（YYYY-MM-DD）
+	Breaking    []Entry   // 破壊的変更を含むコミット
+	Sections    []Section // 種類ごとのコミット（コミットがある種類のみ、表示順）
+	Commits     int       // 範囲内のコミット数（マージコミットと分類外のコミットを含む）
+}
+
+// Section は種類ごとのコミットの一覧を表す構造体です。
+type Section struct {
+	Type    string  // コミットタイプ（例: feat）
+	Title   string  // 見出し（例: 新機能）
+	Entries []Entry // コミット（新しい順）
+}
+
+// Entry はリリースノートに載せるコミット1件を表す構造体です。
+type Entry struct {
+	Hash         string // コミットハッシュ
+	Type         string // コミットタイプ
+	Scope        string // スコープ（省略可）
+	Description  string // 概要（PR 番号の (#123) は除く）
+	Breaking     bool   // 破壊的変更かどうか
+	BreakingNote string // BREAKING CHANGE フッターの内容
+	PRs          []int  // 関連する PR 番号
+}
+
+// ShortHash はコミットハッシュの先頭7文字を返します。
+func (e Entry) ShortHash() string {
+	if len(e.Hash) > 7 {
+		return e.Hash[:7]
+	}
+	return e.Hash
+}
+
+// HasEntries はリリースノートに載せるコミットがあるかを返します。
+func (n *Notes) HasEntries() bool {
+	return len(n.Breaking) > 0 || len(n.Sections) > 0
+}
+
+// logRecord は git log の出力1件を表す構造体です。
+type logRecord struct {
+	hash    string
+	parents []string
+	message string
+}
+
+// Collect は2つのタグ間のコミットを解析してリリースノートのデータを作成します。
+//
+// パラメータ:
+//   - from: 比較元のタグ（空の場合は to までの全コミット）
+//   - to: リリースするタグ
+//
+// 戻り値:
+//   - *Notes: リリースノートのデータ
+//   - error: git コマンドの実行に失敗した場合のエラー
+//
+// 内部処理:
+//  1. git log --format=%H%x00%P%x00%B%x1e <from>..<to> でマージコミットを含めて取得
+//  2. "Merge pull request #N" のマージコミットは、取り込んだコミット（M^1..M^2）に PR 番号を割り当てる
+//  3. マージ以外のコミットを Conventional Commits として解析し、種類ごとに分類
+func Collect(from, to string) (*Notes, error) {
+	revRange := to
+	if from != "" {
+		revRange = from + ".." + to
+	}
+	output, err := gitcmd.Run("log", "--format=%H%x00%P%x00%B%x1e", revRange)
+	if err != nil {
+		return nil, fmt.Errorf("コミットの取得に失敗しました: %w", err)
+	}
+	records := parseLogRecords(output)
+
+	mergedPRs := make(map[string][]int)
+	for _, r := range records {
+		if len(r.parents) < 2 {
+			continue
+		}
+		m := mergePRPattern.FindStringSubmatch(r.message)
+		if m == nil {
+			continue
+		}
+		pr, _ := strconv.Atoi(m[1])
+		merged, err := gitcmd.Run("rev-list", r.parents[0]+".."+r.parents[1])
+		if err != nil {
+			continue
+		}
+		for _, hash := range strings.Fields(string(merged)) {
+			mergedPRs[hash] = appendUnique(mergedPRs[hash], pr)
+		}
+	}
+
+	date, _ := gitcmd.Run("log", "-1", "--format=%cs", to)
+	notes := &Notes{
+		Version:     to,
+		PreviousTag: from,
+		Date:        strings.TrimSpace(string(date)),
+		Commits:     len(records),
+	}
+
+	byType := make(map[string][]Entry)
+	for _, r := range records {
+		if len(r.parents) > 1 {
+			continue
+		}
+		entry, ok := parseEntry(r.hash, r.message)
+		if !ok {
+			continue
+		}
+		for _, pr := range mergedPRs[r.hash] {
+			entry.PRs = appendUnique(entry.PRs, pr)
+		}
+		if entry.Breaking {
+			notes.Breaking = append(notes.Breaking, entry)
+		}
+		byType[entry.Type] = append(byType[entry.Type], entry)
+	}
+
+	for _, st := range sectionTypes {
+		if entries := byType[st.Type]; len(entries) > 0 {
+			notes.Sections = append(notes.Sections, Section{Type: st.Type, Title: st.Title, Entries: entries})
+		}
+	}
+	return notes, nil
+}
+
+// parseLogRecords は git log --format=%H%x00%P%x00%B%x1e の出力を解析します。
+func parseLogRecords(output []byte) []logRecord {
+	var records []logRecord
+	for _, record := range bytes.Split(output, []byte{0x1e}) {
+		rec := strings.TrimLeft(string(record), "\n")
+		parts := strings.SplitN(rec, "\x00", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		records = append(records, logRecord{
+			hash:    strings.TrimSpace(parts[0]),
+			parents: strings.Fields(parts[1]),
+			message: strings.TrimSpace(parts[2]),
+		})
+	}
+	return records
+}
+
+// parseEntry はコミットメッセージからリリースノートのエントリを作成します。
+//
+// 戻り値:
+//   - Entry: エントリ
+//   - bool: リリースノートに載せる場合は true（feat/fix/perf または破壊的変更）
+func parseEntry(hash, message string) (Entry, bool) {
+	if conventional.IsExempt(message) {
+		return Entry{}, false
+	}
+	c, err := conventional.Parse(message)
+	if err != nil {
+		return Entry{}, false
+	}
+
+	entry := Entry{
+		Hash:         hash,
+		Type:         c.Type,
+		Scope:        c.Scope,
+		Description:  c.Description,
+		Breaking:     c.Breaking,
+		BreakingNote: c.BreakingDescription(),
+	}
+	if m := squashPRPattern.FindStringSubmatch(entry.Description); m != nil {
+		pr, _ := strconv.Atoi(m[1])
+		entry.PRs = []int{pr}
+		entry.Description = strings.TrimSpace(squashPRPattern.ReplaceAllString(entry.Description, ""))
+	}
+
+	if entry.Breaking {
+		return entry, true
+	}
+	for _, st := range sectionTypes {
+		if st.Type == entry.Type {
+			return entry, true
+		}
+	}
+	return Entry{}, false
+}
+
+// PreviousTag は指定したタグの1つ前のリリースタグを返します。
+//
+// パラメータ:
+//   - tag: リリースするタグ（例: v1.3.0、api/v1.3.0）
+//   - tags: リポジトリのタグ一覧
+//
+// 戻り値:
+//   - string: 1つ前のタグ（見つからない場合は空文字列）
+//
+// 内部処理:
+//
+//	tag と同じプレフィックスを持ち、SemVer の優先順位が tag より低いタグのうち
+//	最も高いものを返します。tag が正式版の場合はプレリリースを除外し、
+//	v1.3.0 の比較元を v1.3.0-rc.2 ではなく v1.2.x にします。
+func PreviousTag(tag string, tags []string) string {
+	prefix, version, err := semver.SplitTag(tag)
+	if err != nil {
+		return ""
+	}
+
+	previous := ""
+	var previousVersion semver.Version
+	for _, t := range tags {
+		v, err := semver.ParseTag(t, prefix)
+		if err != nil || semver.Compare(v, version) >= 0 {
+			continue
+		}
+		if !version.IsPrerelease() && v.IsPrerelease() {
+			continue
+		}
+		if previous == "" || semver.Compare(v, previousVersion) > 0 {
+			previous, previousVersion = t, v
+		}
+	}
+	return previous
+}
+
+// ListTags はリポジトリのタグ一覧を返します。
+func ListTags() ([]string, error) {
+	output, err := gitcmd.Run("tag")
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(output)), nil
+}
+
+// LoadTemplate はリリースノートのテンプレートを読み込みます。
+//
+// パラメータ:
+//   - path: テンプレートファイルのパス（空の場合は git config gitplus.releasenotes.template、
+//     それも未設定の場合は DefaultTemplate）
+//
+// 戻り値:
+//   - string: テンプレートの内容
+//   - error: ファイルの読み込みに失敗した場合のエラー
+//
+// 内部処理:
+//
+//	git config で指定した相対パスはリポジトリのルートからのパスとして扱います。
+func LoadTemplate(path string) (string, error) {
+	if path == "" {
+		output, err := gitcmd.Run("config", "--get", TemplateConfigKey)
+		if err != nil {
+			return DefaultTemplate, nil
+		}
+		path = strings.TrimSpace(string(output))
+		if !filepath.IsAbs(path) {
+			if root, err := gitcmd.Run("rev-parse", "--show-toplevel"); err == nil {
+				path = filepath.Join(strings.TrimSpace(string(root)), path)
+			}
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("テンプレートの読み込みに失敗しました: %w", err)
+	}
+	return string(content), nil
+}
+
+// Render はテンプレートからリリースノートの Markdown を生成します。
+//
+// パラメータ:
+//   - notes: リリースノートのデータ
+//   - tmpl: テンプレート（text/template 形式）
+//
+// 戻り値:
+//   - string: 生成した Markdown（末尾の改行は1つにそろえる）
+//   - error: テンプレートの解析・実行に失敗した場合のエラー
+func Render(notes *Notes, tmpl string) (string, error) {
+	t, err := template.New("release-notes").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("テンプレートの解析に失敗しました: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, notes); err != nil {
+		return "", fmt.Errorf("テンプレートの実行に失敗しました: %w", err)
+	}
+	return strings.TrimSpace(buf.String()) + "\n", nil
+}
+
+// Generate は2つのタグ間のリリースノートを生成します。
+// from が空の場合は PreviousTag で比較元を決定します。
+//
+// パラメータ:
+//   - from: 比較元のタグ（空の場合は自動で決定）
+//   - to: リリースするタグ
+//   - templatePath: テンプレートファイルのパス（空の場合は LoadTemplate の既定）
+//
+// 戻り値:
+//   - *Notes: リリースノートのデータ
+//   - string: 生成した Markdown
+//   - error: 生成に失敗した場合のエラー
+func Generate(from, to, templatePath string) (*Notes, string, error) {
+	if from == "" {
+		tags, err := ListTags()
+		if err != nil {
+			return nil, "", fmt.Errorf("タグの取得に失敗しました: %w", err)
+		}
+		from = PreviousTag(to, tags)
+	}
+
+	notes, err := Collect(from, to)
+	if err != nil {
+		return nil, "", err
+	}
+	tmpl, err := LoadTemplate(templatePath)
+	if err != nil {
+		return nil, "", err
+	}
+	body, err := Render(notes, tmpl)
+	if err != nil {
+		return nil, "", err
+	}
+	return notes, body, nil
+}
+
+// appendUnique は重複しないように PR 番号を追加し、昇順に並べます。
+func appendUnique(prs []int, pr int) []int {
+	for _, p := range prs {
+		if p == pr {
+			return prs
+		}
+	}
+	prs = append(prs, pr)
+	sort.Ints(prs)
+	return prs
+}
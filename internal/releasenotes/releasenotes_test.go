@@ -0,0 +1,225 @@
+package releasenotes
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tonbiattack/git-plus/internal/testutil"
+)
+
+// chdirRepo はテスト用リポジトリに移動し、終了時に元のディレクトリへ戻します
+func chdirRepo(t *testing.T, dir string) {
+	t.Helper()
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(oldDir) })
+
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+}
+
+// setupReleaseRepo は v1.0.0 と v1.1.0 の間にさまざまなコミットを持つリポジトリを作成します
+func setupReleaseRepo(t *testing.T) *testutil.GitRepo {
+	t.Helper()
+
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("README.md", "# Test")
+	repo.Commit("feat: 最初の機能")
+	repo.CreateTag("v1.0.0", "Release v1.0.0")
+	mainBranch := repo.CurrentBranch()
+
+	repo.CreateFile("search.go", "package main")
+	repo.Commit("feat(search): 全文検索を追加 (#12)")
+	repo.CreateFile("README.md", "# Test\n")
+	repo.Commit("docs: README を更新")
+
+	// PR のマージコミットで取り込まれたコミット
+	repo.CreateAndCheckoutBranch("fix-timeout")
+	repo.CreateFile("client.go", "package main")
+	repo.Commit("fix(api): タイムアウトを修正")
+	repo.CreateFile("cache.go", "package main")
+	repo.Commit("perf: キャッシュを追加")
+	repo.CheckoutBranch(mainBranch)
+	repo.MustGit("merge", "--no-ff", "fix-timeout", "-m", "Merge pull request #15 from owner/fix-timeout")
+
+	repo.CreateFile("config.go", "package main")
+	repo.Commit("refactor!: 設定形式を変更\n\nBREAKING CHANGE: config.yml は使えなくなりました")
+	repo.CreateTag("v1.1.0", "Release v1.1.0")
+	return repo
+}
+
+// TestCollect はコミットの分類と PR 番号の取得をテストします
+func TestCollect(t *testing.T) {
+	repo := setupReleaseRepo(t)
+	chdirRepo(t, repo.Dir)
+
+	notes, err := Collect("v1.0.0", "v1.1.0")
+	if err != nil {
+		t.Fatalf("Collect returned error: %v", err)
+	}
+
+	if notes.Version != "v1.1.0" || notes.PreviousTag != "v1.0.0" {
+		t.Errorf("Version = %q, PreviousTag = %q", notes.Version, notes.PreviousTag)
+	}
+	if notes.Date == "" {
+		t.Error("Date should be set")
+	}
+	if notes.Commits != 6 {
+		t.Errorf("Commits = %d, want 6", notes.Commits)
+	}
+
+	if len(notes.Breaking) != 1 || notes.Breaking[0].BreakingNote != "config.yml は使えなくなりました" {
+		t.Errorf("Breaking = %+v", notes.Breaking)
+	}
+
+	var titles []string
+	for _, s := range notes.Sections {
+		titles = append(titles, s.Type)
+	}
+	if !reflect.DeepEqual(titles, []string{"feat", "fix", "perf"}) {
+		t.Errorf("sections = %v, want [feat fix perf]", titles)
+	}
+
+	feat := notes.Sections[0].Entries
+	if len(feat) != 1 || feat[0].Description != "全文検索を追加" || feat[0].Scope != "search" || !reflect.DeepEqual(feat[0].PRs, []int{12}) {
+		t.Errorf("feat entries = %+v", feat)
+	}
+	fix := notes.Sections[1].Entries
+	if len(fix) != 1 || !reflect.DeepEqual(fix[0].PRs, []int{15}) {
+		t.Errorf("fix entries = %+v", fix)
+	}
+	perf := notes.Sections[2].Entries
+	if len(perf) != 1 || !reflect.DeepEqual(perf[0].PRs, []int{15}) {
+		t.Errorf("perf entries = %+v", perf)
+	}
+}
+
+// TestGenerate は比較元のタグを自動で決定して Markdown を生成できることを確認します
+func TestGenerate(t *testing.T) {
+	repo := setupReleaseRepo(t)
+	chdirRepo(t, repo.Dir)
+
+	notes, body, err := Generate("", "v1.1.0", "")
+	if err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	if notes.PreviousTag != "v1.0.0" {
+		t.Errorf("PreviousTag = %q, want v1.0.0", notes.PreviousTag)
+	}
+
+	for _, want := range []string{
+		"## v1.1.0 (",
+		"### ⚠ 破壊的変更",
+		"- config.yml は使えなくなりました (",
+		"### 新機能",
+		"- **search:** 全文検索を追加 (#12) (",
+		"### バグ修正",
+		"- **api:** タイムアウトを修正 (#15) (",
+		"### パフォーマンス改善",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body should contain %q:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "README") {
+		t.Errorf("body should not contain docs commits:\n%s", body)
+	}
+}
+
+// TestRender_NoEntries は対象のコミットがない場合の標準テンプレートの出力をテストします
+func TestRender_NoEntries(t *testing.T) {
+	body, err := Render(&Notes{Version: "v1.0.1", Date: "2026-01-02"}, DefaultTemplate)
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	want := "## v1.0.1 (2026-01-02)\n\n特筆すべき変更はありません。\n"
+	if body != want {
+		t.Errorf("body = %q, want %q", body, want)
+	}
+}
+
+// TestRender_CustomTemplate はカスタムテンプレートを使用できることを確認します
+func TestRender_CustomTemplate(t *testing.T) {
+	notes := &Notes{
+		Version: "v2.0.0",
+		Sections: []Section{
+			{Type: "feat", Title: "新機能", Entries: []Entry{{Hash: "0123456789abcdef", Description: "A", PRs: []int{1, 2}}}},
+		},
+	}
+	tmpl := "# {{.Version}}\n{{range .Sections}}{{range .Entries}}* {{.Description}} {{.ShortHash}}{{range .PRs}} #{{.}}{{end}}\n{{end}}{{end}}"
+
+	body, err := Render(notes, tmpl)
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if body != "# v2.0.0\n* A 0123456 #1 #2\n" {
+		t.Errorf("body = %q", body)
+	}
+
+	if _, err := Render(notes, "{{.Unknown"); err == nil {
+		t.Error("Render with invalid template should return error")
+	}
+}
+
+// TestLoadTemplate はテンプレートの読み込み元の優先順位をテストします
+func TestLoadTemplate(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	chdirRepo(t, repo.Dir)
+
+	got, err := LoadTemplate("")
+	if err != nil || got != DefaultTemplate {
+		t.Errorf("LoadTemplate without config = %q, %v; want DefaultTemplate", got, err)
+	}
+
+	repo.CreateFile(".github/release-notes.tmpl", "config {{.Version}}")
+	repo.MustGit("config", TemplateConfigKey, ".github/release-notes.tmpl")
+	chdirRepo(t, repo.Path(".github"))
+	if got, err := LoadTemplate(""); err != nil || got != "config {{.Version}}" {
+		t.Errorf("LoadTemplate with config = %q, %v", got, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "custom.tmpl")
+	if err := os.WriteFile(path, []byte("flag"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := LoadTemplate(path); err != nil || got != "flag" {
+		t.Errorf("LoadTemplate(path) = %q, %v", got, err)
+	}
+
+	if _, err := LoadTemplate(filepath.Join(t.TempDir(), "missing.tmpl")); err == nil {
+		t.Error("LoadTemplate with missing file should return error")
+	}
+}
+
+// TestPreviousTag は1つ前のリリースタグの決定をテストします
+func TestPreviousTag(t *testing.T) {
+	tags := []string{"v1.0.0", "v1.2.0", "v1.3.0-rc.1", "v1.3.0-rc.2", "v1.3.0", "v1.10.0", "api/v1.2.5", "api/v1.3.0", "latest"}
+
+	tests := []struct {
+		tag      string
+		expected string
+	}{
+		{tag: "v1.3.0", expected: "v1.2.0"},
+		{tag: "v1.3.0-rc.2", expected: "v1.3.0-rc.1"},
+		{tag: "v1.3.0-rc.1", expected: "v1.2.0"},
+		{tag: "v1.10.0", expected: "v1.3.0"},
+		{tag: "v1.0.0", expected: ""},
+		{tag: "api/v1.3.0", expected: "api/v1.2.5"},
+		{tag: "latest", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			if got := PreviousTag(tt.tag, tags); got != tt.expected {
+				t.Errorf("PreviousTag(%q) = %q, want %q", tt.tag, got, tt.expected)
+			}
+		})
+	}
+}
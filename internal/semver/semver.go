@@ -0,0 +1,373 @@
+// ================================================================================
+// Package semver - セマンティックバージョニング 2.0 パーサー
+// ================================================================================
+// このパッケージは、SemVer 2.0.0 (https://semver.org/) に従ってバージョン文字列を
+// 解析・比較・更新するための共通ユーティリティを提供します。
+//
+// 提供する機能:
+// - Parse(): タグ名（v プレフィックス可）を解析して Version 構造体に変換
+// - ParseTag(): api/v1.2.0 のような名前空間付きのタグを解析
+// - SplitTag(): タグ名をプレフィックスとバージョンに分割
+// - Compare(): 2つのバージョンの優先順位を比較
+// - CompareTags(): SemVer でないタグも含めてタグ名を比較
+// - Bump(): major/minor/patch やプレリリース用のタイプで次のバージョンを計算
+//
+// 使用目的:
+// new-tag（次のタグの計算）、tag-checkout（タグの並べ替え）、
+// tag-diff-all（プレフィックス付きタグの並べ替え）で同じ判定を共有し、
+// v1.4.0-rc.1 や 1.2.3+build.5 のようなタグを正しく扱います。
+//
+// 優先順位の規則:
+//
+//	1.0.0-alpha < 1.0.0-alpha.1 < 1.0.0-beta < 1.0.0-rc.1 < 1.0.0
+//	ビルドメタデータ（+ 以降）は優先順位に影響しません。
+//
+// ================================================================================
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultPreid はプレリリース識別子が指定されていない場合に使用する識別子です。
+const DefaultPreid = "rc"
+
+// Version は解析済みのセマンティックバージョンを表す構造体です。
+type Version struct {
+	Major      int      // メジャーバージョン
+	Minor      int      // マイナーバージョン
+	Patch      int      // パッチバージョン
+	Prerelease []string // プレリリース識別子（例: ["rc", "1"]）
+	Build      []string // ビルドメタデータ（例: ["build", "5"]）
+}
+
+var (
+	versionPattern    = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-([0-9A-Za-z.-]+))?(?:\+([0-9A-Za-z.-]+))?$`)
+	identifierPattern = regexp.MustCompile(`^[0-9A-Za-z-]+$`)
+)
+
+// Parse はバージョン文字列を解析して Version を返します。
+//
+// パラメータ:
+//   - s: バージョン文字列（例: v1.4.0-rc.1、1.2.3+build.5）
+//
+// 戻り値:
+//   - Version: 解析結果
+//   - error: SemVer 2.0 の形式でない場合のエラー
+//
+// 内部処理:
+//
+//	先頭の v は省略可能です。数値部分の先頭ゼロ（01 など）、空の識別子、
+//	数値のみのプレリリース識別子の先頭ゼロは仕様に従い無効とします。
+func Parse(s string) (Version, error) {
+	matches := versionPattern.FindStringSubmatch(s)
+	if matches == nil {
+		return Version{}, fmt.Errorf("無効なバージョン形式: %s", s)
+	}
+
+	var v Version
+	var err error
+	if v.Major, err = strconv.Atoi(matches[1]); err != nil {
+		return Version{}, fmt.Errorf("無効なバージョン形式: %s", s)
+	}
+	if v.Minor, err = strconv.Atoi(matches[2]); err != nil {
+		return Version{}, fmt.Errorf("無効なバージョン形式: %s", s)
+	}
+	if v.Patch, err = strconv.Atoi(matches[3]); err != nil {
+		return Version{}, fmt.Errorf("無効なバージョン形式: %s", s)
+	}
+
+	if matches[4] != "" {
+		v.Prerelease = strings.Split(matches[4], ".")
+		for _, id := range v.Prerelease {
+			if !isValidIdentifier(id) || (isNumeric(id) && len(id) > 1 && id[0] == '0') {
+				return Version{}, fmt.Errorf("無効なプレリリース識別子: %s", s)
+			}
+		}
+	}
+	if matches[5] != "" {
+		v.Build = strings.Split(matches[5], ".")
+		for _, id := range v.Build {
+			if !isValidIdentifier(id) {
+				return Version{}, fmt.Errorf("無効なビルドメタデータ: %s", s)
+			}
+		}
+	}
+
+	return v, nil
+}
+
+// ParseTag はプレフィックス付きのタグ名を解析します。
+//
+// パラメータ:
+//   - tag: タグ名（例: api/v1.2.0）
+//   - prefix: タグの名前空間を表すプレフィックス（例: api/）。空の場合は Parse と同じ
+//
+// 戻り値:
+//   - Version: プレフィックスを除いた部分の解析結果
+//   - error: プレフィックスが一致しない場合や SemVer 2.0 の形式でない場合のエラー
+func ParseTag(tag, prefix string) (Version, error) {
+	if !strings.HasPrefix(tag, prefix) {
+		return Version{}, fmt.Errorf("タグ %s はプレフィックス %s で始まっていません", tag, prefix)
+	}
+	return Parse(strings.TrimPrefix(tag, prefix))
+}
+
+// SplitTag はタグ名をプレフィックスとバージョンに分割します。
+//
+// パラメータ:
+//   - tag: タグ名（例: v1.2.0、api/v1.2.0、web-1.0.0-rc.1）
+//
+// 戻り値:
+//   - string: プレフィックス（例: ""、"api/"、"web-"）
+//   - Version: 解析したバージョン
+//   - error: タグのどの位置からも SemVer として解析できない場合のエラー
+//
+// 内部処理:
+//
+//	先頭から順に区切り位置を試し、残りが SemVer として解析できる最も短い
+//	プレフィックスを採用します。
+func SplitTag(tag string) (string, Version, error) {
+	for i := 0; i < len(tag); i++ {
+		if v, err := Parse(tag[i:]); err == nil {
+			return tag[:i], v, nil
+		}
+	}
+	return "", Version{}, fmt.Errorf("無効なバージョン形式: %s", tag)
+}
+
+// String はバージョンを v なしの文字列（例: 1.4.0-rc.1+build.5）で返します。
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Prerelease) > 0 {
+		s += "-" + strings.Join(v.Prerelease, ".")
+	}
+	if len(v.Build) > 0 {
+		s += "+" + strings.Join(v.Build, ".")
+	}
+	return s
+}
+
+// IsPrerelease はプレリリース版かどうかを返します。
+func (v Version) IsPrerelease() bool {
+	return len(v.Prerelease) > 0
+}
+
+// Compare は2つのバージョンの優先順位を比較します。
+//
+// 戻り値:
+//   - int: a < b なら -1、a == b なら 0、a > b なら 1
+//
+// 比較規則:
+//  1. major/minor/patch を数値として比較
+//  2. プレリリースなしはプレリリースありより大きい
+//  3. プレリリース識別子を先頭から比較（数値同士は数値比較、数値は英数字より小さい、
+//     英数字同士は ASCII 順）。すべて等しければ識別子が多い方が大きい
+//  4. ビルドメタデータは無視
+func Compare(a, b Version) int {
+	if c := compareInt(a.Major, b.Major); c != 0 {
+		return c
+	}
+	if c := compareInt(a.Minor, b.Minor); c != 0 {
+		return c
+	}
+	if c := compareInt(a.Patch, b.Patch); c != 0 {
+		return c
+	}
+
+	switch {
+	case len(a.Prerelease) == 0 && len(b.Prerelease) == 0:
+		return 0
+	case len(a.Prerelease) == 0:
+		return 1
+	case len(b.Prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(a.Prerelease) && i < len(b.Prerelease); i++ {
+		if c := compareIdentifier(a.Prerelease[i], b.Prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInt(len(a.Prerelease), len(b.Prerelease))
+}
+
+// CompareTags はタグ名をバージョンとして比較します。
+//
+// パラメータ:
+//   - a, b: 比較するタグ名
+//
+// 戻り値:
+//   - int: a < b なら -1、a == b なら 0、a > b なら 1
+//
+// 内部処理:
+//
+//	SemVer として解析できるタグは解析できないタグより大きいとみなします。
+//	優先順位が等しい場合（ビルドメタデータのみ異なる場合など）や
+//	どちらも解析できない場合はタグ名の文字列順で比較し、並び順を一意にします。
+func CompareTags(a, b string) int {
+	return CompareTagsWithPrefix(a, b, "")
+}
+
+// CompareTagsWithPrefix はプレフィックスを除いた部分をバージョンとして比較します。
+// プレフィックスで始まらないタグは解析できないタグとして扱います。
+func CompareTagsWithPrefix(a, b, prefix string) int {
+	va, errA := ParseTag(a, prefix)
+	vb, errB := ParseTag(b, prefix)
+
+	switch {
+	case errA == nil && errB == nil:
+		if c := Compare(va, vb); c != 0 {
+			return c
+		}
+	case errA == nil:
+		return 1
+	case errB == nil:
+		return -1
+	}
+	return strings.Compare(a, b)
+}
+
+// Bump は指定したタイプで次のバージョンを計算します。
+//
+// パラメータ:
+//   - v: 現在のバージョン
+//   - kind: major, minor, patch, premajor, preminor, prepatch, prerelease, release のいずれか
+//   - preid: プレリリース識別子（例: rc、beta）。空の場合は現在の識別子または DefaultPreid
+//
+// 戻り値:
+//   - Version: 次のバージョン（ビルドメタデータは引き継がない）
+//   - error: 不明なタイプ、プレリリースでないバージョンへの release、
+//     または結果が現在のバージョン以下になる場合のエラー
+//
+// ルール:
+//   - major: 1.2.3 → 2.0.0（2.0.0-rc.1 のように X.0.0 のプレリリースなら 2.0.0）
+//   - minor: 1.2.3 → 1.3.0（1.3.0-rc.1 のように X.Y.0 のプレリリースなら 1.3.0）
+//   - patch: 1.2.3 → 1.2.4（1.2.4-rc.1 のようなプレリリースなら 1.2.4）
+//   - premajor/preminor/prepatch: 1.2.3 → 2.0.0-rc.0 / 1.3.0-rc.0 / 1.2.4-rc.0
+//   - prerelease: 1.2.4-rc.0 → 1.2.4-rc.1、1.2.3 → 1.2.4-rc.0、
+//     1.2.4-beta.2 に preid=rc を指定すると 1.2.4-rc.0
+//   - release: 1.2.4-rc.1 → 1.2.4
+func Bump(v Version, kind, preid string) (Version, error) {
+	next := Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	if preid != "" && !isValidIdentifier(preid) {
+		return Version{}, fmt.Errorf("無効なプレリリース識別子: %s", preid)
+	}
+	newPrerelease := func() []string {
+		if preid == "" {
+			return []string{DefaultPreid, "0"}
+		}
+		return []string{preid, "0"}
+	}
+
+	switch kind {
+	case "major":
+		if !v.IsPrerelease() || v.Minor != 0 || v.Patch != 0 {
+			next = Version{Major: v.Major + 1}
+		}
+	case "minor":
+		if !v.IsPrerelease() || v.Patch != 0 {
+			next = Version{Major: v.Major, Minor: v.Minor + 1}
+		}
+	case "patch":
+		if !v.IsPrerelease() {
+			next.Patch++
+		}
+	case "premajor":
+		next = Version{Major: v.Major + 1, Prerelease: newPrerelease()}
+	case "preminor":
+		next = Version{Major: v.Major, Minor: v.Minor + 1, Prerelease: newPrerelease()}
+	case "prepatch":
+		next.Patch++
+		next.Prerelease = newPrerelease()
+	case "prerelease":
+		switch {
+		case !v.IsPrerelease():
+			next.Patch++
+			next.Prerelease = newPrerelease()
+		case preid == "" || v.Prerelease[0] == preid:
+			next.Prerelease = incrementPrerelease(v.Prerelease)
+		default:
+			next.Prerelease = newPrerelease()
+		}
+	case "release":
+		if !v.IsPrerelease() {
+			return Version{}, fmt.Errorf("%s はプレリリース版ではありません", v)
+		}
+	default:
+		return Version{}, fmt.Errorf("不明なバージョンタイプ: %s", kind)
+	}
+
+	if Compare(next, v) <= 0 {
+		return Version{}, fmt.Errorf("新しいバージョン %s が現在のバージョン %s 以下になります", next, v)
+	}
+	return next, nil
+}
+
+// incrementPrerelease はプレリリース識別子の最後の数値を1つ増やします。
+// 数値の識別子がない場合は末尾に 0 を追加します（rc → rc.0）。
+func incrementPrerelease(ids []string) []string {
+	next := append([]string(nil), ids...)
+	for i := len(next) - 1; i >= 0; i-- {
+		if isNumeric(next[i]) {
+			n, err := strconv.Atoi(next[i])
+			if err == nil {
+				next[i] = strconv.Itoa(n + 1)
+				return next
+			}
+		}
+	}
+	return append(next, "0")
+}
+
+// compareIdentifier はプレリリース識別子1つを比較します。
+func compareIdentifier(a, b string) int {
+	aNum, bNum := isNumeric(a), isNumeric(b)
+	switch {
+	case aNum && bNum:
+		// 桁数で比較してから文字列比較することで、大きな数値でも溢れずに比較する
+		if c := compareInt(len(a), len(b)); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	case aNum:
+		return -1
+	case bNum:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// compareInt は2つの整数を比較します。
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// isValidIdentifier は識別子が空でなく英数字とハイフンのみで構成されているかを判定します。
+func isValidIdentifier(id string) bool {
+	return identifierPattern.MatchString(id)
+}
+
+// isNumeric は識別子が数字のみで構成されているかを判定します。
+func isNumeric(id string) bool {
+	if id == "" {
+		return false
+	}
+	for _, r := range id {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,286 @@
+package semver
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// TestParse はバージョン文字列の解析をテストします
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected Version
+	}{
+		{name: "vプレフィックス付き", input: "v1.2.3", expected: Version{Major: 1, Minor: 2, Patch: 3}},
+		{name: "vプレフィックスなし", input: "1.2.3", expected: Version{Major: 1, Minor: 2, Patch: 3}},
+		{name: "プレリリース", input: "v1.4.0-rc.1", expected: Version{Major: 1, Minor: 4, Prerelease: []string{"rc", "1"}}},
+		{name: "ビルドメタデータ", input: "1.2.3+build.5", expected: Version{Major: 1, Minor: 2, Patch: 3, Build: []string{"build", "5"}}},
+		{
+			name:     "プレリリースとビルドメタデータ",
+			input:    "v1.0.0-alpha-1.beta+exp.sha.5114f85",
+			expected: Version{Major: 1, Prerelease: []string{"alpha-1", "beta"}, Build: []string{"exp", "sha", "5114f85"}},
+		},
+		{name: "ビルドメタデータの先頭ゼロは有効", input: "1.0.0+001", expected: Version{Major: 1, Build: []string{"001"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestParse_Invalid は無効なバージョン文字列でエラーになることを確認します
+func TestParse_Invalid(t *testing.T) {
+	inputs := []string{
+		"",
+		"invalid",
+		"v1.2",
+		"v1.2.3.4",
+		"v01.2.3",
+		"v1.2.3-",
+		"v1.2.3-rc..1",
+		"v1.2.3-rc.01",
+		"v1.2.3+",
+		"v1.2.3-rc_1",
+		"release-1.2.3",
+	}
+
+	for _, input := range inputs {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q) should return error", input)
+		}
+	}
+}
+
+// TestParseTag はプレフィックス付きタグの解析をテストします
+func TestParseTag(t *testing.T) {
+	tests := []struct {
+		name        string
+		tag         string
+		prefix      string
+		expected    string
+		expectError bool
+	}{
+		{name: "プレフィックスなし", tag: "v1.2.3", prefix: "", expected: "1.2.3"},
+		{name: "名前空間付き", tag: "api/v1.2.0", prefix: "api/", expected: "1.2.0"},
+		{name: "名前空間とプレリリース", tag: "web/v3.0.1-rc.2", prefix: "web/", expected: "3.0.1-rc.2"},
+		{name: "vなし", tag: "api-1.0.0", prefix: "api-", expected: "1.0.0"},
+		{name: "別の名前空間", tag: "web/v1.0.0", prefix: "api/", expectError: true},
+		{name: "プレフィックスを指定しない名前空間付きタグ", tag: "api/v1.0.0", prefix: "", expectError: true},
+		{name: "残りが SemVer でない", tag: "api/latest", prefix: "api/", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTag(tt.tag, tt.prefix)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("ParseTag(%q, %q) = %s, want error", tt.tag, tt.prefix, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTag(%q, %q) returned error: %v", tt.tag, tt.prefix, err)
+			}
+			if got.String() != tt.expected {
+				t.Errorf("ParseTag(%q, %q) = %s, want %s", tt.tag, tt.prefix, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestSplitTag はタグ名のプレフィックスとバージョンへの分割をテストします
+func TestSplitTag(t *testing.T) {
+	tests := []struct {
+		tag            string
+		expectedPrefix string
+		expected       string
+	}{
+		{tag: "v1.2.0", expectedPrefix: "", expected: "1.2.0"},
+		{tag: "api/v1.2.0", expectedPrefix: "api/", expected: "1.2.0"},
+		{tag: "web-1.0.0-rc.1", expectedPrefix: "web-", expected: "1.0.0-rc.1"},
+		{tag: "svc/v2/v1.0.0", expectedPrefix: "svc/v2/", expected: "1.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			prefix, v, err := SplitTag(tt.tag)
+			if err != nil {
+				t.Fatalf("SplitTag(%q) returned error: %v", tt.tag, err)
+			}
+			if prefix != tt.expectedPrefix || v.String() != tt.expected {
+				t.Errorf("SplitTag(%q) = %q, %s; want %q, %s", tt.tag, prefix, v, tt.expectedPrefix, tt.expected)
+			}
+		})
+	}
+
+	if _, _, err := SplitTag("latest"); err == nil {
+		t.Error("SplitTag(latest) should return error")
+	}
+}
+
+// TestVersion_String は文字列化が解析と往復できることを確認します
+func TestVersion_String(t *testing.T) {
+	for _, input := range []string{"1.2.3", "1.4.0-rc.1", "1.2.3+build.5", "1.0.0-beta.11+exp.1"} {
+		v, err := Parse(input)
+		if err != nil {
+			t.Fatalf("Parse(%q) returned error: %v", input, err)
+		}
+		if got := v.String(); got != input {
+			t.Errorf("String() = %q, want %q", got, input)
+		}
+	}
+}
+
+// TestCompare は SemVer 2.0 の優先順位をテストします
+func TestCompare(t *testing.T) {
+	// 仕様書の例（昇順）
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"2.0.0",
+		"10.0.0",
+	}
+
+	for i := 0; i < len(ordered); i++ {
+		for j := 0; j < len(ordered); j++ {
+			a, _ := Parse(ordered[i])
+			b, _ := Parse(ordered[j])
+			want := compareInt(i, j)
+			if got := Compare(a, b); got != want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", ordered[i], ordered[j], got, want)
+			}
+		}
+	}
+}
+
+// TestCompare_IgnoresBuild はビルドメタデータが優先順位に影響しないことを確認します
+func TestCompare_IgnoresBuild(t *testing.T) {
+	a, _ := Parse("1.2.3+build.5")
+	b, _ := Parse("1.2.3+build.6")
+	if got := Compare(a, b); got != 0 {
+		t.Errorf("Compare = %d, want 0", got)
+	}
+}
+
+// TestCompare_LargeNumericIdentifier は桁数の多い数値識別子を数値として比較することを確認します
+func TestCompare_LargeNumericIdentifier(t *testing.T) {
+	a, _ := Parse("1.0.0-rc.99999999999999999999")
+	b, _ := Parse("1.0.0-rc.100000000000000000000")
+	if got := Compare(a, b); got != -1 {
+		t.Errorf("Compare = %d, want -1", got)
+	}
+}
+
+// TestCompareTags は SemVer でないタグを含む並べ替えをテストします
+func TestCompareTags(t *testing.T) {
+	tags := []string{"v1.4.0", "legacy", "v1.4.0-rc.1", "v1.10.0", "v1.2.3+build.5", "v1.4.0-rc.10", "v1.4.0-rc.2", "v1.2.3", "archive"}
+	sort.Slice(tags, func(i, j int) bool {
+		return CompareTags(tags[i], tags[j]) > 0
+	})
+
+	expected := []string{"v1.10.0", "v1.4.0", "v1.4.0-rc.10", "v1.4.0-rc.2", "v1.4.0-rc.1", "v1.2.3+build.5", "v1.2.3", "legacy", "archive"}
+	if !reflect.DeepEqual(tags, expected) {
+		t.Errorf("sorted tags = %v, want %v", tags, expected)
+	}
+}
+
+// TestCompareTagsWithPrefix はプレフィックスを除いた部分で並べ替えることを確認します
+func TestCompareTagsWithPrefix(t *testing.T) {
+	tags := []string{"api/v1.10.0", "api/v1.9.0", "api/v2.0.0-rc.1", "api/notes"}
+	sort.Slice(tags, func(i, j int) bool {
+		return CompareTagsWithPrefix(tags[i], tags[j], "api/") < 0
+	})
+
+	expected := []string{"api/notes", "api/v1.9.0", "api/v1.10.0", "api/v2.0.0-rc.1"}
+	if !reflect.DeepEqual(tags, expected) {
+		t.Errorf("sorted tags = %v, want %v", tags, expected)
+	}
+}
+
+// TestBump は次のバージョンの計算をテストします
+func TestBump(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  string
+		kind     string
+		preid    string
+		expected string
+	}{
+		{name: "major", current: "1.2.3", kind: "major", expected: "2.0.0"},
+		{name: "minor", current: "1.2.3", kind: "minor", expected: "1.3.0"},
+		{name: "patch", current: "1.2.3", kind: "patch", expected: "1.2.4"},
+		{name: "ビルドメタデータは引き継がない", current: "1.2.3+build.5", kind: "patch", expected: "1.2.4"},
+		{name: "major のプレリリースから major", current: "2.0.0-rc.1", kind: "major", expected: "2.0.0"},
+		{name: "minor のプレリリースから major", current: "1.3.0-rc.1", kind: "major", expected: "2.0.0"},
+		{name: "minor のプレリリースから minor", current: "1.3.0-rc.1", kind: "minor", expected: "1.3.0"},
+		{name: "patch のプレリリースから minor", current: "1.2.4-rc.1", kind: "minor", expected: "1.3.0"},
+		{name: "patch のプレリリースから patch", current: "1.2.4-rc.1", kind: "patch", expected: "1.2.4"},
+		{name: "premajor", current: "1.2.3", kind: "premajor", expected: "2.0.0-rc.0"},
+		{name: "preminor", current: "1.2.3", kind: "preminor", preid: "beta", expected: "1.3.0-beta.0"},
+		{name: "prepatch", current: "1.2.3", kind: "prepatch", expected: "1.2.4-rc.0"},
+		{name: "prerelease 通常版から", current: "1.2.3", kind: "prerelease", expected: "1.2.4-rc.0"},
+		{name: "prerelease 番号を増やす", current: "1.4.0-rc.1", kind: "prerelease", expected: "1.4.0-rc.2"},
+		{name: "prerelease 同じ preid", current: "1.4.0-rc.9", kind: "prerelease", preid: "rc", expected: "1.4.0-rc.10"},
+		{name: "prerelease preid を変更", current: "1.4.0-beta.3", kind: "prerelease", preid: "rc", expected: "1.4.0-rc.0"},
+		{name: "prerelease 数値なし", current: "1.4.0-rc", kind: "prerelease", expected: "1.4.0-rc.0"},
+		{name: "release", current: "1.4.0-rc.1+build.7", kind: "release", expected: "1.4.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, err := Parse(tt.current)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.current, err)
+			}
+			got, err := Bump(current, tt.kind, tt.preid)
+			if err != nil {
+				t.Fatalf("Bump(%s, %s, %q) returned error: %v", tt.current, tt.kind, tt.preid, err)
+			}
+			if got.String() != tt.expected {
+				t.Errorf("Bump(%s, %s, %q) = %s, want %s", tt.current, tt.kind, tt.preid, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestBump_Errors は計算できない組み合わせでエラーになることを確認します
+func TestBump_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		kind    string
+		preid   string
+	}{
+		{name: "通常版への release", current: "1.2.3", kind: "release"},
+		{name: "不明なタイプ", current: "1.2.3", kind: "unknown"},
+		{name: "無効な preid", current: "1.2.3", kind: "prerelease", preid: "rc_1"},
+		{name: "優先順位が下がる preid", current: "1.4.0-rc.1", kind: "prerelease", preid: "beta"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, _ := Parse(tt.current)
+			if got, err := Bump(current, tt.kind, tt.preid); err == nil {
+				t.Errorf("Bump(%s, %s, %q) = %s, want error", tt.current, tt.kind, tt.preid, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,355 @@
+// ================================================================================
+// Package stashbundle - スタッシュの持ち運び用アーカイブ
+// ================================================================================
+// このパッケージは、スタッシュを別のマシンや他の開発者に渡すための
+// アーカイブ（JSON ファイル）の作成と、アーカイブからのスタッシュの復元を提供します。
+//
+// 提供する機能:
+// - Collect(): スタッシュを、差分とメタデータを含むエントリに変換
+// - Write() / Read(): アーカイブファイルの書き込みと読み込み
+// - Restore(): エントリから本物のスタッシュ（git stash list に表示されるもの）を再作成
+// - BaseExists(): エントリのベースコミットがローカルに存在するか確認
+//
+// アーカイブの内容:
+// スタッシュはコミットの組（作業ツリー・インデックス・untracked ファイル）として
+// 保存されていますが、ベースコミットが相手のリポジトリにあるとは限らないため、
+// git bundle ではなく、ベースコミットからの差分（git diff --binary）として保存します。
+//   - IndexPatch:     ベースコミット → インデックス（stash^2）
+//   - WorktreePatch:  ベースコミット → 作業ツリー（stash）
+//   - UntrackedPatch: 空のツリー → untracked ファイル（stash^3、ある場合のみ）
+//
+// 復元時は、一時的なインデックスファイル上でベースコミットのツリーに差分を適用して
+// 同じ構造のスタッシュコミットを作成し、git stash store で一覧に追加します。
+// ================================================================================
+package stashbundle
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tonbiattack/git-plus/internal/gitcmd"
+)
+
+// FormatVersion はアーカイブの形式のバージョンです。
+const FormatVersion = 1
+
+// Bundle はアーカイブファイル全体を表す構造体です。
+type Bundle struct {
+	Version  int       `json:"version"`
+	Exported time.Time `json:"exported"`
+	Stashes  []Entry   `json:"stashes"` // stash@{0} に近いもの（新しいもの）から順
+}
+
+// Entry はスタッシュ1件を表す構造体です。
+type Entry struct {
+	Message          string    `json:"message"`                     // スタッシュのメッセージ（例: On main: 作業中）
+	Commit           string    `json:"commit"`                      // エクスポート元のスタッシュのコミットハッシュ
+	Base             string    `json:"base"`                        // ベースコミット（stash^1）のハッシュ
+	BaseSubject      string    `json:"base_subject"`                // ベースコミットの件名（ベースが見つからない場合の手がかり）
+	Created          time.Time `json:"created"`                     // スタッシュの作成日時
+	IndexMessage     string    `json:"index_message"`               // インデックスのコミットのメッセージ
+	IndexPatch       string    `json:"index_patch"`                 // ベースコミット → インデックスの差分
+	WorktreePatch    string    `json:"worktree_patch"`              // ベースコミット → 作業ツリーの差分
+	UntrackedMessage string    `json:"untracked_message,omitempty"` // untracked ファイルのコミットのメッセージ
+	UntrackedPatch   string    `json:"untracked_patch,omitempty"`   // 空のツリー → untracked ファイルの差分
+}
+
+// Collect はスタッシュをアーカイブ用のエントリに変換します。
+//
+// パラメータ:
+//   - ref: スタッシュの参照（例: stash@{0}、コミットハッシュ）
+//
+// 戻り値:
+//   - Entry: 差分とメタデータを含むエントリ
+//   - error: スタッシュでない場合や git コマンドの実行に失敗した場合のエラー
+func Collect(ref string) (Entry, error) {
+	commit, err := revParse(ref)
+	if err != nil {
+		return Entry{}, fmt.Errorf("%s が見つかりません: %w", ref, err)
+	}
+	if gitcmd.RunQuiet("rev-parse", "--verify", "--quiet", commit+"^2") != nil {
+		return Entry{}, fmt.Errorf("%s はスタッシュではありません", ref)
+	}
+
+	output, err := gitcmd.Run("log", "-1", "--format=%P%x00%ct%x00%s", commit)
+	if err != nil {
+		return Entry{}, fmt.Errorf("スタッシュ情報の取得に失敗しました: %w", err)
+	}
+	fields := strings.SplitN(strings.TrimRight(string(output), "\n"), "\x00", 3)
+	if len(fields) != 3 {
+		return Entry{}, fmt.Errorf("スタッシュ情報の解析に失敗しました: %s", ref)
+	}
+	parents := strings.Fields(fields[0])
+	created, _ := strconv.ParseInt(fields[1], 10, 64)
+
+	entry := Entry{
+		Message: fields[2],
+		Commit:  commit,
+		Base:    parents[0],
+		Created: time.Unix(created, 0),
+	}
+
+	if entry.BaseSubject, err = subject(entry.Base); err != nil {
+		return Entry{}, err
+	}
+	if entry.IndexMessage, err = subject(parents[1]); err != nil {
+		return Entry{}, err
+	}
+	if entry.IndexPatch, err = diff(entry.Base, parents[1]); err != nil {
+		return Entry{}, err
+	}
+	if entry.WorktreePatch, err = diff(entry.Base, commit); err != nil {
+		return Entry{}, err
+	}
+
+	if len(parents) >= 3 {
+		empty, err := emptyTree()
+		if err != nil {
+			return Entry{}, err
+		}
+		if entry.UntrackedMessage, err = subject(parents[2]); err != nil {
+			return Entry{}, err
+		}
+		if entry.UntrackedPatch, err = diff(empty, parents[2]); err != nil {
+			return Entry{}, err
+		}
+	}
+
+	return entry, nil
+}
+
+// Write はアーカイブをファイルに書き込みます。
+func Write(path string, stashes []Entry) error {
+	bundle := Bundle{
+		Version:  FormatVersion,
+		Exported: time.Now(),
+		Stashes:  stashes,
+	}
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return fmt.Errorf("アーカイブの作成に失敗しました: %w", err)
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("ディレクトリの作成に失敗しました: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("アーカイブの書き込みに失敗しました: %w", err)
+	}
+	return nil
+}
+
+// Read はアーカイブファイルを読み込みます。
+// 形式のバージョンが新しすぎる場合はエラーを返します。
+func Read(path string) (*Bundle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("アーカイブの読み込みに失敗しました: %w", err)
+	}
+	var bundle Bundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("アーカイブの解析に失敗しました: %w", err)
+	}
+	if bundle.Version < 1 || bundle.Version > FormatVersion {
+		return nil, fmt.Errorf("対応していないアーカイブの形式です（バージョン %d）", bundle.Version)
+	}
+	return &bundle, nil
+}
+
+// BaseExists はエントリのベースコミットがローカルリポジトリに存在するか確認します。
+func BaseExists(entry Entry) bool {
+	return entry.Base != "" && gitcmd.RunQuiet("cat-file", "-e", entry.Base+"^{commit}") == nil
+}
+
+// Restore はエントリからスタッシュを再作成し、git stash list に追加します。
+//
+// パラメータ:
+//   - entry: 復元するエントリ
+//   - base: ベースにするコミット（空の場合はエントリのベースコミット）。
+//     ベースコミットが存在しない場合に、代わりのコミットを指定するために使用します
+//
+// 戻り値:
+//   - string: 作成したスタッシュのコミットハッシュ
+//   - error: 差分を適用できない場合などのエラー
+//
+// 内部処理:
+//  1. 一時的なインデックスファイルにベースのツリーを読み込み、差分を適用して
+//     インデックス・作業ツリー・untracked ファイルのツリーを作成
+//  2. git stash と同じ親子関係でコミットを作成（作成日時はエクスポート元と同じ）
+//  3. git stash store -m <メッセージ> で一覧に追加
+func Restore(entry Entry, base string) (string, error) {
+	if base == "" {
+		base = entry.Base
+	}
+	base, err := revParse(base + "^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("ベースコミット %s が見つかりません: %w", entry.Base, err)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "git-plus-stash-import-")
+	if err != nil {
+		return "", fmt.Errorf("一時ディレクトリの作成に失敗しました: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	root, err := gitcmd.Run("rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", fmt.Errorf("リポジトリのルートの取得に失敗しました: %w", err)
+	}
+
+	r := restorer{
+		dir: strings.TrimSpace(string(root)),
+		env: append(os.Environ(),
+			"GIT_INDEX_FILE="+filepath.Join(tmpDir, "index"),
+			"GIT_AUTHOR_DATE="+dateEnv(entry.Created),
+			"GIT_COMMITTER_DATE="+dateEnv(entry.Created),
+		),
+	}
+
+	indexTree, err := r.buildTree(base, entry.IndexPatch)
+	if err != nil {
+		return "", fmt.Errorf("インデックスの差分を適用できません: %w", err)
+	}
+	worktreeTree, err := r.buildTree(base, entry.WorktreePatch)
+	if err != nil {
+		return "", fmt.Errorf("作業ツリーの差分を適用できません: %w", err)
+	}
+
+	indexCommit, err := r.commitTree(indexTree, entry.IndexMessage, base)
+	if err != nil {
+		return "", err
+	}
+	parents := []string{base, indexCommit}
+
+	if entry.UntrackedPatch != "" {
+		untrackedTree, err := r.buildTree("", entry.UntrackedPatch)
+		if err != nil {
+			return "", fmt.Errorf("untracked ファイルの差分を適用できません: %w", err)
+		}
+		untrackedCommit, err := r.commitTree(untrackedTree, entry.UntrackedMessage)
+		if err != nil {
+			return "", err
+		}
+		parents = append(parents, untrackedCommit)
+	}
+
+	commit, err := r.commitTree(worktreeTree, entry.Message, parents...)
+	if err != nil {
+		return "", err
+	}
+
+	if err := gitcmd.RunQuiet("stash", "store", "-m", entry.Message, commit); err != nil {
+		return "", fmt.Errorf("スタッシュの登録に失敗しました: %w", err)
+	}
+	return commit, nil
+}
+
+// restorer は一時的なインデックスファイルと作成日時を環境変数で指定して git を実行します。
+// git apply はサブディレクトリで実行するとディレクトリ外の変更を無視するため、
+// リポジトリのルートで実行します。
+type restorer struct {
+	dir string
+	env []string
+}
+
+// buildTree は base のツリー（空の場合は空のツリー）に差分を適用したツリーを作成します。
+func (r restorer) buildTree(base, patch string) (string, error) {
+	if base == "" {
+		if _, err := r.run(nil, "read-tree", "--empty"); err != nil {
+			return "", err
+		}
+	} else if _, err := r.run(nil, "read-tree", base); err != nil {
+		return "", err
+	}
+	if patch != "" {
+		if _, err := r.run([]byte(patch), "apply", "--cached"); err != nil {
+			return "", err
+		}
+	}
+	return r.run(nil, "write-tree")
+}
+
+// commitTree はツリーと親コミットからコミットを作成します。
+func (r restorer) commitTree(tree, message string, parents ...string) (string, error) {
+	args := []string{"commit-tree", tree, "-m", message}
+	for _, p := range parents {
+		args = append(args, "-p", p)
+	}
+	commit, err := r.run(nil, args...)
+	if err != nil {
+		return "", fmt.Errorf("コミットの作成に失敗しました: %w", err)
+	}
+	return commit, nil
+}
+
+// run は git コマンドを実行し、標準出力を前後の空白を除いて返します。
+func (r restorer) run(stdin []byte, args ...string) (string, error) {
+	c := exec.Command("git", args...)
+	c.Dir = r.dir
+	c.Env = r.env
+	if stdin != nil {
+		c.Stdin = bytes.NewReader(stdin)
+	}
+	var stderr bytes.Buffer
+	c.Stderr = &stderr
+	output, err := c.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// dateEnv は GIT_AUTHOR_DATE / GIT_COMMITTER_DATE に指定する日時の文字列を返します。
+func dateEnv(t time.Time) string {
+	if t.IsZero() {
+		t = time.Now()
+	}
+	return fmt.Sprintf("@%d %s", t.Unix(), t.Format("-0700"))
+}
+
+// diff は2つのツリー間の差分（バイナリを含む）を返します。
+func diff(from, to string) (string, error) {
+	output, err := gitcmd.Run("diff", "--binary", "--full-index", "--no-renames", "--no-color", "--no-ext-diff", "--src-prefix=a/", "--dst-prefix=b/", from, to)
+	if err != nil {
+		return "", fmt.Errorf("差分の取得に失敗しました: %w", err)
+	}
+	return string(output), nil
+}
+
+// subject はコミットの件名を返します。
+func subject(commit string) (string, error) {
+	output, err := gitcmd.Run("log", "-1", "--format=%s", commit)
+	if err != nil {
+		return "", fmt.Errorf("コミット %s の取得に失敗しました: %w", commit, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// emptyTree は空のツリーのハッシュを返します（SHA-1 / SHA-256 の両方に対応）。
+func emptyTree() (string, error) {
+	output, err := gitcmd.Run("hash-object", "-t", "tree", os.DevNull)
+	if err != nil {
+		return "", fmt.Errorf("空のツリーの取得に失敗しました: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// revParse は参照をコミットハッシュに解決します。
+func revParse(ref string) (string, error) {
+	output, err := gitcmd.Run("rev-parse", "--verify", "--quiet", ref)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
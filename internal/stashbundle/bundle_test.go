@@ -0,0 +1,224 @@
+package stashbundle
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tonbiattack/git-plus/internal/testutil"
+)
+
+// chdirRepo はテスト用リポジトリに移動し、終了時に元のディレクトリへ戻します
+func chdirRepo(t *testing.T, dir string) {
+	t.Helper()
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(oldDir) })
+
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+}
+
+// setupStashRepo はステージ済み・未ステージ・untracked の変更を含むスタッシュを作成します
+func setupStashRepo(t *testing.T) *testutil.GitRepo {
+	t.Helper()
+
+	repo := testutil.NewGitRepo(t)
+	repo.CreateFile("a.txt", "a1\n")
+	repo.CreateFile("dir/b.txt", "b1\n")
+	repo.Commit("Initial commit")
+
+	repo.CreateFile("a.txt", "a-staged\n")
+	repo.MustGit("add", "a.txt")
+	repo.CreateFile("a.txt", "a-worktree\n")
+	repo.CreateFile("dir/b.txt", "b2\n")
+	repo.CreateFile("dir/new.txt", "new\n")
+	repo.MustGit("stash", "push", "-u", "-m", "portable work")
+	return repo
+}
+
+// treeOf はコミットのツリーのハッシュを返します
+func treeOf(t *testing.T, repo *testutil.GitRepo, rev string) string {
+	t.Helper()
+	return strings.TrimSpace(repo.MustGit("rev-parse", rev+"^{tree}"))
+}
+
+// TestCollect はスタッシュからエントリを作成できることを確認します
+func TestCollect(t *testing.T) {
+	repo := setupStashRepo(t)
+	chdirRepo(t, repo.Dir)
+
+	entry, err := Collect("stash@{0}")
+	if err != nil {
+		t.Fatalf("Collect returned error: %v", err)
+	}
+
+	if !strings.HasSuffix(entry.Message, ": portable work") {
+		t.Errorf("Message = %q", entry.Message)
+	}
+	if entry.Base != strings.TrimSpace(repo.MustGit("rev-parse", "HEAD")) {
+		t.Errorf("Base = %q, want HEAD", entry.Base)
+	}
+	if entry.BaseSubject != "Initial commit" {
+		t.Errorf("BaseSubject = %q, want %q", entry.BaseSubject, "Initial commit")
+	}
+	if !strings.Contains(entry.IndexPatch, "+a-staged") {
+		t.Errorf("IndexPatch should contain staged change:\n%s", entry.IndexPatch)
+	}
+	if !strings.Contains(entry.WorktreePatch, "+a-worktree") || !strings.Contains(entry.WorktreePatch, "+b2") {
+		t.Errorf("WorktreePatch should contain worktree changes:\n%s", entry.WorktreePatch)
+	}
+	if !strings.Contains(entry.UntrackedPatch, "dir/new.txt") {
+		t.Errorf("UntrackedPatch should contain untracked file:\n%s", entry.UntrackedPatch)
+	}
+	if entry.Created.IsZero() {
+		t.Error("Created should be set")
+	}
+}
+
+// TestCollect_NotStash はスタッシュ以外のコミットを指定した場合にエラーになることを確認します
+func TestCollect_NotStash(t *testing.T) {
+	repo := setupStashRepo(t)
+	chdirRepo(t, repo.Dir)
+
+	if _, err := Collect("HEAD"); err == nil {
+		t.Error("Collect(HEAD) should return error")
+	}
+	if _, err := Collect("stash@{5}"); err == nil {
+		t.Error("Collect(stash@{5}) should return error")
+	}
+}
+
+// TestRestore はエントリから同じ内容のスタッシュを再作成できることを確認します
+func TestRestore(t *testing.T) {
+	repo := setupStashRepo(t)
+	chdirRepo(t, repo.Path("dir"))
+
+	entry, err := Collect("stash@{0}")
+	if err != nil {
+		t.Fatalf("Collect returned error: %v", err)
+	}
+	original := strings.TrimSpace(repo.MustGit("rev-parse", "stash@{0}"))
+	repo.MustGit("stash", "drop")
+
+	commit, err := Restore(entry, "")
+	if err != nil {
+		t.Fatalf("Restore returned error: %v", err)
+	}
+
+	if got := strings.TrimSpace(repo.MustGit("rev-parse", "stash@{0}")); got != commit {
+		t.Errorf("stash@{0} = %s, want %s", got, commit)
+	}
+	for _, rev := range []string{"", "^1", "^2", "^3"} {
+		if treeOf(t, repo, commit+rev) != treeOf(t, repo, original+rev) {
+			t.Errorf("tree of stash%s differs from original", rev)
+		}
+	}
+	if got := strings.TrimSpace(repo.MustGit("log", "-1", "--format=%s", "stash@{0}")); got != entry.Message {
+		t.Errorf("message = %q, want %q", got, entry.Message)
+	}
+	if got := strings.TrimSpace(repo.MustGit("log", "-1", "--format=%ct", "stash@{0}")); got != strings.TrimSpace(repo.MustGit("log", "-1", "--format=%ct", original)) {
+		t.Errorf("created time should be preserved, got %s", got)
+	}
+
+	// 再作成したスタッシュを --index 付きで適用できる
+	repo.MustGit("stash", "pop", "--index")
+	if got := repo.ReadFile("a.txt"); got != "a-worktree\n" {
+		t.Errorf("a.txt = %q, want %q", got, "a-worktree\n")
+	}
+	if got := repo.MustGit("show", ":a.txt"); got != "a-staged\n" {
+		t.Errorf("staged a.txt = %q, want %q", got, "a-staged\n")
+	}
+	if got := repo.ReadFile("dir/new.txt"); got != "new\n" {
+		t.Errorf("dir/new.txt = %q, want %q", got, "new\n")
+	}
+}
+
+// TestRestore_OtherRepository はベースコミットがない別のリポジトリに代わりのベースで復元できることを確認します
+func TestRestore_OtherRepository(t *testing.T) {
+	repo := setupStashRepo(t)
+	chdirRepo(t, repo.Dir)
+
+	entry, err := Collect("stash@{0}")
+	if err != nil {
+		t.Fatalf("Collect returned error: %v", err)
+	}
+
+	other := testutil.NewGitRepo(t)
+	other.CreateFile("a.txt", "a1\n")
+	other.CreateFile("dir/b.txt", "b1\n")
+	other.Commit("Same content, different history")
+	chdirRepo(t, other.Dir)
+
+	if BaseExists(entry) {
+		t.Fatal("base commit should not exist in other repository")
+	}
+	if _, err := Restore(entry, ""); err == nil {
+		t.Error("Restore without base should fail when base is missing")
+	}
+
+	if _, err := Restore(entry, "HEAD"); err != nil {
+		t.Fatalf("Restore with HEAD returned error: %v", err)
+	}
+	other.MustGit("stash", "pop")
+	if got := other.ReadFile("dir/b.txt"); got != "b2\n" {
+		t.Errorf("dir/b.txt = %q, want %q", got, "b2\n")
+	}
+}
+
+// TestWriteRead はアーカイブファイルの書き込みと読み込みをテストします
+func TestWriteRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out", "stashes.json")
+	entries := []Entry{
+		{Message: "On main: one", Base: "abc", Created: time.Unix(1700000000, 0), WorktreePatch: "diff --git a/x b/x\n"},
+		{Message: "On main: two", Base: "def"},
+	}
+
+	if err := Write(path, entries); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	bundle, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+	if bundle.Version != FormatVersion {
+		t.Errorf("Version = %d, want %d", bundle.Version, FormatVersion)
+	}
+	if len(bundle.Stashes) != 2 || bundle.Stashes[0].Message != "On main: one" || bundle.Stashes[0].WorktreePatch != entries[0].WorktreePatch {
+		t.Errorf("Stashes = %+v", bundle.Stashes)
+	}
+	if !bundle.Stashes[0].Created.Equal(entries[0].Created) {
+		t.Errorf("Created = %v, want %v", bundle.Stashes[0].Created, entries[0].Created)
+	}
+}
+
+// TestRead_Invalid は不正なアーカイブファイルでエラーになることを確認します
+func TestRead_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "JSON ではない", content: "not json"},
+		{name: "バージョンなし", content: `{"stashes": []}`},
+		{name: "新しいバージョン", content: `{"version": 99, "stashes": []}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.name+".json")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Read(path); err == nil {
+				t.Errorf("Read(%q) should return error", tt.content)
+			}
+		})
+	}
+}
@@ -0,0 +1,439 @@
+// ================================================================================
+// Package versionfile - プロジェクトのファイル内のバージョン文字列の書き換え
+// ================================================================================
+// このパッケージは、VERSION・package.json・Chart.yaml・Go の const Version など、
+// リポジトリ内のファイルに書かれたバージョン番号を新しいバージョンに書き換えるための
+// 共通ユーティリティを提供します。
+//
+// 提供する機能:
+// - ParseRule(): "パス[:種類=式]" 形式のルールを解析
+// - Plan(): ルールに従って書き換え後の内容を計算（ファイルは変更しない）
+// - Apply(): 計算した内容をファイルに書き込み
+// - Change.Diff(): 変更された行の差分を表示用に生成
+//
+// ルールの形式:
+//
+//	VERSION                                   ファイル全体がバージョン
+//	package.json:json=version                 JSON のキー（. 区切りで入れ子を指定）
+//	charts/app/Chart.yaml:yaml=appVersion     YAML のキー（. 区切りで入れ子を指定）
+//	version.go:regex=Version = "([^"]+)"      正規表現の最初のグループ
+//
+// JSON と YAML は再整形せず、値の部分だけを置き換えるため、
+// インデントやキーの順序、コメントはそのまま残ります。
+// ================================================================================
+package versionfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ルールの種類
+const (
+	KindFile  = "file"  // ファイル全体がバージョン
+	KindJSON  = "json"  // JSON のキー
+	KindYAML  = "yaml"  // YAML のキー
+	KindRegex = "regex" // 正規表現の最初のグループ
+)
+
+// Rule はバージョンを書き換えるファイルと場所を表す構造体です。
+type Rule struct {
+	Path string // リポジトリのルートからの相対パス
+	Kind string // ルールの種類（KindFile など）
+	Expr string // JSON/YAML のキー、または正規表現（KindFile の場合は空文字列）
+}
+
+// Change は1つのファイルの書き換え内容を表す構造体です。
+type Change struct {
+	Path   string // リポジトリのルートからの相対パス
+	Before []byte // 書き換え前の内容
+	After  []byte // 書き換え後の内容
+}
+
+var yamlKeyPattern = regexp.MustCompile(`^(\s*)([A-Za-z0-9_.\-]+|"[^"]*"|'[^']*'):(\s*)(.*)$`)
+
+// ParseRule は "パス[:種類=式]" 形式のルールを解析します。
+//
+// パラメータ:
+//   - spec: ルール（例: package.json:json=version）
+//
+// 戻り値:
+//   - Rule: 解析結果
+//   - error: 種類が不明な場合や、式が空・不正な場合のエラー
+//
+// 内部処理:
+//
+//	最初の ":" でパスと残りに分けるため、正規表現には ":" を含められます。
+func ParseRule(spec string) (Rule, error) {
+	spec = strings.TrimSpace(spec)
+	path, rest, hasKind := strings.Cut(spec, ":")
+	if path == "" {
+		return Rule{}, fmt.Errorf("バージョンファイルのルールにパスがありません: %q", spec)
+	}
+	if !hasKind {
+		return Rule{Path: path, Kind: KindFile}, nil
+	}
+
+	kind, expr, ok := strings.Cut(rest, "=")
+	if !ok || expr == "" {
+		return Rule{}, fmt.Errorf("バージョンファイルのルールの形式が正しくありません: %q（例: package.json:json=version）", spec)
+	}
+	switch kind {
+	case KindJSON, KindYAML:
+	case KindRegex:
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return Rule{}, fmt.Errorf("正規表現 %q が正しくありません: %w", expr, err)
+		}
+		if re.NumSubexp() < 1 {
+			return Rule{}, fmt.Errorf("正規表現 %q にはバージョンを囲むグループ ( ) が必要です", expr)
+		}
+	default:
+		return Rule{}, fmt.Errorf("バージョンファイルのルールの種類 %q には対応していません（json/yaml/regex）", kind)
+	}
+	return Rule{Path: path, Kind: kind, Expr: expr}, nil
+}
+
+// ParseRules は複数のルールを解析します。
+func ParseRules(specs []string) ([]Rule, error) {
+	rules := make([]Rule, 0, len(specs))
+	for _, spec := range specs {
+		rule, err := ParseRule(spec)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// String はルールを ParseRule で解析できる形式に戻します。
+func (r Rule) String() string {
+	if r.Kind == KindFile {
+		return r.Path
+	}
+	return fmt.Sprintf("%s:%s=%s", r.Path, r.Kind, r.Expr)
+}
+
+// Rewrite はルールに従って内容のバージョンを書き換えます。
+//
+// パラメータ:
+//   - content: ファイルの内容
+//   - version: 新しいバージョン（例: 1.3.0）
+//
+// 戻り値:
+//   - []byte: 書き換え後の内容
+//   - error: ルールに一致する場所が見つからない場合のエラー
+func (r Rule) Rewrite(content []byte, version string) ([]byte, error) {
+	switch r.Kind {
+	case KindJSON:
+		return rewriteJSON(content, r.Expr, version)
+	case KindYAML:
+		return rewriteYAML(content, r.Expr, version)
+	case KindRegex:
+		return rewriteRegex(content, r.Expr, version)
+	default:
+		// 末尾の改行の有無は元のファイルに合わせる
+		suffix := ""
+		if bytes.HasSuffix(content, []byte("\n")) || len(content) == 0 {
+			suffix = "\n"
+		}
+		return []byte(version + suffix), nil
+	}
+}
+
+// Plan はルールに従って書き換え後の内容を計算します（ファイルは変更しません）。
+//
+// パラメータ:
+//   - root: リポジトリのルートディレクトリ
+//   - rules: ルール（同じファイルに複数のルールを指定した場合は順に適用）
+//   - version: 新しいバージョン
+//
+// 戻り値:
+//   - []Change: 内容が変わるファイルの一覧（ルールの順）
+//   - error: ファイルの読み込みに失敗した場合や、ルールに一致しない場合のエラー
+func Plan(root string, rules []Rule, version string) ([]Change, error) {
+	var order []string
+	changes := make(map[string]*Change)
+
+	for _, rule := range rules {
+		change, ok := changes[rule.Path]
+		if !ok {
+			content, err := os.ReadFile(filepath.Join(root, rule.Path))
+			if err != nil {
+				return nil, fmt.Errorf("%s の読み込みに失敗しました: %w", rule.Path, err)
+			}
+			change = &Change{Path: rule.Path, Before: content, After: content}
+			changes[rule.Path] = change
+			order = append(order, rule.Path)
+		}
+
+		after, err := rule.Rewrite(change.After, version)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", rule, err)
+		}
+		change.After = after
+	}
+
+	var result []Change
+	for _, path := range order {
+		if change := changes[path]; !bytes.Equal(change.Before, change.After) {
+			result = append(result, *change)
+		}
+	}
+	return result, nil
+}
+
+// Apply は書き換え後の内容をファイルに書き込みます。
+//
+// パラメータ:
+//   - root: リポジトリのルートディレクトリ
+//   - changes: Plan で計算した書き換え内容
+func Apply(root string, changes []Change) error {
+	for _, change := range changes {
+		path := filepath.Join(root, change.Path)
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("%s の確認に失敗しました: %w", change.Path, err)
+		}
+		if err := os.WriteFile(path, change.After, info.Mode().Perm()); err != nil {
+			return fmt.Errorf("%s の書き込みに失敗しました: %w", change.Path, err)
+		}
+	}
+	return nil
+}
+
+// Diff は変更された行を "-" と "+" の行で表した差分を返します。
+//
+// 内部処理:
+//
+//	書き換えは行内の値の置き換えのみで行数は変わらないため、同じ行番号どうしを比較します。
+func (c Change) Diff() string {
+	before := strings.Split(string(c.Before), "\n")
+	after := strings.Split(string(c.After), "\n")
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("--- a/%s\n+++ b/%s\n", c.Path, c.Path))
+	for i := 0; i < len(before) || i < len(after); i++ {
+		var oldLine, newLine string
+		if i < len(before) {
+			oldLine = before[i]
+		}
+		if i < len(after) {
+			newLine = after[i]
+		}
+		if oldLine == newLine {
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("@@ %d 行目 @@\n", i+1))
+		if i < len(before) {
+			builder.WriteString("-" + oldLine + "\n")
+		}
+		if i < len(after) {
+			builder.WriteString("+" + newLine + "\n")
+		}
+	}
+	return builder.String()
+}
+
+// rewriteRegex は正規表現の最初のグループをすべての一致箇所で置き換えます
+func rewriteRegex(content []byte, expr, version string) ([]byte, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	matches := re.FindAllSubmatchIndex(content, -1)
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("正規表現に一致する箇所がありません")
+	}
+
+	var out bytes.Buffer
+	last := 0
+	for _, m := range matches {
+		if m[2] < 0 {
+			continue
+		}
+		out.Write(content[last:m[2]])
+		out.WriteString(version)
+		last = m[3]
+	}
+	out.Write(content[last:])
+	return out.Bytes(), nil
+}
+
+// rewriteJSON は JSON のキーの文字列値を置き換えます。
+//
+// 内部処理:
+//
+//	json.Decoder でトークンを順に読み、キーの経路が一致した値の位置（InputOffset）を求め、
+//	その範囲だけを置き換えます。再整形しないため、インデントや順序は変わりません。
+func rewriteJSON(content []byte, key, version string) ([]byte, error) {
+	target := strings.Split(key, ".")
+	dec := json.NewDecoder(bytes.NewReader(content))
+
+	type frame struct {
+		object    bool
+		expectKey bool
+		key       string
+	}
+	var stack []frame
+
+	// 値を1つ読み終えたら、親がオブジェクトであれば次はキーを読む
+	valueDone := func() {
+		if n := len(stack); n > 0 && stack[n-1].object {
+			stack[n-1].expectKey = true
+		}
+	}
+
+	for {
+		offset := dec.InputOffset()
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("JSON の解析に失敗しました: %w", err)
+		}
+
+		if n := len(stack); n > 0 && stack[n-1].object && stack[n-1].expectKey {
+			if name, ok := tok.(string); ok {
+				stack[n-1].key = name
+				stack[n-1].expectKey = false
+				continue
+			}
+		}
+
+		switch tok {
+		case json.Delim('{'):
+			stack = append(stack, frame{object: true, expectKey: true})
+			continue
+		case json.Delim('['):
+			stack = append(stack, frame{})
+			continue
+		case json.Delim('}'), json.Delim(']'):
+			stack = stack[:len(stack)-1]
+			valueDone()
+			continue
+		}
+
+		if _, ok := tok.(string); ok && len(stack) == len(target) {
+			matched := true
+			for i, f := range stack {
+				if !f.object || f.key != target[i] {
+					matched = false
+					break
+				}
+			}
+			if matched {
+				start := skipJSONSeparators(content, int(offset))
+				end := int(dec.InputOffset())
+				quoted, err := json.Marshal(version)
+				if err != nil {
+					return nil, err
+				}
+				out := append([]byte{}, content[:start]...)
+				out = append(out, quoted...)
+				return append(out, content[end:]...), nil
+			}
+		}
+		valueDone()
+	}
+	return nil, fmt.Errorf("JSON のキー %q（文字列）が見つかりません", key)
+}
+
+// skipJSONSeparators は値の前の空白・":"・"," を読み飛ばした位置を返します
+func skipJSONSeparators(content []byte, pos int) int {
+	for pos < len(content) {
+		switch content[pos] {
+		case ' ', '\t', '\r', '\n', ':', ',':
+			pos++
+		default:
+			return pos
+		}
+	}
+	return pos
+}
+
+// rewriteYAML は YAML のキーのスカラー値を置き換えます。
+//
+// 内部処理:
+//
+//	行ごとにインデントからキーの経路を追い、一致したキーの値だけを置き換えます。
+//	引用符（"..." / '...'）と行末のコメントはそのまま残します。
+//	改行が CRLF の場合は \r を除いてから解析し、書き換えた行の末尾に戻します。
+//	ブロックスカラーやフロー形式（{ }）などの複雑な書き方には対応していません。
+func rewriteYAML(content []byte, key, version string) ([]byte, error) {
+	target := strings.Split(key, ".")
+	lines := strings.Split(string(content), "\n")
+
+	type entry struct {
+		indent int
+		key    string
+	}
+	var stack []entry
+
+	for i, raw := range lines {
+		line := strings.TrimSuffix(raw, "\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || trimmed == "---" {
+			continue
+		}
+		m := yamlKeyPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		indent := len(m[1])
+		name := strings.Trim(m[2], `"'`)
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+
+		value := m[4]
+		if value == "" || strings.HasPrefix(value, "#") {
+			stack = append(stack, entry{indent: indent, key: name})
+			continue
+		}
+
+		if len(stack)+1 != len(target) || name != target[len(target)-1] {
+			continue
+		}
+		matched := true
+		for j, e := range stack {
+			if e.key != target[j] {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		lines[i] = line[:len(line)-len(value)] + replaceYAMLScalar(value, version) + raw[len(line):]
+		return []byte(strings.Join(lines, "\n")), nil
+	}
+	return nil, fmt.Errorf("YAML のキー %q が見つかりません", key)
+}
+
+// replaceYAMLScalar は YAML のスカラー値を置き換えます（引用符と行末のコメントは残す）
+func replaceYAMLScalar(value, version string) string {
+	if quote := value[0]; quote == '"' || quote == '\'' {
+		if end := strings.IndexByte(value[1:], quote); end >= 0 {
+			return string(quote) + version + value[end+1:]
+		}
+	}
+
+	comment := ""
+	if idx := strings.Index(value, " #"); idx >= 0 {
+		comment = value[idx:]
+		value = value[:idx]
+	}
+	trailing := value[len(strings.TrimRight(value, " \t")):]
+	return version + trailing + comment
+}
@@ -0,0 +1,199 @@
+package versionfile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestParseRule はルールの解析をテストします
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    Rule
+		wantErr bool
+	}{
+		{spec: "VERSION", want: Rule{Path: "VERSION", Kind: KindFile}},
+		{spec: "package.json:json=version", want: Rule{Path: "package.json", Kind: KindJSON, Expr: "version"}},
+		{spec: "charts/app/Chart.yaml:yaml=appVersion", want: Rule{Path: "charts/app/Chart.yaml", Kind: KindYAML, Expr: "appVersion"}},
+		{spec: `version.go:regex=Version = "([^"]+)"`, want: Rule{Path: "version.go", Kind: KindRegex, Expr: `Version = "([^"]+)"`}},
+		{spec: "a.txt:regex=v(\\d+):(\\d+)", want: Rule{Path: "a.txt", Kind: KindRegex, Expr: `v(\d+):(\d+)`}},
+		{spec: "", wantErr: true},
+		{spec: "package.json:json", wantErr: true},
+		{spec: "package.json:toml=version", wantErr: true},
+		{spec: "version.go:regex=Version", wantErr: true},
+		{spec: "version.go:regex=(", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseRule(tt.spec)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseRule(%q) should fail", tt.spec)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRule(%q) error = %v", tt.spec, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseRule(%q) = %+v, want %+v", tt.spec, got, tt.want)
+			}
+			if got.String() != tt.spec {
+				t.Errorf("String() = %q, want %q", got.String(), tt.spec)
+			}
+		})
+	}
+}
+
+// TestRuleRewrite はルールごとの書き換えをテストします
+func TestRuleRewrite(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    Rule
+		content string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "ファイル全体",
+			rule:    Rule{Path: "VERSION", Kind: KindFile},
+			content: "1.2.3\n",
+			want:    "1.3.0\n",
+		},
+		{
+			name:    "ファイル全体（改行なし）",
+			rule:    Rule{Path: "VERSION", Kind: KindFile},
+			content: "1.2.3",
+			want:    "1.3.0",
+		},
+		{
+			name:    "JSON のトップレベルのキー",
+			rule:    Rule{Kind: KindJSON, Expr: "version"},
+			content: "{\n  \"name\": \"app\",\n  \"dependencies\": {\"version\": \"9.9.9\"},\n  \"version\": \"1.2.3\",\n  \"private\": true\n}\n",
+			want:    "{\n  \"name\": \"app\",\n  \"dependencies\": {\"version\": \"9.9.9\"},\n  \"version\": \"1.3.0\",\n  \"private\": true\n}\n",
+		},
+		{
+			name:    "JSON の入れ子のキー",
+			rule:    Rule{Kind: KindJSON, Expr: "app.version"},
+			content: `{"items": [{"version": "0.0.1"}], "app": {"version" : "1.2.3"}}`,
+			want:    `{"items": [{"version": "0.0.1"}], "app": {"version" : "1.3.0"}}`,
+		},
+		{
+			name:    "JSON のキーがない",
+			rule:    Rule{Kind: KindJSON, Expr: "version"},
+			content: `{"name": "app"}`,
+			wantErr: true,
+		},
+		{
+			name:    "YAML のトップレベルのキー",
+			rule:    Rule{Kind: KindYAML, Expr: "version"},
+			content: "apiVersion: v2\nname: app\nversion: 1.2.3 # chart\nappVersion: \"1.2.3\"\n",
+			want:    "apiVersion: v2\nname: app\nversion: 1.3.0 # chart\nappVersion: \"1.2.3\"\n",
+		},
+		{
+			name:    "YAML の引用符付きの値",
+			rule:    Rule{Kind: KindYAML, Expr: "appVersion"},
+			content: "version: 1.2.3\nappVersion: \"1.2.3\"\n",
+			want:    "version: 1.2.3\nappVersion: \"1.3.0\"\n",
+		},
+		{
+			name:    "YAML の入れ子のキー",
+			rule:    Rule{Kind: KindYAML, Expr: "image.tag"},
+			content: "sidecar:\n  tag: 0.1.0\nimage:\n  repository: app\n  tag: '1.2.3'\n",
+			want:    "sidecar:\n  tag: 0.1.0\nimage:\n  repository: app\n  tag: '1.3.0'\n",
+		},
+		{
+			name:    "YAML の改行が CRLF",
+			rule:    Rule{Kind: KindYAML, Expr: "image.tag"},
+			content: "version: 1.2.3\r\nimage:\r\n  tag: 1.2.3\r\n  pullPolicy: Always\r\n",
+			want:    "version: 1.2.3\r\nimage:\r\n  tag: 1.3.0\r\n  pullPolicy: Always\r\n",
+		},
+		{
+			name:    "YAML のキーがない",
+			rule:    Rule{Kind: KindYAML, Expr: "image.tag"},
+			content: "tag: 1.2.3\n",
+			wantErr: true,
+		},
+		{
+			name:    "Go の const Version",
+			rule:    Rule{Kind: KindRegex, Expr: `const Version = "([^"]+)"`},
+			content: "package version\n\nconst Version = \"1.2.3\"\n",
+			want:    "package version\n\nconst Version = \"1.3.0\"\n",
+		},
+		{
+			name:    "正規表現に一致しない",
+			rule:    Rule{Kind: KindRegex, Expr: `Version = "([^"]+)"`},
+			content: "package version\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.rule.Rewrite([]byte(tt.content), "1.3.0")
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Rewrite() should fail, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Rewrite() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Rewrite() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPlanAndApply は書き換え内容の計算と書き込みをテストします
+func TestPlanAndApply(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"VERSION":    "1.2.3\n",
+		"Chart.yaml": "version: 1.2.3\nappVersion: 1.2.3\n",
+		"NOTES":      "1.3.0\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rules, err := ParseRules([]string{"VERSION", "Chart.yaml:yaml=version", "Chart.yaml:yaml=appVersion", "NOTES"})
+	if err != nil {
+		t.Fatalf("ParseRules() error = %v", err)
+	}
+
+	changes, err := Plan(root, rules, "1.3.0")
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	// NOTES は既に新しいバージョンのため含まれない
+	if len(changes) != 2 || changes[0].Path != "VERSION" || changes[1].Path != "Chart.yaml" {
+		t.Fatalf("Plan() = %+v", changes)
+	}
+
+	diff := changes[1].Diff()
+	for _, want := range []string{"--- a/Chart.yaml", "-version: 1.2.3", "+version: 1.3.0", "-appVersion: 1.2.3", "+appVersion: 1.3.0"} {
+		if !strings.Contains(diff, want) {
+			t.Errorf("Diff() should contain %q, got:\n%s", want, diff)
+		}
+	}
+
+	if err := Apply(root, changes); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	content, _ := os.ReadFile(filepath.Join(root, "Chart.yaml"))
+	if string(content) != "version: 1.3.0\nappVersion: 1.3.0\n" {
+		t.Errorf("Chart.yaml = %q", content)
+	}
+
+	if _, err := Plan(root, []Rule{{Path: "missing.txt", Kind: KindFile}}, "1.3.0"); err == nil {
+		t.Error("Plan() should fail for a missing file")
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/tonbiattack/git-plus/cmd"
 
 	// サブパッケージをインポートして各コマンドを登録
+	_ "github.com/tonbiattack/git-plus/cmd/backups"
 	_ "github.com/tonbiattack/git-plus/cmd/branch"
 	_ "github.com/tonbiattack/git-plus/cmd/commit"
 	_ "github.com/tonbiattack/git-plus/cmd/issue"
@@ -47,7 +47,7 @@ if errorlevel 1 (
 )
 
 REM Step 3: Copy executables for each command
-set "commands=git-newbranch git-rename-branch git-reset-tag git-amend git-squash git-track git-delete-local-branches git-undo-last-commit git-tag-diff git-tag-diff-all git-tag-checkout git-stash-cleanup git-stash-select git-recent git-step git-sync git-pr-create-merge git-pr-merge git-pr-list git-pause git-resume git-create-repository git-new-tag git-browse git-pr-checkout git-clone-org git-batch-clone git-abort git-issue-list git-issue-create git-issue-edit git-issue-bulk-close git-release-notes git-repo-others git-pr-browse git-pr-issue-link git-worktree-new git-worktree-switch git-worktree-delete"
+set "commands=git-newbranch git-rename-branch git-reset-tag git-amend git-squash git-track git-delete-local-branches git-undo-last-commit git-tag-diff git-tag-diff-all git-tag-checkout git-stash-cleanup git-stash-select git-recent git-step git-sync git-pr-create-merge git-pr-merge git-pr-list git-pause git-resume git-create-repository git-new-tag git-browse git-pr-checkout git-clone-org git-batch-clone git-abort git-issue-list git-issue-create git-issue-edit git-issue-bulk-close git-release-notes git-repo-others git-pr-browse git-pr-issue-link git-worktree-new git-worktree-switch git-worktree-delete git-conventional-commit git-lint-commits git-split-commit git-backups git-stash-export git-stash-import git-stash-grep git-verify-tags git-release git-hotfix git-backport"
 
 echo.
 echo Creating command copies...
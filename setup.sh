@@ -107,7 +107,18 @@ git-pr-browse
 git-pr-issue-link
 git-worktree-new
 git-worktree-switch
-git-worktree-delete"
+git-worktree-delete
+git-conventional-commit
+git-lint-commits
+git-split-commit
+git-backups
+git-stash-export
+git-stash-import
+git-stash-grep
+git-verify-tags
+git-release
+git-hotfix
+git-backport"
 
 echo ""
 echo "シンボリックリンクを作成中..."